@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"lease/configs"
+	"log"
+)
+
+// PrintConfig 输出当前运行环境下脱敏后的生效配置，用于排查配置分层合并结果
+func PrintConfig() {
+	if err := configs.Init(configs.DefaultConfigPath); err != nil {
+		log.Fatalf("配置初始化失败: %v", err)
+		return
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("获取配置失败: %v", err)
+		return
+	}
+
+	dump, err := configs.Dump(config)
+	if err != nil {
+		log.Fatalf("配置输出失败: %v", err)
+		return
+	}
+	fmt.Printf("# profile: %s\n%s\n", configs.Profile(), dump)
+}
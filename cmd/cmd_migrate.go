@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"lease/configs"
+	"lease/internal/db"
+	"lease/internal/logger"
+	"lease/internal/migration"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// DEFAULT_ROLLBACK_STEPS 未指定版本数时回滚的版本数
+const DEFAULT_ROLLBACK_STEPS = 1
+
+// Migrate 执行全部待执行的数据库迁移
+func Migrate() {
+	config := connectForMigration()
+
+	applied, err := db.Migrate(context.Background(), config)
+	for _, m := range applied {
+		fmt.Printf("已执行 %d_%s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		log.Fatalf("数据库迁移失败: %v", err)
+		return
+	}
+	if len(applied) == 0 {
+		fmt.Println("没有待执行的迁移")
+	}
+}
+
+// Rollback 回滚最近执行的数据库迁移
+// 参数：
+//   - args: 命令参数，第一个参数为回滚的版本数，默认为 1
+func Rollback(args []string) {
+	steps := DEFAULT_ROLLBACK_STEPS
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			log.Fatalf("回滚版本数须为正整数: %s", args[0])
+			return
+		}
+		steps = n
+	}
+
+	config := connectForMigration()
+
+	rolledBack, err := db.Rollback(context.Background(), config, steps)
+	for _, m := range rolledBack {
+		fmt.Printf("已回滚 %d_%s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		log.Fatalf("数据库回滚失败: %v", err)
+		return
+	}
+	if len(rolledBack) == 0 {
+		fmt.Println("没有可回滚的迁移")
+	}
+}
+
+// MigrationStatus 输出全部数据库迁移的执行状态
+func MigrationStatus() {
+	config := connectForMigration()
+
+	statuses, err := db.MigrationStatus(context.Background(), config)
+	if err != nil {
+		log.Fatalf("查询迁移状态失败: %v", err)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt > 0 {
+			appliedAt = time.Unix(status.AppliedAt, 0).Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+	}
+	w.Flush()
+
+	for _, status := range statuses {
+		if status.State == migration.STATE_PENDING {
+			os.Exit(1)
+		}
+	}
+}
+
+// connectForMigration 加载配置并连接数据库，供迁移命令使用
+// 返回值：
+//   - *configs.Config: 应用配置
+func connectForMigration() *configs.Config {
+	if err := configs.Init(configs.DefaultConfigPath); err != nil {
+		log.Fatalf("配置初始化失败: %v", err)
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("获取配置失败: %v", err)
+	}
+
+	logger.New()
+	log.Printf("当前运行环境: %s", configs.Profile())
+	db.Connect(config)
+	return config
+}
@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"lease/configs"
 	"lease/internal/db"
 	"lease/internal/logger"
 	"lease/internal/middleware"
+	"lease/internal/oidc"
 	"lease/internal/redis"
+	"lease/internal/sms"
+	"lease/internal/storage"
+	"lease/internal/utils"
+	"lease/pkg/job"
 	"lease/pkg/router"
 	"log"
 )
@@ -26,6 +32,19 @@ func Start() {
 
 	// 初始化 Logger
 	logger.New()
+	log.Printf("当前运行环境: %s", configs.Profile())
+
+	// 加载令牌签名密钥
+	if err := utils.InitJWTSecrets(config.SecurityConfig); err != nil {
+		log.Fatalf("加载令牌签名密钥失败: %v", err)
+		return
+	}
+
+	// 加载常见或已泄露密码列表
+	if err := utils.InitCommonPasswords(config.SecurityConfig); err != nil {
+		log.Fatalf("加载常见密码列表失败: %v", err)
+		return
+	}
 
 	// 初始化 gin 实例
 	app := gin.New()
@@ -33,15 +52,27 @@ func Start() {
 	// 初始化中间件
 	middleware.New(app)
 
-	// 初始化数据库连接并自动迁移模型
+	// 初始化数据库连接并执行或校验数据库迁移
 	db.New(config)
 
 	// 初始化 Redis 连接
 	redis.New(config)
 
+	// 初始化文件存储
+	storage.New(config)
+
+	// 初始化短信组件
+	sms.New(config)
+
+	// 初始化第三方身份登录
+	oidc.New(config)
+
 	// 注册路由
 	router.New(app)
 
+	// 启动定时任务
+	job.New(context.Background())
+
 	// 启动服务
 	addr := fmt.Sprintf("%s:%s", config.AppConfig.AppHost, config.AppConfig.AppPort)
 	log.Printf("Gin server starting on %s...", addr)
@@ -0,0 +1,113 @@
+// Package configs 提供应用程序配置加载和更新功能
+package configs
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+)
+
+// ChangeHandler 配置变更回调，在新配置生效前调用；返回错误时本次变更整体回滚
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//
+// 返回值：
+//   - error: 新配置无法应用时返回错误
+type ChangeHandler func(oldConfig, newConfig *Config) error
+
+// subscriber 配置变更订阅者
+type subscriber struct {
+	section string        // 订阅的配置段，对应配置文件顶层键，为空时订阅全部变更
+	handler ChangeHandler // 变更回调
+}
+
+var (
+	subscribers    []subscriber // 按订阅顺序排列的订阅者
+	subscriberLock sync.RWMutex // 订阅者列表读写锁
+	reloadLock     sync.Mutex   // 配置变更锁，保证同一时刻只应用一次变更
+)
+
+// OnChange 订阅配置段的变更；配置文件更新后按订阅顺序回调，任一回调返回错误时，
+// 已应用的回调按相反顺序以新旧配置互换的方式回滚，全局配置保持不变
+// 参数：
+//   - section: 配置段，对应配置文件顶层键（如 log、redis），为空时订阅全部变更
+//   - handler: 变更回调
+func OnChange(section string, handler ChangeHandler) {
+	subscriberLock.Lock()
+	defer subscriberLock.Unlock()
+	subscribers = append(subscribers, subscriber{section: section, handler: handler})
+}
+
+// applyConfig 通知订阅者并切换全局配置，订阅者全部应用成功后新配置才生效
+// 参数：
+//   - newConfig: 新配置
+//
+// 返回值：
+//   - error: 新配置与当前配置类型不一致或订阅者拒绝时返回错误，此时全局配置与各组件均保持原状
+func applyConfig(newConfig *Config) error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	configLock.RLock()
+	oldConfig := *globalConfig
+	configLock.RUnlock()
+
+	changes := make(map[string][2]interface{})
+	if !compareStructs(oldConfig, *newConfig, "", changes) {
+		return fmt.Errorf("配置类型不一致")
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	sections := changedSections(&oldConfig, newConfig)
+	subscriberLock.RLock()
+	var pending []subscriber
+	for _, sub := range subscribers {
+		if sub.section == "" || sections[sub.section] {
+			pending = append(pending, sub)
+		}
+	}
+	subscriberLock.RUnlock()
+
+	for i, sub := range pending {
+		if err := sub.handler(&oldConfig, newConfig); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if rollbackErr := pending[j].handler(newConfig, &oldConfig); rollbackErr != nil {
+					log.Printf("配置段 [%s] 回滚失败: %v", pending[j].section, rollbackErr)
+				}
+			}
+			return fmt.Errorf("配置段 [%s] 应用失败: %w", sub.section, err)
+		}
+	}
+
+	configLock.Lock()
+	globalConfig = newConfig
+	configLock.Unlock()
+
+	for path, values := range changes {
+		log.Printf("配置项 [%s] 发生变化: %v -> %v", path, values[0], values[1])
+	}
+	return nil
+}
+
+// changedSections 找出发生变化的配置段
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//
+// 返回值：
+//   - map[string]bool: 发生变化的配置段，键为配置文件顶层键
+func changedSections(oldConfig, newConfig *Config) map[string]bool {
+	sections := make(map[string]bool)
+	oldVal := reflect.ValueOf(*oldConfig)
+	newVal := reflect.ValueOf(*newConfig)
+	for i := 0; i < oldVal.NumField(); i++ {
+		if !reflect.DeepEqual(oldVal.Field(i).Interface(), newVal.Field(i).Interface()) {
+			sections[oldVal.Type().Field(i).Tag.Get("mapstructure")] = true
+		}
+	}
+	return sections
+}
@@ -4,6 +4,7 @@ package configs
 import (
 	"fmt"
 	"log"
+	"os"
 	"reflect"
 	"sync"
 
@@ -19,6 +20,13 @@ type AppConfig struct {
 	EmailType string `mapstructure:"EMAIL_TYPE"`
 	FromEmail string `mapstructure:"FROM_EMAIL"`
 	EmailSmtp string `mapstructure:"EMAIL_SMTP"`
+
+	MagicLinkURL string `mapstructure:"MAGIC_LINK_URL"`
+	MagicLinkTTL int64  `mapstructure:"MAGIC_LINK_TTL"`
+
+	PasswordResetURL string `mapstructure:"PASSWORD_RESET_URL"`
+
+	ActivationURL string `mapstructure:"ACTIVATION_URL"`
 }
 
 // DatabaseConfig 数据库配置
@@ -30,6 +38,22 @@ type DatabaseConfig struct {
 	DBUser     string `mapstructure:"DB_USER"`
 	DBPassword string `mapstructure:"DB_PSW"`
 	DBPath     string `mapstructure:"DB_PATH"`
+
+	DBMaxOpenConns    int `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns    int `mapstructure:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime int `mapstructure:"DB_CONN_MAX_LIFETIME"`
+	DBConnMaxIdleTime int `mapstructure:"DB_CONN_MAX_IDLE_TIME"`
+	DBConnectTimeout  int `mapstructure:"DB_CONNECT_TIMEOUT"`
+
+	DBSSLMode  string `mapstructure:"DB_SSL_MODE"`
+	DBSSLCA    string `mapstructure:"DB_SSL_CA"`
+	DBSSLCert  string `mapstructure:"DB_SSL_CERT"`
+	DBSSLKey   string `mapstructure:"DB_SSL_KEY"`
+	DBTimeZone string `mapstructure:"DB_TIME_ZONE"`
+
+	DBReplicas []string `mapstructure:"DB_REPLICAS"`
+
+	DBAutoMigrate bool `mapstructure:"DB_AUTO_MIGRATE"`
 }
 
 // RedisConfig Redis配置
@@ -56,13 +80,84 @@ type SwaggerConfig struct {
 	SwaggerEnabled string `mapstructure:"SWAGGER_ENABLED"`
 }
 
+// SecurityConfig 安全策略配置
+type SecurityConfig struct {
+	PasswordMinLength        int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength        int    `mapstructure:"PASSWORD_MAX_LENGTH"`
+	PasswordRequireUpper     bool   `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower     bool   `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireDigit     bool   `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSymbol    bool   `mapstructure:"PASSWORD_REQUIRE_SYMBOL"`
+	PasswordHistorySize      int    `mapstructure:"PASSWORD_HISTORY_SIZE"`
+	PasswordCheckBreached    bool   `mapstructure:"PASSWORD_CHECK_BREACHED"`
+	PasswordBreachedListFile string `mapstructure:"PASSWORD_BREACHED_LIST_FILE"`
+
+	MFARequiredRoles []string `mapstructure:"MFA_REQUIRED_ROLES"`
+
+	AccountDeletionGraceDays int `mapstructure:"ACCOUNT_DELETION_GRACE_DAYS"`
+	AccountPurgeInterval     int `mapstructure:"ACCOUNT_PURGE_INTERVAL"`
+
+	HSTSMaxAge            int    `mapstructure:"HSTS_MAX_AGE"`
+	ContentSecurityPolicy string `mapstructure:"CONTENT_SECURITY_POLICY"`
+	FrameOptions          string `mapstructure:"X_FRAME_OPTIONS"`
+	CSRFCookieSecure      bool   `mapstructure:"CSRF_COOKIE_SECURE"`
+
+	JWTAccessSecret  string `mapstructure:"JWT_ACCESS_SECRET"`
+	JWTRefreshSecret string `mapstructure:"JWT_REFRESH_SECRET"`
+	MagicLinkSecret  string `mapstructure:"MAGIC_LINK_SECRET"`
+}
+
+// CORSConfig 跨域配置
+type CORSConfig struct {
+	AllowedOrigins   []string `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	AllowCredentials bool     `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+}
+
+// StorageConfig 文件存储配置
+type StorageConfig struct {
+	StorageType string `mapstructure:"STORAGE_TYPE"`
+	LocalPath   string `mapstructure:"STORAGE_LOCAL_PATH"`
+	PublicURL   string `mapstructure:"STORAGE_PUBLIC_URL"`
+}
+
+// SMSConfig 短信配置
+type SMSConfig struct {
+	SMSProvider        string `mapstructure:"SMS_PROVIDER"`
+	FilePath           string `mapstructure:"SMS_FILE_PATH"`
+	SignName           string `mapstructure:"SMS_SIGN_NAME"`
+	DefaultCountryCode string `mapstructure:"SMS_DEFAULT_COUNTRY_CODE"`
+}
+
+// OIDCProviderConfig 单个 OpenID Connect 身份提供方配置
+type OIDCProviderConfig struct {
+	Name         string   `mapstructure:"NAME"`
+	DisplayName  string   `mapstructure:"DISPLAY_NAME"`
+	Issuer       string   `mapstructure:"ISSUER"`
+	ClientID     string   `mapstructure:"CLIENT_ID"`
+	ClientSecret string   `mapstructure:"CLIENT_SECRET"`
+	RedirectURL  string   `mapstructure:"REDIRECT_URL"`
+	Scopes       []string `mapstructure:"SCOPES"`
+}
+
+// OIDCConfig 第三方身份登录配置
+type OIDCConfig struct {
+	AutoRegister bool                 `mapstructure:"OIDC_AUTO_REGISTER"`
+	StateTTL     int64                `mapstructure:"OIDC_STATE_TTL"`
+	Providers    []OIDCProviderConfig `mapstructure:"OIDC_PROVIDERS"`
+}
+
 // Config 总配置结构
 type Config struct {
-	AppConfig     AppConfig      `mapstructure:"app"`
-	DBConfig      DatabaseConfig `mapstructure:"database"`
-	RedisConfig   RedisConfig    `mapstructure:"redis"`
-	LogConfig     LogConfig      `mapstructure:"log"`
-	SwaggerConfig SwaggerConfig  `mapstructure:"swagger"`
+	AppConfig      AppConfig      `mapstructure:"app"`
+	DBConfig       DatabaseConfig `mapstructure:"database"`
+	RedisConfig    RedisConfig    `mapstructure:"redis"`
+	LogConfig      LogConfig      `mapstructure:"log"`
+	SwaggerConfig  SwaggerConfig  `mapstructure:"swagger"`
+	SecurityConfig SecurityConfig `mapstructure:"security"`
+	CORSConfig     CORSConfig     `mapstructure:"cors"`
+	StorageConfig  StorageConfig  `mapstructure:"storage"`
+	SMSConfig      SMSConfig      `mapstructure:"sms"`
+	OIDCConfig     OIDCConfig     `mapstructure:"oidc"`
 }
 
 // DefaultConfigPath 默认配置文件路径
@@ -71,33 +166,43 @@ const DefaultConfigPath = "./configs/config.yml"
 var (
 	globalConfig  *Config      // 全局配置实例
 	configLock    sync.RWMutex // 配置读写锁
-	viperInstance *viper.Viper // viper实例
+	configFile    string       // 主配置文件路径
+	activeProfile string       // 当前运行环境
 )
 
-// Init 初始化配置
+// Init 初始化配置，按优先级从低到高合并内置默认值、主配置文件、环境配置文件（如 config.prod.yml）、
+// LEASE_ 前缀的环境变量与 *_FILE 密钥文件；运行环境由 LEASE_PROFILE 指定，默认为 dev。
+// 合并结果须通过 Validate 校验，存在问题时一次返回全部错误
 // 参数：
-//   - configPath: 配置文件路径
+//   - configPath: 主配置文件路径
 //
 // 返回值：
 //   - error: 初始化过程中的错误
 func Init(configPath string) error {
-	viperInstance = viper.New()
-	viperInstance.SetConfigFile(configPath)
-
-	if err := viperInstance.ReadInConfig(); err != nil {
-		return fmt.Errorf("配置文件读取失败: %w", err)
+	profile, err := resolveProfile()
+	if err != nil {
+		return err
 	}
 
-	var config Config
-	if err := viperInstance.Unmarshal(&config); err != nil {
-		return fmt.Errorf("配置解析失败: %w", err)
+	config, err := loadLayeredConfig(configPath, profile)
+	if err != nil {
+		return err
 	}
 
-	globalConfig = &config
+	configFile = configPath
+	activeProfile = profile
+	globalConfig = config
 	go monitorConfigChanges()
 	return nil
 }
 
+// Profile 获取当前运行环境
+// 返回值：
+//   - string: 运行环境，取值为 dev、test、prod
+func Profile() string {
+	return activeProfile
+}
+
 // LoadConfig 获取配置
 // 返回值：
 //   - *Config: 配置副本
@@ -114,33 +219,40 @@ func LoadConfig() (*Config, error) {
 	return &configCopy, nil
 }
 
-// monitorConfigChanges 监听配置变更
+// monitorConfigChanges 监听主配置文件与环境配置文件的变更，变更后重新合并全部配置层，
+// 经订阅者全部应用成功后才生效，否则保持原配置
 func monitorConfigChanges() {
-	viperInstance.WatchConfig()
-	viperInstance.OnConfigChange(func(e fsnotify.Event) {
-		var newConfig Config
-		if err := viperInstance.Unmarshal(&newConfig); err != nil {
-			log.Printf("新配置解析失败: %v", err)
-			return
-		}
-
-		configLock.Lock()
-		defer configLock.Unlock()
+	watchConfigFile(configFile)
+	watchConfigFile(profileConfigPath(configFile, activeProfile))
+}
 
-		oldConfig := *globalConfig
-		changes := make(map[string][2]interface{})
+// watchConfigFile 监听单个配置文件，文件不存在时跳过
+// 参数：
+//   - path: 配置文件路径
+func watchConfigFile(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
 
-		if !compareStructs(oldConfig, newConfig, "", changes) {
-			log.Printf("配置类型不一致，变更被阻止")
+	watcher := viper.New()
+	watcher.SetConfigFile(path)
+	watcher.OnConfigChange(func(e fsnotify.Event) {
+		// 编辑器保存时可能先清空文件再写入，空文件视为写入未完成
+		if info, err := os.Stat(e.Name); err == nil && info.Size() == 0 {
 			return
 		}
 
-		globalConfig = &newConfig
+		newConfig, err := loadLayeredConfig(configFile, activeProfile)
+		if err != nil {
+			log.Printf("新配置无效，保持原配置: %v", err)
+			return
+		}
 
-		for path, values := range changes {
-			log.Printf("配置项 [%s] 发生变化: %v -> %v", path, values[0], values[1])
+		if err := applyConfig(newConfig); err != nil {
+			log.Printf("配置变更被阻止，已回滚: %v", err)
 		}
 	})
+	watcher.WatchConfig()
 }
 
 // compareStructs 比较结构体并收集变更
@@ -0,0 +1,86 @@
+// Package configs 提供应用程序配置加载和更新功能
+package configs
+
+// defaultConfig 内置默认配置，作为最低优先级的配置层；密码、授权码等敏感项不设默认值
+// 返回值：
+//   - *Config: 默认配置
+func defaultConfig() *Config {
+	return &Config{
+		AppConfig: AppConfig{
+			AppName:   "Lease",
+			AppHost:   "127.0.0.1",
+			AppPort:   "9010",
+			EmailType: "qq",
+
+			MagicLinkTTL: 15,
+		},
+		DBConfig: DatabaseConfig{
+			DBDialect: "sqlite",
+			DBName:    "lease",
+			DBPath:    "./database",
+
+			DBMaxOpenConns:    50,
+			DBMaxIdleConns:    10,
+			DBConnMaxLifetime: 3600,
+			DBConnMaxIdleTime: 600,
+			DBConnectTimeout:  10,
+
+			DBSSLMode:  "disable",
+			DBTimeZone: "Asia/Shanghai",
+
+			DBReplicas: []string{},
+
+			DBAutoMigrate: true,
+		},
+		RedisConfig: RedisConfig{
+			RedisHost: "127.0.0.1",
+			RedisPort: "6379",
+			RedisDB:   "0",
+		},
+		LogConfig: LogConfig{
+			LogFilePath:     ".logs/",
+			LogFileName:     "app.log",
+			LogTimestampFmt: "2006-01-02 15:04:05",
+			LogMaxAge:       72,
+			LogRotationTime: 24,
+			LogLevel:        "INFO",
+		},
+		SwaggerConfig: SwaggerConfig{
+			SwaggerHost:    "localhost:9010",
+			SwaggerEnabled: "false",
+		},
+		SecurityConfig: SecurityConfig{
+			PasswordMinLength:     8,
+			PasswordMaxLength:     64,
+			PasswordRequireLower:  true,
+			PasswordRequireDigit:  true,
+			PasswordHistorySize:   5,
+			PasswordCheckBreached: true,
+
+			MFARequiredRoles: []string{"landlord", "admin"},
+
+			AccountDeletionGraceDays: 30,
+			AccountPurgeInterval:     60,
+
+			FrameOptions: "SAMEORIGIN",
+		},
+		CORSConfig: CORSConfig{
+			AllowedOrigins: []string{"*"},
+		},
+		StorageConfig: StorageConfig{
+			StorageType: "local",
+			LocalPath:   "./uploads",
+			PublicURL:   "/uploads",
+		},
+		SMSConfig: SMSConfig{
+			SMSProvider:        "console",
+			FilePath:           ".logs/sms.log",
+			SignName:           "Lease",
+			DefaultCountryCode: "86",
+		},
+		OIDCConfig: OIDCConfig{
+			StateTTL:  600,
+			Providers: []OIDCProviderConfig{},
+		},
+	}
+}
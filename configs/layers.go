@@ -0,0 +1,248 @@
+// Package configs 提供应用程序配置加载和更新功能
+package configs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// 分层配置相关常量
+const (
+	ENV_PREFIX         = "LEASE_"        // 环境变量前缀，完整变量名为 LEASE_<配置项>，如 LEASE_DB_PSW
+	ENV_PROFILE        = "LEASE_PROFILE" // 选择运行环境的环境变量
+	SECRET_FILE_SUFFIX = "_FILE"         // 密钥文件环境变量后缀，如 LEASE_DB_PSW_FILE=/run/secrets/db_psw
+	REDACTED_VALUE     = "******"        // 脱敏后的取值
+	PROFILE_DEV        = "dev"           // 开发环境
+	PROFILE_TEST       = "test"          // 测试环境
+	PROFILE_PROD       = "prod"          // 生产环境
+	DEFAULT_PROFILE    = PROFILE_DEV     // 未指定时的运行环境
+	SECRET_FILE_LIMIT  = 64 * 1024       // 密钥文件大小上限
+	profileFilePattern = "%s.%s%s"       // 环境配置文件名格式：<主配置文件名>.<环境><扩展名>
+	yamlConfigType     = "yaml"          // 配置文件格式
+)
+
+var (
+	profiles          = []string{PROFILE_DEV, PROFILE_TEST, PROFILE_PROD}                     // 支持的运行环境
+	sensitiveSuffixes = []string{"PSW", "PASSWORD", "SECRET", "SMTP", "TOKEN", "PRIVATE_KEY"} // 配置项名称以其一结尾即视为敏感项
+)
+
+// configKey 可由环境变量覆盖的配置项
+type configKey struct {
+	key string // viper 键，格式为 <配置段>.<配置项>
+	env string // 对应的环境变量名
+}
+
+// resolveProfile 读取 LEASE_PROFILE 确定运行环境
+// 返回值：
+//   - string: 运行环境
+//   - error: 取值不受支持时返回错误
+func resolveProfile() (string, error) {
+	profile := strings.ToLower(strings.TrimSpace(os.Getenv(ENV_PROFILE)))
+	if profile == "" {
+		return DEFAULT_PROFILE, nil
+	}
+	if !slices.Contains(profiles, profile) {
+		return "", fmt.Errorf("%s 仅支持 %s: %s", ENV_PROFILE, strings.Join(profiles, "、"), profile)
+	}
+	return profile, nil
+}
+
+// profileConfigPath 获取运行环境对应的配置文件路径，如 configs/config.prod.yml
+// 参数：
+//   - configPath: 主配置文件路径
+//   - profile: 运行环境
+//
+// 返回值：
+//   - string: 环境配置文件路径
+func profileConfigPath(configPath, profile string) string {
+	ext := filepath.Ext(configPath)
+	return fmt.Sprintf(profileFilePattern, strings.TrimSuffix(configPath, ext), profile, ext)
+}
+
+// loadLayeredConfig 按优先级从低到高合并配置：内置默认值、主配置文件、环境配置文件、LEASE_ 环境变量、*_FILE 密钥文件
+// 参数：
+//   - configPath: 主配置文件路径
+//   - profile: 运行环境
+//
+// 返回值：
+//   - *Config: 合并后的配置
+//   - error: 读取、解析或校验失败时返回错误
+func loadLayeredConfig(configPath, profile string) (*Config, error) {
+	v := viper.New()
+	keys, err := registerDefaults(v)
+	if err != nil {
+		return nil, err
+	}
+
+	v.SetConfigFile(configPath)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("配置文件读取失败: %w", err)
+	}
+
+	overlayPath := profileConfigPath(configPath, profile)
+	overlay, err := os.ReadFile(overlayPath)
+	switch {
+	case err == nil:
+		v.SetConfigType(yamlConfigType)
+		if err := v.MergeConfig(bytes.NewReader(overlay)); err != nil {
+			return nil, fmt.Errorf("环境配置文件 %s 读取失败: %w", overlayPath, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("环境配置文件 %s 读取失败: %w", overlayPath, err)
+	}
+
+	for _, key := range keys {
+		if err := v.BindEnv(key.key, key.env); err != nil {
+			return nil, fmt.Errorf("绑定环境变量 %s 失败: %w", key.env, err)
+		}
+		if err := applySecretFile(v, key); err != nil {
+			return nil, err
+		}
+	}
+
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("配置解析失败: %w", err)
+	}
+	if err := Validate(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// registerDefaults 注册内置默认值，并收集可由环境变量覆盖的配置项；列表类型的结构体配置（如身份提供方）只能在配置文件中设置
+// 参数：
+//   - v: viper 实例
+//
+// 返回值：
+//   - []configKey: 可由环境变量覆盖的配置项
+//   - error: 配置项重名导致环境变量无法区分时返回错误
+func registerDefaults(v *viper.Viper) ([]configKey, error) {
+	var keys []configKey
+	seen := make(map[string]string)
+
+	config := reflect.ValueOf(*defaultConfig())
+	for i := 0; i < config.NumField(); i++ {
+		sectionTag := config.Type().Field(i).Tag.Get("mapstructure")
+		section := config.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			name := section.Type().Field(j).Tag.Get("mapstructure")
+			key := sectionTag + "." + name
+			v.SetDefault(key, section.Field(j).Interface())
+
+			field := section.Field(j)
+			if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct {
+				continue
+			}
+			if previous, ok := seen[name]; ok {
+				return nil, fmt.Errorf("配置项 %s 与 %s 重名，无法映射环境变量", key, previous)
+			}
+			seen[name] = key
+			keys = append(keys, configKey{key: key, env: ENV_PREFIX + name})
+		}
+	}
+	return keys, nil
+}
+
+// applySecretFile 设置了 <环境变量>_FILE 时读取文件内容作为配置值，优先级高于环境变量；文件末尾的换行会被去除
+// 参数：
+//   - v: viper 实例
+//   - key: 配置项
+//
+// 返回值：
+//   - error: 文件无法读取或过大时返回错误
+func applySecretFile(v *viper.Viper, key configKey) error {
+	path := os.Getenv(key.env + SECRET_FILE_SUFFIX)
+	if path == "" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("密钥文件 %s%s 读取失败: %w", key.env, SECRET_FILE_SUFFIX, err)
+	}
+	if info.Size() > SECRET_FILE_LIMIT {
+		return fmt.Errorf("密钥文件 %s%s 超过 %d 字节", key.env, SECRET_FILE_SUFFIX, SECRET_FILE_LIMIT)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("密钥文件 %s%s 读取失败: %w", key.env, SECRET_FILE_SUFFIX, err)
+	}
+
+	v.Set(key.key, strings.TrimRight(string(content), "\r\n"))
+	return nil
+}
+
+// Dump 输出脱敏后的配置，密码、密钥、授权码等敏感项以 ****** 代替，未设置的敏感项保持为空以便排查
+// 参数：
+//   - config: 配置
+//
+// 返回值：
+//   - []byte: 按配置文件结构组织的 JSON
+//   - error: 序列化失败时返回错误
+func Dump(config *Config) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(redact(reflect.ValueOf(*config), "")); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// redact 按配置文件键名组织配置并脱敏敏感项
+// 参数：
+//   - value: 配置值
+//   - name: 配置项名称
+//
+// 返回值：
+//   - interface{}: 脱敏后的取值
+func redact(value reflect.Value, name string) interface{} {
+	switch value.Kind() {
+	case reflect.Struct:
+		fields := make(map[string]interface{}, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			tag := value.Type().Field(i).Tag.Get("mapstructure")
+			fields[tag] = redact(value.Field(i), tag)
+		}
+		return fields
+	case reflect.Slice:
+		items := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, redact(value.Index(i), name))
+		}
+		return items
+	case reflect.String:
+		if value.String() != "" && isSensitiveKey(name) {
+			return REDACTED_VALUE
+		}
+		return value.String()
+	default:
+		return value.Interface()
+	}
+}
+
+// isSensitiveKey 判断配置项是否为敏感项
+// 参数：
+//   - name: 配置项名称
+//
+// 返回值：
+//   - bool: 敏感项返回 true
+func isSensitiveKey(name string) bool {
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
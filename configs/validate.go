@@ -0,0 +1,472 @@
+// Package configs 提供应用程序配置加载和更新功能
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 配置校验相关常量
+const (
+	MIN_PORT            = 1     // 端口下限
+	MAX_PORT            = 65535 // 端口上限
+	MAX_PASSWORD_LENGTH = 72    // 密码长度上限（bcrypt 只处理前 72 字节）
+	MIN_SECRET_LENGTH   = 32    // 签名密钥长度下限（字节）
+)
+
+// 配置项可选值，与各组件的实现保持一致
+var (
+	dbDialects      = []string{"mysql", "postgres", "sqlite"}                                            // 见 internal/db
+	dbSSLModes      = []string{"disable", "prefer", "require", "verify-ca", "verify-full"}               // 见 internal/db/tls.go
+	emailTypes      = []string{"qq", "gmail", "outlook"}                                                 // 见 internal/utils/email_utils.go
+	swaggerSwitches = []string{"true", "false"}                                                          // 见 internal/middleware
+	frameOptions    = []string{"DENY", "SAMEORIGIN"}                                                     // 见 internal/middleware/secure
+	storageTypes    = []string{"local"}                                                                  // 见 internal/storage
+	smsProviders    = []string{"console", "file"}                                                        // 见 internal/sms
+	roleCodes       = []string{"tenant", "landlord", "property_manager", "admin"}                        // 见 internal/model/rbac
+	insecureSecrets = []string{"lease-access-secret", "lease-refresh-secret", "lease-magic-link-secret"} // 曾随源码公开的签名密钥，不可再使用
+)
+
+// ValidationError 单个配置项的校验错误
+type ValidationError struct {
+	Path    string // 配置项在配置文件中的路径，如 database.DB_PORT
+	Message string // 错误描述
+}
+
+// Error 实现 error 接口
+// 返回值：
+//   - string: 错误描述
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors 一次校验发现的全部错误
+type ValidationErrors []ValidationError
+
+// Error 实现 error 接口，每行一个错误
+// 返回值：
+//   - string: 错误描述
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, "  - "+err.Error())
+	}
+	return fmt.Sprintf("配置校验失败，共 %d 项:\n%s", len(e), strings.Join(lines, "\n"))
+}
+
+// validator 收集校验错误
+type validator struct {
+	errs ValidationErrors
+}
+
+// Validate 校验配置的类型、取值范围、各数据库类型的必填项与路径可达性，一次返回全部问题
+// 参数：
+//   - config: 配置
+//
+// 返回值：
+//   - error: 存在问题时返回 ValidationErrors
+func Validate(config *Config) error {
+	v := &validator{}
+	v.app(config.AppConfig)
+	v.database(config.DBConfig)
+	v.redis(config.RedisConfig)
+	v.log(config.LogConfig)
+	v.swagger(config.SwaggerConfig)
+	v.security(config.SecurityConfig)
+	v.cors(config.CORSConfig)
+	v.storage(config.StorageConfig)
+	v.sms(config.SMSConfig)
+	v.oidc(config.OIDCConfig)
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// app 校验应用配置
+// 参数：
+//   - c: 应用配置
+func (v *validator) app(c AppConfig) {
+	v.required("app.APP_NAME", c.AppName)
+	v.required("app.APP_HOST", c.AppHost)
+	v.port("app.APP_PORT", c.AppPort)
+	v.oneOf("app.EMAIL_TYPE", c.EmailType, emailTypes, false)
+	v.positive("app.MAGIC_LINK_TTL", c.MagicLinkTTL)
+	v.optionalURL("app.MAGIC_LINK_URL", c.MagicLinkURL)
+	v.optionalURL("app.PASSWORD_RESET_URL", c.PasswordResetURL)
+	v.optionalURL("app.ACTIVATION_URL", c.ActivationURL)
+}
+
+// database 校验数据库配置，SQLite 需要可写的数据目录，其余类型需要主机、端口与用户
+// 参数：
+//   - c: 数据库配置
+func (v *validator) database(c DatabaseConfig) {
+	v.required("database.DB_NAME", c.DBName)
+	if !v.oneOf("database.DB_DIALECT", c.DBDialect, dbDialects, false) {
+		return
+	}
+
+	v.nonNegative("database.DB_MAX_OPEN_CONNS", int64(c.DBMaxOpenConns))
+	v.nonNegative("database.DB_MAX_IDLE_CONNS", int64(c.DBMaxIdleConns))
+	if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		v.add("database.DB_MAX_IDLE_CONNS", "不能大于 DB_MAX_OPEN_CONNS: %d > %d", c.DBMaxIdleConns, c.DBMaxOpenConns)
+	}
+	v.nonNegative("database.DB_CONN_MAX_LIFETIME", int64(c.DBConnMaxLifetime))
+	v.nonNegative("database.DB_CONN_MAX_IDLE_TIME", int64(c.DBConnMaxIdleTime))
+	v.nonNegative("database.DB_CONNECT_TIMEOUT", int64(c.DBConnectTimeout))
+	if v.required("database.DB_TIME_ZONE", c.DBTimeZone) {
+		if _, err := time.LoadLocation(c.DBTimeZone); err != nil || c.DBTimeZone == "Local" {
+			v.add("database.DB_TIME_ZONE", "须为 IANA 时区名称，如 Asia/Shanghai: %q", c.DBTimeZone)
+		}
+	}
+
+	switch c.DBDialect {
+	case "sqlite":
+		v.dir("database.DB_PATH", c.DBPath)
+		if len(c.DBReplicas) > 0 {
+			v.add("database.DB_REPLICAS", "SQLite 不支持只读副本")
+		}
+	default:
+		v.required("database.DB_HOST", c.DBHost)
+		v.port("database.DB_PORT", c.DBPort)
+		v.required("database.DB_USER", c.DBUser)
+		v.databaseTLS(c)
+		for i, replica := range c.DBReplicas {
+			path := fmt.Sprintf("database.DB_REPLICAS[%d]", i)
+			host, port, err := net.SplitHostPort(replica)
+			if err != nil || host == "" {
+				v.add(path, "须为 主机:端口 格式: %q", replica)
+				continue
+			}
+			v.port(path, port)
+		}
+	}
+}
+
+// databaseTLS 校验数据库 TLS 配置，证书文件须可读取
+// 参数：
+//   - c: 数据库配置
+func (v *validator) databaseTLS(c DatabaseConfig) {
+	if !v.oneOf("database.DB_SSL_MODE", c.DBSSLMode, dbSSLModes, false) {
+		return
+	}
+	if c.DBSSLMode == "verify-ca" {
+		v.required("database.DB_SSL_CA", c.DBSSLCA)
+	}
+	if (c.DBSSLCert == "") != (c.DBSSLKey == "") {
+		v.add("database.DB_SSL_CERT", "须与 DB_SSL_KEY 同时设置")
+	}
+	v.file("database.DB_SSL_CA", c.DBSSLCA)
+	v.file("database.DB_SSL_CERT", c.DBSSLCert)
+	v.file("database.DB_SSL_KEY", c.DBSSLKey)
+}
+
+// redis 校验 Redis 配置
+// 参数：
+//   - c: Redis 配置
+func (v *validator) redis(c RedisConfig) {
+	v.required("redis.REDIS_HOST", c.RedisHost)
+	v.port("redis.REDIS_PORT", c.RedisPort)
+	if db, err := strconv.Atoi(c.RedisDB); err != nil || db < 0 {
+		v.add("redis.REDIS_DB", "须为非负整数: %q", c.RedisDB)
+	}
+}
+
+// log 校验日志配置
+// 参数：
+//   - c: 日志配置
+func (v *validator) log(c LogConfig) {
+	v.dir("log.LOG_FILE_PATH", c.LogFilePath)
+	if v.required("log.LOG_FILE_NAME", c.LogFileName) && filepath.Base(c.LogFileName) != c.LogFileName {
+		v.add("log.LOG_FILE_NAME", "只能是文件名，不能包含目录: %q", c.LogFileName)
+	}
+	v.required("log.LOG_TIMESTAMP_FMT", c.LogTimestampFmt)
+	v.positive("log.LOG_MAX_AGE", c.LogMaxAge)
+	v.positive("log.LOG_ROTATION_TIME", c.LogRotationTime)
+	if _, err := logrus.ParseLevel(c.LogLevel); err != nil {
+		v.add("log.LOG_LEVEL", "不是有效的日志级别: %q", c.LogLevel)
+	}
+}
+
+// swagger 校验 Swagger 配置
+// 参数：
+//   - c: Swagger 配置
+func (v *validator) swagger(c SwaggerConfig) {
+	if v.oneOf("swagger.SWAGGER_ENABLED", c.SwaggerEnabled, swaggerSwitches, false) && c.SwaggerEnabled == "true" {
+		v.required("swagger.SWAGGER_HOST", c.SwaggerHost)
+	}
+}
+
+// security 校验安全策略配置
+// 参数：
+//   - c: 安全策略配置
+func (v *validator) security(c SecurityConfig) {
+	if c.PasswordMinLength < 1 {
+		v.add("security.PASSWORD_MIN_LENGTH", "须大于 0: %d", c.PasswordMinLength)
+	}
+	switch {
+	case c.PasswordMaxLength > MAX_PASSWORD_LENGTH:
+		v.add("security.PASSWORD_MAX_LENGTH", "不能超过 %d: %d", MAX_PASSWORD_LENGTH, c.PasswordMaxLength)
+	case c.PasswordMaxLength < c.PasswordMinLength:
+		v.add("security.PASSWORD_MAX_LENGTH", "不能小于 PASSWORD_MIN_LENGTH: %d < %d", c.PasswordMaxLength, c.PasswordMinLength)
+	}
+	v.nonNegative("security.PASSWORD_HISTORY_SIZE", int64(c.PasswordHistorySize))
+	v.file("security.PASSWORD_BREACHED_LIST_FILE", c.PasswordBreachedListFile)
+	for i, role := range c.MFARequiredRoles {
+		v.oneOf(fmt.Sprintf("security.MFA_REQUIRED_ROLES[%d]", i), role, roleCodes, false)
+	}
+	v.nonNegative("security.ACCOUNT_DELETION_GRACE_DAYS", int64(c.AccountDeletionGraceDays))
+	v.nonNegative("security.ACCOUNT_PURGE_INTERVAL", int64(c.AccountPurgeInterval))
+	v.nonNegative("security.HSTS_MAX_AGE", int64(c.HSTSMaxAge))
+	if c.FrameOptions != "" {
+		v.oneOf("security.X_FRAME_OPTIONS", c.FrameOptions, frameOptions, true)
+	}
+
+	v.secrets(map[string]string{
+		"security.JWT_ACCESS_SECRET":  c.JWTAccessSecret,
+		"security.JWT_REFRESH_SECRET": c.JWTRefreshSecret,
+		"security.MAGIC_LINK_SECRET":  c.MagicLinkSecret,
+	})
+}
+
+// secrets 校验签名密钥：必填、长度不低于 MIN_SECRET_LENGTH、不是曾公开的默认值，且互不相同
+// 参数：
+//   - secrets: 配置项路径到密钥的映射
+func (v *validator) secrets(secrets map[string]string) {
+	paths := make([]string, 0, len(secrets))
+	for path := range secrets {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	used := make(map[string]string, len(secrets))
+	for _, path := range paths {
+		secret := secrets[path]
+		if !v.required(path, secret) {
+			continue
+		}
+		name := path[strings.LastIndex(path, ".")+1:]
+		switch {
+		case slices.Contains(insecureSecrets, secret):
+			v.add(path, "不能使用默认密钥，请通过 %s%s 或 %s%s%s 注入随机密钥", ENV_PREFIX, name, ENV_PREFIX, name, SECRET_FILE_SUFFIX)
+		case len(secret) < MIN_SECRET_LENGTH:
+			v.add(path, "长度不能少于 %d 字节: %d", MIN_SECRET_LENGTH, len(secret))
+		}
+		if previous, ok := used[secret]; ok {
+			v.add(path, "不能与 %s 相同", previous)
+		}
+		used[secret] = path
+	}
+}
+
+// cors 校验跨域配置，来源须为 * 或不含路径的 http(s) 地址
+// 参数：
+//   - c: 跨域配置
+func (v *validator) cors(c CORSConfig) {
+	for i, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			v.add(fmt.Sprintf("cors.CORS_ALLOWED_ORIGINS[%d]", i), "须为 * 或形如 https://example.com 的来源: %q", origin)
+		}
+	}
+}
+
+// storage 校验文件存储配置
+// 参数：
+//   - c: 文件存储配置
+func (v *validator) storage(c StorageConfig) {
+	if c.StorageType != "" && !v.oneOf("storage.STORAGE_TYPE", c.StorageType, storageTypes, true) {
+		return
+	}
+	v.dir("storage.STORAGE_LOCAL_PATH", c.LocalPath)
+	v.required("storage.STORAGE_PUBLIC_URL", c.PublicURL)
+}
+
+// sms 校验短信配置
+// 参数：
+//   - c: 短信配置
+func (v *validator) sms(c SMSConfig) {
+	if c.SMSProvider != "" && v.oneOf("sms.SMS_PROVIDER", c.SMSProvider, smsProviders, true) &&
+		strings.EqualFold(c.SMSProvider, "file") && v.required("sms.SMS_FILE_PATH", c.FilePath) {
+		v.dir("sms.SMS_FILE_PATH", filepath.Dir(c.FilePath))
+	}
+	if code := c.DefaultCountryCode; code != "" {
+		if _, err := strconv.ParseUint(code, 10, 16); err != nil || len(code) > 3 {
+			v.add("sms.SMS_DEFAULT_COUNTRY_CODE", "须为 1 至 3 位数字: %q", code)
+		}
+	}
+}
+
+// oidc 校验第三方身份登录配置
+// 参数：
+//   - c: 第三方身份登录配置
+func (v *validator) oidc(c OIDCConfig) {
+	v.positive("oidc.OIDC_STATE_TTL", c.StateTTL)
+
+	names := make(map[string]int, len(c.Providers))
+	for i, provider := range c.Providers {
+		path := fmt.Sprintf("oidc.OIDC_PROVIDERS[%d]", i)
+		if v.required(path+".NAME", provider.Name) {
+			if previous, ok := names[provider.Name]; ok {
+				v.add(path+".NAME", "与 OIDC_PROVIDERS[%d] 重名: %q", previous, provider.Name)
+			}
+			names[provider.Name] = i
+		}
+		if v.required(path+".ISSUER", provider.Issuer) {
+			v.optionalURL(path+".ISSUER", provider.Issuer)
+		}
+		v.required(path+".CLIENT_ID", provider.ClientID)
+		if v.required(path+".REDIRECT_URL", provider.RedirectURL) {
+			v.optionalURL(path+".REDIRECT_URL", provider.RedirectURL)
+		}
+	}
+}
+
+// add 记录一个校验错误
+// 参数：
+//   - path: 配置项路径
+//   - format: 错误描述格式
+//   - args: 格式参数
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// required 校验必填项
+// 参数：
+//   - path: 配置项路径
+//   - value: 取值
+//
+// 返回值：
+//   - bool: 已填写返回 true
+func (v *validator) required(path, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, "不能为空")
+		return false
+	}
+	return true
+}
+
+// port 校验端口
+// 参数：
+//   - path: 配置项路径
+//   - value: 取值
+func (v *validator) port(path, value string) {
+	if port, err := strconv.Atoi(value); err != nil || port < MIN_PORT || port > MAX_PORT {
+		v.add(path, "须为 %d 至 %d 之间的整数: %q", MIN_PORT, MAX_PORT, value)
+	}
+}
+
+// positive 校验正整数
+// 参数：
+//   - path: 配置项路径
+//   - value: 取值
+func (v *validator) positive(path string, value int64) {
+	if value <= 0 {
+		v.add(path, "须大于 0: %d", value)
+	}
+}
+
+// nonNegative 校验非负整数
+// 参数：
+//   - path: 配置项路径
+//   - value: 取值
+func (v *validator) nonNegative(path string, value int64) {
+	if value < 0 {
+		v.add(path, "不能为负数: %d", value)
+	}
+}
+
+// oneOf 校验取值是否在可选范围内
+// 参数：
+//   - path: 配置项路径
+//   - value: 取值
+//   - options: 可选值
+//   - ignoreCase: 是否忽略大小写
+//
+// 返回值：
+//   - bool: 取值有效返回 true
+func (v *validator) oneOf(path, value string, options []string, ignoreCase bool) bool {
+	if slices.ContainsFunc(options, func(option string) bool {
+		return option == value || (ignoreCase && strings.EqualFold(option, strings.TrimSpace(value)))
+	}) {
+		return true
+	}
+	v.add(path, "仅支持 %s: %q", strings.Join(options, "、"), value)
+	return false
+}
+
+// optionalURL 校验 http(s) 绝对地址，为空时跳过
+// 参数：
+//   - path: 配置项路径
+//   - value: 取值
+func (v *validator) optionalURL(path, value string) {
+	if value == "" {
+		return
+	}
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(path, "须为 http(s) 绝对地址: %q", value)
+	}
+}
+
+// file 校验文件可读取，为空时跳过
+// 参数：
+//   - path: 配置项路径
+//   - value: 文件路径
+func (v *validator) file(path, value string) {
+	if value == "" {
+		return
+	}
+	info, err := os.Stat(value)
+	switch {
+	case err != nil:
+		v.add(path, "无法访问 %q: %v", value, err)
+	case info.IsDir():
+		v.add(path, "%q 是目录", value)
+	}
+}
+
+// dir 校验目录可达：目录已存在，或其最近的已存在上级是目录，启动时可自动创建
+// 参数：
+//   - path: 配置项路径
+//   - value: 目录
+func (v *validator) dir(path, value string) {
+	if !v.required(path, value) {
+		return
+	}
+
+	current := filepath.Clean(value)
+	for {
+		info, err := os.Stat(current)
+		switch {
+		case err == nil && info.IsDir():
+			return
+		case err == nil:
+			v.add(path, "%q 不是目录", current)
+			return
+		case !errors.Is(err, os.ErrNotExist):
+			v.add(path, "无法访问 %q: %v", current, err)
+			return
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			v.add(path, "无法创建目录: %q", value)
+			return
+		}
+		current = parent
+	}
+}
@@ -6,9 +6,12 @@ package db
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
+	"time"
 
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -25,10 +28,20 @@ const (
 	DIALECT_MYSQL    = "mysql"    // MySQL 数据库
 )
 
-// New 初始化数据库连接
+// New 初始化数据库连接，执行或校验版本化迁移并写入初始数据
 // 参数：
 //   - config: 应用配置
 func New(config *configs.Config) {
+	Connect(config)
+	migrateOnStartup(config)
+	seedLegacyOrganization()
+	seedRBAC()
+}
+
+// Connect 仅初始化数据库连接，不执行迁移，供迁移命令使用
+// 参数：
+//   - config: 应用配置
+func Connect(config *configs.Config) {
 	var err error
 
 	switch config.DBConfig.DBDialect {
@@ -61,7 +74,12 @@ func New(config *configs.Config) {
 	log.Printf("「%s」数据库连接成功...", config.DBConfig.DBName)
 	global.SysLog.Infof("「%s」数据库连接成功！", config.DBConfig.DBName)
 
-	autoMigrate()
+	if err := registerReplicas(global.DB, config); err != nil {
+		global.SysLog.Fatalf("注册只读副本失败: %v", err)
+	}
+	configs.OnChange("database", applyDatabaseConfig)
+
+	registerOrgScope(global.DB)
 }
 
 // connectToSystemDB 连接到系统数据库
@@ -100,7 +118,7 @@ func ensureDBExists(db *gorm.DB, config *configs.Config) error {
 	}
 }
 
-// connectToDB 连接到指定数据库
+// connectToDB 连接到指定数据库，并按配置设置连接池
 // 参数：
 //   - config: 应用配置
 //   - dbName: 数据库名称
@@ -109,30 +127,50 @@ func ensureDBExists(db *gorm.DB, config *configs.Config) error {
 //   - *gorm.DB: 数据库连接
 //   - error: 连接过程中的错误
 func connectToDB(config *configs.Config, dbName string) (*gorm.DB, error) {
-	dialector, err := getDialector(config, dbName)
+	dialector, err := getDialector(config, config.DBConfig.DBHost, config.DBConfig.DBPort, dbName)
 	if err != nil {
 		return nil, fmt.Errorf("获取数据库驱动器失败: %v", err)
 	}
 
-	return gorm.Open(dialector, &gorm.Config{})
+	location, err := time.LoadLocation(config.DBConfig.DBTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("加载数据库时区失败: %v", err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		NowFunc:        func() time.Time { return time.Now().In(location) },
+		TranslateError: true, // 将唯一约束等数据库错误转换为 gorm.ErrDuplicatedKey 等统一错误
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("获取数据库连接池失败: %v", err)
+	}
+	configurePool(sqlDB, config.DBConfig)
+	return db, nil
 }
 
 // getDialector 根据数据库类型获取对应的驱动器
 // 参数：
 //   - config: 应用配置
+//   - host: 数据库主机，SQLite 忽略
+//   - port: 数据库端口，SQLite 忽略
 //   - dbName: 数据库名称
 //
 // 返回值：
 //   - gorm.Dialector: 数据库方言
 //   - error: 获取方言过程中的错误
-func getDialector(config *configs.Config, dbName string) (gorm.Dialector, error) {
+func getDialector(config *configs.Config, host, port, dbName string) (gorm.Dialector, error) {
 	switch config.DBConfig.DBDialect {
 	case DIALECT_POSTGRES:
-		return getPostgresDialector(config, dbName), nil
+		return getPostgresDialector(config, host, port, dbName), nil
 	case DIALECT_SQLITE:
 		return getSqliteDialector(config, dbName)
 	case DIALECT_MYSQL:
-		return getMySQLDialector(config, dbName), nil
+		return getMySQLDialector(config, host, port, dbName)
 	default:
 		return nil, fmt.Errorf("不支持的数据库类型: %s", config.DBConfig.DBDialect)
 	}
@@ -141,39 +179,65 @@ func getDialector(config *configs.Config, dbName string) (gorm.Dialector, error)
 // getPostgresDialector 获取 PostgreSQL 驱动器
 // 参数：
 //   - config: 应用配置
+//   - host: 数据库主机
+//   - port: 数据库端口
 //   - dbName: 数据库名称
 //
 // 返回值：
 //   - gorm.Dialector: PostgreSQL 方言
-func getPostgresDialector(config *configs.Config, dbName string) gorm.Dialector {
+func getPostgresDialector(config *configs.Config, host, port, dbName string) gorm.Dialector {
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
-		config.DBConfig.DBHost,
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=%s connect_timeout=%d",
+		host,
 		config.DBConfig.DBUser,
 		config.DBConfig.DBPassword,
 		dbName,
-		config.DBConfig.DBPort,
+		port,
+		config.DBConfig.DBSSLMode,
+		config.DBConfig.DBTimeZone,
+		config.DBConfig.DBConnectTimeout,
 	)
+	if config.DBConfig.DBSSLCA != "" {
+		dsn += " sslrootcert=" + config.DBConfig.DBSSLCA
+	}
+	if config.DBConfig.DBSSLCert != "" {
+		dsn += fmt.Sprintf(" sslcert=%s sslkey=%s", config.DBConfig.DBSSLCert, config.DBConfig.DBSSLKey)
+	}
 	return postgres.Open(dsn)
 }
 
 // getMySQLDialector 获取 MySQL 驱动器
 // 参数：
 //   - config: 应用配置
+//   - host: 数据库主机
+//   - port: 数据库端口
 //   - dbName: 数据库名称
 //
 // 返回值：
 //   - gorm.Dialector: MySQL 方言
-func getMySQLDialector(config *configs.Config, dbName string) gorm.Dialector {
-	dsn := fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		config.DBConfig.DBUser,
-		config.DBConfig.DBPassword,
-		config.DBConfig.DBHost,
-		config.DBConfig.DBPort,
-		dbName,
-	)
-	return mysql.Open(dsn)
+//   - error: 时区或 TLS 配置无效时返回错误
+func getMySQLDialector(config *configs.Config, host, port, dbName string) (gorm.Dialector, error) {
+	location, err := time.LoadLocation(config.DBConfig.DBTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("加载数据库时区失败: %v", err)
+	}
+	tlsConfig, err := registerMySQLTLS(config.DBConfig, host)
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := mysqlDriver.NewConfig()
+	dsn.User = config.DBConfig.DBUser
+	dsn.Passwd = config.DBConfig.DBPassword
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(host, port)
+	dsn.DBName = dbName
+	dsn.Params = map[string]string{"charset": "utf8mb4"}
+	dsn.ParseTime = true
+	dsn.Loc = location
+	dsn.Timeout = time.Duration(config.DBConfig.DBConnectTimeout) * time.Second
+	dsn.TLSConfig = tlsConfig
+	return mysql.Open(dsn.FormatDSN()), nil
 }
 
 // getSqliteDialector 获取 SQLite 驱动器并确保目录存在
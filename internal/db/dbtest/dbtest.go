@@ -0,0 +1,113 @@
+// Package dbtest 提供基于临时 SQLite 数据库的业务层测试环境，仅供测试使用
+package dbtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/configs"
+	"lease/internal/db"
+	"lease/internal/logger"
+	auth_middleware "lease/internal/middleware/auth"
+	"lease/internal/utils"
+)
+
+// testConfig 测试使用的配置，占位符为临时目录；业务层测试不访问 Redis，仅需通过配置校验
+const testConfig = `app:
+  APP_NAME: "Lease"
+  APP_HOST: "127.0.0.1"
+  APP_PORT: "9010"
+  EMAIL_TYPE: "qq"
+  FROM_EMAIL: "test@example.com"
+  EMAIL_SMTP: "test"
+database:
+  DB_DIALECT: "sqlite"
+  DB_NAME: "lease"
+  DB_PATH: "%[1]s/db"
+  DB_AUTO_MIGRATE: true
+redis:
+  REDIS_HOST: "127.0.0.1"
+  REDIS_PORT: "6379"
+  REDIS_DB: "0"
+  REDIS_PSW: ""
+log:
+  LOG_FILE_PATH: "%[1]s/logs/"
+  LOG_FILE_NAME: "app.log"
+  LOG_TIMESTAMP_FMT: "2006-01-02 15:04:05"
+  LOG_MAX_AGE: 72
+  LOG_ROTATION_TIME: 24
+  LOG_LEVEL: "ERROR"
+swagger:
+  SWAGGER_HOST: "localhost:9010"
+  SWAGGER_ENABLED: "false"
+security:
+  JWT_ACCESS_SECRET: "test-access-secret-0123456789abcdef"
+  JWT_REFRESH_SECRET: "test-refresh-secret-0123456789abcdef"
+  MAGIC_LINK_SECRET: "test-magic-link-secret-0123456789abcdef"
+`
+
+// Run 以临时 SQLite 数据库初始化配置、日志与数据库连接后执行测试，供各包的 TestMain 调用
+// 参数：
+//   - m: 测试入口
+//
+// 返回值：
+//   - int: 测试退出码
+func Run(m *testing.M) int {
+	code, err := setup(m)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "初始化测试环境失败: %v\n", err)
+		return 1
+	}
+	return code
+}
+
+// NewContext 创建模拟已认证请求的 Gin 上下文，数据库读写限定在指定组织内
+// 参数：
+//   - accountID: 当前账户 ID
+//   - organizationID: 当前组织 ID
+//   - permissions: 当前账户持有的权限编码
+//
+// 返回值：
+//   - *gin.Context: Gin 上下文
+func NewContext(accountID, organizationID int64, permissions ...string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request = req.WithContext(utils.WithOrganizationID(req.Context(), organizationID))
+	c.Set(auth_middleware.ACCOUNT_ID_CONTEXT_KEY, accountID)
+	c.Set(auth_middleware.ORGANIZATION_ID_CONTEXT_KEY, organizationID)
+	c.Set(auth_middleware.PERMISSIONS_CONTEXT_KEY, permissions)
+	return c
+}
+
+// setup 初始化测试环境并执行测试
+func setup(m *testing.M) (int, error) {
+	tempDir, err := os.MkdirTemp("", "lease-db-test")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	configPath := filepath.Join(tempDir, "config.yml")
+	if err := os.WriteFile(configPath, []byte(fmt.Sprintf(testConfig, tempDir)), 0o600); err != nil {
+		return 0, err
+	}
+	if err := configs.Init(configPath); err != nil {
+		return 0, err
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+
+	logger.New()
+	gin.SetMode(gin.TestMode)
+	db.New(config)
+
+	return m.Run(), nil
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+
+	"lease/configs"
+	"lease/internal/global"
+	"lease/internal/migration"
+	model "lease/internal/model"
+	accountModel "lease/internal/model/account"
+)
+
+// BASELINE_VERSION 基线迁移版本，与引入版本化迁移前自动迁移生成的表结构一致
+const BASELINE_VERSION = 1
+
+// ADOPTED_VERSION 接管旧数据库时视为已执行的最高版本：自动迁移按当前模型补齐表结构，已包含至该版本的全部变更
+const ADOPTED_VERSION = 2
+
+// migrationFS 按数据库类型分目录存放的迁移脚本
+//
+//go:embed migrations
+var migrationFS embed.FS
+
+// newMigrator 按配置的数据库类型创建迁移执行器
+// 参数：
+//   - config: 应用配置
+//
+// 返回值：
+//   - *migration.Migrator: 迁移执行器
+//   - error: 迁移脚本加载失败时返回错误
+func newMigrator(config *configs.Config) (*migration.Migrator, error) {
+	migrations, err := migration.Load(migrationFS, "migrations/"+config.DBConfig.DBDialect)
+	if err != nil {
+		return nil, err
+	}
+	return migration.New(global.DB, config.DBConfig.DBDialect, migrations, adoptLegacySchema), nil
+}
+
+// adoptLegacySchema 接管引入版本化迁移前由自动迁移创建的数据库：先按旧方式补齐表结构，再将至 ADOPTED_VERSION 的版本记为已执行
+// 参数：
+//   - ctx: 上下文
+//   - db: 数据库连接
+//
+// 返回值：
+//   - int64: 已存在业务表时返回 ADOPTED_VERSION，全新数据库返回 0
+//   - error: 补齐表结构失败时返回错误
+func adoptLegacySchema(ctx context.Context, db *gorm.DB) (int64, error) {
+	if !db.Migrator().HasTable(&accountModel.Account{}) {
+		return 0, nil
+	}
+
+	migrateLegacyAccountStatus()
+	if err := migrateLegacyAccountRoles(db); err != nil {
+		return 0, err
+	}
+	if err := db.AutoMigrate(model.GetAllModels()...); err != nil {
+		return 0, fmt.Errorf("补齐基线表结构失败: %w", err)
+	}
+	if err := migrateLegacyIdentityIndex(db); err != nil {
+		return 0, err
+	}
+	global.SysLog.Infof("已接管由自动迁移创建的数据库，版本 %d 及之前的迁移记为已执行", ADOPTED_VERSION)
+	return ADOPTED_VERSION, nil
+}
+
+// Migrate 执行全部待执行的迁移
+// 参数：
+//   - ctx: 上下文
+//   - config: 应用配置
+//
+// 返回值：
+//   - []migration.Migration: 本次执行的迁移
+//   - error: 执行失败时返回错误
+func Migrate(ctx context.Context, config *configs.Config) ([]migration.Migration, error) {
+	migrator, err := newMigrator(config)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Up(ctx)
+}
+
+// Rollback 回滚最近执行的若干个迁移
+// 参数：
+//   - ctx: 上下文
+//   - config: 应用配置
+//   - steps: 回滚的版本数
+//
+// 返回值：
+//   - []migration.Migration: 本次回滚的迁移
+//   - error: 回滚失败时返回错误
+func Rollback(ctx context.Context, config *configs.Config, steps int) ([]migration.Migration, error) {
+	migrator, err := newMigrator(config)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Down(ctx, steps)
+}
+
+// MigrationStatus 获取全部迁移的状态
+// 参数：
+//   - ctx: 上下文
+//   - config: 应用配置
+//
+// 返回值：
+//   - []migration.Status: 按版本号升序排列的迁移状态
+//   - error: 查询失败时返回错误
+func MigrationStatus(ctx context.Context, config *configs.Config) ([]migration.Status, error) {
+	migrator, err := newMigrator(config)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Status(ctx)
+}
+
+// migrateOnStartup 启动时处理迁移：开启 DB_AUTO_MIGRATE 时执行待执行的迁移，否则存在待执行迁移时拒绝启动
+// 参数：
+//   - config: 应用配置
+func migrateOnStartup(config *configs.Config) {
+	ctx := context.Background()
+	if config.DBConfig.DBAutoMigrate {
+		applied, err := Migrate(ctx, config)
+		if err != nil {
+			global.SysLog.Fatalf("数据库迁移失败: %v", err)
+		}
+		for _, m := range applied {
+			log.Printf("已执行数据库迁移 %d_%s", m.Version, m.Name)
+			global.SysLog.Infof("已执行数据库迁移 %d_%s", m.Version, m.Name)
+		}
+		return
+	}
+
+	statuses, err := MigrationStatus(ctx, config)
+	if err != nil {
+		global.SysLog.Fatalf("查询数据库迁移状态失败: %v", err)
+	}
+	for _, status := range statuses {
+		switch status.State {
+		case migration.STATE_PENDING:
+			global.SysLog.Fatalf("数据库迁移 %d_%s 尚未执行，请先执行 `lease migrate`", status.Version, status.Name)
+		case migration.STATE_MODIFIED, migration.STATE_MISSING:
+			global.SysLog.Warnf("数据库迁移 %d_%s 状态异常: %s", status.Version, status.Name, status.State)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"lease/internal/global"
+	rbacModel "lease/internal/model/rbac"
+)
+
+// migrateLegacyAccountRoles 接管旧数据库时清理账户角色表中已撤销的逻辑删除记录与重复授予的记录，
+// 以便自动迁移创建 (organization_id, account_id, role_id) 唯一索引；重复记录只保留最早的一条
+// 参数：
+//   - db: 数据库连接
+//
+// 返回值：
+//   - error: 清理失败时返回错误
+func migrateLegacyAccountRoles(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&rbacModel.AccountRole{}) {
+		return nil
+	}
+
+	revoked := db.Where("deleted = ?", true).Delete(&rbacModel.AccountRole{})
+	if revoked.Error != nil {
+		return fmt.Errorf("清理已撤销的账户角色失败: %w", revoked.Error)
+	}
+	kept := db.Model(&rbacModel.AccountRole{}).Select("MIN(id)").Group("organization_id, account_id, role_id")
+	duplicated := db.Where("id NOT IN (?)", db.Table("(?) AS kept", kept).Select("*")).Delete(&rbacModel.AccountRole{})
+	if duplicated.Error != nil {
+		return fmt.Errorf("清理重复授予的账户角色失败: %w", duplicated.Error)
+	}
+	if revoked.RowsAffected > 0 || duplicated.RowsAffected > 0 {
+		global.SysLog.Infof("已清理账户角色记录：已撤销 %d 条，重复授予 %d 条", revoked.RowsAffected, duplicated.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"log"
+	"strings"
+
+	"lease/internal/global"
+	accountModel "lease/internal/model/account"
+)
+
+// migrateLegacyAccountStatus 接管旧数据库时删除升级前布尔类型的账户状态列，由自动迁移按新定义重建；
+// 旧列从未被读写，重建后存量账户取默认值 active，与升级前均可登录的行为一致
+func migrateLegacyAccountStatus() {
+	migrator := global.DB.Migrator()
+	if !migrator.HasTable(&accountModel.Account{}) || !migrator.HasColumn(&accountModel.Account{}, "status") {
+		return
+	}
+
+	columnTypes, err := migrator.ColumnTypes(&accountModel.Account{})
+	if err != nil {
+		log.Fatalf("查询账户表结构失败: %v", err)
+	}
+	for _, column := range columnTypes {
+		if column.Name() != "status" {
+			continue
+		}
+		typeName := strings.ToLower(column.DatabaseTypeName())
+		if !strings.Contains(typeName, "bool") && !strings.Contains(typeName, "tinyint") && typeName != "bit" {
+			return
+		}
+		if err := migrator.DropColumn(&accountModel.Account{}, "status"); err != nil {
+			log.Fatalf("删除旧账户状态列失败: %v", err)
+		}
+		global.SysLog.Infof("已删除布尔类型的旧账户状态列，将按账户生命周期状态重建")
+		return
+	}
+}
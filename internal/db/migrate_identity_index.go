@@ -0,0 +1,46 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"lease/internal/global"
+	accountModel "lease/internal/model/account"
+)
+
+// IDENTITY_SUBJECT_INDEX 第三方身份 (provider, subject) 索引名
+const IDENTITY_SUBJECT_INDEX = "idx_account_identity_subject"
+
+// migrateLegacyIdentityIndex 接管旧数据库时将第三方身份的 (provider, subject) 普通索引重建为唯一索引；
+// 自动迁移不会修改已存在的同名索引，存在重复的外部身份时重建失败，须先人工处理重复记录
+// 参数：
+//   - db: 数据库连接
+//
+// 返回值：
+//   - error: 重建索引失败时返回错误
+func migrateLegacyIdentityIndex(db *gorm.DB) error {
+	migrator := db.Migrator()
+	indexes, err := migrator.GetIndexes(&accountModel.AccountIdentity{})
+	if err != nil {
+		return fmt.Errorf("查询第三方身份表索引失败: %w", err)
+	}
+	for _, index := range indexes {
+		if index.Name() != IDENTITY_SUBJECT_INDEX {
+			continue
+		}
+		if unique, ok := index.Unique(); !ok || unique {
+			return nil
+		}
+		if err := migrator.DropIndex(&accountModel.AccountIdentity{}, IDENTITY_SUBJECT_INDEX); err != nil {
+			return fmt.Errorf("删除第三方身份普通索引失败: %w", err)
+		}
+		break
+	}
+
+	if err := migrator.CreateIndex(&accountModel.AccountIdentity{}, IDENTITY_SUBJECT_INDEX); err != nil {
+		return fmt.Errorf("创建第三方身份唯一索引失败，请先处理重复关联的外部身份: %w", err)
+	}
+	global.SysLog.Infof("已将第三方身份索引 %s 重建为唯一索引", IDENTITY_SUBJECT_INDEX)
+	return nil
+}
@@ -0,0 +1,28 @@
+-- 回滚基线版本：删除全部业务表
+
+DROP TABLE IF EXISTS `maintenance_ticket_events`;
+DROP TABLE IF EXISTS `maintenance_comments`;
+DROP TABLE IF EXISTS `maintenance_tickets`;
+DROP TABLE IF EXISTS `deposit_deductions`;
+DROP TABLE IF EXISTS `deposit_settlements`;
+DROP TABLE IF EXISTS `payments`;
+DROP TABLE IF EXISTS `ledger_entries`;
+DROP TABLE IF EXISTS `ledger_transactions`;
+DROP TABLE IF EXISTS `recurring_fees`;
+DROP TABLE IF EXISTS `invoice_items`;
+DROP TABLE IF EXISTS `invoices`;
+DROP TABLE IF EXISTS `lease_contract_audits`;
+DROP TABLE IF EXISTS `lease_contracts`;
+DROP TABLE IF EXISTS `units`;
+DROP TABLE IF EXISTS `properties`;
+DROP TABLE IF EXISTS `account_roles`;
+DROP TABLE IF EXISTS `role_permissions`;
+DROP TABLE IF EXISTS `permissions`;
+DROP TABLE IF EXISTS `roles`;
+DROP TABLE IF EXISTS `organization_invitations`;
+DROP TABLE IF EXISTS `organizations`;
+DROP TABLE IF EXISTS `account_identities`;
+DROP TABLE IF EXISTS `account_password_histories`;
+DROP TABLE IF EXISTS `account_recovery_codes`;
+DROP TABLE IF EXISTS `account_mfas`;
+DROP TABLE IF EXISTS `accounts`;
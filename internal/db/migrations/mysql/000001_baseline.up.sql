@@ -0,0 +1,517 @@
+-- 基线版本：引入版本化迁移时的全部业务表，与同版本模型定义一致
+
+CREATE TABLE `accounts` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `phone` varchar(32) DEFAULT null,
+    `phone_verified` boolean DEFAULT false,
+    `email` varchar(64) NOT NULL,
+    `password` varchar(255) NOT NULL,
+    `avatar` varchar(255) DEFAULT null,
+    `nickname` varchar(64) NOT NULL,
+    `status` varchar(32) NOT NULL DEFAULT 'active',
+    `deletion_requested_at` bigint DEFAULT 0,
+    `deletion_scheduled_at` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_accounts_organization_id` (`organization_id`),
+    INDEX `idx_accounts_status` (`status`),
+    INDEX `idx_accounts_deletion_scheduled_at` (`deletion_scheduled_at`),
+    CONSTRAINT `uni_accounts_phone` UNIQUE (`phone`),
+    CONSTRAINT `uni_accounts_email` UNIQUE (`email`)
+);
+
+CREATE TABLE `account_mfas` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `account_id` bigint NOT NULL,
+    `secret` varchar(64) NOT NULL,
+    `enabled` boolean DEFAULT false,
+    `enabled_at` bigint DEFAULT 0,
+    `last_used_step` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_account_mfas_organization_id` (`organization_id`),
+    UNIQUE INDEX `idx_account_mfas_account_id` (`account_id`)
+);
+
+CREATE TABLE `account_recovery_codes` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `account_id` bigint NOT NULL,
+    `code_hash` varchar(64) NOT NULL,
+    `used_at` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_account_recovery_codes_organization_id` (`organization_id`),
+    INDEX `idx_account_recovery_codes_account_id` (`account_id`),
+    INDEX `idx_account_recovery_codes_code_hash` (`code_hash`)
+);
+
+CREATE TABLE `account_password_histories` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `account_id` bigint NOT NULL,
+    `password_hash` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_account_password_histories_organization_id` (`organization_id`),
+    INDEX `idx_account_password_histories_account_id` (`account_id`)
+);
+
+CREATE TABLE `account_identities` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `account_id` bigint NOT NULL,
+    `provider` varchar(32) NOT NULL,
+    `subject` varchar(255) NOT NULL,
+    `email` varchar(64),
+    `linked_at` bigint DEFAULT 0,
+    `last_login_at` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_account_identities_organization_id` (`organization_id`),
+    INDEX `idx_account_identities_account_id` (`account_id`),
+    UNIQUE INDEX `idx_account_identity_subject` (`provider`,`subject`)
+);
+
+CREATE TABLE `organizations` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `name` varchar(100) NOT NULL,
+    `owner_id` bigint NOT NULL,
+    `seat_limit` bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_organizations_owner_id` (`owner_id`)
+);
+
+CREATE TABLE `organization_invitations` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `email` varchar(64) NOT NULL,
+    `role_code` varchar(32) NOT NULL,
+    `code` varchar(64) NOT NULL,
+    `status` varchar(16) NOT NULL,
+    `invited_by` bigint NOT NULL,
+    `expires_at` bigint NOT NULL,
+    `accepted_by` bigint DEFAULT 0,
+    `accepted_at` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_organization_invitations_organization_id` (`organization_id`),
+    INDEX `idx_organization_invitations_email` (`email`),
+    UNIQUE INDEX `idx_organization_invitations_code` (`code`),
+    INDEX `idx_organization_invitations_status` (`status`)
+);
+
+CREATE TABLE `roles` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `code` varchar(32) NOT NULL,
+    `name` varchar(64) NOT NULL,
+    `description` varchar(255) DEFAULT null,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_roles_code` (`code`)
+);
+
+CREATE TABLE `permissions` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `code` varchar(64) NOT NULL,
+    `name` varchar(64) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_permissions_code` (`code`)
+);
+
+CREATE TABLE `role_permissions` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `role_id` bigint NOT NULL,
+    `permission_id` bigint NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_role_permission` (`role_id`,`permission_id`)
+);
+
+CREATE TABLE `account_roles` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `account_id` bigint NOT NULL,
+    `role_id` bigint NOT NULL,
+    `granted_by` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_account_roles_organization_id` (`organization_id`),
+    INDEX `idx_account_roles_account_id` (`account_id`),
+    INDEX `idx_account_roles_role_id` (`role_id`)
+);
+
+CREATE TABLE `properties` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `owner_id` bigint NOT NULL,
+    `name` varchar(128) NOT NULL,
+    `province` varchar(64) DEFAULT null,
+    `city` varchar(64) NOT NULL,
+    `district` varchar(64) DEFAULT null,
+    `address` varchar(255) NOT NULL,
+    `latitude` decimal(10,7) DEFAULT 0,
+    `longitude` decimal(10,7) DEFAULT 0,
+    `description` varchar(1024) DEFAULT null,
+    PRIMARY KEY (`id`),
+    INDEX `idx_properties_organization_id` (`organization_id`),
+    INDEX `idx_properties_owner_id` (`owner_id`),
+    INDEX `idx_properties_city` (`city`)
+);
+
+CREATE TABLE `units` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `property_id` bigint NOT NULL,
+    `unit_no` varchar(32) NOT NULL,
+    `floor` bigint NOT NULL,
+    `area` decimal(10,2) NOT NULL,
+    `rooms` bigint NOT NULL DEFAULT 1,
+    `rent_list_price` bigint NOT NULL,
+    `status` varchar(16) NOT NULL DEFAULT 'vacant',
+    PRIMARY KEY (`id`),
+    INDEX `idx_units_organization_id` (`organization_id`),
+    INDEX `idx_units_property_id` (`property_id`),
+    INDEX `idx_units_status` (`status`)
+);
+
+CREATE TABLE `lease_contracts` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `unit_id` bigint NOT NULL,
+    `property_id` bigint NOT NULL,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint NOT NULL,
+    `start_date` varchar(10) NOT NULL,
+    `end_date` varchar(10) NOT NULL,
+    `monthly_rent` bigint NOT NULL,
+    `deposit` bigint NOT NULL DEFAULT 0,
+    `billing_cycle` varchar(16) NOT NULL,
+    `status` varchar(32) NOT NULL,
+    `renewed_from_id` bigint DEFAULT 0,
+    `signed_at` bigint DEFAULT 0,
+    `terminated_at` bigint DEFAULT 0,
+    `termination_reason` varchar(255) DEFAULT null,
+    PRIMARY KEY (`id`),
+    INDEX `idx_lease_contracts_organization_id` (`organization_id`),
+    INDEX `idx_lease_contracts_unit_id` (`unit_id`),
+    INDEX `idx_lease_contracts_property_id` (`property_id`),
+    INDEX `idx_lease_contracts_landlord_id` (`landlord_id`),
+    INDEX `idx_lease_contracts_tenant_id` (`tenant_id`),
+    INDEX `idx_lease_contracts_status` (`status`),
+    INDEX `idx_lease_contracts_renewed_from_id` (`renewed_from_id`)
+);
+
+CREATE TABLE `lease_contract_audits` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `contract_id` bigint NOT NULL,
+    `action` varchar(32) NOT NULL,
+    `from_status` varchar(32) DEFAULT null,
+    `to_status` varchar(32) NOT NULL,
+    `operator_id` bigint NOT NULL,
+    `remark` varchar(255) DEFAULT null,
+    PRIMARY KEY (`id`),
+    INDEX `idx_lease_contract_audits_organization_id` (`organization_id`),
+    INDEX `idx_lease_contract_audits_contract_id` (`contract_id`)
+);
+
+CREATE TABLE `invoices` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `contract_id` bigint NOT NULL,
+    `period_start` varchar(10) NOT NULL,
+    `period_end` varchar(10) NOT NULL,
+    `unit_id` bigint NOT NULL,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint NOT NULL,
+    `due_date` varchar(10) NOT NULL,
+    `amount` bigint NOT NULL,
+    `paid_amount` bigint NOT NULL DEFAULT 0,
+    `prorated` boolean DEFAULT false,
+    `status` varchar(16) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_invoices_organization_id` (`organization_id`),
+    UNIQUE INDEX `idx_invoice_contract_period` (`contract_id`,`period_start`),
+    INDEX `idx_invoices_unit_id` (`unit_id`),
+    INDEX `idx_invoices_landlord_id` (`landlord_id`),
+    INDEX `idx_invoices_tenant_id` (`tenant_id`),
+    INDEX `idx_invoices_status` (`status`)
+);
+
+CREATE TABLE `invoice_items` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `invoice_id` bigint NOT NULL,
+    `item_type` varchar(16) NOT NULL,
+    `fee_id` bigint DEFAULT 0,
+    `name` varchar(64) NOT NULL,
+    `amount` bigint NOT NULL,
+    `remark` varchar(255) DEFAULT null,
+    PRIMARY KEY (`id`),
+    INDEX `idx_invoice_items_organization_id` (`organization_id`),
+    INDEX `idx_invoice_items_invoice_id` (`invoice_id`)
+);
+
+CREATE TABLE `recurring_fees` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `contract_id` bigint NOT NULL,
+    `category` varchar(16) NOT NULL,
+    `name` varchar(64) NOT NULL,
+    `monthly_amount` bigint NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_recurring_fees_organization_id` (`organization_id`),
+    INDEX `idx_recurring_fees_contract_id` (`contract_id`)
+);
+
+CREATE TABLE `ledger_transactions` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `txn_type` varchar(16) NOT NULL,
+    `contract_id` bigint NOT NULL,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint NOT NULL,
+    `invoice_id` bigint DEFAULT 0,
+    `payment_id` bigint DEFAULT 0,
+    `amount` bigint NOT NULL,
+    `memo` varchar(255) DEFAULT null,
+    `operator_id` bigint NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_ledger_transactions_organization_id` (`organization_id`),
+    INDEX `idx_ledger_transactions_txn_type` (`txn_type`),
+    INDEX `idx_ledger_transactions_contract_id` (`contract_id`),
+    INDEX `idx_ledger_transactions_landlord_id` (`landlord_id`),
+    INDEX `idx_ledger_transactions_tenant_id` (`tenant_id`),
+    INDEX `idx_ledger_transactions_invoice_id` (`invoice_id`),
+    INDEX `idx_ledger_transactions_payment_id` (`payment_id`)
+);
+
+CREATE TABLE `ledger_entries` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `transaction_id` bigint NOT NULL,
+    `account` varchar(32) NOT NULL,
+    `direction` varchar(8) NOT NULL,
+    `amount` bigint NOT NULL,
+    `contract_id` bigint NOT NULL,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint NOT NULL,
+    `invoice_id` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_ledger_entries_organization_id` (`organization_id`),
+    INDEX `idx_ledger_entries_transaction_id` (`transaction_id`),
+    INDEX `idx_ledger_entries_account` (`account`),
+    INDEX `idx_ledger_entries_contract_id` (`contract_id`),
+    INDEX `idx_ledger_entries_landlord_id` (`landlord_id`),
+    INDEX `idx_ledger_entries_tenant_id` (`tenant_id`),
+    INDEX `idx_ledger_entries_invoice_id` (`invoice_id`)
+);
+
+CREATE TABLE `payments` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `contract_id` bigint NOT NULL,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint NOT NULL,
+    `purpose` varchar(16) NOT NULL,
+    `method` varchar(16) NOT NULL,
+    `reference` varchar(64) DEFAULT null,
+    `amount` bigint NOT NULL,
+    `applied_amount` bigint NOT NULL DEFAULT 0,
+    `credit_amount` bigint NOT NULL DEFAULT 0,
+    `paid_date` varchar(10) NOT NULL,
+    `remark` varchar(255) DEFAULT null,
+    `operator_id` bigint NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_payments_organization_id` (`organization_id`),
+    INDEX `idx_payments_contract_id` (`contract_id`),
+    INDEX `idx_payments_landlord_id` (`landlord_id`),
+    INDEX `idx_payments_tenant_id` (`tenant_id`)
+);
+
+CREATE TABLE `deposit_settlements` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `contract_id` bigint NOT NULL,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint NOT NULL,
+    `status` varchar(16) NOT NULL,
+    `held_amount` bigint NOT NULL DEFAULT 0,
+    `deduction_amount` bigint NOT NULL DEFAULT 0,
+    `refund_amount` bigint NOT NULL DEFAULT 0,
+    `tenant_comment` varchar(255) DEFAULT null,
+    `acknowledged_at` bigint DEFAULT 0,
+    `settled_at` bigint DEFAULT 0,
+    `transaction_id` bigint DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_deposit_settlements_organization_id` (`organization_id`),
+    UNIQUE INDEX `idx_deposit_settlements_contract_id` (`contract_id`),
+    INDEX `idx_deposit_settlements_landlord_id` (`landlord_id`),
+    INDEX `idx_deposit_settlements_tenant_id` (`tenant_id`)
+);
+
+CREATE TABLE `deposit_deductions` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `settlement_id` bigint NOT NULL,
+    `category` varchar(16) NOT NULL,
+    `reason` varchar(255) NOT NULL,
+    `amount` bigint NOT NULL,
+    `evidence_ids` json,
+    PRIMARY KEY (`id`),
+    INDEX `idx_deposit_deductions_organization_id` (`organization_id`),
+    INDEX `idx_deposit_deductions_settlement_id` (`settlement_id`)
+);
+
+CREATE TABLE `maintenance_tickets` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `unit_id` bigint NOT NULL,
+    `property_id` bigint NOT NULL,
+    `contract_id` bigint DEFAULT 0,
+    `landlord_id` bigint NOT NULL,
+    `tenant_id` bigint DEFAULT 0,
+    `reporter_id` bigint NOT NULL,
+    `assignee_id` bigint DEFAULT 0,
+    `title` varchar(100) NOT NULL,
+    `description` text,
+    `category` varchar(16) NOT NULL,
+    `priority` varchar(16) NOT NULL,
+    `status` varchar(16) NOT NULL,
+    `scheduled_at` varchar(16) DEFAULT null,
+    `resolved_at` bigint DEFAULT 0,
+    `closed_at` bigint DEFAULT 0,
+    `cost` bigint NOT NULL DEFAULT 0,
+    `resolution` varchar(255) DEFAULT null,
+    PRIMARY KEY (`id`),
+    INDEX `idx_maintenance_tickets_organization_id` (`organization_id`),
+    INDEX `idx_maintenance_tickets_unit_id` (`unit_id`),
+    INDEX `idx_maintenance_tickets_landlord_id` (`landlord_id`),
+    INDEX `idx_maintenance_tickets_tenant_id` (`tenant_id`),
+    INDEX `idx_maintenance_tickets_assignee_id` (`assignee_id`),
+    INDEX `idx_maintenance_tickets_priority` (`priority`),
+    INDEX `idx_maintenance_tickets_status` (`status`)
+);
+
+CREATE TABLE `maintenance_comments` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `ticket_id` bigint NOT NULL,
+    `author_id` bigint NOT NULL,
+    `content` text NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_maintenance_comments_organization_id` (`organization_id`),
+    INDEX `idx_maintenance_comments_ticket_id` (`ticket_id`)
+);
+
+CREATE TABLE `maintenance_ticket_events` (
+    `id` bigint AUTO_INCREMENT,
+    `gmt_create` bigint,
+    `gmt_modified` bigint,
+    `ext` json,
+    `deleted` boolean DEFAULT false,
+    `organization_id` bigint NOT NULL DEFAULT 0,
+    `ticket_id` bigint NOT NULL,
+    `action` varchar(16) NOT NULL,
+    `from_status` varchar(16) DEFAULT null,
+    `to_status` varchar(16) NOT NULL,
+    `operator_id` bigint NOT NULL,
+    `remark` varchar(255) DEFAULT null,
+    PRIMARY KEY (`id`),
+    INDEX `idx_maintenance_ticket_events_organization_id` (`organization_id`),
+    INDEX `idx_maintenance_ticket_events_ticket_id` (`ticket_id`)
+);
@@ -0,0 +1,3 @@
+-- 回滚账户角色唯一索引，已清理的记录不恢复
+
+DROP INDEX `idx_account_role` ON `account_roles`;
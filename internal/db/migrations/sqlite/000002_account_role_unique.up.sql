@@ -0,0 +1,11 @@
+-- 账户角色唯一索引：同一组织内同一账户的同一角色只保留一条，撤销角色改为物理删除
+
+-- 清理已撤销的逻辑删除记录，重复授予的记录只保留最早的一条
+DELETE FROM `account_roles` WHERE `deleted` = true;
+DELETE FROM `account_roles` WHERE `id` NOT IN (
+    SELECT `id` FROM (
+        SELECT MIN(`id`) AS `id` FROM `account_roles` GROUP BY `organization_id`, `account_id`, `role_id`
+    ) AS `kept`
+);
+
+CREATE UNIQUE INDEX `idx_account_role` ON `account_roles` (`organization_id`,`account_id`,`role_id`);
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"log"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"lease/internal/utils"
+)
+
+// ORGANIZATION_FIELD 按组织隔离的模型字段名
+const ORGANIZATION_FIELD = "OrganizationID"
+
+// registerOrgScope 注册组织隔离回调：认证后的请求在查询、更新、删除时自动追加所属组织条件，创建时自动填充所属组织
+// 参数：
+//   - db: 数据库连接
+func registerOrgScope(db *gorm.DB) {
+	callbacks := []error{
+		db.Callback().Create().Before("gorm:create").Register("org_scope:create", fillOrganizationID),
+		db.Callback().Query().Before("gorm:query").Register("org_scope:query", whereOrganizationID),
+		db.Callback().Update().Before("gorm:update").Register("org_scope:update", whereOrganizationID),
+		db.Callback().Delete().Before("gorm:delete").Register("org_scope:delete", whereOrganizationID),
+		db.Callback().Row().Before("gorm:row").Register("org_scope:row", whereOrganizationID),
+	}
+	for _, err := range callbacks {
+		if err != nil {
+			log.Fatalf("注册组织隔离回调失败: %v", err)
+		}
+	}
+}
+
+// organizationField 获取语句涉及模型的组织字段及当前组织 ID
+// 参数：
+//   - db: 数据库语句
+//
+// 返回值：
+//   - *schema.Field: 组织字段，模型不按组织隔离时为 nil
+//   - int64: 当前组织 ID
+//   - bool: 是否需要按组织隔离
+func organizationField(db *gorm.DB) (*schema.Field, int64, bool) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.SQL.Len() > 0 {
+		return nil, 0, false
+	}
+	field := db.Statement.Schema.LookUpField(ORGANIZATION_FIELD)
+	if field == nil {
+		return nil, 0, false
+	}
+	organizationID, ok := utils.OrganizationIDFromContext(db.Statement.Context)
+	if !ok {
+		return nil, 0, false
+	}
+	return field, organizationID, true
+}
+
+// whereOrganizationID 为查询、更新、删除追加所属组织条件
+// 参数：
+//   - db: 数据库语句
+func whereOrganizationID(db *gorm.DB) {
+	field, organizationID, ok := organizationField(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: field.DBName}, Value: organizationID},
+	}})
+}
+
+// fillOrganizationID 为未指定所属组织的新记录填充当前组织
+// 参数：
+//   - db: 数据库语句
+func fillOrganizationID(db *gorm.DB) {
+	field, organizationID, ok := organizationField(db)
+	if !ok {
+		return
+	}
+
+	fill := func(rv reflect.Value) {
+		if _, zero := field.ValueOf(db.Statement.Context, rv); zero {
+			if err := field.Set(db.Statement.Context, rv, organizationID); err != nil {
+				db.AddError(err)
+			}
+		}
+	}
+
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			fill(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		fill(rv)
+	}
+}
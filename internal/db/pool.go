@@ -0,0 +1,109 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"lease/configs"
+	"lease/internal/global"
+	"lease/internal/utils"
+)
+
+// replicaResolver 只读副本路由，未配置副本时为 nil
+var replicaResolver *dbresolver.DBResolver
+
+// configurePool 按配置设置连接池参数，取值为 0 时表示不限制
+// 参数：
+//   - sqlDB: 连接池
+//   - config: 数据库配置
+func configurePool(sqlDB *sql.DB, config configs.DatabaseConfig) {
+	sqlDB.SetMaxOpenConns(config.DBMaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.DBMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(config.DBConnMaxLifetime) * time.Second)
+	sqlDB.SetConnMaxIdleTime(time.Duration(config.DBConnMaxIdleTime) * time.Second)
+}
+
+// registerReplicas 注册只读副本：通过 utils.UseReportDB 标记为报表查询的请求，其事务外的读操作随机路由到副本，
+// 写操作及未标记的查询仍使用主库；副本与主库使用相同的库名、用户、密码与 TLS 配置
+// 参数：
+//   - db: 主库连接
+//   - config: 应用配置
+//
+// 返回值：
+//   - error: 副本地址无效或连接失败时返回错误
+func registerReplicas(db *gorm.DB, config *configs.Config) error {
+	if len(config.DBConfig.DBReplicas) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(config.DBConfig.DBReplicas))
+	for _, replica := range config.DBConfig.DBReplicas {
+		host, port, err := net.SplitHostPort(replica)
+		if err != nil {
+			return fmt.Errorf("只读副本地址「%s」无效: %w", replica, err)
+		}
+		dialector, err := getDialector(config, host, port, config.DBConfig.DBName)
+		if err != nil {
+			return fmt.Errorf("获取只读副本「%s」驱动器失败: %w", replica, err)
+		}
+		replicas = append(replicas, dialector)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}, utils.DB_RESOLVER_REPORT)
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("注册只读副本失败: %w", err)
+	}
+	setResolverPool(resolver, config.DBConfig)
+
+	replicaResolver = resolver
+	global.SysLog.Infof("已注册 %d 个只读副本，报表查询将路由到副本", len(replicas))
+	return nil
+}
+
+// setResolverPool 按配置设置主库与全部只读副本的连接池参数
+// 参数：
+//   - resolver: 只读副本路由
+//   - config: 数据库配置
+func setResolverPool(resolver *dbresolver.DBResolver, config configs.DatabaseConfig) {
+	resolver.SetMaxOpenConns(config.DBMaxOpenConns).
+		SetMaxIdleConns(config.DBMaxIdleConns).
+		SetConnMaxLifetime(time.Duration(config.DBConnMaxLifetime) * time.Second).
+		SetConnMaxIdleTime(time.Duration(config.DBConnMaxIdleTime) * time.Second)
+}
+
+// applyDatabaseConfig 应用变更后的连接池参数；连接地址、账户、TLS、时区与副本的变更需重启后生效
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//
+// 返回值：
+//   - error: 获取连接池失败时返回错误
+func applyDatabaseConfig(oldConfig, newConfig *configs.Config) error {
+	sqlDB, err := global.DB.DB()
+	if err != nil {
+		return fmt.Errorf("获取数据库连接池失败: %w", err)
+	}
+	configurePool(sqlDB, newConfig.DBConfig)
+	if replicaResolver != nil {
+		setResolverPool(replicaResolver, newConfig.DBConfig)
+	}
+
+	oldConnection, newConnection := oldConfig.DBConfig, newConfig.DBConfig
+	for _, c := range []*configs.DatabaseConfig{&oldConnection, &newConnection} {
+		c.DBMaxOpenConns, c.DBMaxIdleConns, c.DBConnMaxLifetime, c.DBConnMaxIdleTime = 0, 0, 0, 0
+	}
+	if !reflect.DeepEqual(oldConnection, newConnection) {
+		global.SysLog.Warn("数据库连接地址、账户、TLS、时区与只读副本的变更需重启后生效")
+	}
+	global.SysLog.Info("数据库连接池参数已更新")
+	return nil
+}
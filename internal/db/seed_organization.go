@@ -0,0 +1,53 @@
+package db
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+
+	"lease/internal/global"
+	model "lease/internal/model"
+	accountModel "lease/internal/model/account"
+	organizationModel "lease/internal/model/organization"
+)
+
+// LEGACY_ORGANIZATION_NAME 升级前已有数据归入的默认组织名称
+const LEGACY_ORGANIZATION_NAME = "默认组织"
+
+// seedLegacyOrganization 将未归属组织的存量账户及业务数据归入同一个默认组织，兼容单用户版本升级
+func seedLegacyOrganization() {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		var owner accountModel.Account
+		result := tx.Where("organization_id = ?", 0).Order("gmt_create ASC").Limit(1).Find(&owner)
+		if result.Error != nil {
+			return fmt.Errorf("查询未归属组织的账户失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		organization := &organizationModel.Organization{Name: LEGACY_ORGANIZATION_NAME, OwnerID: owner.ID}
+		if err := tx.Create(organization).Error; err != nil {
+			return fmt.Errorf("创建默认组织失败: %w", err)
+		}
+
+		for _, m := range model.GetAllModels() {
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(m); err != nil {
+				return fmt.Errorf("解析模型失败: %w", err)
+			}
+			if stmt.Schema.LookUpField(ORGANIZATION_FIELD) == nil {
+				continue
+			}
+			if err := tx.Model(m).Where("organization_id = ?", 0).
+				UpdateColumn("organization_id", organization.ID).Error; err != nil {
+				return fmt.Errorf("迁移「%s」表所属组织失败: %w", stmt.Schema.Table, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("存量数据组织归属迁移失败: %v", err)
+	}
+}
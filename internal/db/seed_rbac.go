@@ -0,0 +1,54 @@
+package db
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+
+	"lease/internal/global"
+	model "lease/internal/model/rbac"
+)
+
+// seedRBAC 同步内置角色与权限，仅补充缺失的记录，不会覆盖管理员的调整
+func seedRBAC() {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		permissionIDs := make(map[string]int64, len(model.BuiltinPermissions))
+		createdPermissions := make(map[string]bool)
+		for _, p := range model.BuiltinPermissions {
+			permission := model.Permission{Code: p.Code, Name: p.Name}
+			result := tx.Where("code = ? AND deleted = ?", p.Code, false).FirstOrCreate(&permission)
+			if result.Error != nil {
+				return fmt.Errorf("同步权限「%s」失败: %w", p.Code, result.Error)
+			}
+			permissionIDs[p.Code] = permission.ID
+			createdPermissions[p.Code] = result.RowsAffected > 0
+		}
+
+		for _, r := range model.BuiltinRoles {
+			role := model.Role{Code: r.Code, Name: r.Name, Description: r.Description}
+			result := tx.Where("code = ? AND deleted = ?", r.Code, false).FirstOrCreate(&role)
+			if result.Error != nil {
+				return fmt.Errorf("同步角色「%s」失败: %w", r.Code, result.Error)
+			}
+			// 新建的角色写入全部默认权限，已有角色仅补充本次新增的内置权限
+			roleCreated := result.RowsAffected > 0
+			for _, code := range r.Permissions {
+				if !roleCreated && !createdPermissions[code] {
+					continue
+				}
+				rolePermission := model.RolePermission{RoleID: role.ID, PermissionID: permissionIDs[code]}
+				if err := tx.Create(&rolePermission).Error; err != nil {
+					return fmt.Errorf("同步角色「%s」权限「%s」失败: %w", r.Code, code, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("内置角色权限同步失败: %v", err)
+	}
+
+	log.Println("内置角色权限同步成功...")
+	global.SysLog.Infof("内置角色权限同步成功...")
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+
+	"lease/configs"
+)
+
+// TLS 模式常量，PostgreSQL 直接作为 sslmode 使用，MySQL 转换为对应的 TLS 配置
+const (
+	SSL_MODE_DISABLE     = "disable"     // 不加密
+	SSL_MODE_PREFER      = "prefer"      // 服务端支持时加密，不校验证书
+	SSL_MODE_REQUIRE     = "require"     // 必须加密，不校验证书
+	SSL_MODE_VERIFY_CA   = "verify-ca"   // 必须加密，校验证书由受信任的 CA 签发
+	SSL_MODE_VERIFY_FULL = "verify-full" // 必须加密，校验证书签发方与主机名
+)
+
+// MYSQL_TLS_CONFIG_PREFIX MySQL 驱动中注册的 TLS 配置名前缀，每个主机单独注册以便按主机名校验证书
+const MYSQL_TLS_CONFIG_PREFIX = "lease-"
+
+// registerMySQLTLS 按 TLS 模式为 MySQL 主机注册 TLS 配置
+// 参数：
+//   - config: 数据库配置
+//   - host: 数据库主机
+//
+// 返回值：
+//   - string: DSN 中 tls 参数的取值，不加密时为空
+//   - error: 证书加载失败时返回错误
+func registerMySQLTLS(config configs.DatabaseConfig, host string) (string, error) {
+	switch config.DBSSLMode {
+	case "", SSL_MODE_DISABLE:
+		return "", nil
+	case SSL_MODE_PREFER:
+		return "preferred", nil
+	}
+
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	if config.DBSSLCA != "" {
+		pool, err := loadCertPool(config.DBSSLCA)
+		if err != nil {
+			return "", err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.DBSSLCert != "" {
+		cert, err := tls.LoadX509KeyPair(config.DBSSLCert, config.DBSSLKey)
+		if err != nil {
+			return "", fmt.Errorf("加载数据库客户端证书失败: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	switch config.DBSSLMode {
+	case SSL_MODE_REQUIRE:
+		tlsConfig.InsecureSkipVerify = true
+	case SSL_MODE_VERIFY_CA:
+		// 跳过默认校验中的主机名比对，仅校验证书链
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = verifyCertificateChain(tlsConfig.RootCAs)
+	case SSL_MODE_VERIFY_FULL:
+	default:
+		return "", fmt.Errorf("不支持的 TLS 模式: %s", config.DBSSLMode)
+	}
+
+	name := MYSQL_TLS_CONFIG_PREFIX + host
+	if err := mysqlDriver.RegisterTLSConfig(name, tlsConfig); err != nil {
+		return "", fmt.Errorf("注册 MySQL TLS 配置失败: %w", err)
+	}
+	return name, nil
+}
+
+// loadCertPool 加载 CA 证书文件
+// 参数：
+//   - caFile: PEM 格式的 CA 证书文件
+//
+// 返回值：
+//   - *x509.CertPool: 证书池
+//   - error: 文件读取或解析失败时返回错误
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("读取数据库 CA 证书失败: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("数据库 CA 证书 %s 中没有有效的 PEM 证书", caFile)
+	}
+	return pool, nil
+}
+
+// verifyCertificateChain 返回只校验证书链、不校验主机名的证书校验函数
+// 参数：
+//   - roots: 受信任的 CA，为空时使用系统证书
+//
+// 返回值：
+//   - func: tls.Config.VerifyPeerCertificate 回调
+func verifyCertificateChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("数据库服务端未提供证书")
+		}
+
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("解析数据库服务端证书失败: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		if _, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+			return fmt.Errorf("数据库服务端证书校验失败: %w", err)
+		}
+		return nil
+	}
+}
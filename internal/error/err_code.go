@@ -9,9 +9,46 @@ const (
 	UNKNOWN_ERR = 00000
 	SERVER_ERR  = 10000
 	BAD_REQUEST = 20000
+	FORBIDDEN   = 20003
+
+	ACCOUNT_LOCKED     = 20004
+	LOGIN_TOO_FREQUENT = 20005
+	MFA_TOKEN_INVALID  = 20006
+	MAGIC_LINK_INVALID = 20007
+
+	PASSWORD_RESET_TOO_FREQUENT = 20008
+	PASSWORD_RESET_INVALID      = 20009
+	PASSWORD_POLICY_VIOLATION   = 20010
+
+	PHONE_ALREADY_USED = 20011
+	AVATAR_INVALID     = 20012
+	AVATAR_TOO_LARGE   = 20013
+	PHONE_INVALID      = 20014
+	PHONE_CODE_INVALID = 20015
+	SMS_TOO_FREQUENT   = 20016
+
+	ACCOUNT_PENDING_VERIFICATION    = 20017
+	ACCOUNT_SUSPENDED               = 20018
+	ACCOUNT_CLOSED                  = 20019
+	ACCOUNT_ACTIVATION_INVALID      = 20020
+	ACCOUNT_ACTIVATION_TOO_FREQUENT = 20021
+	ACCOUNT_STATUS_CONFLICT         = 20022
+	PASSWORD_INCORRECT              = 20023
+
+	OIDC_PROVIDER_NOT_FOUND  = 20024
+	OIDC_STATE_INVALID       = 20025
+	OIDC_LOGIN_FAILED        = 20026
+	OIDC_IDENTITY_NOT_LINKED = 20027
+	OIDC_IDENTITY_CONFLICT   = 20028
+
+	MFA_ENROLLMENT_REQUIRED = 20029
+
+	CREDIT_BALANCE_INSUFFICIENT = 20030
+	REAUTHENTICATION_REQUIRED   = 20031
 
 	SEND_IMG_VERIFICATION_CODE_FAIL   = 10001
 	SEND_EMAIL_VERIFICATION_CODE_FAIL = 10002
+	SEND_SMS_VERIFICATION_CODE_FAIL   = 10003
 )
 
 // CodeMsg 错误码对应的错误信息
@@ -20,9 +57,46 @@ var CodeMsg = map[int]string{
 	UNKNOWN_ERR: "未知业务异常",
 	SERVER_ERR:  "服务端异常",
 	BAD_REQUEST: "错误请求",
+	FORBIDDEN:   "权限不足",
+
+	ACCOUNT_LOCKED:     "账户已被临时锁定",
+	LOGIN_TOO_FREQUENT: "登录尝试过于频繁",
+	MFA_TOKEN_INVALID:  "两步验证凭证无效或已过期",
+	MAGIC_LINK_INVALID: "登录链接无效或已失效",
+
+	PASSWORD_RESET_TOO_FREQUENT: "找回密码请求过于频繁",
+	PASSWORD_RESET_INVALID:      "重置密码链接无效或已失效",
+	PASSWORD_POLICY_VIOLATION:   "密码不符合安全策略",
+
+	PHONE_ALREADY_USED: "手机号已被其他账户使用",
+	AVATAR_INVALID:     "头像文件格式不支持",
+	AVATAR_TOO_LARGE:   "头像文件过大",
+	PHONE_INVALID:      "手机号格式无效",
+	PHONE_CODE_INVALID: "短信验证码错误或已过期",
+	SMS_TOO_FREQUENT:   "短信发送过于频繁",
+
+	ACCOUNT_PENDING_VERIFICATION:    "账户尚未激活",
+	ACCOUNT_SUSPENDED:               "账户已被停用",
+	ACCOUNT_CLOSED:                  "账户已注销",
+	ACCOUNT_ACTIVATION_INVALID:      "激活链接无效或已失效",
+	ACCOUNT_ACTIVATION_TOO_FREQUENT: "激活邮件发送过于频繁",
+	ACCOUNT_STATUS_CONFLICT:         "账户当前状态不允许该操作",
+	PASSWORD_INCORRECT:              "密码错误",
+
+	OIDC_PROVIDER_NOT_FOUND:  "身份提供方不存在",
+	OIDC_STATE_INVALID:       "第三方登录请求无效或已过期",
+	OIDC_LOGIN_FAILED:        "第三方登录失败",
+	OIDC_IDENTITY_NOT_LINKED: "第三方身份尚未关联账户",
+	OIDC_IDENTITY_CONFLICT:   "第三方身份关联冲突",
+
+	MFA_ENROLLMENT_REQUIRED: "账户角色要求启用两步验证",
+
+	CREDIT_BALANCE_INSUFFICIENT: "预收余额不足",
+	REAUTHENTICATION_REQUIRED:   "请重新验证身份",
 
 	SEND_IMG_VERIFICATION_CODE_FAIL:   "图形验证码发送失败",
 	SEND_EMAIL_VERIFICATION_CODE_FAIL: "邮箱验证码发送失败",
+	SEND_SMS_VERIFICATION_CODE_FAIL:   "短信验证码发送失败",
 }
 
 // GetMessage 根据错误码获取对应的错误信息
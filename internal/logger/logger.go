@@ -2,6 +2,7 @@
 package logger
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -89,4 +90,32 @@ func New() {
 	}
 
 	global.SysLog = logger
+
+	// 日志级别随配置热更新，输出路径与轮转策略需重启生效
+	configs.OnChange("log", applyLogConfig)
+}
+
+// applyLogConfig 应用变更后的日志配置
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//
+// 返回值：
+//   - error: 日志级别无效时返回错误
+func applyLogConfig(oldConfig, newConfig *configs.Config) error {
+	logLevel, err := logrus.ParseLevel(newConfig.LogConfig.LogLevel)
+	if err != nil {
+		return fmt.Errorf("日志级别「%s」无效: %w", newConfig.LogConfig.LogLevel, err)
+	}
+	global.SysLog.SetLevel(logLevel)
+
+	if oldConfig.LogConfig.LogFilePath != newConfig.LogConfig.LogFilePath ||
+		oldConfig.LogConfig.LogFileName != newConfig.LogConfig.LogFileName ||
+		oldConfig.LogConfig.LogMaxAge != newConfig.LogConfig.LogMaxAge ||
+		oldConfig.LogConfig.LogRotationTime != newConfig.LogConfig.LogRotationTime ||
+		oldConfig.LogConfig.LogTimestampFmt != newConfig.LogConfig.LogTimestampFmt {
+		global.SysLog.Warn("日志输出路径、轮转策略与时间格式的变更需重启后生效")
+	}
+	global.SysLog.Infof("日志级别已更新为 %s", logLevel)
+	return nil
 }
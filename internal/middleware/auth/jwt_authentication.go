@@ -4,14 +4,17 @@
 package auth_middleware
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 
-	"jank.com/jank_blog/internal/global"
-	"jank.com/jank_blog/internal/utils"
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
 )
 
 // JWTConfig 定义了 Token 相关的配置
@@ -19,7 +22,6 @@ type JWTConfig struct {
 	Authorization string // 认证头名称
 	TokenPrefix   string // Token前缀
 	RefreshToken  string // 刷新令牌头名称
-	UserCache     string // 用户缓存键前缀
 }
 
 // DefaultJWTConfig 默认配置
@@ -27,51 +29,205 @@ var DefaultJWTConfig = JWTConfig{
 	Authorization: "Authorization",
 	TokenPrefix:   "Bearer ",
 	RefreshToken:  "REFRESH_TOKEN",
-	UserCache:     "USER_CACHE",
 }
 
+// 上下文键
+const (
+	ACCOUNT_ID_CONTEXT_KEY      = "account_id"      // 上下文中保存当前账户 ID 的键
+	ORGANIZATION_ID_CONTEXT_KEY = "organization_id" // 上下文中保存当前组织 ID 的键
+	PERMISSIONS_CONTEXT_KEY     = "permissions"     // 上下文中保存当前账户权限编码的键
+	SESSION_ID_CONTEXT_KEY      = "session_id"      // 上下文中保存当前会话 ID 的键
+)
+
 // AuthMiddleware 处理 JWT 认证中间件
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func AuthMiddleware() echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			// 从请求头中提取 Access Token
-			authHeader := c.Request().Header.Get(DefaultJWTConfig.Authorization)
-			if authHeader == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "缺少 Authorization 请求头")
-			}
-			tokenString := strings.TrimPrefix(authHeader, DefaultJWTConfig.TokenPrefix)
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从请求头中提取 Access Token
+		authHeader := c.GetHeader(DefaultJWTConfig.Authorization)
+		if authHeader == "" {
+			abortUnauthorized(c, "缺少 Authorization 请求头")
+			return
+		}
+		tokenString := strings.TrimPrefix(authHeader, DefaultJWTConfig.TokenPrefix)
 
-			// 验证 JWT Token；若验证失败则尝试使用 Refresh Token 刷新
-			_, err := utils.ValidateJWTToken(tokenString, false)
-			if err != nil {
-				refreshHeader := c.Request().Header.Get(DefaultJWTConfig.RefreshToken)
-				if refreshHeader == "" {
-					return echo.NewHTTPError(http.StatusUnauthorized, "无效 Access Token，请重新登录")
-				}
-				refreshTokenString := strings.TrimPrefix(refreshHeader, DefaultJWTConfig.TokenPrefix)
-				newTokens, refreshErr := utils.RefreshTokenLogic(refreshTokenString)
-				if refreshErr != nil {
-					return echo.NewHTTPError(http.StatusUnauthorized, "无效 Access 和 Refresh Token，请重新登录")
-				}
-				c.Response().Header().Set(DefaultJWTConfig.Authorization, DefaultJWTConfig.TokenPrefix+newTokens["accessToken"])
-				c.Response().Header().Set(DefaultJWTConfig.RefreshToken, DefaultJWTConfig.TokenPrefix+newTokens["refreshToken"])
-				tokenString = newTokens["accessToken"]
+		// 验证 JWT Token；若验证失败则尝试使用 Refresh Token 刷新
+		_, err := utils.ValidateJWTToken(tokenString, false)
+		if err != nil {
+			refreshHeader := c.GetHeader(DefaultJWTConfig.RefreshToken)
+			if refreshHeader == "" {
+				abortUnauthorized(c, "无效 Access Token，请重新登录")
+				return
+			}
+			refreshTokenString := strings.TrimPrefix(refreshHeader, DefaultJWTConfig.TokenPrefix)
+			newTokens, refreshErr := utils.RefreshTokenLogic(c.Request.Context(), refreshTokenString, func(accountID int64) (*utils.TokenSubject, error) {
+				return LoadTokenSubject(c, accountID)
+			})
+			if errors.Is(refreshErr, utils.ErrRefreshTokenReused) {
+				utils.BizLogger(c).Warnf("检测到 Refresh Token 重复使用，已注销对应会话")
+				abortUnauthorized(c, "检测到 Refresh Token 重复使用，该会话已注销，请重新登录")
+				return
 			}
+			if refreshErr != nil {
+				abortUnauthorized(c, "无效 Access 和 Refresh Token，请重新登录")
+				return
+			}
+			c.Header(DefaultJWTConfig.Authorization, DefaultJWTConfig.TokenPrefix+newTokens["accessToken"])
+			c.Header(DefaultJWTConfig.RefreshToken, DefaultJWTConfig.TokenPrefix+newTokens["refreshToken"])
+			tokenString = newTokens["accessToken"]
+			// 后续处理函数统一从请求头读取令牌，刷新后同步替换
+			c.Request.Header.Set(DefaultJWTConfig.Authorization, DefaultJWTConfig.TokenPrefix+tokenString)
+		}
 
-			// 从 Token 中解析 accountID
-			accountID, err := utils.ParseAccountAndRoleIDFromJWT(tokenString)
+		// 从 Token 中解析 accountID
+		accountID, err := utils.ParseAccountAndRoleIDFromJWT(tokenString)
+		if err != nil {
+			abortUnauthorized(c, "无效的 Access Token，请重新登录")
+			return
+		}
+
+		// 会话被注销后，其签发的 Access Token 即使未过期也立即失效
+		sessionID, err := utils.ParseSessionIDFromJWT(tokenString)
+		if err != nil {
+			abortUnauthorized(c, "无效的 Access Token，请重新登录")
+			return
+		}
+		authorization, err := utils.TouchSession(c.Request.Context(), accountID, sessionID)
+		if err != nil {
+			abortUnauthorized(c, "无效会话，请重新登录")
+			return
+		}
+
+		// 组织与权限以令牌声明为准；按会话缓存的账户当前角色用于使撤销的权限在令牌过期前即时失效
+		organizationID, err := utils.ParseOrganizationIDFromJWT(tokenString)
+		if err != nil {
+			abortUnauthorized(c, "无效的 Access Token，请重新登录")
+			return
+		}
+		permissions, err := utils.ParsePermissionsFromJWT(tokenString)
+		if err != nil {
+			abortUnauthorized(c, "无效的 Access Token，请重新登录")
+			return
+		}
+		if authorization == nil {
+			authorization, err = LoadAuthorization(c, accountID)
 			if err != nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, "无效的 Access Token，请重新登录")
+				utils.BizLogger(c).Errorf("加载账户「%d」权限失败: %v", accountID, err)
+				abortUnauthorized(c, "账户不存在或已失效，请重新登录")
+				return
 			}
-
-			sessionCacheKey := fmt.Sprintf("%s:%d", DefaultJWTConfig.UserCache, accountID)
-			if sessionVal, err := global.RedisClient.Get(c.Request().Context(), sessionCacheKey).Result(); err != nil || sessionVal == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "无效会话，请重新登录")
+			if err := utils.CacheSessionAuthorization(c.Request.Context(), accountID, sessionID, authorization); err != nil {
+				abortUnauthorized(c, "无效会话，请重新登录")
+				return
 			}
-
-			return next(c)
 		}
+		if authorization.OrganizationID != organizationID {
+			abortUnauthorized(c, "所属组织已变更，请重新登录")
+			return
+		}
+		permissions = slices.DeleteFunc(permissions, func(code string) bool {
+			return !slices.Contains(authorization.Permissions, code)
+		})
+
+		c.Set(ACCOUNT_ID_CONTEXT_KEY, accountID)
+		c.Set(ORGANIZATION_ID_CONTEXT_KEY, organizationID)
+		c.Set(PERMISSIONS_CONTEXT_KEY, permissions)
+		c.Set(SESSION_ID_CONTEXT_KEY, sessionID)
+		// 后续数据库读写均限定在当前组织内
+		c.Request = c.Request.WithContext(utils.WithOrganizationID(c.Request.Context(), organizationID))
+		c.Next()
+	}
+}
+
+// GetAccountID 获取经认证中间件写入上下文的账户 ID
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - int64: 账户 ID
+//   - bool: 是否存在
+func GetAccountID(c *gin.Context) (int64, bool) {
+	accountID, ok := c.Get(ACCOUNT_ID_CONTEXT_KEY)
+	if !ok {
+		return 0, false
+	}
+	id, ok := accountID.(int64)
+	return id, ok
+}
+
+// GetOrganizationID 获取经认证中间件写入上下文的组织 ID
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - int64: 组织 ID
+//   - bool: 是否存在
+func GetOrganizationID(c *gin.Context) (int64, bool) {
+	organizationID, ok := c.Get(ORGANIZATION_ID_CONTEXT_KEY)
+	if !ok {
+		return 0, false
 	}
+	id, ok := organizationID.(int64)
+	return id, ok
+}
+
+// GetSessionID 获取经认证中间件写入上下文的会话 ID
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - string: 会话 ID
+//   - bool: 是否存在
+func GetSessionID(c *gin.Context) (string, bool) {
+	sessionID, ok := c.Get(SESSION_ID_CONTEXT_KEY)
+	if !ok {
+		return "", false
+	}
+	id, ok := sessionID.(string)
+	return id, ok
+}
+
+// LoadTokenSubject 按账户当前所属组织与角色加载写入令牌的账户信息
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - *utils.TokenSubject: 账户信息
+//   - error: 加载过程中的错误
+func LoadTokenSubject(c *gin.Context, accountID int64) (*utils.TokenSubject, error) {
+	authorization, err := LoadAuthorization(c, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.TokenSubject{AccountID: accountID, OrganizationID: authorization.OrganizationID, Permissions: authorization.Permissions}, nil
+}
+
+// LoadAuthorization 按账户当前所属组织与角色加载组织与权限
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - *utils.SessionAuthorization: 组织与权限
+//   - error: 加载过程中的错误
+func LoadAuthorization(c *gin.Context, accountID int64) (*utils.SessionAuthorization, error) {
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := mapper.GetPermissionCodesByAccountID(c, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.SessionAuthorization{OrganizationID: acc.OrganizationID, Permissions: permissions}, nil
+}
+
+// abortUnauthorized 以 401 中断请求
+// 参数：
+//   - c: Gin 上下文
+//   - msg: 错误信息
+func abortUnauthorized(c *gin.Context, msg string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, vo.Fail(c, nil, bizErr.New(http.StatusUnauthorized, msg)))
 }
@@ -0,0 +1,43 @@
+// Package auth_middleware 提供JWT认证相关中间件
+package auth_middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/pkg/vo"
+)
+
+// RequirePermission 校验当前账户的 Access Token 中包含指定权限编码且该权限未被撤销，须挂载在 AuthMiddleware 之后
+// 参数：
+//   - code: 权限编码，如 lease:write
+//
+// 返回值：
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func RequirePermission(code string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(GetPermissions(c), code) {
+			c.AbortWithStatusJSON(http.StatusForbidden, vo.Fail(c, nil, bizErr.New(bizErr.FORBIDDEN, "权限不足: 需要「"+code+"」权限")))
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetPermissions 获取经认证中间件写入上下文的权限编码
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - []string: 权限编码
+func GetPermissions(c *gin.Context) []string {
+	permissions, ok := c.Get(PERMISSIONS_CONTEXT_KEY)
+	if !ok {
+		return nil
+	}
+	codes, _ := permissions.([]string)
+	return codes
+}
@@ -5,18 +5,39 @@ package cors_middleware
 
 import (
 	"net/http"
+	"slices"
 	"strings"
+	"sync/atomic"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 
-	"jank.com/jank_blog/internal/global"
+	"lease/configs"
+	"lease/internal/global"
 )
 
-// InitCORS 初始化 CORS 中间件
+// currentCORSConfig 当前生效的 CORS 配置，随配置热更新替换
+var currentCORSConfig atomic.Pointer[corsConfig]
+
+// InitCORS 初始化 CORS 中间件，允许的来源与是否携带凭证读取 cors 配置段并随配置热更新
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func InitCORS() echo.MiddlewareFunc {
-	return corsWithConfig(defaultCORSConfig())
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func InitCORS() gin.HandlerFunc {
+	config := defaultCORSConfig()
+	if cfg, err := configs.LoadConfig(); err == nil {
+		config = corsConfigFrom(cfg)
+	} else {
+		global.SysLog.Errorf("加载 CORS 配置失败，使用默认配置: %v", err)
+	}
+	currentCORSConfig.Store(&config)
+
+	configs.OnChange("cors", func(oldConfig, newConfig *configs.Config) error {
+		config := corsConfigFrom(newConfig)
+		currentCORSConfig.Store(&config)
+		global.SysLog.Infof("CORS 允许的来源已更新为 %v", config.AllowedOrigins)
+		return nil
+	})
+
+	return corsWithConfig(&currentCORSConfig)
 }
 
 // CORSConfig 定义 CORS 中间件的配置
@@ -39,39 +60,78 @@ func defaultCORSConfig() corsConfig {
 	}
 }
 
-// corsWithConfig 返回一个 CORS 中间件函数
+// corsConfigFrom 以默认配置为基础，覆盖 cors 配置段中的允许来源与凭证设置
 // 参数：
-//   - config: CORS配置
+//   - cfg: 应用配置
 //
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func corsWithConfig(config corsConfig) echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			c.Response().Header().Set("Access-Control-Allow-Origin", strings.Join(config.AllowedOrigins, ","))
-			c.Response().Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ","))
-			c.Response().Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ","))
-
-			if config.AllowCredentials {
-				c.Response().Header().Set("Access-Control-Allow-Credentials", "true")
-			}
+//   - corsConfig: CORS 配置
+func corsConfigFrom(cfg *configs.Config) corsConfig {
+	config := defaultCORSConfig()
+	if len(cfg.CORSConfig.AllowedOrigins) > 0 {
+		config.AllowedOrigins = cfg.CORSConfig.AllowedOrigins
+	}
+	config.AllowCredentials = cfg.CORSConfig.AllowCredentials
+	return config
+}
 
-			// 处理预检请求，缓存预检请求结果 24 小时
-			if c.Request().Method == "OPTIONS" {
-				c.Set("Access-Control-Max-Age", "86400")
-				return c.NoContent(http.StatusNoContent)
-			}
+// allowOrigin 计算 Access-Control-Allow-Origin 的取值：允许全部来源且不携带凭证时为 "*"，
+// 否则仅在请求来源被允许时原样回写，浏览器不接受多个来源或携带凭证时的通配符
+// 参数：
+//   - config: CORS 配置
+//   - origin: 请求来源
+//
+// 返回值：
+//   - string: 头部取值，来源不被允许时为空
+func allowOrigin(config *corsConfig, origin string) string {
+	wildcard := slices.Contains(config.AllowedOrigins, "*")
+	if wildcard && !config.AllowCredentials {
+		return "*"
+	}
+	if origin != "" && (wildcard || slices.Contains(config.AllowedOrigins, origin)) {
+		return origin
+	}
+	return ""
+}
 
-			// 记录 CORS 请求
-			if global.BizLog != nil {
-				global.BizLog.Info("CORS request",
-					"method", c.Request().Method,
-					"path", c.Request().URL.Path,
-					"origin", c.Request().Header.Get("Origin"),
-				)
+// corsWithConfig 返回一个 CORS 中间件函数，每个请求读取当前生效的配置
+// 参数：
+//   - current: 当前生效的 CORS 配置
+//
+// 返回值：
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func corsWithConfig(current *atomic.Pointer[corsConfig]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config := current.Load()
+		if origin := allowOrigin(config, c.GetHeader("Origin")); origin != "" {
+			c.Header("Access-Control-Allow-Origin", origin)
+			if origin != "*" {
+				c.Header("Vary", "Origin")
 			}
+		}
+		c.Header("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ","))
+		c.Header("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ","))
+
+		if config.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
 
-			return next(c)
+		// 处理预检请求，缓存预检请求结果 24 小时
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Max-Age", "86400")
+			c.AbortWithStatus(http.StatusNoContent)
+			return
 		}
+
+		// 记录 CORS 请求
+		if global.BizLog != nil {
+			global.BizLog.Info("CORS request",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"origin", c.GetHeader("Origin"),
+			)
+		}
+
+		c.Next()
 	}
 }
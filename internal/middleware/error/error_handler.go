@@ -8,39 +8,50 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 
-	bizerr "jank.com/jank_blog/internal/error"
-	"jank.com/jank_blog/internal/global"
-	"jank.com/jank_blog/pkg/vo"
+	bizerr "lease/internal/error"
+	"lease/internal/global"
+	"lease/pkg/vo"
 )
 
-// InitError 全局错误处理中间件
+// InitError 全局错误处理中间件，处理通过 c.Error 记录的错误
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func InitError() echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			if err := next(c); err != nil {
-				code := http.StatusInternalServerError
-				var e *bizerr.Err
-				if errors.As(err, &e) {
-					code = e.Code
-				}
-
-				// 捕获请求信息：请求方法、请求URI、客户端IP、User-Agent
-				requestMethod := c.Request().Method
-				requestURI := c.Request().RequestURI
-				clientIP := c.Request().RemoteAddr
-				userAgent := c.Request().UserAgent()
-
-				// 构建日志消息
-				logMessage := fmt.Sprintf("请求异常: %v | Method: %s | URI: %s | IP: %s | User-Agent: %s", err, requestMethod, requestURI, clientIP, userAgent)
-				global.SysLog.Error(logMessage)
-
-				return c.JSON(code, vo.Fail(c, nil, bizerr.New(code, err.Error())))
-			}
-			return nil
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func InitError() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		code := http.StatusInternalServerError
+		var e *bizerr.Err
+		if errors.As(err, &e) {
+			code = e.Code
+		}
+
+		// 捕获请求信息：请求方法、请求URI、客户端IP、User-Agent
+		requestMethod := c.Request.Method
+		requestURI := c.Request.RequestURI
+		clientIP := c.ClientIP()
+		userAgent := c.Request.UserAgent()
+
+		// 构建日志消息
+		logMessage := fmt.Sprintf("请求异常: %v | Method: %s | URI: %s | IP: %s | User-Agent: %s", err, requestMethod, requestURI, clientIP, userAgent)
+		global.SysLog.Error(logMessage)
+
+		// 处理函数已写出响应时不再覆盖
+		if c.Writer.Written() {
+			return
+		}
+
+		status := code
+		if http.StatusText(status) == "" {
+			status = http.StatusInternalServerError
 		}
+		c.JSON(status, vo.Fail(c, nil, bizerr.New(code, err.Error())))
 	}
 }
@@ -38,7 +38,7 @@ func New(app *gin.Engine) {
 	initSwagger(app)
 }
 
-// initSwagger 根据配置初始化 Swagger 文档中间件
+// initSwagger 根据配置初始化 Swagger 文档中间件，禁用时同样注册，以便配置热更新后启用
 // 参数：
 //   - app: gin实例
 func initSwagger(app *gin.Engine) {
@@ -50,9 +50,10 @@ func initSwagger(app *gin.Engine) {
 
 	switch cfg.SwaggerConfig.SwaggerEnabled {
 	case "true":
-		app.Use(swagger_middleware.InitSwagger())
+		app.Use(swagger_middleware.InitSwagger(true))
 		global.SysLog.Info("Swagger 已启用")
 	default:
+		app.Use(swagger_middleware.InitSwagger(false))
 		global.SysLog.Info("Swagger 已禁用")
 	}
 }
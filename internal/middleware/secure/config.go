@@ -0,0 +1,69 @@
+// Package secure_middleware 提供安全相关中间件
+package secure_middleware
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"lease/configs"
+	"lease/internal/global"
+)
+
+var (
+	currentXSSConfig  atomic.Pointer[xssConfig]  // 当前生效的 XSS 防护配置
+	currentCSRFConfig atomic.Pointer[csrfConfig] // 当前生效的 CSRF 配置
+	loadConfigOnce    sync.Once                  // 保证配置只加载并订阅一次
+)
+
+// loadSecureConfig 按 security 配置段初始化安全中间件配置并订阅其变更，配置无效时使用默认配置
+func loadSecureConfig() {
+	loadConfigOnce.Do(func() {
+		xss, csrf := defaultXSSConfig, defaultCSRFConfig
+		currentXSSConfig.Store(&xss)
+		currentCSRFConfig.Store(&csrf)
+
+		cfg, err := configs.LoadConfig()
+		if err != nil {
+			global.SysLog.Errorf("加载安全中间件配置失败，使用默认配置: %v", err)
+		} else if err := applySecureConfig(nil, cfg); err != nil {
+			global.SysLog.Errorf("安全中间件配置无效，使用默认配置: %v", err)
+		}
+
+		configs.OnChange("security", applySecureConfig)
+	})
+}
+
+// applySecureConfig 以默认配置为基础应用 security 配置段中的安全响应头与 CSRF Cookie 设置
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//
+// 返回值：
+//   - error: 配置取值无效时返回错误，此时保持原配置
+func applySecureConfig(oldConfig, newConfig *configs.Config) error {
+	security := newConfig.SecurityConfig
+	xss, csrf := defaultXSSConfig, defaultCSRFConfig
+
+	if security.HSTSMaxAge < 0 {
+		return fmt.Errorf("HSTS_MAX_AGE 不能为负数: %d", security.HSTSMaxAge)
+	}
+	switch frameOptions := strings.ToUpper(strings.TrimSpace(security.FrameOptions)); frameOptions {
+	case "":
+	case "DENY", "SAMEORIGIN":
+		xss.XFrameOptions = frameOptions
+	default:
+		return fmt.Errorf("X_FRAME_OPTIONS 仅支持 DENY、SAMEORIGIN: %s", security.FrameOptions)
+	}
+	xss.HSTSMaxAge = security.HSTSMaxAge
+	xss.ContentSecurityPolicy = security.ContentSecurityPolicy
+	csrf.CookieSecure = security.CSRFCookieSecure
+
+	currentXSSConfig.Store(&xss)
+	currentCSRFConfig.Store(&csrf)
+	if oldConfig != nil {
+		global.SysLog.Info("安全响应头与 CSRF Cookie 配置已更新")
+	}
+	return nil
+}
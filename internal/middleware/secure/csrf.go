@@ -9,21 +9,25 @@ import (
 	"errors"
 	"net/http"
 	"strings"
-	"time"
+	"sync/atomic"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/pkg/vo"
 )
 
-// InitCSRF 初始化 CSRF 中间件，使用默认配置
+// InitCSRF 初始化 CSRF 中间件，以默认配置为基础应用 security 配置段并随配置热更新
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func InitCSRF() echo.MiddlewareFunc {
-	return csrfWithConfig(defaultCSRFConfig)
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func InitCSRF() gin.HandlerFunc {
+	loadSecureConfig()
+	return csrfWithConfig(&currentCSRFConfig)
 }
 
 // csrfConfig 定义了 CSRF 中间件的配置
 type csrfConfig struct {
-	Skipper        func(echo.Context) bool // 用于跳过中间件的配置
+	Skipper        func(*gin.Context) bool // 用于跳过中间件的配置
 	TokenLength    uint8                   // Token 的长度
 	TokenLookup    string                  // Token 查找方式，默认 "header:X-CSRF-Token"
 	ContextKey     string                  // 上下文存储 CSRF Token 的键
@@ -38,70 +42,71 @@ type csrfConfig struct {
 
 // defaultCSRFConfig 提供默认的 CSRF 配置
 var defaultCSRFConfig = csrfConfig{
-	Skipper:        func(c echo.Context) bool { return false },
-	TokenLength:    32,                                // Token 默认长度 32 字节
-	TokenLookup:    "header:" + echo.HeaderXCSRFToken, // 默认从 Header 查找 X-CSRF-Token
-	ContextKey:     "csrf",                            // 上下文中的 CSRF Token 键
-	CookieName:     "_csrf",                           // 默认 CSRF Cookie 名称
-	CookiePath:     "/",                               // Cookie 默认路径
-	CookieDomain:   "",                                // 默认不设置 Cookie 域
-	CookieSecure:   false,                             // 默认不开启 Secure
-	CookieHTTPOnly: true,                              // 默认启用 HttpOnly
-	CookieSameSite: http.SameSiteLaxMode,              // 默认 SameSite 设置为 Lax
-	CookieMaxAge:   86400,                             // Cookie 默认 24 小时有效期
+	Skipper:        func(c *gin.Context) bool { return false },
+	TokenLength:    32,                    // Token 默认长度 32 字节
+	TokenLookup:    "header:X-CSRF-Token", // 默认从 Header 查找 X-CSRF-Token
+	ContextKey:     "csrf",                // 上下文中的 CSRF Token 键
+	CookieName:     "_csrf",               // 默认 CSRF Cookie 名称
+	CookiePath:     "/",                   // Cookie 默认路径
+	CookieDomain:   "",                    // 默认不设置 Cookie 域
+	CookieSecure:   false,                 // 默认不开启 Secure
+	CookieHTTPOnly: true,                  // 默认启用 HttpOnly
+	CookieSameSite: http.SameSiteLaxMode,  // 默认 SameSite 设置为 Lax
+	CookieMaxAge:   86400,                 // Cookie 默认 24 小时有效期
 }
 
-// csrfWithConfig 使用传入的配置生成 CSRF 中间件
+// csrfWithConfig 使用当前生效的配置生成 CSRF 中间件，每个请求读取最新配置
 // 参数：
-//   - config: CSRF 配置
+//   - current: 当前生效的 CSRF 配置
 //
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func csrfWithConfig(config csrfConfig) echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			if config.Skipper(c) {
-				return next(c)
-			}
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func csrfWithConfig(current *atomic.Pointer[csrfConfig]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config := *current.Load()
+		if config.Skipper(c) {
+			c.Next()
+			return
+		}
 
-			token, err := getTokenFromRequest(c, config.TokenLookup)
-			if err != nil || token == "" {
-				token = generateCSRFToken(config.TokenLength)
-				setCSRFCookie(c, config, token)
-			} else {
-				csrfCookie, err := c.Cookie(config.CookieName)
-				if err != nil || csrfCookie.Value != token {
-					return echo.NewHTTPError(http.StatusForbidden, "CSRF token 验证失败")
-				}
+		token, err := getTokenFromRequest(c, config.TokenLookup)
+		if err != nil || token == "" {
+			token = generateCSRFToken(config.TokenLength)
+			setCSRFCookie(c, config, token)
+		} else {
+			csrfCookie, err := c.Cookie(config.CookieName)
+			if err != nil || csrfCookie != token {
+				c.AbortWithStatusJSON(http.StatusForbidden, vo.Fail(c, nil, bizErr.New(http.StatusForbidden, "CSRF token 验证失败")))
+				return
 			}
+		}
 
-			c.Set(config.ContextKey, token)
+		c.Set(config.ContextKey, token)
 
-			return next(c)
-		}
+		c.Next()
 	}
 }
 
 // getTokenFromRequest 从请求中获取 CSRF token
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - lookup: Token 查找方式
 //
 // 返回值：
 //   - string: CSRF Token
 //   - error: 获取过程中的错误
-func getTokenFromRequest(c echo.Context, lookup string) (string, error) {
+func getTokenFromRequest(c *gin.Context, lookup string) (string, error) {
 	parts := strings.Split(lookup, ":")
 	if len(parts) != 2 {
 		return "", errors.New("无效的 Token 查找方式")
 	}
 	switch parts[0] {
 	case "header":
-		return c.Request().Header.Get(parts[1]), nil
+		return c.GetHeader(parts[1]), nil
 	case "form":
-		return c.FormValue(parts[1]), nil
+		return c.PostForm(parts[1]), nil
 	case "query":
-		return c.QueryParam(parts[1]), nil
+		return c.Query(parts[1]), nil
 	default:
 		return "", errors.New("不支持的 Token 查找类型")
 	}
@@ -121,19 +126,18 @@ func generateCSRFToken(length uint8) string {
 
 // setCSRFCookie 设置 CSRF Token 到 Cookie
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - config: CSRF 配置
 //   - token: CSRF Token
-func setCSRFCookie(c echo.Context, config csrfConfig, token string) {
-	cookie := &http.Cookie{
-		Name:     config.CookieName,
-		Value:    token,
-		Path:     config.CookiePath,
-		Domain:   config.CookieDomain,
-		Secure:   config.CookieSecure,
-		HttpOnly: config.CookieHTTPOnly,
-		SameSite: config.CookieSameSite,
-		Expires:  time.Now().Add(time.Duration(config.CookieMaxAge) * time.Second),
-	}
-	c.SetCookie(cookie)
+func setCSRFCookie(c *gin.Context, config csrfConfig, token string) {
+	c.SetSameSite(config.CookieSameSite)
+	c.SetCookie(
+		config.CookieName,
+		token,
+		config.CookieMaxAge,
+		config.CookiePath,
+		config.CookieDomain,
+		config.CookieSecure,
+		config.CookieHTTPOnly,
+	)
 }
@@ -5,20 +5,22 @@ package secure_middleware
 
 import (
 	"strconv"
+	"sync/atomic"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 )
 
-// InitXss 返回一个 XSS 防护中间件，使用默认配置
+// InitXss 返回一个 XSS 防护中间件，以默认配置为基础应用 security 配置段并随配置热更新
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func InitXss() echo.MiddlewareFunc {
-	return xssWithConfig(defaultXSSConfig)
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func InitXss() gin.HandlerFunc {
+	loadSecureConfig()
+	return xssWithConfig(&currentXSSConfig)
 }
 
 // xssConfig 用于配置 XSS 防护中间件
 type xssConfig struct {
-	Skipper               func(echo.Context) bool // 用于跳过中间件的配置
+	Skipper               func(*gin.Context) bool // 用于跳过中间件的配置
 	XSSPrevention         string                  // X-XSS-Protection 头部配置
 	ContentTypeNosniff    string                  // X-Content-Type-Options 头部配置
 	XFrameOptions         string                  // X-Frame-Options 头部配置
@@ -29,7 +31,7 @@ type xssConfig struct {
 
 // defaultXSSConfig 默认的 XSS 防护配置
 var defaultXSSConfig = xssConfig{
-	Skipper:               func(c echo.Context) bool { return false }, // 默认不跳过
+	Skipper:               func(c *gin.Context) bool { return false }, // 默认不跳过
 	XSSPrevention:         "1; mode=block",                            // 开启 XSS 防护
 	ContentTypeNosniff:    "nosniff",                                  // 禁止浏览器自动猜测内容类型
 	XFrameOptions:         "SAMEORIGIN",                               // 允许来自同一来源的嵌入式框架
@@ -38,43 +40,39 @@ var defaultXSSConfig = xssConfig{
 	ContentSecurityPolicy: "",                                         // Content-Security-Policy 头部配置
 }
 
-// xssWithConfig 返回一个 XSS 防护中间件函数
+// xssWithConfig 返回一个 XSS 防护中间件函数，每个请求读取当前生效的配置
 // 参数：
-//   - config: XSS 防护配置
+//   - current: 当前生效的 XSS 防护配置
 //
 // 返回值：
-//   - echo.MiddlewareFunc: Echo 框架中间件函数
-func xssWithConfig(config xssConfig) echo.MiddlewareFunc {
-	if config.Skipper == nil {
-		config.Skipper = defaultXSSConfig.Skipper
-	}
-
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			if config.Skipper(c) {
-				return next(c)
-			}
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func xssWithConfig(current *atomic.Pointer[xssConfig]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config := current.Load()
+		if config.Skipper != nil && config.Skipper(c) {
+			c.Next()
+			return
+		}
 
-			// 设置 X-XSS-Protection 头部
-			c.Response().Header().Set("X-XSS-Protection", config.XSSPrevention)
-			// 设置 X-Content-Type-Options 头部
-			c.Response().Header().Set("X-Content-Type-Options", config.ContentTypeNosniff)
-			// 设置 X-Frame-Options 头部
-			c.Response().Header().Set("X-Frame-Options", config.XFrameOptions)
-			// 设置 Strict-Transport-Security 头部
-			if config.HSTSMaxAge > 0 {
-				hstsHeader := "max-age=" + strconv.Itoa(config.HSTSMaxAge)
-				if config.HSTSExcludeSubdomains {
-					hstsHeader += "; includeSubDomains"
-				}
-				c.Response().Header().Set("Strict-Transport-Security", hstsHeader)
+		// 设置 X-XSS-Protection 头部
+		c.Header("X-XSS-Protection", config.XSSPrevention)
+		// 设置 X-Content-Type-Options 头部
+		c.Header("X-Content-Type-Options", config.ContentTypeNosniff)
+		// 设置 X-Frame-Options 头部
+		c.Header("X-Frame-Options", config.XFrameOptions)
+		// 设置 Strict-Transport-Security 头部
+		if config.HSTSMaxAge > 0 {
+			hstsHeader := "max-age=" + strconv.Itoa(config.HSTSMaxAge)
+			if config.HSTSExcludeSubdomains {
+				hstsHeader += "; includeSubDomains"
 			}
-			// 设置 Content-Security-Policy 头部
-			if config.ContentSecurityPolicy != "" {
-				c.Response().Header().Set("Content-Security-Policy", config.ContentSecurityPolicy)
-			}
-
-			return next(c)
+			c.Header("Strict-Transport-Security", hstsHeader)
 		}
+		// 设置 Content-Security-Policy 头部
+		if config.ContentSecurityPolicy != "" {
+			c.Header("Content-Security-Policy", config.ContentSecurityPolicy)
+		}
+
+		c.Next()
 	}
 }
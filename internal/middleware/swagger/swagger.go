@@ -5,27 +5,56 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 
-	"github.com/labstack/echo/v4"
-	echoSwagger "github.com/swaggo/echo-swagger"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 
-	"jank.com/jank_blog/configs"
-	"jank.com/jank_blog/docs"
-	"jank.com/jank_blog/internal/global"
+	"lease/configs"
+	"lease/docs"
+	"lease/internal/global"
 )
 
-var swaggerOnce sync.Once
+var (
+	swaggerOnce    sync.Once
+	swaggerEnabled atomic.Bool // Swagger 是否启用，随配置热更新
+)
 
-func InitSwagger() echo.MiddlewareFunc {
-	initSwagger()
+// InitSwagger 初始化 Swagger 文档中间件，启用时拦截 /swagger/ 路径下的请求；启用状态与文档地址随配置热更新
+// 参数：
+//   - enabled: 启动时是否启用
+//
+// 返回值：
+//   - gin.HandlerFunc: Gin 框架中间件函数
+func InitSwagger(enabled bool) gin.HandlerFunc {
+	swaggerEnabled.Store(enabled)
+	if enabled {
+		initSwagger()
+	}
 
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			if strings.HasPrefix(c.Request().URL.Path, "/swagger/") {
-				return echoSwagger.WrapHandler(c)
-			}
-			return next(c)
+	configs.OnChange("swagger", func(oldConfig, newConfig *configs.Config) error {
+		if newConfig.SwaggerConfig.SwaggerEnabled != "true" {
+			swaggerEnabled.Store(false)
+			global.SysLog.Info("Swagger 已禁用")
+			return nil
 		}
+
+		initSwagger()
+		docs.SwaggerInfo.Host = swaggerHost(newConfig)
+		swaggerEnabled.Store(true)
+		global.SysLog.Info("Swagger 已启用")
+		return nil
+	})
+
+	handler := ginSwagger.WrapHandler(swaggerFiles.Handler)
+	return func(c *gin.Context) {
+		if swaggerEnabled.Load() && strings.HasPrefix(c.Request.URL.Path, "/swagger/") {
+			handler(c)
+			c.Abort()
+			return
+		}
+		c.Next()
 	}
 }
 
@@ -38,13 +67,10 @@ func initSwagger() {
 			return
 		}
 
-		docs.SwaggerInfo.Title = "Jank Blog API"
-		docs.SwaggerInfo.Description = "这是 Jank Blog 的 API 文档，适用于账户管理、用户认证、角色权限管理，文章管理，类目管理、评论管理等功能。"
+		docs.SwaggerInfo.Title = "Lease API"
+		docs.SwaggerInfo.Description = "这是 Lease 的 API 文档，适用于账户管理、用户认证等功能。"
 		docs.SwaggerInfo.Version = "1.0"
-		docs.SwaggerInfo.Host = config.SwaggerConfig.SwaggerHost
-		if docs.SwaggerInfo.Host == "" {
-			docs.SwaggerInfo.Host = "localhost:9010"
-		}
+		docs.SwaggerInfo.Host = swaggerHost(config)
 
 		docs.SwaggerInfo.BasePath = "/"
 		docs.SwaggerInfo.Schemes = []string{"http", "https"}
@@ -61,3 +87,16 @@ func initSwagger() {
 		fmt.Printf("Swagger service started on: http://%s/swagger/index.html\n", docs.SwaggerInfo.Host)
 	})
 }
+
+// swaggerHost 获取 Swagger 文档地址，未配置时使用默认地址
+// 参数：
+//   - config: 应用配置
+//
+// 返回值：
+//   - string: 文档地址
+func swaggerHost(config *configs.Config) string {
+	if config.SwaggerConfig.SwaggerHost == "" {
+		return "localhost:9010"
+	}
+	return config.SwaggerConfig.SwaggerHost
+}
@@ -0,0 +1,187 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 迁移锁相关常量
+const (
+	LOCK_NAME           = "lease_schema_migrations" // 迁移锁名称
+	LOCK_TABLE          = "schema_migrations_lock"  // SQLite 使用的迁移锁表
+	LOCK_TIMEOUT        = 2 * time.Minute           // 等待迁移锁的超时时间
+	LOCK_RETRY_INTERVAL = 500 * time.Millisecond    // 获取迁移锁的重试间隔
+	LOCK_STALE_AFTER    = 30 * time.Minute          // SQLite 迁移锁的过期时间，持有进程异常退出后可被接管
+	createLockTableSQL  = "CREATE TABLE IF NOT EXISTS " + LOCK_TABLE + " (id INTEGER NOT NULL PRIMARY KEY, owner VARCHAR(128) NOT NULL, locked_at BIGINT NOT NULL)"
+)
+
+// ErrLockTimeout 等待迁移锁超时，通常是另一个实例正在执行迁移
+var ErrLockTimeout = errors.New("等待迁移锁超时，可能有其他实例正在执行迁移")
+
+// locker 跨进程的迁移锁
+type locker interface {
+	// tryLock 尝试获取锁，已被占用时返回 false
+	tryLock(ctx context.Context) (bool, error)
+	// unlock 释放锁
+	unlock(ctx context.Context) error
+}
+
+// newLocker 按数据库类型创建迁移锁：PostgreSQL 与 MySQL 使用会话级锁，连接断开时自动释放；SQLite 使用锁表
+// 参数：
+//   - ctx: 上下文
+//   - db: 数据库连接
+//   - dialect: 数据库类型
+//
+// 返回值：
+//   - locker: 迁移锁
+//   - error: 获取专用连接或创建锁表失败时返回错误
+func newLocker(ctx context.Context, db *gorm.DB, dialect string) (locker, error) {
+	switch dialect {
+	case DIALECT_POSTGRES, DIALECT_MYSQL:
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("获取数据库连接池失败: %w", err)
+		}
+		// 会话级锁须在同一连接上获取与释放
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取迁移锁专用连接失败: %w", err)
+		}
+		return &sessionLocker{conn: conn, dialect: dialect}, nil
+	default:
+		if err := db.WithContext(ctx).Exec(createLockTableSQL).Error; err != nil {
+			return nil, fmt.Errorf("创建迁移锁表失败: %w", err)
+		}
+		hostname, _ := os.Hostname()
+		return &tableLocker{db: db, owner: fmt.Sprintf("%s:%d", hostname, os.Getpid())}, nil
+	}
+}
+
+// acquireLock 在超时时间内反复尝试获取迁移锁
+// 参数：
+//   - ctx: 上下文
+//   - lock: 迁移锁
+//
+// 返回值：
+//   - error: 超时返回 ErrLockTimeout
+func acquireLock(ctx context.Context, lock locker) error {
+	ctx, cancel := context.WithTimeout(ctx, LOCK_TIMEOUT)
+	defer cancel()
+
+	for {
+		locked, err := lock.tryLock(ctx)
+		if err != nil {
+			return fmt.Errorf("获取迁移锁失败: %w", err)
+		}
+		if locked {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrLockTimeout
+		case <-time.After(LOCK_RETRY_INTERVAL):
+		}
+	}
+}
+
+// sessionLocker PostgreSQL 咨询锁或 MySQL 命名锁
+type sessionLocker struct {
+	conn    *sql.Conn
+	dialect string
+}
+
+// tryLock 尝试获取会话级锁
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - bool: 获取成功返回 true
+//   - error: 查询失败时返回错误
+func (l *sessionLocker) tryLock(ctx context.Context) (bool, error) {
+	switch l.dialect {
+	case DIALECT_POSTGRES:
+		var locked bool
+		err := l.conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey()).Scan(&locked)
+		return locked, err
+	default:
+		var locked sql.NullInt64
+		if err := l.conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", LOCK_NAME).Scan(&locked); err != nil {
+			return false, err
+		}
+		return locked.Valid && locked.Int64 == 1, nil
+	}
+}
+
+// unlock 释放会话级锁并归还专用连接
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - error: 释放失败时返回错误
+func (l *sessionLocker) unlock(ctx context.Context) error {
+	defer l.conn.Close()
+
+	var err error
+	switch l.dialect {
+	case DIALECT_POSTGRES:
+		_, err = l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey())
+	default:
+		_, err = l.conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", LOCK_NAME)
+	}
+	return err
+}
+
+// advisoryLockKey 由锁名称计算 PostgreSQL 咨询锁的键
+// 返回值：
+//   - int64: 咨询锁键
+func advisoryLockKey() int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(LOCK_NAME))
+	return int64(hash.Sum64())
+}
+
+// tableLocker 基于锁表唯一主键的迁移锁，锁超过 LOCK_STALE_AFTER 未释放时视为持有进程已退出
+type tableLocker struct {
+	db    *gorm.DB
+	owner string
+}
+
+// tryLock 尝试写入锁记录，先清理过期的锁
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - bool: 获取成功返回 true
+//   - error: 查询失败时返回错误
+func (l *tableLocker) tryLock(ctx context.Context) (bool, error) {
+	db := l.db.WithContext(ctx)
+	now := time.Now()
+	if err := db.Exec("DELETE FROM "+LOCK_TABLE+" WHERE locked_at < ?", now.Add(-LOCK_STALE_AFTER).Unix()).Error; err != nil {
+		return false, err
+	}
+
+	result := db.Exec("INSERT INTO "+LOCK_TABLE+" (id, owner, locked_at) SELECT 1, ?, ? WHERE NOT EXISTS (SELECT 1 FROM "+LOCK_TABLE+")",
+		l.owner, now.Unix())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// unlock 删除本进程持有的锁记录
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - error: 删除失败时返回错误
+func (l *tableLocker) unlock(ctx context.Context) error {
+	return l.db.WithContext(ctx).Exec("DELETE FROM "+LOCK_TABLE+" WHERE id = 1 AND owner = ?", l.owner).Error
+}
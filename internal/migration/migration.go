@@ -0,0 +1,171 @@
+// Package migration 提供版本化的 SQL 数据库迁移，按数据库类型分别维护升级与回滚脚本
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 迁移脚本相关常量
+const (
+	DIRECTION_UP   = "up"   // 升级脚本
+	DIRECTION_DOWN = "down" // 回滚脚本
+)
+
+// fileNamePattern 迁移脚本文件名格式：<版本号>_<名称>.<up|down>.sql，如 000001_baseline.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration 单个版本的迁移
+type Migration struct {
+	Version  int64  // 版本号，按数值升序执行
+	Name     string // 名称
+	UpSQL    string // 升级脚本
+	DownSQL  string // 回滚脚本
+	Checksum string // 升级脚本的 SHA-256，用于发现已执行后又被修改的脚本
+}
+
+// Load 从文件系统的 <dialect> 目录加载迁移脚本，每个版本须同时提供升级与回滚脚本
+// 参数：
+//   - fsys: 迁移脚本所在文件系统
+//   - dialect: 数据库类型，即脚本子目录名
+//
+// 返回值：
+//   - []Migration: 按版本号升序排列的迁移
+//   - error: 目录不存在、文件名不合规、版本重复或缺少脚本时返回错误
+func Load(fsys fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("读取「%s」迁移脚本目录失败: %w", dialect, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("迁移脚本文件名「%s」不符合 <版本号>_<名称>.<up|down>.sql 格式", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("迁移脚本「%s」的版本号无效", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dialect, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("读取迁移脚本「%s」失败: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("版本 %d 存在多个名称不同的迁移脚本: %s、%s", version, migration.Name, match[2])
+		}
+
+		switch match[3] {
+		case DIRECTION_UP:
+			migration.UpSQL = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		case DIRECTION_DOWN:
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpSQL == "" || migration.DownSQL == "" {
+			return nil, fmt.Errorf("版本 %d_%s 须同时提供 up 与 down 脚本", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// splitStatements 将脚本拆分为单条语句：以分号结束语句，忽略 -- 注释，
+// 字符串、引号标识符与 PostgreSQL 的 $$ 块内的分号不作为语句结束
+// 参数：
+//   - script: 迁移脚本
+//
+// 返回值：
+//   - []string: 语句列表，不含结尾分号
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote byte       // 当前所在的引号，0 表示不在引号内
+	var dollarTag string // 当前所在的 $tag$ 块，为空表示不在块内
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case dollarTag != "":
+			if strings.HasPrefix(script[i:], dollarTag) {
+				current.WriteString(dollarTag)
+				i += len(dollarTag) - 1
+				dollarTag = ""
+				continue
+			}
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case ch == '-' && strings.HasPrefix(script[i:], "--"):
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end
+				current.WriteByte('\n')
+			} else {
+				i = len(script)
+			}
+			continue
+		case ch == '$':
+			if end := strings.IndexByte(script[i+1:], '$'); end >= 0 && isDollarTag(script[i+1:i+1+end]) {
+				dollarTag = script[i : i+end+2]
+				current.WriteString(dollarTag)
+				i += end + 1
+				continue
+			}
+		case ch == ';':
+			flush()
+			continue
+		}
+		current.WriteByte(ch)
+	}
+	flush()
+	return statements
+}
+
+// isDollarTag 判断是否为 PostgreSQL $tag$ 中的标签，标签可为空
+// 参数：
+//   - tag: 两个 $ 之间的内容
+//
+// 返回值：
+//   - bool: 是合法标签返回 true
+func isDollarTag(tag string) bool {
+	for i, ch := range tag {
+		if !(ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (i > 0 && ch >= '0' && ch <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,333 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 迁移记录相关常量
+const (
+	SCHEMA_MIGRATIONS_TABLE = "schema_migrations" // 已执行迁移的记录表
+	createMigrationsSQL     = "CREATE TABLE IF NOT EXISTS " + SCHEMA_MIGRATIONS_TABLE + " (version BIGINT NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL, checksum VARCHAR(64) NOT NULL, applied_at BIGINT NOT NULL)"
+)
+
+// 支持的数据库类型，与 internal/db 保持一致
+const (
+	DIALECT_POSTGRES = "postgres" // PostgreSQL 数据库
+	DIALECT_SQLITE   = "sqlite"   // SQLite 数据库
+	DIALECT_MYSQL    = "mysql"    // MySQL 数据库
+)
+
+// 迁移状态常量
+const (
+	STATE_APPLIED  = "applied"  // 已执行
+	STATE_PENDING  = "pending"  // 待执行
+	STATE_MODIFIED = "modified" // 已执行，但脚本在执行后被修改
+	STATE_MISSING  = "missing"  // 已执行，但脚本已不存在
+)
+
+// Adopter 迁移记录为空时调用，用于接管引入版本化迁移之前已存在的数据库
+// 参数：
+//   - ctx: 上下文
+//   - db: 数据库连接
+//
+// 返回值：
+//   - int64: 视为已执行的最高版本号，全新数据库返回 0
+//   - error: 接管失败时返回错误
+type Adopter func(ctx context.Context, db *gorm.DB) (int64, error)
+
+// Status 单个版本的迁移状态
+type Status struct {
+	Version   int64  // 版本号
+	Name      string // 名称
+	State     string // 状态，取值见 STATE_*
+	AppliedAt int64  // 执行时间，未执行时为 0
+}
+
+// record 迁移记录表中的一行
+type record struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt int64
+}
+
+// Migrator 迁移执行器，升级、回滚与接管均在迁移锁内进行，多个实例同时启动时依次执行
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+	adopter    Adopter
+}
+
+// New 创建迁移执行器
+// 参数：
+//   - db: 数据库连接
+//   - dialect: 数据库类型
+//   - migrations: 按版本号升序排列的迁移
+//   - adopter: 接管已存在数据库的回调，可为 nil
+//
+// 返回值：
+//   - *Migrator: 迁移执行器
+func New(db *gorm.DB, dialect string, migrations []Migration, adopter Adopter) *Migrator {
+	return &Migrator{db: db, dialect: dialect, migrations: migrations, adopter: adopter}
+}
+
+// Up 按版本号升序执行全部待执行的迁移，每个版本与其迁移记录在同一事务中提交；
+// MySQL 的 DDL 会隐式提交事务，失败时可能已部分执行，需人工修复后重试
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - []Migration: 本次执行的迁移
+//   - error: 执行失败时返回错误，此前已执行的版本保持已执行
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(records map[int64]record) error {
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, migration, DIRECTION_UP); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down 按版本号降序回滚最近执行的若干个迁移
+// 参数：
+//   - ctx: 上下文
+//   - steps: 回滚的版本数
+//
+// 返回值：
+//   - []Migration: 本次回滚的迁移
+//   - error: 回滚失败或已执行的版本缺少脚本时返回错误
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("回滚版本数须大于 0: %d", steps)
+	}
+
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(records map[int64]record) error {
+		versions := sortedVersions(records)
+		for i := len(versions) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration, ok := byVersion[versions[i]]
+			if !ok {
+				return fmt.Errorf("版本 %d_%s 的迁移脚本不存在，无法回滚", versions[i], records[versions[i]].Name)
+			}
+			if err := m.apply(ctx, migration, DIRECTION_DOWN); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status 获取全部迁移的状态，包括已执行但脚本已不存在的版本
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - []Status: 按版本号升序排列的迁移状态
+//   - error: 查询失败时返回错误
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	records, err := m.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	known := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := Status{Version: migration.Version, Name: migration.Name, State: STATE_PENDING}
+		if rec, ok := records[migration.Version]; ok {
+			status.State, status.AppliedAt = STATE_APPLIED, rec.AppliedAt
+			if rec.Checksum != migration.Checksum {
+				status.State = STATE_MODIFIED
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	for _, version := range sortedVersions(records) {
+		if !known[version] {
+			rec := records[version]
+			statuses = append(statuses, Status{Version: version, Name: rec.Name, State: STATE_MISSING, AppliedAt: rec.AppliedAt})
+		}
+	}
+	sortStatuses(statuses)
+	return statuses, nil
+}
+
+// withLock 在迁移锁内确保记录表存在、按需接管已存在的数据库，再以最新的迁移记录执行 fn
+// 参数：
+//   - ctx: 上下文
+//   - fn: 持锁期间执行的操作，参数为已执行的迁移记录
+//
+// 返回值：
+//   - error: 获取锁、接管或 fn 执行失败时返回错误
+func (m *Migrator) withLock(ctx context.Context, fn func(records map[int64]record) error) (err error) {
+	lock, err := newLocker(ctx, m.db, m.dialect)
+	if err != nil {
+		return err
+	}
+	if err := acquireLock(ctx, lock); err != nil {
+		// 未持有锁时释放不产生影响，此处用于归还专用连接
+		lock.unlock(context.Background())
+		return err
+	}
+	defer func() {
+		if unlockErr := lock.unlock(context.Background()); unlockErr != nil && err == nil {
+			err = fmt.Errorf("释放迁移锁失败: %w", unlockErr)
+		}
+	}()
+
+	if err := m.db.WithContext(ctx).Exec(createMigrationsSQL).Error; err != nil {
+		return fmt.Errorf("创建迁移记录表失败: %w", err)
+	}
+	records, err := m.records(ctx)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 && m.adopter != nil {
+		if err := m.adopt(ctx); err != nil {
+			return err
+		}
+		if records, err = m.records(ctx); err != nil {
+			return err
+		}
+	}
+	return fn(records)
+}
+
+// adopt 调用接管回调，并将不超过其返回版本的迁移记为已执行
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - error: 接管失败时返回错误
+func (m *Migrator) adopt(ctx context.Context) error {
+	version, err := m.adopter(ctx, m.db.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("接管已存在的数据库失败: %w", err)
+	}
+	if version == 0 {
+		return nil
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if err := insertRecord(tx, migration); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// apply 在事务中执行迁移脚本并更新迁移记录
+// 参数：
+//   - ctx: 上下文
+//   - migration: 迁移
+//   - direction: 执行方向，取值为 DIRECTION_UP 或 DIRECTION_DOWN
+//
+// 返回值：
+//   - error: 执行失败时返回错误
+func (m *Migrator) apply(ctx context.Context, migration Migration, direction string) error {
+	script := migration.UpSQL
+	if direction == DIRECTION_DOWN {
+		script = migration.DownSQL
+	}
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range splitStatements(script) {
+			if _, err := tx.Statement.ConnPool.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("执行语句失败: %w\n%s", err, statement)
+			}
+		}
+		if direction == DIRECTION_DOWN {
+			return tx.Exec("DELETE FROM "+SCHEMA_MIGRATIONS_TABLE+" WHERE version = ?", migration.Version).Error
+		}
+		return insertRecord(tx, migration)
+	})
+	if err != nil {
+		return fmt.Errorf("版本 %d_%s %s 失败: %w", migration.Version, migration.Name, direction, err)
+	}
+	return nil
+}
+
+// records 查询已执行的迁移记录
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - map[int64]record: 版本号到迁移记录的映射，记录表不存在时为空
+//   - error: 查询失败时返回错误
+func (m *Migrator) records(ctx context.Context) (map[int64]record, error) {
+	records := make(map[int64]record)
+	if !m.db.WithContext(ctx).Migrator().HasTable(SCHEMA_MIGRATIONS_TABLE) {
+		return records, nil
+	}
+
+	var rows []record
+	if err := m.db.WithContext(ctx).Raw("SELECT version, name, checksum, applied_at FROM " + SCHEMA_MIGRATIONS_TABLE).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("查询迁移记录失败: %w", err)
+	}
+	for _, row := range rows {
+		records[row.Version] = row
+	}
+	return records, nil
+}
+
+// insertRecord 写入迁移记录
+// 参数：
+//   - tx: 数据库事务
+//   - migration: 迁移
+//
+// 返回值：
+//   - error: 写入失败时返回错误
+func insertRecord(tx *gorm.DB, migration Migration) error {
+	return tx.Exec("INSERT INTO "+SCHEMA_MIGRATIONS_TABLE+" (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)",
+		migration.Version, migration.Name, migration.Checksum, time.Now().Unix()).Error
+}
+
+// sortedVersions 获取按升序排列的已执行版本号
+// 参数：
+//   - records: 迁移记录
+//
+// 返回值：
+//   - []int64: 版本号列表
+func sortedVersions(records map[int64]record) []int64 {
+	versions := make([]int64, 0, len(records))
+	for version := range records {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// sortStatuses 按版本号升序排列迁移状态
+// 参数：
+//   - statuses: 迁移状态
+func sortStatuses(statuses []Status) {
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+}
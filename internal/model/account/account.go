@@ -3,15 +3,28 @@ package model
 
 import "lease/internal/model/base"
 
+// 账户状态
+const (
+	ACCOUNT_STATUS_PENDING_VERIFICATION = "pending_verification" // 待激活，注册时未验证邮箱，完成邮件激活后转为生效
+	ACCOUNT_STATUS_ACTIVE               = "active"               // 生效中，唯一允许登录的状态
+	ACCOUNT_STATUS_SUSPENDED            = "suspended"            // 已停用，由管理员停用，可重新启用
+	ACCOUNT_STATUS_CLOSED               = "closed"               // 已注销，个人信息已匿名化，不可恢复
+)
+
 // Account 用户账户模型
 type Account struct {
 	base.Base
-	Phone    string `gorm:"type:varchar(32);unique;default:null" json:"phone"` // 手机号，次登录方式
-	Email    string `gorm:"type:varchar(64);unique;not null" json:"email"`     // 邮箱，主登录方式
-	Password string `gorm:"type:varchar(255);not null" json:"password"`        // 加密密码
-	Avatar   string `gorm:"type:varchar(255);default:null" json:"avatar"`      // 用户头像
-	Nickname string `gorm:"type:varchar(64);not null" json:"nickname"`         // 昵称
-	Status   bool   `gorm:"type:boolean;default:false" json:"status"`          // 账号状态
+	base.OrgScoped
+	Phone         string `gorm:"type:varchar(32);unique;default:null" json:"phone"`            // 手机号（E.164 格式），次登录方式
+	PhoneVerified bool   `gorm:"type:boolean;default:false" json:"phone_verified"`             // 手机号是否已通过短信验证，验证后才可用于登录
+	Email         string `gorm:"type:varchar(64);unique;not null" json:"email"`                // 邮箱，主登录方式
+	Password      string `gorm:"type:varchar(255);not null" json:"password"`                   // 加密密码
+	Avatar        string `gorm:"type:varchar(255);default:null" json:"avatar"`                 // 用户头像
+	Nickname      string `gorm:"type:varchar(64);not null" json:"nickname"`                    // 昵称
+	Status        string `gorm:"type:varchar(32);not null;default:active;index" json:"status"` // 账户状态，取值见 ACCOUNT_STATUS_*
+
+	DeletionRequestedAt int64 `gorm:"type:bigint;default:0" json:"deletion_requested_at"`       // 申请注销时间，0 表示未申请
+	DeletionScheduledAt int64 `gorm:"type:bigint;default:0;index" json:"deletion_scheduled_at"` // 宽限期结束、执行匿名化的时间，宽限期内可撤销
 }
 
 // TableName 指定表名
@@ -0,0 +1,23 @@
+// Package model 提供用户账户数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// AccountIdentity 账户关联的第三方身份，以身份提供方标识与其用户标识 (sub) 唯一确定一个外部身份
+type AccountIdentity struct {
+	base.Base
+	base.OrgScoped
+	AccountID   int64  `gorm:"type:bigint;not null;index" json:"account_id"`                                       // 账户 ID
+	Provider    string `gorm:"type:varchar(32);not null;uniqueIndex:idx_account_identity_subject" json:"provider"` // 身份提供方标识
+	Subject     string `gorm:"type:varchar(255);not null;uniqueIndex:idx_account_identity_subject" json:"subject"` // 身份提供方的用户标识
+	Email       string `gorm:"type:varchar(64)" json:"email"`                                                      // 关联时身份提供方返回的邮箱
+	LinkedAt    int64  `gorm:"type:bigint;default:0" json:"linked_at"`                                             // 关联时间
+	LastLoginAt int64  `gorm:"type:bigint;default:0" json:"last_login_at"`                                         // 最近一次通过该身份登录的时间
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AccountIdentity) TableName() string {
+	return "account_identities"
+}
@@ -0,0 +1,38 @@
+// Package model 提供用户账户数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// AccountMFA 账户两步验证配置，密钥在绑定确认前保持未启用状态
+type AccountMFA struct {
+	base.Base
+	base.OrgScoped
+	AccountID    int64  `gorm:"type:bigint;uniqueIndex;not null" json:"account_id"` // 账户 ID
+	Secret       string `gorm:"type:varchar(64);not null" json:"-"`                 // Base32 编码的 TOTP 密钥
+	Enabled      bool   `gorm:"type:boolean;default:false" json:"enabled"`          // 是否已启用
+	EnabledAt    int64  `gorm:"type:bigint;default:0" json:"enabled_at"`            // 启用时间
+	LastUsedStep int64  `gorm:"type:bigint;default:0" json:"-"`                     // 最近一次通过校验的时间步，用于拒绝动态码重放
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AccountMFA) TableName() string {
+	return "account_mfas"
+}
+
+// AccountRecoveryCode 两步验证恢复码，仅保存哈希，每个恢复码只能使用一次
+type AccountRecoveryCode struct {
+	base.Base
+	base.OrgScoped
+	AccountID int64  `gorm:"type:bigint;not null;index" json:"account_id"` // 账户 ID
+	CodeHash  string `gorm:"type:varchar(64);not null;index" json:"-"`     // 恢复码 SHA-256 哈希
+	UsedAt    int64  `gorm:"type:bigint;default:0" json:"used_at"`         // 使用时间，0 表示未使用
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AccountRecoveryCode) TableName() string {
+	return "account_recovery_codes"
+}
@@ -0,0 +1,19 @@
+// Package model 提供用户账户数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// AccountPasswordHistory 账户密码历史，每次设置密码时记录哈希，用于禁止重复使用最近的密码
+type AccountPasswordHistory struct {
+	base.Base
+	base.OrgScoped
+	AccountID    int64  `gorm:"type:bigint;not null;index" json:"account_id"` // 账户 ID
+	PasswordHash string `gorm:"type:varchar(255);not null" json:"-"`          // 密码 bcrypt 哈希
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AccountPasswordHistory) TableName() string {
+	return "account_password_histories"
+}
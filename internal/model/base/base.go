@@ -24,6 +24,11 @@ type Base struct {
 	Deleted     bool    `gorm:"type:boolean;default:false" json:"deleted"` // 逻辑删除
 }
 
+// OrgScoped 按组织隔离的模型嵌入该结构，认证后的读写会自动限定在当前账户所属组织内
+type OrgScoped struct {
+	OrganizationID int64 `gorm:"type:bigint;not null;default:0;index" json:"organization_id"` // 所属组织 ID
+}
+
 // JSONMap 处理 json 类型字段
 type JSONMap map[string]interface{}
 
@@ -52,6 +57,40 @@ func (j JSONMap) Value() (driver.Value, error) {
 	return json.Marshal(j)
 }
 
+// JSONStringList 处理以 json 数组存储的字符串列表字段
+type JSONStringList []string
+
+// Scan 从数据库读取 json 数组
+// 参数：
+//   - value: 数据库返回的值
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (l *JSONStringList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return errors.New("数据类型错误，无法转换为 []byte 类型")
+	}
+}
+
+// Value 将 JSONStringList 转换为 json 数组存储到数据库
+// 返回值：
+//   - driver.Value: 数据库驱动值
+//   - error: 操作过程中的错误
+func (l JSONStringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	return json.Marshal(l)
+}
+
 // BeforeCreate 创建前操作，设置时间戳等
 // 参数：
 //   - db: GORM数据库连接
@@ -0,0 +1,30 @@
+// Package model 提供押金结算数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 押金扣款类别
+const (
+	DEDUCTION_CATEGORY_DAMAGE      = "damage"      // 物品损坏
+	DEDUCTION_CATEGORY_UNPAID_RENT = "unpaid_rent" // 欠缴租金及杂费
+	DEDUCTION_CATEGORY_CLEANING    = "cleaning"    // 清洁费
+	DEDUCTION_CATEGORY_OTHER       = "other"       // 其他
+)
+
+// DepositDeduction 押金扣款明细，需注明原因并可附带证据附件
+type DepositDeduction struct {
+	base.Base
+	base.OrgScoped
+	SettlementID int64               `gorm:"type:bigint;not null;index" json:"settlement_id"` // 押金结算单 ID
+	Category     string              `gorm:"type:varchar(16);not null" json:"category"`       // 扣款类别
+	Reason       string              `gorm:"type:varchar(255);not null" json:"reason"`        // 扣款原因
+	Amount       int64               `gorm:"type:bigint;not null" json:"amount"`              // 扣款金额（分）
+	EvidenceIDs  base.JSONStringList `gorm:"type:json" json:"evidence_ids"`                   // 证据附件 ID 列表
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (DepositDeduction) TableName() string {
+	return "deposit_deductions"
+}
@@ -0,0 +1,37 @@
+// Package model 提供押金结算数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 押金结算状态
+const (
+	SETTLEMENT_STATUS_DRAFT        = "draft"        // 业主编辑扣款明细
+	SETTLEMENT_STATUS_PENDING_ACK  = "pending_ack"  // 待租客确认
+	SETTLEMENT_STATUS_DISPUTED     = "disputed"     // 租客有异议，退回业主修改
+	SETTLEMENT_STATUS_ACKNOWLEDGED = "acknowledged" // 租客已确认
+	SETTLEMENT_STATUS_SETTLED      = "settled"      // 已结算入账
+)
+
+// DepositSettlement 押金结算单，每份合同仅有一张，经租客确认后结算入账
+type DepositSettlement struct {
+	base.Base
+	base.OrgScoped
+	ContractID      int64  `gorm:"type:bigint;not null;uniqueIndex" json:"contract_id"`    // 合同 ID
+	LandlordID      int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`          // 业主账户 ID
+	TenantID        int64  `gorm:"type:bigint;not null;index" json:"tenant_id"`            // 租客账户 ID
+	Status          string `gorm:"type:varchar(16);not null" json:"status"`                // 结算状态
+	HeldAmount      int64  `gorm:"type:bigint;not null;default:0" json:"held_amount"`      // 结算时代管押金（分）
+	DeductionAmount int64  `gorm:"type:bigint;not null;default:0" json:"deduction_amount"` // 扣款合计（分）
+	RefundAmount    int64  `gorm:"type:bigint;not null;default:0" json:"refund_amount"`    // 退还金额（分）
+	TenantComment   string `gorm:"type:varchar(255);default:null" json:"tenant_comment"`   // 租客确认或异议意见
+	AcknowledgedAt  int64  `gorm:"type:bigint;default:0" json:"acknowledged_at"`           // 租客确认时间
+	SettledAt       int64  `gorm:"type:bigint;default:0" json:"settled_at"`                // 结算入账时间
+	TransactionID   int64  `gorm:"type:bigint;default:0" json:"transaction_id"`            // 结算账本凭证 ID
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (DepositSettlement) TableName() string {
+	return "deposit_settlements"
+}
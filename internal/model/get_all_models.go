@@ -4,11 +4,15 @@
 package model
 
 import (
-	account "jank.com/jank_blog/internal/model/account"
-	association "jank.com/jank_blog/internal/model/association"
-	category "jank.com/jank_blog/internal/model/category"
-	comment "jank.com/jank_blog/internal/model/comment"
-	post "jank.com/jank_blog/internal/model/post"
+	account "lease/internal/model/account"
+	deposit "lease/internal/model/deposit"
+	invoice "lease/internal/model/invoice"
+	lease "lease/internal/model/lease"
+	ledger "lease/internal/model/ledger"
+	maintenance "lease/internal/model/maintenance"
+	organization "lease/internal/model/organization"
+	property "lease/internal/model/property"
+	rbac "lease/internal/model/rbac"
 )
 
 // GetAllModels 获取并注册所有模型
@@ -18,17 +22,46 @@ func GetAllModels() []interface{} {
 	return []interface{}{
 		// account 模块
 		&account.Account{},
+		&account.AccountMFA{},
+		&account.AccountRecoveryCode{},
+		&account.AccountPasswordHistory{},
+		&account.AccountIdentity{},
 
-		// post 模块
-		&post.Post{},
+		// organization 模块
+		&organization.Organization{},
+		&organization.OrganizationInvitation{},
 
-		// category 模块
-		&category.Category{},
+		// rbac 模块
+		&rbac.Role{},
+		&rbac.Permission{},
+		&rbac.RolePermission{},
+		&rbac.AccountRole{},
 
-		// comment 模块
-		&comment.Comment{},
+		// property 模块
+		&property.Property{},
+		&property.Unit{},
 
-		// association 跨模块中间表
-		&association.PostCategory{},
+		// lease 模块
+		&lease.LeaseContract{},
+		&lease.LeaseContractAudit{},
+
+		// invoice 模块
+		&invoice.Invoice{},
+		&invoice.InvoiceItem{},
+		&invoice.RecurringFee{},
+
+		// ledger 模块
+		&ledger.LedgerTransaction{},
+		&ledger.LedgerEntry{},
+		&ledger.Payment{},
+
+		// deposit 模块
+		&deposit.DepositSettlement{},
+		&deposit.DepositDeduction{},
+
+		// maintenance 模块
+		&maintenance.MaintenanceTicket{},
+		&maintenance.MaintenanceComment{},
+		&maintenance.MaintenanceTicketEvent{},
 	}
 }
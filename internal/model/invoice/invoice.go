@@ -0,0 +1,36 @@
+// Package model 提供租金账单数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 账单状态
+const (
+	INVOICE_STATUS_UNPAID         = "unpaid"         // 待支付
+	INVOICE_STATUS_PARTIALLY_PAID = "partially_paid" // 部分支付
+	INVOICE_STATUS_PAID           = "paid"           // 已结清
+	INVOICE_STATUS_VOID           = "void"           // 已作废
+)
+
+// Invoice 租金账单模型，每份合同的每个计费周期仅生成一张账单
+type Invoice struct {
+	base.Base
+	base.OrgScoped
+	ContractID  int64  `gorm:"type:bigint;not null;uniqueIndex:idx_invoice_contract_period" json:"contract_id"`       // 合同 ID
+	PeriodStart string `gorm:"type:varchar(10);not null;uniqueIndex:idx_invoice_contract_period" json:"period_start"` // 计费周期开始日期，格式 2006-01-02
+	PeriodEnd   string `gorm:"type:varchar(10);not null" json:"period_end"`                                           // 计费周期结束日期（含当日），格式 2006-01-02
+	UnitID      int64  `gorm:"type:bigint;not null;index" json:"unit_id"`                                             // 出租单元 ID
+	LandlordID  int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`                                         // 业主账户 ID
+	TenantID    int64  `gorm:"type:bigint;not null;index" json:"tenant_id"`                                           // 租客账户 ID
+	DueDate     string `gorm:"type:varchar(10);not null" json:"due_date"`                                             // 应付日期，格式 2006-01-02
+	Amount      int64  `gorm:"type:bigint;not null" json:"amount"`                                                    // 应付总额（分）
+	PaidAmount  int64  `gorm:"type:bigint;not null;default:0" json:"paid_amount"`                                     // 已付金额（分）
+	Prorated    bool   `gorm:"type:boolean;default:false" json:"prorated"`                                            // 是否为按天折算的非完整周期
+	Status      string `gorm:"type:varchar(16);not null;index" json:"status"`                                         // 账单状态
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Invoice) TableName() string {
+	return "invoices"
+}
@@ -0,0 +1,29 @@
+// Package model 提供租金账单数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 账单明细类型
+const (
+	INVOICE_ITEM_TYPE_RENT = "rent" // 租金
+	INVOICE_ITEM_TYPE_FEE  = "fee"  // 周期性杂费
+)
+
+// InvoiceItem 账单明细，记录租金及各项杂费在本周期内的应付金额
+type InvoiceItem struct {
+	base.Base
+	base.OrgScoped
+	InvoiceID int64  `gorm:"type:bigint;not null;index" json:"invoice_id"` // 账单 ID
+	ItemType  string `gorm:"type:varchar(16);not null" json:"item_type"`   // 明细类型
+	FeeID     int64  `gorm:"type:bigint;default:0" json:"fee_id"`          // 周期性杂费 ID，租金明细为 0
+	Name      string `gorm:"type:varchar(64);not null" json:"name"`        // 明细名称
+	Amount    int64  `gorm:"type:bigint;not null" json:"amount"`           // 金额（分）
+	Remark    string `gorm:"type:varchar(255);default:null" json:"remark"` // 计费说明，如折算天数
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (InvoiceItem) TableName() string {
+	return "invoice_items"
+}
@@ -0,0 +1,28 @@
+// Package model 提供租金账单数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 周期性杂费类别
+const (
+	FEE_CATEGORY_MANAGEMENT = "management" // 物业管理费
+	FEE_CATEGORY_PARKING    = "parking"    // 停车费
+	FEE_CATEGORY_OTHER      = "other"      // 其他
+)
+
+// RecurringFee 合同周期性杂费，按月计价，随租金在每个计费周期一并出账
+type RecurringFee struct {
+	base.Base
+	base.OrgScoped
+	ContractID    int64  `gorm:"type:bigint;not null;index" json:"contract_id"` // 合同 ID
+	Category      string `gorm:"type:varchar(16);not null" json:"category"`     // 杂费类别
+	Name          string `gorm:"type:varchar(64);not null" json:"name"`         // 杂费名称
+	MonthlyAmount int64  `gorm:"type:bigint;not null" json:"monthly_amount"`    // 月费用（分）
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (RecurringFee) TableName() string {
+	return "recurring_fees"
+}
@@ -0,0 +1,51 @@
+// Package model 提供租约合同数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 合同状态
+const (
+	CONTRACT_STATUS_DRAFT             = "draft"             // 草稿
+	CONTRACT_STATUS_PENDING_SIGNATURE = "pending_signature" // 待签署
+	CONTRACT_STATUS_ACTIVE            = "active"            // 生效中
+	CONTRACT_STATUS_TERMINATED        = "terminated"        // 已解约
+	CONTRACT_STATUS_EXPIRED           = "expired"           // 已到期
+	CONTRACT_STATUS_RENEWED           = "renewed"           // 已续约
+)
+
+// 计费周期
+const (
+	BILLING_CYCLE_MONTHLY   = "monthly"   // 按月
+	BILLING_CYCLE_QUARTERLY = "quarterly" // 按季
+	BILLING_CYCLE_YEARLY    = "yearly"    // 按年
+)
+
+// DATE_LAYOUT 合同日期格式
+const DATE_LAYOUT = "2006-01-02"
+
+// LeaseContract 租约合同模型，关联出租单元与租客账户
+type LeaseContract struct {
+	base.Base
+	base.OrgScoped
+	UnitID            int64  `gorm:"type:bigint;not null;index" json:"unit_id"`                // 出租单元 ID
+	PropertyID        int64  `gorm:"type:bigint;not null;index" json:"property_id"`            // 房源 ID
+	LandlordID        int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`            // 业主账户 ID
+	TenantID          int64  `gorm:"type:bigint;not null;index" json:"tenant_id"`              // 租客账户 ID
+	StartDate         string `gorm:"type:varchar(10);not null" json:"start_date"`              // 起租日期，格式 2006-01-02
+	EndDate           string `gorm:"type:varchar(10);not null" json:"end_date"`                // 到期日期（含当日），格式 2006-01-02
+	MonthlyRent       int64  `gorm:"type:bigint;not null" json:"monthly_rent"`                 // 月租金（分）
+	Deposit           int64  `gorm:"type:bigint;not null;default:0" json:"deposit"`            // 押金（分）
+	BillingCycle      string `gorm:"type:varchar(16);not null" json:"billing_cycle"`           // 计费周期
+	Status            string `gorm:"type:varchar(32);not null;index" json:"status"`            // 合同状态
+	RenewedFromID     int64  `gorm:"type:bigint;default:0;index" json:"renewed_from_id"`       // 续约来源合同 ID
+	SignedAt          int64  `gorm:"type:bigint;default:0" json:"signed_at"`                   // 签署时间
+	TerminatedAt      int64  `gorm:"type:bigint;default:0" json:"terminated_at"`               // 解约时间
+	TerminationReason string `gorm:"type:varchar(255);default:null" json:"termination_reason"` // 解约原因
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (LeaseContract) TableName() string {
+	return "lease_contracts"
+}
@@ -0,0 +1,34 @@
+// Package model 提供租约合同数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 合同状态流转动作
+const (
+	CONTRACT_ACTION_CREATE    = "create"    // 创建草稿
+	CONTRACT_ACTION_SUBMIT    = "submit"    // 提交签署
+	CONTRACT_ACTION_WITHDRAW  = "withdraw"  // 撤回至草稿
+	CONTRACT_ACTION_SIGN      = "sign"      // 租客签署生效
+	CONTRACT_ACTION_TERMINATE = "terminate" // 提前解约
+	CONTRACT_ACTION_EXPIRE    = "expire"    // 到期终止
+	CONTRACT_ACTION_RENEW     = "renew"     // 续约合同生效
+)
+
+// LeaseContractAudit 合同状态流转审计记录，只增不改
+type LeaseContractAudit struct {
+	base.Base
+	base.OrgScoped
+	ContractID int64  `gorm:"type:bigint;not null;index" json:"contract_id"`    // 合同 ID
+	Action     string `gorm:"type:varchar(32);not null" json:"action"`          // 流转动作
+	FromStatus string `gorm:"type:varchar(32);default:null" json:"from_status"` // 流转前状态
+	ToStatus   string `gorm:"type:varchar(32);not null" json:"to_status"`       // 流转后状态
+	OperatorID int64  `gorm:"type:bigint;not null" json:"operator_id"`          // 操作人账户 ID
+	Remark     string `gorm:"type:varchar(255);default:null" json:"remark"`     // 备注
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (LeaseContractAudit) TableName() string {
+	return "lease_contract_audits"
+}
@@ -0,0 +1,66 @@
+// Package model 提供复式记账账本数据模型定义
+package model
+
+import (
+	"gorm.io/gorm"
+
+	"lease/internal/model/base"
+)
+
+// 会计科目
+const (
+	LEDGER_ACCOUNT_CASH          = "cash"          // 银行存款（资产）
+	LEDGER_ACCOUNT_RECEIVABLE    = "receivable"    // 应收租客款（资产）
+	LEDGER_ACCOUNT_RENT_INCOME   = "rent_income"   // 租金收入（收入）
+	LEDGER_ACCOUNT_FEE_INCOME    = "fee_income"    // 杂费收入（收入）
+	LEDGER_ACCOUNT_TENANT_CREDIT = "tenant_credit" // 租客预收余额（负债）
+	LEDGER_ACCOUNT_DEPOSIT_HELD  = "deposit_held"  // 代管押金（负债）
+	LEDGER_ACCOUNT_DEDUCTION     = "deduction"     // 押金扣款收入（收入）
+)
+
+// 记账方向
+const (
+	ENTRY_DIRECTION_DEBIT  = "debit"  // 借
+	ENTRY_DIRECTION_CREDIT = "credit" // 贷
+)
+
+// LedgerEntry 账本分录，按科目、合同与租客记录单边借贷金额
+type LedgerEntry struct {
+	base.Base
+	base.OrgScoped
+	TransactionID int64  `gorm:"type:bigint;not null;index" json:"transaction_id"` // 凭证 ID
+	Account       string `gorm:"type:varchar(32);not null;index" json:"account"`   // 会计科目
+	Direction     string `gorm:"type:varchar(8);not null" json:"direction"`        // 记账方向
+	Amount        int64  `gorm:"type:bigint;not null" json:"amount"`               // 金额（分），恒为正数
+	ContractID    int64  `gorm:"type:bigint;not null;index" json:"contract_id"`    // 合同 ID
+	LandlordID    int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`    // 业主账户 ID
+	TenantID      int64  `gorm:"type:bigint;not null;index" json:"tenant_id"`      // 租客账户 ID
+	InvoiceID     int64  `gorm:"type:bigint;default:0;index" json:"invoice_id"`    // 关联账单 ID
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
+
+// BeforeUpdate 禁止修改已入账分录
+// 参数：
+//   - db: GORM数据库连接
+//
+// 返回值：
+//   - error: 恒为 ErrLedgerAppendOnly
+func (LedgerEntry) BeforeUpdate(db *gorm.DB) error {
+	return ErrLedgerAppendOnly
+}
+
+// BeforeDelete 禁止删除已入账分录
+// 参数：
+//   - db: GORM数据库连接
+//
+// 返回值：
+//   - error: 恒为 ErrLedgerAppendOnly
+func (LedgerEntry) BeforeDelete(db *gorm.DB) error {
+	return ErrLedgerAppendOnly
+}
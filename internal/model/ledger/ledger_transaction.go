@@ -0,0 +1,64 @@
+// Package model 提供复式记账账本数据模型定义
+package model
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"lease/internal/model/base"
+)
+
+// 账本凭证类型
+const (
+	LEDGER_TXN_INVOICE      = "invoice"      // 账单出账
+	LEDGER_TXN_PAYMENT      = "payment"      // 收款
+	LEDGER_TXN_CREDIT_APPLY = "credit_apply" // 预收余额抵扣账单
+	LEDGER_TXN_REFUND       = "refund"       // 退款
+	LEDGER_TXN_DEPOSIT      = "deposit"      // 押金结算
+)
+
+// ErrLedgerAppendOnly 账本只允许追加，禁止修改或删除已入账记录
+var ErrLedgerAppendOnly = errors.New("账本记录只增不改，请通过冲正凭证调整")
+
+// LedgerTransaction 账本凭证，一次业务事件对应一张凭证，其下分录借贷金额必须相等
+type LedgerTransaction struct {
+	base.Base
+	base.OrgScoped
+	TxnType    string `gorm:"type:varchar(16);not null;index" json:"txn_type"` // 凭证类型
+	ContractID int64  `gorm:"type:bigint;not null;index" json:"contract_id"`   // 合同 ID
+	LandlordID int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`   // 业主账户 ID
+	TenantID   int64  `gorm:"type:bigint;not null;index" json:"tenant_id"`     // 租客账户 ID
+	InvoiceID  int64  `gorm:"type:bigint;default:0;index" json:"invoice_id"`   // 关联账单 ID
+	PaymentID  int64  `gorm:"type:bigint;default:0;index" json:"payment_id"`   // 关联收款 ID
+	Amount     int64  `gorm:"type:bigint;not null" json:"amount"`              // 凭证金额（分），等于借方合计
+	Memo       string `gorm:"type:varchar(255);default:null" json:"memo"`      // 摘要
+	OperatorID int64  `gorm:"type:bigint;not null" json:"operator_id"`         // 操作人账户 ID
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (LedgerTransaction) TableName() string {
+	return "ledger_transactions"
+}
+
+// BeforeUpdate 禁止修改已入账凭证
+// 参数：
+//   - db: GORM数据库连接
+//
+// 返回值：
+//   - error: 恒为 ErrLedgerAppendOnly
+func (LedgerTransaction) BeforeUpdate(db *gorm.DB) error {
+	return ErrLedgerAppendOnly
+}
+
+// BeforeDelete 禁止删除已入账凭证
+// 参数：
+//   - db: GORM数据库连接
+//
+// 返回值：
+//   - error: 恒为 ErrLedgerAppendOnly
+func (LedgerTransaction) BeforeDelete(db *gorm.DB) error {
+	return ErrLedgerAppendOnly
+}
@@ -0,0 +1,44 @@
+// Package model 提供复式记账账本数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 收款用途
+const (
+	PAYMENT_PURPOSE_RENT    = "rent"    // 租金及杂费
+	PAYMENT_PURPOSE_DEPOSIT = "deposit" // 押金
+)
+
+// 收付款方式
+const (
+	PAYMENT_METHOD_CASH          = "cash"          // 现金
+	PAYMENT_METHOD_BANK_TRANSFER = "bank_transfer" // 银行转账
+	PAYMENT_METHOD_ALIPAY        = "alipay"        // 支付宝
+	PAYMENT_METHOD_WECHAT        = "wechat"        // 微信支付
+	PAYMENT_METHOD_OTHER         = "other"         // 其他
+)
+
+// Payment 收款记录，入账后由账本凭证体现其对账单的核销与预收结转
+type Payment struct {
+	base.Base
+	base.OrgScoped
+	ContractID    int64  `gorm:"type:bigint;not null;index" json:"contract_id"`        // 合同 ID
+	LandlordID    int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`        // 业主账户 ID
+	TenantID      int64  `gorm:"type:bigint;not null;index" json:"tenant_id"`          // 租客账户 ID
+	Purpose       string `gorm:"type:varchar(16);not null" json:"purpose"`             // 收款用途
+	Method        string `gorm:"type:varchar(16);not null" json:"method"`              // 收款方式
+	Reference     string `gorm:"type:varchar(64);default:null" json:"reference"`       // 外部流水号
+	Amount        int64  `gorm:"type:bigint;not null" json:"amount"`                   // 收款金额（分）
+	AppliedAmount int64  `gorm:"type:bigint;not null;default:0" json:"applied_amount"` // 核销账单金额（分）
+	CreditAmount  int64  `gorm:"type:bigint;not null;default:0" json:"credit_amount"`  // 结转预收金额（分）
+	PaidDate      string `gorm:"type:varchar(10);not null" json:"paid_date"`           // 收款日期，格式 2006-01-02
+	Remark        string `gorm:"type:varchar(255);default:null" json:"remark"`         // 备注
+	OperatorID    int64  `gorm:"type:bigint;not null" json:"operator_id"`              // 登记人账户 ID
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Payment) TableName() string {
+	return "payments"
+}
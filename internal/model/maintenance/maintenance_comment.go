@@ -0,0 +1,20 @@
+// Package model 提供报修工单数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// MaintenanceComment 报修工单评论，按时间顺序组成工单沟通记录
+type MaintenanceComment struct {
+	base.Base
+	base.OrgScoped
+	TicketID int64  `gorm:"type:bigint;not null;index" json:"ticket_id"` // 工单 ID
+	AuthorID int64  `gorm:"type:bigint;not null" json:"author_id"`       // 评论人账户 ID
+	Content  string `gorm:"type:text;not null" json:"content"`           // 评论内容
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (MaintenanceComment) TableName() string {
+	return "maintenance_comments"
+}
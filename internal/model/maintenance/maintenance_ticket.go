@@ -0,0 +1,67 @@
+// Package model 提供报修工单数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 工单状态
+const (
+	TICKET_STATUS_OPEN        = "open"        // 待指派
+	TICKET_STATUS_ASSIGNED    = "assigned"    // 已指派
+	TICKET_STATUS_SCHEDULED   = "scheduled"   // 已预约上门
+	TICKET_STATUS_IN_PROGRESS = "in_progress" // 维修中
+	TICKET_STATUS_RESOLVED    = "resolved"    // 已修复待关闭
+	TICKET_STATUS_CLOSED      = "closed"      // 已关闭
+	TICKET_STATUS_CANCELLED   = "cancelled"   // 已取消
+)
+
+// 工单优先级
+const (
+	TICKET_PRIORITY_LOW    = "low"    // 低
+	TICKET_PRIORITY_MEDIUM = "medium" // 中
+	TICKET_PRIORITY_HIGH   = "high"   // 高
+	TICKET_PRIORITY_URGENT = "urgent" // 紧急
+)
+
+// 工单类别
+const (
+	TICKET_CATEGORY_PLUMBING   = "plumbing"   // 水管漏水
+	TICKET_CATEGORY_ELECTRICAL = "electrical" // 电路
+	TICKET_CATEGORY_HEATING    = "heating"    // 暖气空调
+	TICKET_CATEGORY_APPLIANCE  = "appliance"  // 家电
+	TICKET_CATEGORY_STRUCTURAL = "structural" // 门窗墙面
+	TICKET_CATEGORY_PEST       = "pest"       // 虫害
+	TICKET_CATEGORY_OTHER      = "other"      // 其他
+)
+
+// SCHEDULE_LAYOUT 上门时间格式
+const SCHEDULE_LAYOUT = "2006-01-02 15:04"
+
+// MaintenanceTicket 报修工单模型，租客或业主针对出租单元提交，由业主指派维修人员处理
+type MaintenanceTicket struct {
+	base.Base
+	base.OrgScoped
+	UnitID      int64  `gorm:"type:bigint;not null;index" json:"unit_id"`         // 出租单元 ID
+	PropertyID  int64  `gorm:"type:bigint;not null" json:"property_id"`           // 房源 ID
+	ContractID  int64  `gorm:"type:bigint;default:0" json:"contract_id"`          // 报修时生效的合同 ID
+	LandlordID  int64  `gorm:"type:bigint;not null;index" json:"landlord_id"`     // 业主账户 ID
+	TenantID    int64  `gorm:"type:bigint;default:0;index" json:"tenant_id"`      // 租客账户 ID，业主自行报修时为 0
+	ReporterID  int64  `gorm:"type:bigint;not null" json:"reporter_id"`           // 报修人账户 ID
+	AssigneeID  int64  `gorm:"type:bigint;default:0;index" json:"assignee_id"`    // 维修人员账户 ID
+	Title       string `gorm:"type:varchar(100);not null" json:"title"`           // 标题
+	Description string `gorm:"type:text" json:"description"`                      // 问题描述
+	Category    string `gorm:"type:varchar(16);not null" json:"category"`         // 工单类别
+	Priority    string `gorm:"type:varchar(16);not null;index" json:"priority"`   // 优先级
+	Status      string `gorm:"type:varchar(16);not null;index" json:"status"`     // 工单状态
+	ScheduledAt string `gorm:"type:varchar(16);default:null" json:"scheduled_at"` // 预约上门时间，格式 2006-01-02 15:04
+	ResolvedAt  int64  `gorm:"type:bigint;default:0" json:"resolved_at"`          // 修复时间
+	ClosedAt    int64  `gorm:"type:bigint;default:0" json:"closed_at"`            // 关闭时间
+	Cost        int64  `gorm:"type:bigint;not null;default:0" json:"cost"`        // 维修费用（分）
+	Resolution  string `gorm:"type:varchar(255);default:null" json:"resolution"`  // 处理结果说明
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (MaintenanceTicket) TableName() string {
+	return "maintenance_tickets"
+}
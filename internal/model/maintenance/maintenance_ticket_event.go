@@ -0,0 +1,35 @@
+// Package model 提供报修工单数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 工单状态流转动作
+const (
+	TICKET_ACTION_CREATE   = "create"   // 提交报修
+	TICKET_ACTION_ASSIGN   = "assign"   // 指派维修人员
+	TICKET_ACTION_SCHEDULE = "schedule" // 预约上门
+	TICKET_ACTION_START    = "start"    // 开始维修
+	TICKET_ACTION_RESOLVE  = "resolve"  // 完成维修
+	TICKET_ACTION_REOPEN   = "reopen"   // 未修好重新打开
+	TICKET_ACTION_CLOSE    = "close"    // 核定费用并关闭
+	TICKET_ACTION_CANCEL   = "cancel"   // 取消报修
+)
+
+// MaintenanceTicketEvent 报修工单状态流转记录，只增不改
+type MaintenanceTicketEvent struct {
+	base.Base
+	base.OrgScoped
+	TicketID   int64  `gorm:"type:bigint;not null;index" json:"ticket_id"`      // 工单 ID
+	Action     string `gorm:"type:varchar(16);not null" json:"action"`          // 流转动作
+	FromStatus string `gorm:"type:varchar(16);default:null" json:"from_status"` // 流转前状态
+	ToStatus   string `gorm:"type:varchar(16);not null" json:"to_status"`       // 流转后状态
+	OperatorID int64  `gorm:"type:bigint;not null" json:"operator_id"`          // 操作人账户 ID
+	Remark     string `gorm:"type:varchar(255);default:null" json:"remark"`     // 备注
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (MaintenanceTicketEvent) TableName() string {
+	return "maintenance_ticket_events"
+}
@@ -0,0 +1,19 @@
+// Package model 提供组织数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// Organization 组织模型，对应一家房东或租赁公司，账户及其业务数据均归属于某个组织
+type Organization struct {
+	base.Base
+	Name      string `gorm:"type:varchar(100);not null" json:"name"`        // 组织名称
+	OwnerID   int64  `gorm:"type:bigint;not null;index" json:"owner_id"`    // 创建人账户 ID
+	SeatLimit int    `gorm:"type:int;not null;default:0" json:"seat_limit"` // 账户席位上限，0 表示不限
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Organization) TableName() string {
+	return "organizations"
+}
@@ -0,0 +1,32 @@
+// Package model 提供组织数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 邀请状态
+const (
+	INVITATION_STATUS_PENDING  = "pending"  // 待接受
+	INVITATION_STATUS_ACCEPTED = "accepted" // 已接受
+	INVITATION_STATUS_REVOKED  = "revoked"  // 已撤销
+)
+
+// OrganizationInvitation 组织邀请，受邀邮箱凭邀请码注册后加入组织并获得指定角色
+type OrganizationInvitation struct {
+	base.Base
+	base.OrgScoped
+	Email      string `gorm:"type:varchar(64);not null;index" json:"email"`      // 受邀邮箱
+	RoleCode   string `gorm:"type:varchar(32);not null" json:"role_code"`        // 加入后授予的角色编码
+	Code       string `gorm:"type:varchar(64);uniqueIndex;not null" json:"code"` // 邀请码
+	Status     string `gorm:"type:varchar(16);not null;index" json:"status"`     // 邀请状态
+	InvitedBy  int64  `gorm:"type:bigint;not null" json:"invited_by"`            // 邀请人账户 ID
+	ExpiresAt  int64  `gorm:"type:bigint;not null" json:"expires_at"`            // 过期时间
+	AcceptedBy int64  `gorm:"type:bigint;default:0" json:"accepted_by"`          // 接受邀请的账户 ID
+	AcceptedAt int64  `gorm:"type:bigint;default:0" json:"accepted_at"`          // 接受时间
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
@@ -0,0 +1,26 @@
+// Package model 提供房源与房间数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// Property 房源模型，一处房源可包含多个出租单元
+type Property struct {
+	base.Base
+	base.OrgScoped
+	OwnerID     int64   `gorm:"type:bigint;not null;index" json:"owner_id"`         // 业主账户 ID
+	Name        string  `gorm:"type:varchar(128);not null" json:"name"`             // 房源名称
+	Province    string  `gorm:"type:varchar(64);default:null" json:"province"`      // 省份
+	City        string  `gorm:"type:varchar(64);not null;index" json:"city"`        // 城市
+	District    string  `gorm:"type:varchar(64);default:null" json:"district"`      // 区县
+	Address     string  `gorm:"type:varchar(255);not null" json:"address"`          // 详细地址
+	Latitude    float64 `gorm:"type:decimal(10,7);default:0" json:"latitude"`       // 纬度
+	Longitude   float64 `gorm:"type:decimal(10,7);default:0" json:"longitude"`      // 经度
+	Description string  `gorm:"type:varchar(1024);default:null" json:"description"` // 房源描述
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Property) TableName() string {
+	return "properties"
+}
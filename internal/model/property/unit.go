@@ -0,0 +1,31 @@
+// Package model 提供房源与房间数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 房间状态
+const (
+	UNIT_STATUS_VACANT      = "vacant"      // 空置
+	UNIT_STATUS_OCCUPIED    = "occupied"    // 已出租
+	UNIT_STATUS_MAINTENANCE = "maintenance" // 维修中
+)
+
+// Unit 出租单元模型
+type Unit struct {
+	base.Base
+	base.OrgScoped
+	PropertyID    int64   `gorm:"type:bigint;not null;index" json:"property_id"`                  // 所属房源 ID
+	UnitNo        string  `gorm:"type:varchar(32);not null" json:"unit_no"`                       // 房间号
+	Floor         int     `gorm:"type:int;not null" json:"floor"`                                 // 楼层
+	Area          float64 `gorm:"type:decimal(10,2);not null" json:"area"`                        // 面积（平方米）
+	Rooms         int     `gorm:"type:int;not null;default:1" json:"rooms"`                       // 房间数
+	RentListPrice int64   `gorm:"type:bigint;not null" json:"rent_list_price"`                    // 挂牌月租金（分）
+	Status        string  `gorm:"type:varchar(16);not null;default:'vacant';index" json:"status"` // 房间状态
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Unit) TableName() string {
+	return "units"
+}
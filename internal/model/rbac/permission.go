@@ -0,0 +1,89 @@
+// Package model 提供角色与权限数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 权限编码，格式为「资源:操作」
+const (
+	PERMISSION_PROPERTY_WRITE     = "property:write"     // 维护房源与出租单元
+	PERMISSION_LEASE_WRITE        = "lease:write"        // 起草、提交、解约、续约合同
+	PERMISSION_LEASE_SIGN         = "lease:sign"         // 以租客身份签署合同
+	PERMISSION_BILLING_WRITE      = "billing:write"      // 出账、登记收款、押金收取与结算
+	PERMISSION_MAINTENANCE_MANAGE = "maintenance:manage" // 指派维修人员并关闭报修工单
+	PERMISSION_RBAC_MANAGE        = "rbac:manage"        // 授予与撤销账户角色
+	PERMISSION_ORG_MANAGE         = "org:manage"         // 维护组织信息、席位上限并邀请成员
+)
+
+// Permission 权限模型
+type Permission struct {
+	base.Base
+	Code string `gorm:"type:varchar(64);uniqueIndex;not null" json:"code"` // 权限编码
+	Name string `gorm:"type:varchar(64);not null" json:"name"`             // 权限名称
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// BuiltinPermission 内置权限定义
+type BuiltinPermission struct {
+	Code string // 权限编码
+	Name string // 权限名称
+}
+
+// BuiltinRole 内置角色定义
+type BuiltinRole struct {
+	Code        string   // 角色编码
+	Name        string   // 角色名称
+	Description string   // 角色说明
+	Permissions []string // 默认拥有的权限编码
+}
+
+// BuiltinPermissions 系统内置权限，启动时同步到数据库
+var BuiltinPermissions = []BuiltinPermission{
+	{Code: PERMISSION_PROPERTY_WRITE, Name: "维护房源"},
+	{Code: PERMISSION_LEASE_WRITE, Name: "管理合同"},
+	{Code: PERMISSION_LEASE_SIGN, Name: "签署合同"},
+	{Code: PERMISSION_BILLING_WRITE, Name: "管理账务"},
+	{Code: PERMISSION_MAINTENANCE_MANAGE, Name: "管理报修"},
+	{Code: PERMISSION_RBAC_MANAGE, Name: "管理角色"},
+	{Code: PERMISSION_ORG_MANAGE, Name: "管理组织"},
+}
+
+// BuiltinRoles 系统内置角色及默认权限，启动时同步到数据库
+var BuiltinRoles = []BuiltinRole{
+	{
+		Code:        ROLE_TENANT,
+		Name:        "租客",
+		Description: "承租出租单元，签署合同并提交报修",
+		Permissions: []string{PERMISSION_LEASE_SIGN},
+	},
+	{
+		Code:        ROLE_LANDLORD,
+		Name:        "业主",
+		Description: "发布房源，管理名下合同、账务与报修",
+		Permissions: []string{PERMISSION_PROPERTY_WRITE, PERMISSION_LEASE_WRITE, PERMISSION_BILLING_WRITE, PERMISSION_MAINTENANCE_MANAGE},
+	},
+	{
+		Code:        ROLE_PROPERTY_MANAGER,
+		Name:        "物业管理员",
+		Description: "协助业主处理合同、账务与报修",
+		Permissions: []string{PERMISSION_LEASE_WRITE, PERMISSION_BILLING_WRITE, PERMISSION_MAINTENANCE_MANAGE},
+	},
+	{
+		Code:        ROLE_ADMIN,
+		Name:        "组织管理员",
+		Description: "拥有组织内全部权限，可邀请成员、授予与撤销账户角色",
+		Permissions: []string{
+			PERMISSION_PROPERTY_WRITE, PERMISSION_LEASE_WRITE, PERMISSION_LEASE_SIGN,
+			PERMISSION_BILLING_WRITE, PERMISSION_MAINTENANCE_MANAGE, PERMISSION_RBAC_MANAGE,
+			PERMISSION_ORG_MANAGE,
+		},
+	},
+}
+
+// DefaultAccountRoles 自行注册并创建组织的账户默认授予的角色，此外还会成为该组织的管理员
+var DefaultAccountRoles = []string{ROLE_TENANT, ROLE_LANDLORD}
@@ -0,0 +1,58 @@
+// Package model 提供角色与权限数据模型定义
+package model
+
+import "lease/internal/model/base"
+
+// 内置角色编码
+const (
+	ROLE_TENANT           = "tenant"           // 租客
+	ROLE_LANDLORD         = "landlord"         // 业主
+	ROLE_PROPERTY_MANAGER = "property_manager" // 物业管理员
+	ROLE_ADMIN            = "admin"            // 组织管理员
+)
+
+// Role 角色模型，一个角色包含多个权限
+type Role struct {
+	base.Base
+	Code        string `gorm:"type:varchar(32);uniqueIndex;not null" json:"code"` // 角色编码
+	Name        string `gorm:"type:varchar(64);not null" json:"name"`             // 角色名称
+	Description string `gorm:"type:varchar(255);default:null" json:"description"` // 角色说明
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Role) TableName() string {
+	return "roles"
+}
+
+// RolePermission 角色与权限的关联
+type RolePermission struct {
+	base.Base
+	RoleID       int64 `gorm:"type:bigint;not null;uniqueIndex:idx_role_permission" json:"role_id"`       // 角色 ID
+	PermissionID int64 `gorm:"type:bigint;not null;uniqueIndex:idx_role_permission" json:"permission_id"` // 权限 ID
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+// AccountRole 账户与角色的关联，一个账户可同时拥有多个角色；同一组织内同一账户的同一角色只保留一条，撤销时物理删除
+// 所属组织参与唯一索引，因此不嵌入 base.OrgScoped 而直接声明同名字段，组织隔离按字段名生效
+type AccountRole struct {
+	base.Base
+	OrganizationID int64 `gorm:"type:bigint;not null;default:0;index;uniqueIndex:idx_account_role,priority:1" json:"organization_id"` // 所属组织 ID
+	AccountID      int64 `gorm:"type:bigint;not null;index;uniqueIndex:idx_account_role,priority:2" json:"account_id"`                // 账户 ID
+	RoleID         int64 `gorm:"type:bigint;not null;index;uniqueIndex:idx_account_role,priority:3" json:"role_id"`                   // 角色 ID
+	GrantedBy      int64 `gorm:"type:bigint;default:0" json:"granted_by"`                                                             // 授权人账户 ID，系统自动授予时为 0
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AccountRole) TableName() string {
+	return "account_roles"
+}
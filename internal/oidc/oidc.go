@@ -0,0 +1,64 @@
+// Package oidc 提供 OpenID Connect 授权码 + PKCE 登录的客户端实现
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"lease/configs"
+	"lease/internal/global"
+)
+
+// ErrProviderNotFound 未配置指定的身份提供方
+var ErrProviderNotFound = errors.New("身份提供方不存在")
+
+// providers 按配置初始化的身份提供方，按配置顺序排列
+var providers []*Provider
+
+// New 按配置初始化身份提供方；提供方元数据在首次使用时读取，启动时不访问外部网络
+// 参数：
+//   - config: 应用配置
+func New(config *configs.Config) {
+	var loaded []*Provider
+	seen := make(map[string]bool)
+	for _, providerConfig := range config.OIDCConfig.Providers {
+		provider, err := NewProvider(providerConfig)
+		if err != nil {
+			global.SysLog.Errorf("身份提供方「%s」初始化失败: %v", providerConfig.Name, err)
+			continue
+		}
+		if seen[provider.Name] {
+			global.SysLog.Errorf("身份提供方「%s」重复配置，已忽略", provider.Name)
+			continue
+		}
+		seen[provider.Name] = true
+		loaded = append(loaded, provider)
+	}
+	providers = loaded
+	global.SysLog.Infof("第三方身份登录初始化成功，已配置 %d 个身份提供方", len(providers))
+}
+
+// Get 按标识获取已初始化的身份提供方
+// 参数：
+//   - name: 提供方标识
+//
+// 返回值：
+//   - *Provider: 身份提供方
+//   - error: 未配置时返回 ErrProviderNotFound
+func Get(name string) (*Provider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, provider := range providers {
+		if provider.Name == name {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
+}
+
+// Providers 获取全部已初始化的身份提供方
+// 返回值：
+//   - []*Provider: 身份提供方列表
+func Providers() []*Provider {
+	return providers
+}
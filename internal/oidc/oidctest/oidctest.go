@@ -0,0 +1,341 @@
+// Package oidctest 提供进程内模拟的 OpenID Connect 身份提供方，仅供测试使用
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"lease/internal/oidc"
+)
+
+// 模拟提供方相关常量
+const (
+	KEY_ID         = "mock"          // 模拟提供方签名密钥 ID
+	KEY_BITS       = 2048            // 模拟提供方 RSA 密钥长度
+	CODE_TTL       = time.Minute     // 授权码有效期
+	ID_TOKEN_TTL   = 5 * time.Minute // ID Token 有效期
+	AUTHORIZE_PATH = "/authorize"    // 授权端点路径
+	TOKEN_PATH     = "/token"        // 令牌端点路径
+	JWKS_PATH      = "/jwks"         // 公钥集路径
+	USERINFO_PATH  = "/userinfo"     // 用户信息端点路径，仅在元数据中声明
+	grantTypeCode  = "authorization_code"
+)
+
+// User 模拟提供方当前登录的用户
+type User struct {
+	Subject       string // 用户唯一标识
+	Email         string // 邮箱
+	EmailVerified bool   // 邮箱是否已验证
+	Name          string // 显示名称
+}
+
+// Provider 进程内模拟的 OpenID Connect 身份提供方，实现元数据、授权、令牌与公钥集端点，用于本地联调与自动化测试；
+// 授权端点不展示登录页，直接以当前用户身份签发授权码，并与真实提供方一样强制校验 PKCE 与客户端凭证
+type Provider struct {
+	ClientID     string // 允许的客户端 ID
+	ClientSecret string // 客户端密钥，为空时按公共客户端处理
+
+	mu     sync.Mutex
+	issuer string
+	user   User
+	key    *rsa.PrivateKey
+	codes  map[string]pendingCode
+}
+
+// pendingCode 已签发、尚未兑换的授权码
+type pendingCode struct {
+	redirectURI string
+	challenge   string
+	nonce       string
+	user        User
+	expiresAt   time.Time
+}
+
+// NewProvider 创建模拟身份提供方，创建后须调用 SetIssuer 设置其对外地址
+// 参数：
+//   - clientID: 允许的客户端 ID
+//   - clientSecret: 客户端密钥，为空时按公共客户端处理
+//
+// 返回值：
+//   - *Provider: 模拟身份提供方
+//   - error: 生成签名密钥失败时返回错误
+func NewProvider(clientID, clientSecret string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, KEY_BITS)
+	if err != nil {
+		return nil, fmt.Errorf("生成模拟提供方签名密钥失败: %w", err)
+	}
+	return &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        make(map[string]pendingCode),
+	}, nil
+}
+
+// SetIssuer 设置模拟提供方的签发方地址，即元数据路径之前的部分，例如 http://127.0.0.1:9000/mock
+// 参数：
+//   - issuer: 签发方地址
+func (m *Provider) SetIssuer(issuer string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.issuer = strings.TrimRight(issuer, "/")
+}
+
+// SetUser 设置此后授权时使用的登录用户
+// 参数：
+//   - user: 登录用户
+func (m *Provider) SetUser(user User) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.user = user
+}
+
+// ServeHTTP 按请求路径分发到各端点
+// 参数：
+//   - w: 响应写入器
+//   - r: HTTP 请求
+func (m *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	issuer := m.issuer
+	m.mu.Unlock()
+
+	basePath := ""
+	if parsed, err := url.Parse(issuer); err == nil {
+		basePath = parsed.Path
+	}
+	switch strings.TrimPrefix(r.URL.Path, basePath) {
+	case oidc.DISCOVERY_PATH:
+		m.serveDiscovery(w, issuer)
+	case AUTHORIZE_PATH:
+		m.serveAuthorize(w, r)
+	case TOKEN_PATH:
+		m.serveToken(w, r, issuer)
+	case JWKS_PATH:
+		m.serveJWKS(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveDiscovery 返回提供方元数据
+// 参数：
+//   - w: 响应写入器
+//   - issuer: 签发方地址
+func (m *Provider) serveDiscovery(w http.ResponseWriter, issuer string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + AUTHORIZE_PATH,
+		"token_endpoint":                        issuer + TOKEN_PATH,
+		"jwks_uri":                              issuer + JWKS_PATH,
+		"userinfo_endpoint":                     issuer + USERINFO_PATH,
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// serveAuthorize 校验授权请求后以当前用户身份签发授权码，并重定向回客户端
+// 参数：
+//   - w: 响应写入器
+//   - r: HTTP 请求
+func (m *Provider) serveAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI := query.Get("redirect_uri")
+	switch {
+	case query.Get("response_type") != "code":
+		writeError(w, "unsupported_response_type", "仅支持授权码模式")
+		return
+	case query.Get("client_id") != m.ClientID:
+		writeError(w, "unauthorized_client", "客户端 ID 不匹配")
+		return
+	case redirectURI == "":
+		writeError(w, "invalid_request", "缺少 redirect_uri")
+		return
+	case query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256":
+		writeError(w, "invalid_request", "须使用 S256 方法的 PKCE")
+		return
+	}
+
+	code, err := oidc.NewState()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	m.mu.Lock()
+	m.codes[code] = pendingCode{
+		redirectURI: redirectURI,
+		challenge:   query.Get("code_challenge"),
+		nonce:       query.Get("nonce"),
+		user:        m.user,
+		expiresAt:   time.Now().Add(CODE_TTL),
+	}
+	m.mu.Unlock()
+
+	callback, err := url.Parse(redirectURI)
+	if err != nil {
+		writeError(w, "invalid_request", "redirect_uri 格式无效")
+		return
+	}
+	params := callback.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	callback.RawQuery = params.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+// serveToken 校验客户端凭证、回调地址与 PKCE 后兑换授权码，授权码只能使用一次
+// 参数：
+//   - w: 响应写入器
+//   - r: HTTP 请求
+//   - issuer: 签发方地址
+func (m *Provider) serveToken(w http.ResponseWriter, r *http.Request, issuer string) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, "invalid_request", "请求体格式无效")
+		return
+	}
+	if r.PostForm.Get("grant_type") != grantTypeCode {
+		writeError(w, "unsupported_grant_type", "仅支持授权码模式")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != m.ClientID ||
+		(m.ClientSecret != "" && subtle.ConstantTimeCompare([]byte(clientSecret), []byte(m.ClientSecret)) != 1) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	m.mu.Lock()
+	grant, found := m.codes[code]
+	delete(m.codes, code)
+	m.mu.Unlock()
+
+	switch {
+	case !found || time.Now().After(grant.expiresAt):
+		writeError(w, "invalid_grant", "授权码无效或已过期")
+		return
+	case r.PostForm.Get("redirect_uri") != grant.redirectURI:
+		writeError(w, "invalid_grant", "redirect_uri 与授权请求不一致")
+		return
+	case oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != grant.challenge:
+		writeError(w, "invalid_grant", "PKCE 校验失败")
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            issuer,
+		"sub":            grant.user.Subject,
+		"aud":            m.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(ID_TOKEN_TTL).Unix(),
+		"nonce":          grant.nonce,
+		"email":          grant.user.Email,
+		"email_verified": grant.user.EmailVerified,
+		"name":           grant.user.Name,
+	})
+	token.Header["kid"] = KEY_ID
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	accessToken, err := oidc.NewState()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int(ID_TOKEN_TTL / time.Second),
+		"id_token":     idToken,
+	})
+}
+
+// serveJWKS 返回签名公钥集
+// 参数：
+//   - w: 响应写入器
+func (m *Provider) serveJWKS(w http.ResponseWriter) {
+	publicKey := m.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": KEY_ID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// writeError 返回 OAuth 2.0 格式的错误响应
+// 参数：
+//   - w: 响应写入器
+//   - code: 错误码
+//   - description: 错误描述
+func writeError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+// writeJSON 写入 JSON 响应
+// 参数：
+//   - w: 响应写入器
+//   - status: HTTP 状态码
+//   - body: 响应体
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Authorize 模拟浏览器访问授权地址，不跟随重定向，从回调地址中取出授权码与 state
+// 参数：
+//   - authorizationURL: 客户端生成的授权地址
+//
+// 返回值：
+//   - string: 授权码
+//   - string: state
+//   - error: 提供方未重定向或回调地址无效时返回错误
+func Authorize(authorizationURL string) (string, string, error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authorizationURL)
+	if err != nil {
+		return "", "", fmt.Errorf("访问授权地址失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("授权端点未重定向: %d", resp.StatusCode)
+	}
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", fmt.Errorf("回调地址无效: %w", err)
+	}
+	return callback.Query().Get("code"), callback.Query().Get("state"), nil
+}
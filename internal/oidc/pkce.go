@@ -0,0 +1,57 @@
+// Package oidc 提供 OpenID Connect 授权码 + PKCE 登录的客户端实现
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// 随机值字节数
+const (
+	CODE_VERIFIER_BYTES = 32 // PKCE code_verifier 随机字节数，编码后 43 个字符
+	STATE_BYTES         = 24 // state 与 nonce 随机字节数
+)
+
+// NewCodeVerifier 生成 PKCE code_verifier
+// 返回值：
+//   - string: base64url 编码的随机串
+//   - error: 操作过程中的错误
+func NewCodeVerifier() (string, error) {
+	return randomString(CODE_VERIFIER_BYTES)
+}
+
+// NewState 生成授权请求的 state 或 nonce
+// 返回值：
+//   - string: base64url 编码的随机串
+//   - error: 操作过程中的错误
+func NewState() (string, error) {
+	return randomString(STATE_BYTES)
+}
+
+// CodeChallenge 按 S256 方法计算 code_verifier 对应的 code_challenge
+// 参数：
+//   - verifier: code_verifier
+//
+// 返回值：
+//   - string: code_challenge
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString 生成 base64url 编码的随机串
+// 参数：
+//   - size: 随机字节数
+//
+// 返回值：
+//   - string: 随机串
+//   - error: 操作过程中的错误
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,480 @@
+// Package oidc 提供 OpenID Connect 授权码 + PKCE 登录的客户端实现
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"lease/configs"
+)
+
+// 客户端相关常量
+const (
+	DISCOVERY_PATH       = "/.well-known/openid-configuration" // 提供方元数据路径
+	HTTP_TIMEOUT         = 10 * time.Second                    // 访问提供方的超时时间
+	JWKS_REFRESH_MINIMUM = time.Minute                         // 遇到未知 kid 时两次刷新公钥的最小间隔
+	ID_TOKEN_LEEWAY      = time.Minute                         // 校验 ID Token 时间字段允许的时钟偏差
+	MAX_RESPONSE_SIZE    = 1 << 20                             // 提供方响应体大小上限
+)
+
+// DEFAULT_SCOPES 未配置时请求的授权范围
+var DEFAULT_SCOPES = []string{"openid", "email", "profile"}
+
+// ErrIDTokenInvalid ID Token 签名、签发方、受众、有效期或 nonce 校验未通过
+var ErrIDTokenInvalid = errors.New("ID Token 校验失败")
+
+// Metadata 身份提供方元数据，取自 /.well-known/openid-configuration
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// Claims 从 ID Token 中取得的用户身份信息
+type Claims struct {
+	Subject       string // 用户在提供方的唯一标识
+	Email         string // 邮箱，提供方未返回时为空
+	EmailVerified bool   // 邮箱是否已由提供方验证
+	Name          string // 显示名称
+	Picture       string // 头像地址
+}
+
+// Provider OpenID Connect 身份提供方客户端
+type Provider struct {
+	Name        string // 提供方标识
+	DisplayName string // 展示名称
+
+	config configs.OIDCProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *Metadata
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// NewProvider 创建身份提供方客户端
+// 参数：
+//   - config: 提供方配置
+//
+// 返回值：
+//   - *Provider: 身份提供方客户端
+//   - error: 配置不完整时返回错误
+func NewProvider(config configs.OIDCProviderConfig) (*Provider, error) {
+	name := strings.ToLower(strings.TrimSpace(config.Name))
+	if name == "" || config.Issuer == "" || config.ClientID == "" || config.RedirectURL == "" {
+		return nil, fmt.Errorf("NAME、ISSUER、CLIENT_ID、REDIRECT_URL 均不能为空")
+	}
+	if _, err := url.Parse(config.RedirectURL); err != nil {
+		return nil, fmt.Errorf("REDIRECT_URL 格式无效: %w", err)
+	}
+
+	config.Issuer = strings.TrimRight(config.Issuer, "/")
+	if len(config.Scopes) == 0 {
+		config.Scopes = DEFAULT_SCOPES
+	}
+	displayName := config.DisplayName
+	if displayName == "" {
+		displayName = config.Name
+	}
+
+	return &Provider{
+		Name:        name,
+		DisplayName: displayName,
+		config:      config,
+		client:      &http.Client{Timeout: HTTP_TIMEOUT},
+	}, nil
+}
+
+// AuthCodeURL 生成跳转到提供方的授权地址
+// 参数：
+//   - ctx: 上下文
+//   - state: 防跨站请求伪造的随机串
+//   - nonce: 绑定 ID Token 的随机串
+//   - verifier: PKCE code_verifier
+//
+// 返回值：
+//   - string: 授权地址
+//   - error: 读取提供方元数据失败时返回错误
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange 使用授权码换取令牌并校验 ID Token
+// 参数：
+//   - ctx: 上下文
+//   - code: 授权码
+//   - verifier: 发起授权时生成的 PKCE code_verifier
+//   - nonce: 发起授权时生成的 nonce
+//
+// 返回值：
+//   - *Claims: 用户身份信息
+//   - error: 换取令牌失败或 ID Token 校验未通过时返回错误
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("code_verifier", verifier)
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("构造令牌请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &token)
+	if err != nil {
+		return nil, fmt.Errorf("换取令牌失败: %w", err)
+	}
+	if status != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("换取令牌失败: HTTP %d %s %s", status, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("换取令牌失败: 响应中缺少 id_token")
+	}
+
+	return p.VerifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// VerifyIDToken 校验 ID Token 的签名、签发方、受众、有效期与 nonce，并取出用户身份信息
+// 参数：
+//   - ctx: 上下文
+//   - rawIDToken: ID Token
+//   - nonce: 发起授权时生成的 nonce
+//
+// 返回值：
+//   - *Claims: 用户身份信息
+//   - error: 校验未通过时返回包装 ErrIDTokenInvalid 的错误
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(ID_TOKEN_LEEWAY),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIDTokenInvalid, err)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce 不匹配", ErrIDTokenInvalid)
+	}
+	// 存在多个受众时 azp 必须为本客户端
+	if audience, _ := claims.GetAudience(); len(audience) > 1 {
+		if azp, _ := claims["azp"].(string); azp != p.config.ClientID {
+			return nil, fmt.Errorf("%w: azp 不匹配", ErrIDTokenInvalid)
+		}
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: 缺少 sub", ErrIDTokenInvalid)
+	}
+
+	result := &Claims{Subject: subject}
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.Picture, _ = claims["picture"].(string)
+	// 部分提供方以字符串形式返回 email_verified
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = strings.EqualFold(verified, "true")
+	}
+	return result, nil
+}
+
+// discover 读取并缓存提供方元数据，签发方须与配置一致
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - *Metadata: 提供方元数据
+//   - error: 操作过程中的错误
+func (p *Provider) discover(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.Issuer+DISCOVERY_PATH, nil)
+	if err != nil {
+		return nil, fmt.Errorf("构造元数据请求失败: %w", err)
+	}
+	var metadata Metadata
+	status, err := p.doJSON(req, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("读取提供方元数据失败: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("读取提供方元数据失败: HTTP %d", status)
+	}
+	if strings.TrimRight(metadata.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("提供方元数据签发方 %s 与配置 %s 不一致", metadata.Issuer, p.config.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("提供方元数据缺少授权、令牌或公钥地址")
+	}
+
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// publicKey 按 kid 获取提供方签名公钥，遇到未知 kid 时重新读取公钥集以支持密钥轮换
+// 参数：
+//   - ctx: 上下文
+//   - kid: 密钥 ID，ID Token 未携带时仅在公钥集只有一把密钥时可用
+//
+// 返回值：
+//   - crypto.PublicKey: 签名公钥
+//   - error: 操作过程中的错误
+func (p *Provider) publicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+	if !p.keysFetchedAt.IsZero() && time.Since(p.keysFetchedAt) < JWKS_REFRESH_MINIMUM {
+		return nil, fmt.Errorf("未找到签名公钥: %s", kid)
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("未找到签名公钥: %s", kid)
+}
+
+// fetchKeys 读取提供方公钥集，须在持有 p.mu 时调用
+// 参数：
+//   - ctx: 上下文
+//
+// 返回值：
+//   - map[string]crypto.PublicKey: 以 kid 为键的签名公钥
+//   - error: 操作过程中的错误
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if p.metadata == nil {
+		return nil, fmt.Errorf("提供方元数据未读取")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("构造公钥集请求失败: %w", err)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	status, err := p.doJSON(req, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("读取提供方公钥集失败: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("读取提供方公钥集失败: HTTP %d", status)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// 不支持的密钥类型不影响其余密钥
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// doJSON 发送请求并按 JSON 解析响应体
+// 参数：
+//   - req: HTTP 请求
+//   - out: 解析目标
+//
+// 返回值：
+//   - int: HTTP 状态码
+//   - error: 请求或解析失败时返回错误
+func (p *Provider) doJSON(req *http.Request, out interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MAX_RESPONSE_SIZE))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("响应不是有效的 JSON: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// lookupKey 按 kid 查找公钥，kid 为空且仅有一把公钥时返回该公钥
+// 参数：
+//   - keys: 公钥集
+//   - kid: 密钥 ID
+//
+// 返回值：
+//   - crypto.PublicKey: 签名公钥
+//   - bool: 是否找到
+func lookupKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, bool) {
+	if key, ok := keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// jsonWebKey JWK 格式的公钥
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey 将 JWK 转换为公钥，支持 RSA 与 P-256/P-384/P-521 椭圆曲线
+// 返回值：
+//   - crypto.PublicKey: 公钥
+//   - error: 不支持的密钥类型或参数无效时返回错误
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA 公钥指数无效")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的椭圆曲线: %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("椭圆曲线公钥无效")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型: %s", k.Kty)
+	}
+}
+
+// decodeBigInt 解码 base64url 编码的大整数
+// 参数：
+//   - value: base64url 编码值
+//
+// 返回值：
+//   - *big.Int: 大整数
+//   - error: 编码无效时返回错误
+func decodeBigInt(value string) (*big.Int, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(buf) == 0 {
+		return nil, fmt.Errorf("JWK 参数编码无效")
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
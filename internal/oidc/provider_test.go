@@ -0,0 +1,129 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lease/configs"
+	"lease/internal/oidc"
+	"lease/internal/oidc/oidctest"
+)
+
+const (
+	testClientID     = "lease"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://localhost:3000/oidc/callback"
+)
+
+var testUser = oidctest.User{Subject: "10001", Email: "user@example.com", EmailVerified: true, Name: "测试用户"}
+
+// newTestProvider 启动模拟提供方并创建指向它的客户端
+func newTestProvider(t *testing.T) *oidc.Provider {
+	t.Helper()
+
+	mock, err := oidctest.NewProvider(testClientID, testClientSecret)
+	if err != nil {
+		t.Fatalf("创建模拟提供方失败: %v", err)
+	}
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+	mock.SetIssuer(server.URL + "/mock")
+	mock.SetUser(testUser)
+
+	provider, err := oidc.NewProvider(configs.OIDCProviderConfig{
+		Name:         "Mock",
+		Issuer:       server.URL + "/mock",
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+	})
+	if err != nil {
+		t.Fatalf("创建提供方客户端失败: %v", err)
+	}
+	return provider
+}
+
+// authorize 生成授权地址并模拟浏览器完成授权，返回授权码
+func authorize(t *testing.T, provider *oidc.Provider, state, nonce, verifier string) string {
+	t.Helper()
+
+	authorizationURL, err := provider.AuthCodeURL(context.Background(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("生成授权地址失败: %v", err)
+	}
+	if !strings.Contains(authorizationURL, "code_challenge_method=S256") {
+		t.Fatalf("授权地址缺少 PKCE 参数: %s", authorizationURL)
+	}
+
+	code, callbackState, err := oidctest.Authorize(authorizationURL)
+	if err != nil {
+		t.Fatalf("授权失败: %v", err)
+	}
+	if callbackState != state {
+		t.Fatalf("回调 state = %q，期望 %q", callbackState, state)
+	}
+	if code == "" {
+		t.Fatal("回调中缺少授权码")
+	}
+	return code
+}
+
+// newVerifier 生成 PKCE code_verifier
+func newVerifier(t *testing.T) string {
+	t.Helper()
+
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("生成 code_verifier 失败: %v", err)
+	}
+	return verifier
+}
+
+func TestExchange(t *testing.T) {
+	provider := newTestProvider(t)
+	verifier := newVerifier(t)
+	code := authorize(t, provider, "state-1", "nonce-1", verifier)
+
+	claims, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatalf("换取令牌失败: %v", err)
+	}
+	if claims.Subject != testUser.Subject || claims.Email != testUser.Email || !claims.EmailVerified || claims.Name != testUser.Name {
+		t.Fatalf("身份信息 = %+v，期望 %+v", claims, testUser)
+	}
+
+	// 授权码只能兑换一次
+	if _, err := provider.Exchange(context.Background(), code, verifier, "nonce-1"); err == nil {
+		t.Fatal("重复兑换授权码应失败")
+	}
+}
+
+func TestExchangeNonceMismatch(t *testing.T) {
+	provider := newTestProvider(t)
+	verifier := newVerifier(t)
+	code := authorize(t, provider, "state-1", "nonce-1", verifier)
+
+	_, err := provider.Exchange(context.Background(), code, verifier, "nonce-2")
+	if !errors.Is(err, oidc.ErrIDTokenInvalid) {
+		t.Fatalf("nonce 不匹配时错误 = %v，期望 ErrIDTokenInvalid", err)
+	}
+}
+
+func TestExchangeVerifierMismatch(t *testing.T) {
+	provider := newTestProvider(t)
+	code := authorize(t, provider, "state-1", "nonce-1", newVerifier(t))
+
+	_, err := provider.Exchange(context.Background(), code, newVerifier(t), "nonce-1")
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("code_verifier 不匹配时错误 = %v，期望 invalid_grant", err)
+	}
+}
+
+func TestNewProviderRequiresConfig(t *testing.T) {
+	if _, err := oidc.NewProvider(configs.OIDCProviderConfig{Name: "Mock", ClientID: testClientID}); err == nil {
+		t.Fatal("缺少 ISSUER 与 REDIRECT_URL 时应返回错误")
+	}
+}
@@ -16,11 +16,24 @@ import (
 	"lease/internal/global"
 )
 
+// 配置热更新相关常量
+const (
+	REDIS_RELOAD_PING_TIMEOUT = 5 * time.Second  // 热更新时新连接的连通性检查超时
+	REDIS_RELOAD_CLOSE_DELAY  = 30 * time.Second // 替换后原客户端的关闭延迟
+)
+
 // New 初始化Redis连接
 // 参数：
 //   - config: 应用配置
 func New(config *configs.Config) {
-	client := newRedisClient(config)
+	// 连接参数随配置热更新，启动时连接失败的也可在修正配置后恢复
+	configs.OnChange("redis", applyRedisConfig)
+
+	client, err := newRedisClient(config)
+	if err != nil {
+		global.SysLog.Errorf("Redis 配置无效: %v", err)
+		return
+	}
 	if err := client.Ping(context.Background()).Err(); err != nil {
 		global.SysLog.Errorf("Redis 连接失败: %v", err)
 		return
@@ -29,14 +42,50 @@ func New(config *configs.Config) {
 	global.SysLog.Infof("Redis 连接成功!")
 }
 
+// applyRedisConfig 按变更后的配置建立新连接，连通后替换全局客户端；旧客户端延迟关闭，避免中断进行中的请求
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//
+// 返回值：
+//   - error: 新连接不可用时返回错误，此时继续使用原客户端
+func applyRedisConfig(oldConfig, newConfig *configs.Config) error {
+	client, err := newRedisClient(newConfig)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), REDIS_RELOAD_PING_TIMEOUT)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return fmt.Errorf("Redis 连接失败: %w", err)
+	}
+
+	previous := global.RedisClient
+	global.RedisClient = client
+	if previous != nil {
+		time.AfterFunc(REDIS_RELOAD_CLOSE_DELAY, func() {
+			if err := previous.Close(); err != nil {
+				global.SysLog.Warnf("关闭原 Redis 客户端失败: %v", err)
+			}
+		})
+	}
+	global.SysLog.Infof("Redis 连接已按新配置重建")
+	return nil
+}
+
 // newRedisClient 创建新的Redis客户端
 // 参数：
 //   - config: 应用配置
 //
 // 返回值：
 //   - *redis.Client: Redis客户端实例
-func newRedisClient(config *configs.Config) *redis.Client {
-	db, _ := strconv.Atoi(config.RedisConfig.RedisDB)
+//   - error: 数据库索引无效时返回错误
+func newRedisClient(config *configs.Config) (*redis.Client, error) {
+	db, err := strconv.Atoi(config.RedisConfig.RedisDB)
+	if err != nil {
+		return nil, fmt.Errorf("REDIS_DB 须为整数: %w", err)
+	}
 	return redis.NewClient(&redis.Options{
 		Addr:         fmt.Sprintf("%s:%s", config.RedisConfig.RedisHost, config.RedisConfig.RedisPort),
 		Password:     config.RedisConfig.RedisPassword, // 数据库密码，默认为空字符串
@@ -46,5 +95,5 @@ func newRedisClient(config *configs.Config) *redis.Client {
 		WriteTimeout: 2 * time.Second,                  // 写超时时间
 		PoolSize:     runtime.GOMAXPROCS(10),           // 最大连接池大小
 		MinIdleConns: 50,                               // 最小空闲连接数
-	})
+	}), nil
 }
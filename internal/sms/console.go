@@ -0,0 +1,24 @@
+// Package sms 提供可插拔的短信发送组件
+package sms
+
+import (
+	"context"
+
+	"lease/internal/global"
+)
+
+// ConsoleProvider 将短信输出到系统日志，供开发环境在未接入短信服务商时查看验证码
+type ConsoleProvider struct{}
+
+// Send 将短信输出到系统日志
+// 参数：
+//   - ctx: 上下文
+//   - phone: E.164 格式手机号
+//   - content: 短信内容
+//
+// 返回值：
+//   - error: 始终为 nil
+func (p *ConsoleProvider) Send(ctx context.Context, phone, content string) error {
+	global.SysLog.Infof("[SMS] %s: %s", phone, content)
+	return nil
+}
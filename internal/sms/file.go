@@ -0,0 +1,74 @@
+// Package sms 提供可插拔的短信发送组件
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FILE_PROVIDER_DEFAULT_PATH file 方式的默认输出文件
+const FILE_PROVIDER_DEFAULT_PATH = ".logs/sms.log"
+
+// FileProvider 将短信以 JSON Lines 格式追加写入文件，供测试环境读取验证码
+type FileProvider struct {
+	Path string     // 输出文件
+	mu   sync.Mutex // 保证并发写入时每条记录完整
+}
+
+// fileMessage 写入文件的短信记录
+type fileMessage struct {
+	Phone   string `json:"phone"`
+	Content string `json:"content"`
+	SentAt  int64  `json:"sent_at"`
+}
+
+// NewFileProvider 创建文件短信发送方并确保输出目录存在
+// 参数：
+//   - path: 输出文件，为空时使用 FILE_PROVIDER_DEFAULT_PATH
+//
+// 返回值：
+//   - *FileProvider: 文件短信发送方
+//   - error: 创建目录失败时返回错误
+func NewFileProvider(path string) (*FileProvider, error) {
+	if path == "" {
+		path = FILE_PROVIDER_DEFAULT_PATH
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("创建短信输出目录失败: %w", err)
+	}
+	return &FileProvider{Path: path}, nil
+}
+
+// Send 将短信追加写入文件
+// 参数：
+//   - ctx: 上下文
+//   - phone: E.164 格式手机号
+//   - content: 短信内容
+//
+// 返回值：
+//   - error: 写入失败时返回错误
+func (p *FileProvider) Send(ctx context.Context, phone, content string) error {
+	line, err := json.Marshal(fileMessage{Phone: phone, Content: content, SentAt: time.Now().Unix()})
+	if err != nil {
+		return fmt.Errorf("序列化短信失败: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("打开短信输出文件失败: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("写入短信失败: %w", err)
+	}
+	return nil
+}
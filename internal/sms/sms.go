@@ -0,0 +1,111 @@
+// Package sms 提供可插拔的短信发送组件
+package sms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"lease/configs"
+	"lease/internal/global"
+	"lease/internal/utils"
+)
+
+// 短信发送方式
+const (
+	SMS_PROVIDER_CONSOLE = "console" // 输出到系统日志
+	SMS_PROVIDER_FILE    = "file"    // 追加写入文件
+)
+
+// Provider 短信发送方，手机号均为 E.164 格式
+type Provider interface {
+	// Send 向手机号发送短信
+	Send(ctx context.Context, phone, content string) error
+}
+
+// defaultProvider 按配置初始化的短信发送方
+var defaultProvider Provider
+
+// New 按配置初始化短信发送方
+// 参数：
+//   - config: 应用配置
+func New(config *configs.Config) {
+	provider, err := newProvider(config.SMSConfig)
+	if err != nil {
+		global.SysLog.Errorf("短信组件初始化失败: %v", err)
+		return
+	}
+	defaultProvider = provider
+	global.SysLog.Infof("短信组件初始化成功，发送方式: %s", providerType(config.SMSConfig))
+}
+
+// Default 获取已初始化的短信发送方
+// 返回值：
+//   - Provider: 短信发送方，未初始化时为 nil
+func Default() Provider {
+	return defaultProvider
+}
+
+// Send 使用已初始化的短信发送方发送短信，内容前附加配置的短信签名
+// 参数：
+//   - ctx: 上下文
+//   - phone: E.164 格式手机号
+//   - content: 短信内容
+//
+// 返回值：
+//   - error: 未初始化或发送失败时返回错误
+func Send(ctx context.Context, phone, content string) error {
+	if defaultProvider == nil {
+		return fmt.Errorf("短信组件未初始化")
+	}
+	if cfg, err := configs.LoadConfig(); err == nil && cfg.SMSConfig.SignName != "" {
+		content = fmt.Sprintf("【%s】%s", cfg.SMSConfig.SignName, content)
+	}
+	return defaultProvider.Send(ctx, phone, content)
+}
+
+// NormalizePhone 按配置的默认国家码将手机号规范化为 E.164 格式
+// 参数：
+//   - phone: 用户输入的手机号
+//
+// 返回值：
+//   - string: E.164 格式手机号
+//   - error: 格式无效时返回 utils.ErrPhoneInvalid
+func NormalizePhone(phone string) (string, error) {
+	var countryCode string
+	if cfg, err := configs.LoadConfig(); err == nil {
+		countryCode = cfg.SMSConfig.DefaultCountryCode
+	}
+	return utils.NormalizePhone(phone, countryCode)
+}
+
+// newProvider 创建短信发送方
+// 参数：
+//   - config: 短信配置
+//
+// 返回值：
+//   - Provider: 短信发送方
+//   - error: 不支持的发送方式时返回错误
+func newProvider(config configs.SMSConfig) (Provider, error) {
+	switch providerType(config) {
+	case SMS_PROVIDER_CONSOLE:
+		return &ConsoleProvider{}, nil
+	case SMS_PROVIDER_FILE:
+		return NewFileProvider(config.FilePath)
+	default:
+		return nil, fmt.Errorf("不支持的短信发送方式: %s", config.SMSProvider)
+	}
+}
+
+// providerType 获取生效的发送方式，未配置时输出到系统日志
+// 参数：
+//   - config: 短信配置
+//
+// 返回值：
+//   - string: 发送方式
+func providerType(config configs.SMSConfig) string {
+	if config.SMSProvider == "" {
+		return SMS_PROVIDER_CONSOLE
+	}
+	return strings.ToLower(config.SMSProvider)
+}
@@ -0,0 +1,128 @@
+// Package storage 提供可插拔的文件存储后端
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 本地磁盘存储默认值
+const (
+	LOCAL_STORAGE_DEFAULT_PATH = "./uploads" // 默认存储目录
+	LOCAL_STORAGE_DEFAULT_URL  = "/uploads"  // 默认访问路径前缀
+)
+
+// LocalStorage 本地磁盘存储，对象保存在 Root 目录下，由应用以 PublicURL 为前缀对外提供访问
+type LocalStorage struct {
+	Root      string // 存储根目录
+	PublicURL string // 访问路径前缀
+}
+
+// NewLocalStorage 创建本地磁盘存储并确保根目录存在
+// 参数：
+//   - root: 存储根目录，为空时使用 LOCAL_STORAGE_DEFAULT_PATH
+//   - publicURL: 访问路径前缀，为空时使用 LOCAL_STORAGE_DEFAULT_URL
+//
+// 返回值：
+//   - *LocalStorage: 本地磁盘存储
+//   - error: 创建目录失败时返回错误
+func NewLocalStorage(root, publicURL string) (*LocalStorage, error) {
+	if root == "" {
+		root = LOCAL_STORAGE_DEFAULT_PATH
+	}
+	if publicURL == "" {
+		publicURL = LOCAL_STORAGE_DEFAULT_URL
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("创建存储目录失败: %w", err)
+	}
+	return &LocalStorage{Root: root, PublicURL: strings.TrimRight(publicURL, "/")}, nil
+}
+
+// Put 写入对象，先写入临时文件再重命名，避免读到写了一半的文件
+// 参数：
+//   - ctx: 上下文
+//   - key: 对象键
+//   - data: 对象内容
+//   - contentType: 内容类型，本地存储按扩展名提供访问，不单独保存
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建存储目录失败: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("设置文件权限失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("保存文件失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除对象
+// 参数：
+//   - ctx: 上下文
+//   - key: 对象键
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("删除文件失败: %w", err)
+	}
+	return nil
+}
+
+// URL 返回对象的访问地址
+// 参数：
+//   - key: 对象键
+//
+// 返回值：
+//   - string: 访问地址
+func (s *LocalStorage) URL(key string) string {
+	return s.PublicURL + "/" + strings.TrimLeft(key, "/")
+}
+
+// path 将对象键映射为根目录下的文件路径
+// 参数：
+//   - key: 对象键
+//
+// 返回值：
+//   - string: 文件路径
+//   - error: 键非法时返回 ErrInvalidKey
+func (s *LocalStorage) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,102 @@
+// Package storage 提供可插拔的文件存储后端
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"lease/configs"
+	"lease/internal/global"
+)
+
+// 存储后端类型
+const (
+	STORAGE_TYPE_LOCAL = "local" // 本地磁盘
+)
+
+// ErrInvalidKey 对象键为空、为绝对路径或包含上级目录引用
+var ErrInvalidKey = errors.New("非法的对象键")
+
+// Storage 文件存储后端，对象以斜杠分隔的相对路径作为键
+type Storage interface {
+	// Put 写入对象，同名对象会被覆盖
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete 删除对象，对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+	// URL 返回对象的访问地址
+	URL(key string) string
+}
+
+// defaultStorage 按配置初始化的存储后端
+var defaultStorage Storage
+
+// New 按配置初始化存储后端
+// 参数：
+//   - config: 应用配置
+func New(config *configs.Config) {
+	backend, err := newStorage(config.StorageConfig)
+	if err != nil {
+		global.SysLog.Errorf("文件存储初始化失败: %v", err)
+		return
+	}
+	defaultStorage = backend
+	global.SysLog.Infof("文件存储初始化成功，类型: %s", storageType(config.StorageConfig))
+}
+
+// Default 获取已初始化的存储后端
+// 返回值：
+//   - Storage: 存储后端，未初始化时为 nil
+func Default() Storage {
+	return defaultStorage
+}
+
+// newStorage 创建存储后端
+// 参数：
+//   - config: 存储配置
+//
+// 返回值：
+//   - Storage: 存储后端
+//   - error: 不支持的类型或创建失败时返回错误
+func newStorage(config configs.StorageConfig) (Storage, error) {
+	switch storageType(config) {
+	case STORAGE_TYPE_LOCAL:
+		return NewLocalStorage(config.LocalPath, config.PublicURL)
+	default:
+		return nil, fmt.Errorf("不支持的存储类型: %s", config.StorageType)
+	}
+}
+
+// storageType 获取生效的存储类型，未配置时使用本地磁盘
+// 参数：
+//   - config: 存储配置
+//
+// 返回值：
+//   - string: 存储类型
+func storageType(config configs.StorageConfig) string {
+	if config.StorageType == "" {
+		return STORAGE_TYPE_LOCAL
+	}
+	return strings.ToLower(config.StorageType)
+}
+
+// cleanKey 校验并规范化对象键
+// 参数：
+//   - key: 对象键
+//
+// 返回值：
+//   - string: 规范化后的对象键
+//   - error: 键非法时返回 ErrInvalidKey
+func cleanKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,84 @@
+// Package utils 提供数据库事务与读写路由工具
+package utils
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"lease/internal/global"
+)
+
+// 数据库上下文相关常量
+const (
+	DB_TRANSACTION_CONTEXT_KEY = "db_transaction" // 上下文中保存事务对象的键
+	DB_REPORT_CONTEXT_KEY      = "db_report"      // 上下文中标记报表查询的键
+	DB_RESOLVER_REPORT         = "report"         // 报表查询使用的只读副本路由名称
+)
+
+// RunDBTransaction 在数据库事务中执行业务函数，函数返回错误或发生 panic 时回滚
+// 参数：
+//   - c: Gin 上下文
+//   - fn: 业务函数，参数为事务开启时的错误（恒为 nil）
+//
+// 返回值：
+//   - error: 执行过程中的错误
+func RunDBTransaction(c *gin.Context, fn func(error) error) (err error) {
+	// 已处于事务中时直接复用外层事务
+	if _, ok := c.Get(DB_TRANSACTION_CONTEXT_KEY); ok {
+		return fn(nil)
+	}
+
+	tx := global.DB.WithContext(c.Request.Context()).Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("开启事务失败: %w", tx.Error)
+	}
+
+	c.Set(DB_TRANSACTION_CONTEXT_KEY, tx)
+	defer func() {
+		delete(c.Keys, DB_TRANSACTION_CONTEXT_KEY)
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err = fn(nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+	return nil
+}
+
+// UseReportDB 将当前请求标记为报表查询，此后事务外的读操作路由到只读副本，写操作仍使用主库；
+// 副本存在复制延迟，只应用于可容忍数据稍有滞后的只读请求，未配置副本时仍查询主库
+// 参数：
+//   - c: Gin 上下文
+func UseReportDB(c *gin.Context) {
+	c.Set(DB_REPORT_CONTEXT_KEY, true)
+}
+
+// GetDBFromContext 获取当前上下文中的数据库对象，处于事务中时返回事务对象，报表查询返回路由到只读副本的对象
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *gorm.DB: 数据库对象
+func GetDBFromContext(c *gin.Context) *gorm.DB {
+	if tx, ok := c.Get(DB_TRANSACTION_CONTEXT_KEY); ok {
+		if db, ok := tx.(*gorm.DB); ok {
+			return db
+		}
+	}
+	db := global.DB.WithContext(c.Request.Context())
+	if c.GetBool(DB_REPORT_CONTEXT_KEY) {
+		db = db.Clauses(dbresolver.Use(DB_RESOLVER_REPORT))
+	}
+	return db
+}
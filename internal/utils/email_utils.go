@@ -0,0 +1,85 @@
+// Package utils 提供邮件发送与邮箱校验工具
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"gopkg.in/gomail.v2"
+
+	"lease/configs"
+)
+
+// emailServer 邮件服务器地址与端口
+type emailServer struct {
+	Host string // SMTP 主机
+	Port int    // SMTP SSL 端口
+}
+
+// emailServers 支持的邮箱类型对应的 SMTP 服务器
+var emailServers = map[string]emailServer{
+	"qq":      {Host: "smtp.qq.com", Port: 465},
+	"gmail":   {Host: "smtp.gmail.com", Port: 465},
+	"outlook": {Host: "smtp.office365.com", Port: 587},
+}
+
+// emailRegex 邮箱格式正则
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+// SendEmail 发送邮件
+// 参数：
+//   - content: 邮件正文
+//   - toEmails: 收件人列表
+//
+// 返回值：
+//   - bool: 发送成功返回 true
+//   - error: 发送过程中的错误
+func SendEmail(content string, toEmails []string) (bool, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return false, fmt.Errorf("加载邮件配置失败: %w", err)
+	}
+
+	server, ok := emailServers[cfg.AppConfig.EmailType]
+	if !ok {
+		return false, fmt.Errorf("不支持的邮箱类型: %s", cfg.AppConfig.EmailType)
+	}
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", cfg.AppConfig.FromEmail)
+	msg.SetHeader("To", toEmails...)
+	msg.SetHeader("Subject", cfg.AppConfig.AppName)
+	msg.SetBody("text/plain", content)
+
+	dialer := gomail.NewDialer(server.Host, server.Port, cfg.AppConfig.FromEmail, cfg.AppConfig.EmailSmtp)
+	dialer.TLSConfig = &tls.Config{ServerName: server.Host}
+	if err := dialer.DialAndSend(msg); err != nil {
+		return false, fmt.Errorf("邮件发送失败: %w", err)
+	}
+
+	return true, nil
+}
+
+// ValidEmail 校验邮箱格式
+// 参数：
+//   - email: 邮箱地址
+//
+// 返回值：
+//   - bool: 格式合法返回 true
+func ValidEmail(email string) bool {
+	return emailRegex.MatchString(email)
+}
+
+// NewRand 生成六位数字验证码
+// 返回值：
+//   - int: 100000 ~ 999999 之间的随机数
+func NewRand() int {
+	n, err := rand.Int(rand.Reader, big.NewInt(900000))
+	if err != nil {
+		return 100000
+	}
+	return int(n.Int64()) + 100000
+}
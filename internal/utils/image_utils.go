@@ -0,0 +1,117 @@
+// Package utils 提供图片格式识别、缩放与重新编码工具
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/gabriel-vasile/mimetype"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// 图片处理相关常量
+const (
+	IMAGE_MAX_PIXELS   = 40_000_000 // 解码前允许的最大像素数，防止小文件声明超大尺寸耗尽内存
+	IMAGE_JPEG_QUALITY = 85         // 重新编码 JPEG 的质量
+)
+
+// imageAllowedTypes 允许的图片 MIME 类型
+var imageAllowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// ErrImageUnsupported 文件内容不是支持的图片格式或无法解码
+var ErrImageUnsupported = errors.New("不支持的图片格式")
+
+// NormalizeImage 按文件内容识别图片格式，等比缩放至不超过 maxSide 并重新编码；
+// PNG 保持 PNG 以保留透明通道，其余格式统一编码为 JPEG，重新编码同时去除 EXIF 等元数据
+// 参数：
+//   - data: 原始文件内容
+//   - maxSide: 缩放后长边的最大像素数
+//
+// 返回值：
+//   - []byte: 处理后的图片内容
+//   - string: 处理后的 MIME 类型
+//   - string: 处理后的扩展名，含前导点
+//   - error: 格式不支持或无法解码时返回 ErrImageUnsupported，其余为处理过程中的错误
+func NormalizeImage(data []byte, maxSide int) ([]byte, string, string, error) {
+	detected := mimetype.Detect(data)
+	if !imageAllowedTypes[detected.String()] {
+		return nil, "", "", fmt.Errorf("%w: %s", ErrImageUnsupported, detected.String())
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w: %v", ErrImageUnsupported, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > IMAGE_MAX_PIXELS {
+		return nil, "", "", fmt.Errorf("%w: 图片尺寸 %dx%d 超出限制", ErrImageUnsupported, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w: %v", ErrImageUnsupported, err)
+	}
+	dst := resizeImage(src, maxSide)
+
+	var buf bytes.Buffer
+	if detected.Is("image/png") {
+		if err := png.Encode(&buf, dst); err != nil {
+			return nil, "", "", fmt.Errorf("编码图片失败: %w", err)
+		}
+		return buf.Bytes(), "image/png", ".png", nil
+	}
+	if err := jpeg.Encode(&buf, flattenImage(dst), &jpeg.Options{Quality: IMAGE_JPEG_QUALITY}); err != nil {
+		return nil, "", "", fmt.Errorf("编码图片失败: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", ".jpg", nil
+}
+
+// resizeImage 等比缩放图片，长边不超过 maxSide 时原样返回
+// 参数：
+//   - src: 原始图片
+//   - maxSide: 长边的最大像素数
+//
+// 返回值：
+//   - image.Image: 缩放后的图片
+func resizeImage(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxSide <= 0 || (width <= maxSide && height <= maxSide) {
+		return src
+	}
+
+	if width >= height {
+		height = max(1, height*maxSide/width)
+		width = maxSide
+	} else {
+		width = max(1, width*maxSide/height)
+		height = maxSide
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+// flattenImage 将带透明通道的图片铺在白色背景上，避免编码为 JPEG 后透明区域变黑
+// 参数：
+//   - src: 原始图片
+//
+// 返回值：
+//   - image.Image: 不含透明通道的图片
+func flattenImage(src image.Image) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,32 @@
+// Package utils 提供图形验证码生成工具
+package utils
+
+import (
+	"fmt"
+
+	"github.com/mojocn/base64Captcha"
+)
+
+// 图形验证码参数
+const (
+	IMG_CODE_HEIGHT = 60  // 图片高度
+	IMG_CODE_WIDTH  = 200 // 图片宽度
+	IMG_CODE_LENGTH = 4   // 验证码位数
+)
+
+// GenImgVerificationCode 生成图形验证码
+// 返回值：
+//   - string: Base64 编码的验证码图片
+//   - string: 验证码答案
+//   - error: 生成过程中的错误
+func GenImgVerificationCode() (string, string, error) {
+	driver := base64Captcha.NewDriverDigit(IMG_CODE_HEIGHT, IMG_CODE_WIDTH, IMG_CODE_LENGTH, 0.7, 80)
+	_, content, answer := driver.GenerateIdQuestionAnswer()
+
+	item, err := driver.DrawCaptcha(content)
+	if err != nil {
+		return "", "", fmt.Errorf("绘制图形验证码失败: %w", err)
+	}
+
+	return item.EncodeB64string(), answer, nil
+}
@@ -0,0 +1,291 @@
+// Package utils 提供 JWT 令牌生成、校验与刷新工具
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"lease/configs"
+)
+
+// JWT 相关常量
+const (
+	ACCESS_TOKEN_EXPIRE_TIME  = time.Hour * 2      // Access Token 有效期
+	REFRESH_TOKEN_EXPIRE_TIME = time.Hour * 24 * 7 // Refresh Token 有效期
+	TOKEN_PREFIX              = "Bearer "          // Token 前缀
+	CLAIM_ACCOUNT_ID          = "account_id"       // 账户 ID 声明键
+	CLAIM_TOKEN_TYPE          = "token_type"       // 令牌类型声明键
+	CLAIM_ORGANIZATION_ID     = "organization_id"  // 组织 ID 声明键
+	CLAIM_PERMISSIONS         = "permissions"      // 权限编码声明键
+	CLAIM_SESSION_ID          = "sid"              // 会话 ID 声明键
+	CLAIM_REFRESH_ID          = "jti"              // Refresh Token ID 声明键
+	TOKEN_TYPE_ACCESS         = "access"           // Access Token 类型
+	TOKEN_TYPE_REFRESH        = "refresh"          // Refresh Token 类型
+)
+
+var (
+	accessSecret    []byte // Access Token 签名密钥，由 InitJWTSecrets 从配置加载
+	refreshSecret   []byte // Refresh Token 签名密钥，由 InitJWTSecrets 从配置加载
+	magicLinkSecret []byte // 免密登录令牌签名密钥，与 Access/Refresh Token 分离，避免令牌被互相冒用，由 InitJWTSecrets 从配置加载
+)
+
+// errSecretNotInitialized 签名密钥未加载
+var errSecretNotInitialized = errors.New("令牌签名密钥未初始化")
+
+// InitJWTSecrets 从安全策略配置加载 Access Token、Refresh Token 与免密登录令牌的签名密钥，启动时调用一次
+// 参数：
+//   - config: 安全策略配置，密钥已通过 configs.Validate 校验
+//
+// 返回值：
+//   - error: 密钥为空时返回错误
+func InitJWTSecrets(config configs.SecurityConfig) error {
+	if config.JWTAccessSecret == "" || config.JWTRefreshSecret == "" || config.MagicLinkSecret == "" {
+		return errSecretNotInitialized
+	}
+	accessSecret = []byte(config.JWTAccessSecret)
+	refreshSecret = []byte(config.JWTRefreshSecret)
+	magicLinkSecret = []byte(config.MagicLinkSecret)
+	return nil
+}
+
+// TokenSubject 写入令牌的账户与会话信息
+type TokenSubject struct {
+	AccountID      int64    // 账户 ID
+	OrganizationID int64    // 所属组织 ID
+	Permissions    []string // 权限编码
+	SessionID      string   // 会话 ID，同时写入两种令牌
+	RefreshID      string   // Refresh Token ID，每次轮换重新生成
+}
+
+// GenerateJWT 生成 Access Token 和 Refresh Token，组织与权限编码写入 Access Token，会话 ID 同时写入两种令牌
+// 参数：
+//   - subject: 账户信息
+//
+// 返回值：
+//   - string: Access Token
+//   - string: Refresh Token
+//   - error: 操作过程中的错误
+func GenerateJWT(subject TokenSubject) (string, string, error) {
+	if len(accessSecret) == 0 || len(refreshSecret) == 0 {
+		return "", "", errSecretNotInitialized
+	}
+
+	now := time.Now()
+	accountID := subject.AccountID
+	permissions := subject.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		CLAIM_ACCOUNT_ID:      accountID,
+		CLAIM_TOKEN_TYPE:      TOKEN_TYPE_ACCESS,
+		CLAIM_ORGANIZATION_ID: subject.OrganizationID,
+		CLAIM_PERMISSIONS:     permissions,
+		CLAIM_SESSION_ID:      subject.SessionID,
+		"iat":                 now.Unix(),
+		"exp":                 now.Add(ACCESS_TOKEN_EXPIRE_TIME).Unix(),
+	}).SignedString(accessSecret)
+	if err != nil {
+		return "", "", fmt.Errorf("生成 Access Token 失败: %w", err)
+	}
+
+	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		CLAIM_ACCOUNT_ID: accountID,
+		CLAIM_TOKEN_TYPE: TOKEN_TYPE_REFRESH,
+		CLAIM_SESSION_ID: subject.SessionID,
+		CLAIM_REFRESH_ID: subject.RefreshID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(REFRESH_TOKEN_EXPIRE_TIME).Unix(),
+	}).SignedString(refreshSecret)
+	if err != nil {
+		return "", "", fmt.Errorf("生成 Refresh Token 失败: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// ValidateJWTToken 校验 JWT 令牌
+// 参数：
+//   - tokenString: 令牌字符串
+//   - isRefreshToken: 是否为 Refresh Token
+//
+// 返回值：
+//   - *jwt.Token: 解析后的令牌
+//   - error: 校验过程中的错误
+func ValidateJWTToken(tokenString string, isRefreshToken bool) (*jwt.Token, error) {
+	secret, tokenType := accessSecret, TOKEN_TYPE_ACCESS
+	if isRefreshToken {
+		secret, tokenType = refreshSecret, TOKEN_TYPE_REFRESH
+	}
+	if len(secret) == 0 {
+		return nil, errSecretNotInitialized
+	}
+
+	parser := jwt.NewParser(jwt.WithJSONNumber(), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	token, err := parser.Parse(strings.TrimPrefix(tokenString, TOKEN_PREFIX), func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("令牌解析失败: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("令牌无效")
+	}
+	if claims[CLAIM_TOKEN_TYPE] != tokenType {
+		return nil, errors.New("令牌类型不匹配")
+	}
+
+	return token, nil
+}
+
+// RefreshTokenLogic 使用 Refresh Token 换取新的令牌对并轮换会话中的 Refresh Token ID，组织与权限按账户当前状态重新加载；
+// 已轮换过的 Refresh Token 再次使用时整个会话被注销
+// 参数：
+//   - ctx: 上下文
+//   - refreshTokenString: Refresh Token
+//   - loadSubject: 按账户 ID 加载账户信息的函数
+//
+// 返回值：
+//   - map[string]string: 新令牌，键为 accessToken 与 refreshToken
+//   - error: 刷新过程中的错误，检测到重复使用时为 ErrRefreshTokenReused
+func RefreshTokenLogic(ctx context.Context, refreshTokenString string, loadSubject func(accountID int64) (*TokenSubject, error)) (map[string]string, error) {
+	token, err := ValidateJWTToken(refreshTokenString, true)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	accountID, err := accountIDFromClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, _ := claims[CLAIM_SESSION_ID].(string)
+	refreshID, _ := claims[CLAIM_REFRESH_ID].(string)
+	if sessionID == "" || refreshID == "" {
+		return nil, ErrSessionRevoked
+	}
+
+	newRefreshID, err := RotateSession(ctx, accountID, sessionID, refreshID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := loadSubject(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("加载账户信息失败: %w", err)
+	}
+	subject.SessionID = sessionID
+	subject.RefreshID = newRefreshID
+
+	accessToken, refreshToken, err := GenerateJWT(*subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+	}, nil
+}
+
+// ParseAccountAndRoleIDFromJWT 从 Access Token 中解析账户 ID
+// 参数：
+//   - tokenString: Access Token，可带 Bearer 前缀
+//
+// 返回值：
+//   - int64: 账户 ID
+//   - error: 解析过程中的错误
+func ParseAccountAndRoleIDFromJWT(tokenString string) (int64, error) {
+	token, err := ValidateJWTToken(tokenString, false)
+	if err != nil {
+		return 0, err
+	}
+
+	return accountIDFromClaims(token.Claims.(jwt.MapClaims))
+}
+
+// ParseOrganizationIDFromJWT 从 Access Token 中解析所属组织 ID
+// 参数：
+//   - tokenString: Access Token，可带 Bearer 前缀
+//
+// 返回值：
+//   - int64: 组织 ID，早期签发的令牌不含该声明时为 0
+//   - error: 解析过程中的错误
+func ParseOrganizationIDFromJWT(tokenString string) (int64, error) {
+	token, err := ValidateJWTToken(tokenString, false)
+	if err != nil {
+		return 0, err
+	}
+
+	organizationID, ok := token.Claims.(jwt.MapClaims)[CLAIM_ORGANIZATION_ID].(json.Number)
+	if !ok {
+		return 0, nil
+	}
+	return organizationID.Int64()
+}
+
+// ParseSessionIDFromJWT 从 Access Token 中解析会话 ID
+// 参数：
+//   - tokenString: Access Token，可带 Bearer 前缀
+//
+// 返回值：
+//   - string: 会话 ID，早期签发的令牌不含该声明时为空
+//   - error: 解析过程中的错误
+func ParseSessionIDFromJWT(tokenString string) (string, error) {
+	token, err := ValidateJWTToken(tokenString, false)
+	if err != nil {
+		return "", err
+	}
+
+	sessionID, _ := token.Claims.(jwt.MapClaims)[CLAIM_SESSION_ID].(string)
+	return sessionID, nil
+}
+
+// ParsePermissionsFromJWT 从 Access Token 中解析权限编码
+// 参数：
+//   - tokenString: Access Token，可带 Bearer 前缀
+//
+// 返回值：
+//   - []string: 权限编码
+//   - error: 解析过程中的错误
+func ParsePermissionsFromJWT(tokenString string) ([]string, error) {
+	token, err := ValidateJWTToken(tokenString, false)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := token.Claims.(jwt.MapClaims)[CLAIM_PERMISSIONS].([]interface{})
+	permissions := make([]string, 0, len(raw))
+	for _, p := range raw {
+		if code, ok := p.(string); ok {
+			permissions = append(permissions, code)
+		}
+	}
+	return permissions, nil
+}
+
+// accountIDFromClaims 从令牌声明中读取账户 ID
+// 参数：
+//   - claims: 令牌声明
+//
+// 返回值：
+//   - int64: 账户 ID
+//   - error: 读取过程中的错误
+func accountIDFromClaims(claims jwt.MapClaims) (int64, error) {
+	// 雪花 ID 超出 float64 精度，需以 json.Number 解析
+	switch v := claims[CLAIM_ACCOUNT_ID].(type) {
+	case json.Number:
+		return v.Int64()
+	default:
+		return 0, errors.New("令牌中缺少账户 ID")
+	}
+}
@@ -0,0 +1,36 @@
+// Package utils 提供业务日志工具
+package utils
+
+import (
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"lease/internal/global"
+)
+
+// BIZ_LOG_CONTEXT_KEY 上下文中缓存业务日志对象的键
+const BIZ_LOG_CONTEXT_KEY = "biz_log"
+
+// BizLogger 获取携带请求信息的业务日志对象
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *logrus.Entry: 业务日志对象
+func BizLogger(c *gin.Context) *logrus.Entry {
+	if entry, ok := c.Get(BIZ_LOG_CONTEXT_KEY); ok {
+		if bizLog, ok := entry.(*logrus.Entry); ok {
+			return bizLog
+		}
+	}
+
+	bizLog := global.SysLog.WithFields(logrus.Fields{
+		"requestId": requestid.Get(c),
+		"method":    c.Request.Method,
+		"uri":       c.Request.RequestURI,
+		"ip":        c.ClientIP(),
+	})
+	c.Set(BIZ_LOG_CONTEXT_KEY, bizLog)
+	return bizLog
+}
@@ -0,0 +1,76 @@
+// Package utils 提供模型到视图对象的映射工具
+package utils
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MapModelToVO 将模型中同名且类型兼容的字段复制到视图对象，支持匿名嵌入字段
+// 参数：
+//   - model: 模型对象（结构体或结构体指针）
+//   - vo: 视图对象指针
+//
+// 返回值：
+//   - interface{}: 填充后的视图对象指针
+//   - error: 映射过程中的错误
+func MapModelToVO(model interface{}, vo interface{}) (interface{}, error) {
+	voVal := reflect.ValueOf(vo)
+	if voVal.Kind() != reflect.Ptr || voVal.IsNil() || voVal.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("视图对象必须为非空结构体指针")
+	}
+
+	modelVal := reflect.Indirect(reflect.ValueOf(model))
+	if modelVal.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("模型对象必须为结构体")
+	}
+
+	fields := make(map[string]reflect.Value)
+	collectFields(modelVal, fields)
+
+	voElem := voVal.Elem()
+	for i := 0; i < voElem.NumField(); i++ {
+		field := voElem.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		src, ok := fields[field.Name]
+		if !ok {
+			continue
+		}
+
+		dst := voElem.Field(i)
+		switch {
+		case src.Type().AssignableTo(dst.Type()):
+			dst.Set(src)
+		case src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind():
+			dst.Set(src.Convert(dst.Type()))
+		}
+	}
+
+	return vo, nil
+}
+
+// collectFields 递归收集结构体的导出字段，外层字段优先于嵌入字段
+// 参数：
+//   - val: 结构体值
+//   - fields: 字段名到字段值的映射
+func collectFields(val reflect.Value, fields map[string]reflect.Value) {
+	var embedded []reflect.Value
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && val.Field(i).Kind() == reflect.Struct {
+			embedded = append(embedded, val.Field(i))
+			continue
+		}
+		if _, exists := fields[field.Name]; !exists {
+			fields[field.Name] = val.Field(i)
+		}
+	}
+	for _, e := range embedded {
+		collectFields(e, fields)
+	}
+}
@@ -0,0 +1,33 @@
+// Package utils 提供组织隔离上下文工具
+package utils
+
+import "context"
+
+// organizationContextKey 请求上下文中保存当前组织 ID 的键
+type organizationContextKey struct{}
+
+// WithOrganizationID 将当前组织 ID 写入请求上下文，数据库读写据此限定组织范围
+// 参数：
+//   - ctx: 请求上下文
+//   - organizationID: 组织 ID
+//
+// 返回值：
+//   - context.Context: 携带组织 ID 的上下文
+func WithOrganizationID(ctx context.Context, organizationID int64) context.Context {
+	return context.WithValue(ctx, organizationContextKey{}, organizationID)
+}
+
+// OrganizationIDFromContext 从请求上下文中读取当前组织 ID
+// 参数：
+//   - ctx: 请求上下文
+//
+// 返回值：
+//   - int64: 组织 ID
+//   - bool: 上下文中是否存在组织 ID，未认证的请求不存在
+func OrganizationIDFromContext(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	organizationID, ok := ctx.Value(organizationContextKey{}).(int64)
+	return organizationID, ok
+}
@@ -0,0 +1,169 @@
+// Package utils 提供密码强度策略校验工具
+package utils
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"lease/configs"
+)
+
+// 密码策略默认值，配置项缺省或非法时使用
+const (
+	PASSWORD_DEFAULT_MIN_LENGTH = 8  // 默认最小长度
+	PASSWORD_DEFAULT_MAX_LENGTH = 64 // 默认最大长度
+	PASSWORD_BCRYPT_MAX_BYTES   = 72 // bcrypt 只取前 72 字节，超出部分不参与哈希
+)
+
+// commonPasswordList 随程序打包的常见及已泄露密码列表，仅含少量示例，生产环境应通过 PASSWORD_BREACHED_LIST_FILE 配置完整列表
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords 常见密码集合，键为小写密码；默认取自打包列表，由 InitCommonPasswords 替换为配置的列表
+var commonPasswords, _ = loadCommonPasswords(strings.NewReader(commonPasswordList))
+
+// InitCommonPasswords 从安全策略配置的列表文件加载常见或已泄露密码，未配置时使用随程序打包的示例列表，启动时调用一次
+// 参数：
+//   - config: 安全策略配置
+//
+// 返回值：
+//   - error: 列表文件无法读取或为空时返回错误
+func InitCommonPasswords(config configs.SecurityConfig) error {
+	if config.PasswordBreachedListFile == "" {
+		passwords, err := loadCommonPasswords(strings.NewReader(commonPasswordList))
+		if err != nil {
+			return fmt.Errorf("读取打包的常见密码列表失败: %w", err)
+		}
+		commonPasswords = passwords
+		return nil
+	}
+
+	file, err := os.Open(config.PasswordBreachedListFile)
+	if err != nil {
+		return fmt.Errorf("打开常见密码列表失败: %w", err)
+	}
+	defer file.Close()
+
+	passwords, err := loadCommonPasswords(file)
+	if err != nil {
+		return fmt.Errorf("读取常见密码列表失败: %w", err)
+	}
+	if len(passwords) == 0 {
+		return fmt.Errorf("常见密码列表 %q 为空", config.PasswordBreachedListFile)
+	}
+	commonPasswords = passwords
+	return nil
+}
+
+// CheckPasswordPolicy 按安全策略校验密码的长度、字符类型以及是否属于常见或已泄露密码
+// 参数：
+//   - password: 待校验的明文密码
+//   - policy: 安全策略配置
+//
+// 返回值：
+//   - error: 不满足策略时返回包含全部未满足项的错误
+func CheckPasswordPolicy(password string, policy configs.SecurityConfig) error {
+	minLength, maxLength := passwordLengthBounds(policy)
+
+	var problems []string
+	length := len([]rune(password))
+	if length < minLength {
+		problems = append(problems, fmt.Sprintf("长度不能少于 %d 位", minLength))
+	}
+	if length > maxLength || len(password) > PASSWORD_BCRYPT_MAX_BYTES {
+		problems = append(problems, fmt.Sprintf("长度不能超过 %d 位", maxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if policy.PasswordRequireUpper && !hasUpper {
+		problems = append(problems, "须包含大写字母")
+	}
+	if policy.PasswordRequireLower && !hasLower {
+		problems = append(problems, "须包含小写字母")
+	}
+	if policy.PasswordRequireDigit && !hasDigit {
+		problems = append(problems, "须包含数字")
+	}
+	if policy.PasswordRequireSymbol && !hasSymbol {
+		problems = append(problems, "须包含特殊字符")
+	}
+
+	if policy.PasswordCheckBreached && IsCommonPassword(password) {
+		problems = append(problems, "属于常见或已泄露的密码")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("密码%s", strings.Join(problems, "，"))
+	}
+	return nil
+}
+
+// IsCommonPassword 判断密码是否属于已加载的常见或已泄露密码，忽略大小写
+// 参数：
+//   - password: 明文密码
+//
+// 返回值：
+//   - bool: 属于常见密码返回 true
+func IsCommonPassword(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
+
+// passwordLengthBounds 计算生效的密码长度上下限
+// 参数：
+//   - policy: 安全策略配置
+//
+// 返回值：
+//   - int: 最小长度
+//   - int: 最大长度
+func passwordLengthBounds(policy configs.SecurityConfig) (int, int) {
+	minLength, maxLength := policy.PasswordMinLength, policy.PasswordMaxLength
+	if minLength <= 0 {
+		minLength = PASSWORD_DEFAULT_MIN_LENGTH
+	}
+	if maxLength <= 0 || maxLength > PASSWORD_BCRYPT_MAX_BYTES {
+		maxLength = PASSWORD_DEFAULT_MAX_LENGTH
+	}
+	if minLength > maxLength {
+		minLength = maxLength
+	}
+	return minLength, maxLength
+}
+
+// loadCommonPasswords 解析常见密码列表
+// 参数：
+//   - list: 每行一个密码的文本，以 # 开头的行为注释
+//
+// 返回值：
+//   - map[string]struct{}: 常见密码集合
+//   - error: 读取失败时返回错误
+func loadCommonPasswords(list io.Reader) (map[string]struct{}, error) {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(list)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords, scanner.Err()
+}
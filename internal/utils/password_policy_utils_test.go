@@ -0,0 +1,61 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lease/configs"
+	"lease/internal/utils"
+)
+
+func TestInitCommonPasswords(t *testing.T) {
+	t.Cleanup(func() {
+		if err := utils.InitCommonPasswords(configs.SecurityConfig{}); err != nil {
+			t.Fatalf("恢复打包的常见密码列表失败: %v", err)
+		}
+	})
+
+	if !utils.IsCommonPassword("Dragon") {
+		t.Fatalf("打包列表应包含 dragon")
+	}
+
+	file := filepath.Join(t.TempDir(), "passwords.txt")
+	if err := os.WriteFile(file, []byte("# 注释\n\nCorrect-Horse-42\n"), 0o600); err != nil {
+		t.Fatalf("写入列表文件失败: %v", err)
+	}
+	if err := utils.InitCommonPasswords(configs.SecurityConfig{PasswordBreachedListFile: file}); err != nil {
+		t.Fatalf("加载列表文件失败: %v", err)
+	}
+	if !utils.IsCommonPassword("correct-horse-42") {
+		t.Fatalf("配置的列表应包含 correct-horse-42")
+	}
+	if utils.IsCommonPassword("dragon") || utils.IsCommonPassword("# 注释") {
+		t.Fatalf("配置的列表应替换打包列表且忽略注释")
+	}
+}
+
+func TestInitCommonPasswordsInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("# 仅注释\n"), 0o600); err != nil {
+		t.Fatalf("写入列表文件失败: %v", err)
+	}
+
+	tests := []struct {
+		name, file string
+	}{
+		{"文件不存在", filepath.Join(dir, "missing.txt")},
+		{"列表为空", empty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := utils.InitCommonPasswords(configs.SecurityConfig{PasswordBreachedListFile: tt.file}); err == nil {
+				t.Fatalf("InitCommonPasswords(%q) 应返回错误", tt.file)
+			}
+			if !utils.IsCommonPassword("dragon") {
+				t.Fatalf("加载失败时应保留原列表")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package utils 提供手机号 E.164 格式规范化工具
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// E.164 号码长度限制，不含前导加号
+const (
+	PHONE_E164_MIN_DIGITS = 8  // 国家码加用户号码的最少位数
+	PHONE_E164_MAX_DIGITS = 15 // 国家码加用户号码的最多位数
+)
+
+// ErrPhoneInvalid 手机号格式无效
+var ErrPhoneInvalid = errors.New("手机号格式无效")
+
+// NormalizePhone 将手机号规范化为 E.164 格式（+国家码用户号码）：
+// 去除空格、连字符、点号与括号，00 开头的国际拨号前缀视为加号，未带国家码时按默认国家码补全并去除国内长途前缀 0
+// 参数：
+//   - phone: 用户输入的手机号
+//   - defaultCountryCode: 默认国家码，不含加号，为空时要求输入自带国家码
+//
+// 返回值：
+//   - string: E.164 格式手机号
+//   - error: 格式无效时返回 ErrPhoneInvalid
+func NormalizePhone(phone, defaultCountryCode string) (string, error) {
+	phone = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '\t':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phone))
+
+	var digits string
+	switch {
+	case strings.HasPrefix(phone, "+"):
+		digits = phone[1:]
+	case strings.HasPrefix(phone, "00"):
+		digits = phone[2:]
+	default:
+		countryCode := strings.TrimPrefix(strings.TrimSpace(defaultCountryCode), "+")
+		if countryCode == "" || !isDigits(countryCode) {
+			return "", ErrPhoneInvalid
+		}
+		digits = countryCode + strings.TrimPrefix(phone, "0")
+	}
+
+	if !isDigits(digits) || digits[0] == '0' || len(digits) < PHONE_E164_MIN_DIGITS || len(digits) > PHONE_E164_MAX_DIGITS {
+		return "", ErrPhoneInvalid
+	}
+	return "+" + digits, nil
+}
+
+// isDigits 判断字符串是否非空且仅包含 ASCII 数字
+// 参数：
+//   - s: 字符串
+//
+// 返回值：
+//   - bool: 仅包含数字返回 true
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
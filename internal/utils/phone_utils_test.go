@@ -0,0 +1,51 @@
+package utils_test
+
+import (
+	"errors"
+	"testing"
+
+	"lease/internal/utils"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name, phone, countryCode, want string
+	}{
+		{"本地号码补全默认国家码", "13800138000", "86", "+8613800138000"},
+		{"去除分隔符", "138-0013 8000", "86", "+8613800138000"},
+		{"去除国内长途前缀", "020 8888 8888", "86", "+862088888888"},
+		{"默认国家码带加号", "13800138000", "+86", "+8613800138000"},
+		{"自带国家码", "+1 (415) 555-2671", "86", "+14155552671"},
+		{"国际拨号前缀", "0044 20 7946 0958", "86", "+442079460958"},
+		{"自带国家码时无需默认国家码", "+8613800138000", "", "+8613800138000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.NormalizePhone(tt.phone, tt.countryCode)
+			if err != nil || got != tt.want {
+				t.Fatalf("NormalizePhone(%q, %q) = %q, %v，期望 %q", tt.phone, tt.countryCode, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePhoneInvalid(t *testing.T) {
+	tests := []struct {
+		name, phone, countryCode string
+	}{
+		{"空号码", "", "86"},
+		{"缺少国家码", "13800138000", ""},
+		{"包含字母", "+86 138 0013 800a", "86"},
+		{"国家码以 0 开头", "+0123456789", "86"},
+		{"位数过少", "+1234567", "86"},
+		{"位数过多", "+1234567890123456", "86"},
+		{"仅有加号", "+", "86"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := utils.NormalizePhone(tt.phone, tt.countryCode); !errors.Is(err, utils.ErrPhoneInvalid) {
+				t.Fatalf("NormalizePhone(%q, %q) = %q, %v，期望 ErrPhoneInvalid", tt.phone, tt.countryCode, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+// Package utils 提供基于 Redis 的登录会话登记工具
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"lease/internal/global"
+)
+
+// 会话相关常量
+const (
+	SESSION_CACHE_PREFIX       = "USER_SESSION"       // 会话键前缀，完整键为 USER_SESSION:<账户 ID>:<会话 ID>
+	SESSION_INDEX_CACHE_PREFIX = "USER_SESSION_INDEX" // 账户会话索引键前缀，完整键为 USER_SESSION_INDEX:<账户 ID>
+	SESSION_ID_BYTES           = 16                   // 会话 ID 与 Refresh Token ID 的随机字节数
+	SESSION_AUTHORIZATION_TTL  = 5 * time.Minute      // 会话中缓存的组织与权限的有效期，过期后按账户角色重新加载
+)
+
+// 会话哈希字段
+const (
+	SESSION_FIELD_REFRESH_ID   = "refresh_id"      // 当前有效的 Refresh Token ID
+	SESSION_FIELD_USER_AGENT   = "user_agent"      // 登录设备 User-Agent
+	SESSION_FIELD_IP           = "ip"              // 登录 IP
+	SESSION_FIELD_CREATED_AT   = "created_at"      // 登录时间
+	SESSION_FIELD_LAST_ACTIVE  = "last_active_at"  // 最近活跃时间
+	SESSION_FIELD_REFRESHED_AT = "refreshed_at"    // 最近轮换 Refresh Token 的时间
+	SESSION_FIELD_ORGANIZATION = "organization_id" // 缓存的所属组织 ID
+	SESSION_FIELD_PERMISSIONS  = "permissions"     // 缓存的权限编码，JSON 数组
+	SESSION_FIELD_AUTHZ_AT     = "authz_cached_at" // 缓存组织与权限的时间
+)
+
+var (
+	ErrSessionRevoked     = errors.New("会话已失效")                    // 会话不存在、已过期或已被注销
+	ErrRefreshTokenReused = errors.New("Refresh Token 已被使用，会话已注销") // 同一令牌族中已轮换过的 Refresh Token 再次出现
+)
+
+// Session 一次设备登录产生的会话，会话内轮换出的全部 Refresh Token 构成同一令牌族
+type Session struct {
+	AccountID    int64  // 账户 ID
+	SessionID    string // 会话 ID
+	RefreshID    string // 当前有效的 Refresh Token ID
+	UserAgent    string // 登录设备 User-Agent
+	IP           string // 登录 IP
+	CreatedAt    int64  // 登录时间
+	LastActiveAt int64  // 最近活跃时间
+	RefreshedAt  int64  // 最近轮换 Refresh Token 的时间
+}
+
+// SessionAuthorization 按账户角色加载、缓存在会话中的组织与权限，用于撤销令牌声明中已失效的权限
+type SessionAuthorization struct {
+	OrganizationID int64    // 所属组织 ID
+	Permissions    []string // 权限编码
+}
+
+// rotateSessionScript 校验并轮换 Refresh Token ID：
+// 与当前 ID 一致时写入新 ID 并续期，返回 1；不一致说明旧令牌被重复使用，注销整个会话并返回 0；会话不存在返回 -1
+var rotateSessionScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'refresh_id')
+if not current then
+	return -1
+end
+if current ~= ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	redis.call('SREM', KEYS[2], ARGV[5])
+	return 0
+end
+redis.call('HSET', KEYS[1], 'refresh_id', ARGV[2], 'last_active_at', ARGV[3], 'refreshed_at', ARGV[3])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+return 1
+`)
+
+// touchSessionScript 会话存在时刷新最近活跃时间并返回缓存的组织、权限与缓存时间，否则返回 nil
+var touchSessionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return nil
+end
+redis.call('HSET', KEYS[1], 'last_active_at', ARGV[1])
+return redis.call('HMGET', KEYS[1], 'organization_id', 'permissions', 'authz_cached_at')
+`)
+
+// cacheAuthorizationScript 会话存在时写入组织与权限缓存并返回 1；会话已被注销时不写入，避免重新创建会话
+var cacheAuthorizationScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'organization_id', ARGV[1], 'permissions', ARGV[2], 'authz_cached_at', ARGV[3])
+return 1
+`)
+
+// CreateSession 登记一次新的设备登录，会话有效期与 Refresh Token 一致
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//   - userAgent: 登录设备 User-Agent
+//   - ip: 登录 IP
+//
+// 返回值：
+//   - *Session: 新建的会话
+//   - error: 操作过程中的错误
+func CreateSession(ctx context.Context, accountID int64, userAgent, ip string) (*Session, error) {
+	sessionID, err := newSessionToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshID, err := newSessionToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	session := &Session{
+		AccountID:    accountID,
+		SessionID:    sessionID,
+		RefreshID:    refreshID,
+		UserAgent:    userAgent,
+		IP:           ip,
+		CreatedAt:    now,
+		LastActiveAt: now,
+		RefreshedAt:  now,
+	}
+
+	key, indexKey := sessionKey(accountID, sessionID), sessionIndexKey(accountID)
+	pipe := global.RedisClient.TxPipeline()
+	pipe.HSet(ctx, key,
+		SESSION_FIELD_REFRESH_ID, refreshID,
+		SESSION_FIELD_USER_AGENT, userAgent,
+		SESSION_FIELD_IP, ip,
+		SESSION_FIELD_CREATED_AT, now,
+		SESSION_FIELD_LAST_ACTIVE, now,
+		SESSION_FIELD_REFRESHED_AT, now,
+	)
+	pipe.Expire(ctx, key, REFRESH_TOKEN_EXPIRE_TIME)
+	pipe.SAdd(ctx, indexKey, sessionID)
+	pipe.Expire(ctx, indexKey, REFRESH_TOKEN_EXPIRE_TIME)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("登记会话失败: %w", err)
+	}
+	return session, nil
+}
+
+// RotateSession 使用 Refresh Token 时轮换会话的 Refresh Token ID，已轮换过的旧令牌再次出现时注销整个会话
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//   - sessionID: 会话 ID
+//   - refreshID: 提交的 Refresh Token ID
+//
+// 返回值：
+//   - string: 新的 Refresh Token ID
+//   - error: 会话失效返回 ErrSessionRevoked，检测到重复使用返回 ErrRefreshTokenReused
+func RotateSession(ctx context.Context, accountID int64, sessionID, refreshID string) (string, error) {
+	newRefreshID, err := newSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	keys := []string{sessionKey(accountID, sessionID), sessionIndexKey(accountID)}
+	result, err := rotateSessionScript.Run(ctx, global.RedisClient, keys,
+		refreshID, newRefreshID, time.Now().Unix(),
+		int64(REFRESH_TOKEN_EXPIRE_TIME/time.Second), sessionID).Int()
+	if err != nil {
+		return "", fmt.Errorf("轮换会话令牌失败: %w", err)
+	}
+
+	switch result {
+	case 1:
+		return newRefreshID, nil
+	case 0:
+		return "", ErrRefreshTokenReused
+	default:
+		return "", ErrSessionRevoked
+	}
+}
+
+// TouchSession 校验会话是否有效并刷新最近活跃时间，同时返回会话中缓存的组织与权限
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//   - sessionID: 会话 ID
+//
+// 返回值：
+//   - *SessionAuthorization: 缓存的组织与权限，未缓存或已超过 SESSION_AUTHORIZATION_TTL 时为 nil
+//   - error: 会话失效返回 ErrSessionRevoked
+func TouchSession(ctx context.Context, accountID int64, sessionID string) (*SessionAuthorization, error) {
+	if sessionID == "" {
+		return nil, ErrSessionRevoked
+	}
+	result, err := touchSessionScript.Run(ctx, global.RedisClient, []string{sessionKey(accountID, sessionID)}, time.Now().Unix()).Slice()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionRevoked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("校验会话失败: %w", err)
+	}
+
+	if len(result) != 3 || result[0] == nil || result[1] == nil || result[2] == nil {
+		return nil, nil
+	}
+	cachedAt, _ := strconv.ParseInt(fmt.Sprint(result[2]), 10, 64)
+	if time.Since(time.Unix(cachedAt, 0)) > SESSION_AUTHORIZATION_TTL {
+		return nil, nil
+	}
+	organizationID, err := strconv.ParseInt(fmt.Sprint(result[0]), 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	var permissions []string
+	if err := json.Unmarshal([]byte(fmt.Sprint(result[1])), &permissions); err != nil {
+		return nil, nil
+	}
+	return &SessionAuthorization{OrganizationID: organizationID, Permissions: permissions}, nil
+}
+
+// CacheSessionAuthorization 将按账户角色加载的组织与权限缓存到会话中，会话已被注销时不写入
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//   - sessionID: 会话 ID
+//   - authorization: 组织与权限
+//
+// 返回值：
+//   - error: 会话失效返回 ErrSessionRevoked
+func CacheSessionAuthorization(ctx context.Context, accountID int64, sessionID string, authorization *SessionAuthorization) error {
+	permissions := authorization.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	encoded, err := json.Marshal(permissions)
+	if err != nil {
+		return fmt.Errorf("序列化权限编码失败: %w", err)
+	}
+
+	result, err := cacheAuthorizationScript.Run(ctx, global.RedisClient, []string{sessionKey(accountID, sessionID)},
+		authorization.OrganizationID, string(encoded), time.Now().Unix()).Int()
+	if err != nil {
+		return fmt.Errorf("缓存会话权限失败: %w", err)
+	}
+	if result != 1 {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// GetSessionCreatedAt 获取会话的登录时间，Refresh Token 轮换不改变登录时间
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//   - sessionID: 会话 ID
+//
+// 返回值：
+//   - int64: 登录时间戳
+//   - error: 会话失效返回 ErrSessionRevoked
+func GetSessionCreatedAt(ctx context.Context, accountID int64, sessionID string) (int64, error) {
+	if sessionID == "" {
+		return 0, ErrSessionRevoked
+	}
+	value, err := global.RedisClient.HGet(ctx, sessionKey(accountID, sessionID), SESSION_FIELD_CREATED_AT).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, ErrSessionRevoked
+	}
+	if err != nil {
+		return 0, fmt.Errorf("查询会话失败: %w", err)
+	}
+	return parseSessionTime(value), nil
+}
+
+// ListSessions 列出账户全部有效会话，按最近活跃时间倒序，顺带清理索引中已过期的会话
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []*Session: 会话列表
+//   - error: 操作过程中的错误
+func ListSessions(ctx context.Context, accountID int64) ([]*Session, error) {
+	indexKey := sessionIndexKey(accountID)
+	sessionIDs, err := global.RedisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("查询会话索引失败: %w", err)
+	}
+
+	sessions := make([]*Session, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		fields, err := global.RedisClient.HGetAll(ctx, sessionKey(accountID, sessionID)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("查询会话失败: %w", err)
+		}
+		if len(fields) == 0 {
+			global.RedisClient.SRem(ctx, indexKey, sessionID)
+			continue
+		}
+		sessions = append(sessions, &Session{
+			AccountID:    accountID,
+			SessionID:    sessionID,
+			RefreshID:    fields[SESSION_FIELD_REFRESH_ID],
+			UserAgent:    fields[SESSION_FIELD_USER_AGENT],
+			IP:           fields[SESSION_FIELD_IP],
+			CreatedAt:    parseSessionTime(fields[SESSION_FIELD_CREATED_AT]),
+			LastActiveAt: parseSessionTime(fields[SESSION_FIELD_LAST_ACTIVE]),
+			RefreshedAt:  parseSessionTime(fields[SESSION_FIELD_REFRESHED_AT]),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastActiveAt > sessions[j].LastActiveAt })
+	return sessions, nil
+}
+
+// RevokeSession 注销账户的指定会话，该会话签发的 Access Token 与 Refresh Token 随即失效
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//   - sessionID: 会话 ID
+//
+// 返回值：
+//   - error: 会话不存在返回 ErrSessionRevoked
+func RevokeSession(ctx context.Context, accountID int64, sessionID string) error {
+	pipe := global.RedisClient.TxPipeline()
+	deleted := pipe.Del(ctx, sessionKey(accountID, sessionID))
+	pipe.SRem(ctx, sessionIndexKey(accountID), sessionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("注销会话失败: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// RevokeAllSessions 注销账户的全部会话，会话中缓存的组织与权限随之清除，用于权限变更等需要所有设备重新登录的场景
+// 参数：
+//   - ctx: 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAllSessions(ctx context.Context, accountID int64) error {
+	indexKey := sessionIndexKey(accountID)
+	sessionIDs, err := global.RedisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return fmt.Errorf("查询会话索引失败: %w", err)
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(accountID, sessionID))
+	}
+	keys = append(keys, indexKey)
+	if err := global.RedisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("注销全部会话失败: %w", err)
+	}
+	return nil
+}
+
+// sessionKey 生成会话缓存键
+// 参数：
+//   - accountID: 账户 ID
+//   - sessionID: 会话 ID
+//
+// 返回值：
+//   - string: 缓存键
+func sessionKey(accountID int64, sessionID string) string {
+	return fmt.Sprintf("%s:%d:%s", SESSION_CACHE_PREFIX, accountID, sessionID)
+}
+
+// sessionIndexKey 生成账户会话索引缓存键
+// 参数：
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - string: 缓存键
+func sessionIndexKey(accountID int64) string {
+	return fmt.Sprintf("%s:%d", SESSION_INDEX_CACHE_PREFIX, accountID)
+}
+
+// newSessionToken 生成随机会话 ID 或 Refresh Token ID
+// 返回值：
+//   - string: 十六进制随机串
+//   - error: 生成过程中的错误
+func newSessionToken() (string, error) {
+	buf := make([]byte, SESSION_ID_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成会话标识失败: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// parseSessionTime 解析会话中保存的时间戳
+// 参数：
+//   - value: 时间戳字符串
+//
+// 返回值：
+//   - int64: 时间戳，解析失败时为 0
+func parseSessionTime(value string) int64 {
+	ts, _ := strconv.ParseInt(value, 10, 64)
+	return ts
+}
@@ -0,0 +1,109 @@
+// Package utils 提供基于 RFC 6238 的 TOTP 一次性密码工具
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP 相关常量
+const (
+	TOTP_SECRET_BYTES = 20               // 密钥随机字节数，与 HMAC-SHA1 输出长度一致
+	TOTP_DIGITS       = 6                // 动态码位数
+	TOTP_PERIOD       = 30 * time.Second // 时间步长
+	TOTP_SKEW         = 1                // 校验时前后各容忍的时间步数，用于抵消设备时钟偏差
+)
+
+// totpEncoding 密钥编码，身份验证器应用要求无填充的 Base32
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret 生成 Base32 编码的 TOTP 密钥
+// 返回值：
+//   - string: Base32 密钥
+//   - error: 生成过程中的错误
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, TOTP_SECRET_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成 TOTP 密钥失败: %w", err)
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPProvisioningURI 生成供身份验证器应用扫码绑定的 otpauth URI
+// 参数：
+//   - issuer: 签发方名称
+//   - accountName: 账户名称，通常为邮箱
+//   - secret: Base32 密钥
+//
+// 返回值：
+//   - string: otpauth://totp/ 格式的 URI
+func TOTPProvisioningURI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", TOTP_DIGITS))
+	query.Set("period", fmt.Sprintf("%d", int(TOTP_PERIOD/time.Second)))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// ValidateTOTP 校验动态码，允许前后 TOTP_SKEW 个时间步的偏差
+// 参数：
+//   - secret: Base32 密钥
+//   - code: 用户输入的动态码
+//   - at: 校验时间
+//
+// 返回值：
+//   - int64: 匹配的时间步，调用方应记录以拒绝同一动态码的重放
+//   - bool: 是否校验通过
+func ValidateTOTP(secret, code string, at time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil || len(code) != TOTP_DIGITS {
+		return 0, false
+	}
+
+	current := at.Unix() / int64(TOTP_PERIOD/time.Second)
+	for offset := int64(-TOTP_SKEW); offset <= TOTP_SKEW; offset++ {
+		step := current + offset
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode 按 RFC 4226 计算指定时间步的动态码
+// 参数：
+//   - key: 密钥
+//   - step: 时间步
+//
+// 返回值：
+//   - string: 定长动态码
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// 动态截断
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTP_DIGITS; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTP_DIGITS, value%mod)
+}
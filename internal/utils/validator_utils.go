@@ -0,0 +1,46 @@
+// Package utils 提供请求参数校验工具
+package utils
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// validate 全局校验器实例
+var validate = validator.New()
+
+// ValidErrRes 参数校验错误信息
+type ValidErrRes struct {
+	Field string      `json:"field"` // 字段名
+	Tag   string      `json:"tag"`   // 未通过的校验规则
+	Param string      `json:"param"` // 校验规则参数
+	Value interface{} `json:"value"` // 字段值
+}
+
+// Validator 按 validate 标签校验结构体
+// 参数：
+//   - data: 待校验的结构体
+//
+// 返回值：
+//   - []ValidErrRes: 校验错误列表，校验通过时为 nil
+func Validator(data interface{}) []ValidErrRes {
+	err := validate.Struct(data)
+	if err == nil {
+		return nil
+	}
+
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []ValidErrRes{{Tag: err.Error()}}
+	}
+
+	res := make([]ValidErrRes, 0, len(errs))
+	for _, e := range errs {
+		res = append(res, ValidErrRes{
+			Field: e.Field(),
+			Tag:   e.Tag(),
+			Param: e.Param(),
+			Value: e.Value(),
+		})
+	}
+	return res
+}
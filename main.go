@@ -1,7 +1,26 @@
 package main
 
-import "lease/cmd"
+import (
+	"lease/cmd"
+	"os"
+)
 
 func main() {
-	cmd.Start()
+	command := ""
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	switch command {
+	case "config":
+		cmd.PrintConfig()
+	case "migrate":
+		cmd.Migrate()
+	case "rollback":
+		cmd.Rollback(os.Args[2:])
+	case "status":
+		cmd.MigrationStatus()
+	default:
+		cmd.Start()
+	}
 }
@@ -0,0 +1,48 @@
+package job
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/configs"
+	"lease/internal/global"
+	"lease/internal/utils"
+	accountService "lease/pkg/serve/service/account"
+)
+
+// accountPurgeJob 定时匿名化全部组织中注销宽限期已届满的账户，使注销不依赖账户再次登录或管理员手动执行
+var accountPurgeJob = Job{
+	Name:     "purgeDeletedAccounts",
+	Interval: accountPurgeInterval,
+	Run:      purgeDeletedAccounts,
+}
+
+// accountPurgeInterval 读取注销账户清理间隔
+// 返回值：
+//   - time.Duration: 执行间隔，配置读取失败或未启用时为 0
+func accountPurgeInterval() time.Duration {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		global.SysLog.Errorf("加载配置失败: %v", err)
+		return 0
+	}
+	return time.Duration(cfg.SecurityConfig.AccountPurgeInterval) * time.Minute
+}
+
+// purgeDeletedAccounts 执行一轮注销账户清理
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func purgeDeletedAccounts(c *gin.Context) error {
+	result, err := accountService.PurgeDeletedAccounts(c)
+	if err != nil {
+		return err
+	}
+	if result.Purged > 0 {
+		utils.BizLogger(c).Infof("已匿名化 %d 个注销宽限期届满的账户", result.Purged)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Package job 提供后台定时任务，在全部组织范围内执行周期性维护
+package job
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"lease/internal/global"
+	"lease/internal/utils"
+)
+
+// 定时任务相关常量
+const (
+	JOB_LOCK_CACHE        = "JOB:LOCK"  // 定时任务执行锁键前缀，完整键为 JOB:LOCK:<任务名>
+	JOB_REQUEST_METHOD    = "JOB"       // 定时任务上下文的请求方法，用于日志区分
+	JOB_DISABLED_RECHECK  = time.Minute // 任务未启用时重新读取配置的间隔，配置热更新后无需重启即可生效
+	JOB_FIRST_RUN_DELAY   = time.Minute // 启动后首次执行前的等待时间，避开启动阶段的负载
+	JOB_LOCK_SAFETY_SLACK = time.Second // 执行锁比执行间隔提前释放的时长，避免与下一轮的加锁相互错过
+)
+
+// Job 定时任务
+type Job struct {
+	Name     string                     // 任务名称，用于执行锁与日志
+	Interval func() time.Duration       // 执行间隔，每轮执行前读取，返回值不大于 0 时本轮不执行
+	Run      func(c *gin.Context) error // 任务逻辑，上下文不携带组织，数据库读写覆盖全部组织
+}
+
+// jobs 随服务启动的定时任务
+var jobs = []Job{accountPurgeJob}
+
+// New 启动全部定时任务，ctx 取消后任务停止调度
+// 参数：
+//   - ctx: 上下文
+func New(ctx context.Context) {
+	for _, job := range jobs {
+		go schedule(ctx, job)
+	}
+}
+
+// schedule 按任务间隔循环调度执行
+// 参数：
+//   - ctx: 上下文
+//   - job: 定时任务
+func schedule(ctx context.Context, job Job) {
+	wait := JOB_FIRST_RUN_DELAY
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		interval := job.Interval()
+		if interval <= 0 {
+			wait = JOB_DISABLED_RECHECK
+			continue
+		}
+		runOnce(ctx, job, interval)
+		wait = interval
+	}
+}
+
+// runOnce 取得执行锁后执行一轮任务；多实例部署时同一间隔内只有一个实例执行
+// 参数：
+//   - ctx: 上下文
+//   - job: 定时任务
+//   - interval: 执行间隔，作为执行锁的有效期
+func runOnce(ctx context.Context, job Job, interval time.Duration) {
+	lockTTL := interval - JOB_LOCK_SAFETY_SLACK
+	if lockTTL <= 0 {
+		lockTTL = interval
+	}
+	acquired, err := global.RedisClient.SetNX(ctx, fmt.Sprintf("%s:%s", JOB_LOCK_CACHE, job.Name), time.Now().Unix(), lockTTL).Result()
+	if err != nil {
+		global.SysLog.Warnf("定时任务「%s」获取执行锁失败，跳过本轮: %v", job.Name, err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			global.SysLog.Errorf("定时任务「%s」执行异常: %v", job.Name, r)
+		}
+	}()
+
+	c, err := newJobContext(ctx, job.Name)
+	if err != nil {
+		global.SysLog.Errorf("定时任务「%s」创建上下文失败: %v", job.Name, err)
+		return
+	}
+	if err := job.Run(c); err != nil {
+		global.SysLog.Errorf("定时任务「%s」执行失败: %v", job.Name, err)
+	}
+}
+
+// newJobContext 创建定时任务使用的 Gin 上下文，不携带组织，业务日志标注任务名称
+// 参数：
+//   - ctx: 上下文
+//   - name: 任务名称
+//
+// 返回值：
+//   - *gin.Context: Gin 上下文
+//   - error: 创建请求失败时返回错误
+func newJobContext(ctx context.Context, name string) (*gin.Context, error) {
+	req, err := http.NewRequestWithContext(ctx, JOB_REQUEST_METHOD, "/job/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	c := &gin.Context{Request: req}
+	c.Set(utils.BIZ_LOG_CONTEXT_KEY, global.SysLog.WithFields(logrus.Fields{"job": name}))
+	return c, nil
+}
@@ -2,7 +2,12 @@
 package router
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
+
+	"lease/internal/storage"
+	routers "lease/pkg/router/routers"
 )
 
 // New @title		Lease API
@@ -27,11 +32,26 @@ func New(app *gin.Engine) {
 	// 注册账户相关的路由
 	routers.RegisterAccountRoutes(api1)
 	// 注册验证相关的路由
-	//routers.RegisterVerificationRoutes(api1)
-	//// 注册文章相关的路由
-	//routers.RegisterPostRoutes(api1)
-	//// 注册类目相关的路由
-	//routers.RegisterCategoryRoutes(api1)
-	//// 注册评论相关的路由
-	//routers.RegisterCommentRoutes(api1)
+	routers.RegisterVerificationRoutes(api1)
+	// 注册房源相关的路由
+	routers.RegisterPropertyRoutes(api1)
+	// 注册租约相关的路由
+	routers.RegisterLeaseRoutes(api1)
+	// 注册账单相关的路由
+	routers.RegisterInvoiceRoutes(api1)
+	// 注册账本相关的路由
+	routers.RegisterLedgerRoutes(api1)
+	// 注册押金相关的路由
+	routers.RegisterDepositRoutes(api1)
+	// 注册报修相关的路由
+	routers.RegisterMaintenanceRoutes(api1)
+	// 注册角色权限相关的路由
+	routers.RegisterRBACRoutes(api1)
+	// 注册组织相关的路由
+	routers.RegisterOrganizationRoutes(api1)
+
+	// 本地磁盘存储的上传文件由应用直接提供访问，访问前缀配置为外部地址时由外部服务提供
+	if local, ok := storage.Default().(*storage.LocalStorage); ok && strings.HasPrefix(local.PublicURL, "/") {
+		app.Static(local.PublicURL, local.Root)
+	}
 }
@@ -5,20 +5,56 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+
 	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
 	"lease/pkg/serve/controller/account"
 )
 
 // RegisterAccountRoutes 注册账户相关路由
 // 参数：
-//   - r: Echo 路由组数组，r[0] 为 API v1 版本组
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
 func RegisterAccountRoutes(r ...*gin.RouterGroup) {
 	// api v1 group
 	apiV1 := r[0]
 	accountGroupV1 := apiV1.Group("/account")
-	accountGroupV1.POST("/getAccount", account.GetAccount, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/getAccount", auth_middleware.AuthMiddleware(), account.GetAccount)
+	accountGroupV1.GET("/me", auth_middleware.AuthMiddleware(), account.GetMe)
+	accountGroupV1.PATCH("/me", auth_middleware.AuthMiddleware(), account.UpdateMe)
+	accountGroupV1.POST("/uploadAvatar", auth_middleware.AuthMiddleware(), account.UploadAvatar)
+	accountGroupV1.POST("/verifyPhone", auth_middleware.AuthMiddleware(), account.VerifyPhone)
+	accountGroupV1.GET("/exportData", auth_middleware.AuthMiddleware(), account.ExportAccountData)
+	accountGroupV1.POST("/requestDeletion", auth_middleware.AuthMiddleware(), account.RequestDeletion)
+	accountGroupV1.POST("/cancelDeletion", auth_middleware.AuthMiddleware(), account.CancelDeletion)
 	accountGroupV1.POST("/registerAccount", account.RegisterAcc)
+	accountGroupV1.POST("/resendActivation", account.ResendActivation)
+	accountGroupV1.POST("/activateAccount", account.ActivateAccount)
 	accountGroupV1.POST("/loginAccount", account.LoginAccount)
-	accountGroupV1.POST("/logoutAccount", account.LogoutAccount, auth_middleware.AuthMiddleware())
-	accountGroupV1.POST("/resetPassword", account.ResetPassword, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/loginByPhone", account.LoginByPhone)
+	accountGroupV1.POST("/sendMagicLink", account.SendMagicLink)
+	accountGroupV1.POST("/magicLogin", account.MagicLogin)
+	accountGroupV1.GET("/oidcProviders", account.ListOIDCProviders)
+	accountGroupV1.POST("/oidcAuthorize", account.OIDCAuthorize)
+	accountGroupV1.POST("/oidcLogin", account.OIDCLogin)
+	accountGroupV1.GET("/identities", auth_middleware.AuthMiddleware(), account.ListIdentities)
+	accountGroupV1.POST("/linkIdentity", auth_middleware.AuthMiddleware(), account.LinkIdentity)
+	accountGroupV1.POST("/confirmLinkIdentity", auth_middleware.AuthMiddleware(), account.ConfirmLinkIdentity)
+	accountGroupV1.POST("/unlinkIdentity", auth_middleware.AuthMiddleware(), account.UnlinkIdentity)
+	accountGroupV1.POST("/logoutAccount", auth_middleware.AuthMiddleware(), account.LogoutAccount)
+	accountGroupV1.POST("/resetPassword", auth_middleware.AuthMiddleware(), account.ResetPassword)
+	accountGroupV1.POST("/forgotPassword", account.ForgotPassword)
+	accountGroupV1.POST("/resetForgottenPassword", account.ResetForgottenPassword)
+	accountGroupV1.POST("/listSessions", auth_middleware.AuthMiddleware(), account.ListSessions)
+	accountGroupV1.POST("/revokeSession", auth_middleware.AuthMiddleware(), account.RevokeSession)
+	accountGroupV1.POST("/enrollMFA", auth_middleware.AuthMiddleware(), account.EnrollMFA)
+	accountGroupV1.POST("/activateMFA", auth_middleware.AuthMiddleware(), account.ActivateMFA)
+	accountGroupV1.POST("/disableMFA", auth_middleware.AuthMiddleware(), account.DisableMFA)
+	accountGroupV1.POST("/regenerateRecoveryCodes", auth_middleware.AuthMiddleware(), account.RegenerateRecoveryCodes)
+	accountGroupV1.POST("/verifyMFA", account.VerifyMFA)
+	accountGroupV1.POST("/enrollRequiredMFA", account.EnrollRequiredMFA)
+	accountGroupV1.POST("/activateRequiredMFA", account.ActivateRequiredMFA)
+	accountGroupV1.POST("/unlockAccount", auth_middleware.AuthMiddleware(), auth_middleware.RequirePermission(rbacModel.PERMISSION_ORG_MANAGE), account.UnlockAccount)
+	accountGroupV1.POST("/suspendAccount", auth_middleware.AuthMiddleware(), auth_middleware.RequirePermission(rbacModel.PERMISSION_ORG_MANAGE), account.SuspendAccount)
+	accountGroupV1.POST("/reactivateAccount", auth_middleware.AuthMiddleware(), auth_middleware.RequirePermission(rbacModel.PERMISSION_ORG_MANAGE), account.ReactivateAccount)
+	accountGroupV1.POST("/purgeDeletedAccounts", auth_middleware.AuthMiddleware(), auth_middleware.RequirePermission(rbacModel.PERMISSION_ORG_MANAGE), account.PurgeDeletedAccounts)
 }
@@ -0,0 +1,27 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/deposit"
+)
+
+// RegisterDepositRoutes 注册押金相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterDepositRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	depositGroupV1 := apiV1.Group("/deposit", auth_middleware.AuthMiddleware())
+	depositGroupV1.POST("/holdDeposit", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), deposit.HoldDeposit)
+	depositGroupV1.POST("/getDeposit", deposit.GetDeposit)
+	depositGroupV1.POST("/createSettlement", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), deposit.CreateSettlement)
+	depositGroupV1.POST("/addDeduction", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), deposit.AddDeduction)
+	depositGroupV1.POST("/removeDeduction", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), deposit.RemoveDeduction)
+	depositGroupV1.POST("/submitSettlement", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), deposit.SubmitSettlement)
+	depositGroupV1.POST("/acknowledgeSettlement", deposit.AcknowledgeSettlement)
+	depositGroupV1.POST("/finalizeSettlement", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), deposit.FinalizeSettlement)
+}
@@ -0,0 +1,27 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/invoice"
+)
+
+// RegisterInvoiceRoutes 注册账单相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterInvoiceRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	invoiceGroupV1 := apiV1.Group("/invoice", auth_middleware.AuthMiddleware())
+	invoiceGroupV1.POST("/generateInvoices", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), invoice.GenerateInvoices)
+	invoiceGroupV1.POST("/getBillingSchedule", invoice.GetBillingSchedule)
+	invoiceGroupV1.POST("/getInvoice", invoice.GetInvoice)
+	invoiceGroupV1.POST("/listInvoices", invoice.ListInvoices)
+	invoiceGroupV1.POST("/createRecurringFee", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), invoice.CreateRecurringFee)
+	invoiceGroupV1.POST("/updateRecurringFee", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), invoice.UpdateRecurringFee)
+	invoiceGroupV1.POST("/deleteRecurringFee", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), invoice.DeleteRecurringFee)
+	invoiceGroupV1.POST("/listRecurringFees", invoice.ListRecurringFees)
+}
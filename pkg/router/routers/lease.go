@@ -0,0 +1,30 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/lease"
+)
+
+// RegisterLeaseRoutes 注册租约相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterLeaseRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	leaseGroupV1 := apiV1.Group("/lease", auth_middleware.AuthMiddleware())
+	leaseGroupV1.POST("/createContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.CreateContract)
+	leaseGroupV1.POST("/getContract", lease.GetContract)
+	leaseGroupV1.POST("/updateContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.UpdateContract)
+	leaseGroupV1.POST("/listContracts", lease.ListContracts)
+	leaseGroupV1.POST("/submitContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.SubmitContract)
+	leaseGroupV1.POST("/withdrawContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.WithdrawContract)
+	leaseGroupV1.POST("/signContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_SIGN), lease.SignContract)
+	leaseGroupV1.POST("/terminateContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.TerminateContract)
+	leaseGroupV1.POST("/expireContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.ExpireContract)
+	leaseGroupV1.POST("/renewContract", auth_middleware.RequirePermission(rbacModel.PERMISSION_LEASE_WRITE), lease.RenewContract)
+	leaseGroupV1.POST("/listContractAudits", lease.ListContractAudits)
+}
@@ -0,0 +1,23 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/ledger"
+)
+
+// RegisterLedgerRoutes 注册账本相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterLedgerRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	ledgerGroupV1 := apiV1.Group("/ledger", auth_middleware.AuthMiddleware())
+	ledgerGroupV1.POST("/recordPayment", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), ledger.RecordPayment)
+	ledgerGroupV1.POST("/refundCredit", auth_middleware.RequirePermission(rbacModel.PERMISSION_BILLING_WRITE), ledger.RefundCredit)
+	ledgerGroupV1.POST("/listPayments", ledger.ListPayments)
+	ledgerGroupV1.POST("/getStatement", ledger.GetStatement)
+}
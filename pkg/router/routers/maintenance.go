@@ -0,0 +1,30 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/maintenance"
+)
+
+// RegisterMaintenanceRoutes 注册报修相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterMaintenanceRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	maintenanceGroupV1 := apiV1.Group("/maintenance", auth_middleware.AuthMiddleware())
+	maintenanceGroupV1.POST("/createTicket", maintenance.CreateTicket)
+	maintenanceGroupV1.POST("/getTicket", maintenance.GetTicket)
+	maintenanceGroupV1.POST("/listTickets", maintenance.ListTickets)
+	maintenanceGroupV1.POST("/assignTicket", auth_middleware.RequirePermission(rbacModel.PERMISSION_MAINTENANCE_MANAGE), maintenance.AssignTicket)
+	maintenanceGroupV1.POST("/scheduleTicket", maintenance.ScheduleTicket)
+	maintenanceGroupV1.POST("/startTicket", maintenance.StartTicket)
+	maintenanceGroupV1.POST("/resolveTicket", maintenance.ResolveTicket)
+	maintenanceGroupV1.POST("/reopenTicket", maintenance.ReopenTicket)
+	maintenanceGroupV1.POST("/closeTicket", auth_middleware.RequirePermission(rbacModel.PERMISSION_MAINTENANCE_MANAGE), maintenance.CloseTicket)
+	maintenanceGroupV1.POST("/cancelTicket", maintenance.CancelTicket)
+	maintenanceGroupV1.POST("/addComment", maintenance.AddComment)
+}
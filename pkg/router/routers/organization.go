@@ -0,0 +1,27 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/organization"
+)
+
+// RegisterOrganizationRoutes 注册组织相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterOrganizationRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	organizationGroupV1 := apiV1.Group("/organization", auth_middleware.AuthMiddleware())
+	organizationGroupV1.POST("/getOrganization", organization.GetOrganization)
+
+	manageGroupV1 := organizationGroupV1.Group("", auth_middleware.RequirePermission(rbacModel.PERMISSION_ORG_MANAGE))
+	manageGroupV1.POST("/updateOrganization", organization.UpdateOrganization)
+	manageGroupV1.POST("/listMembers", organization.ListMembers)
+	manageGroupV1.POST("/createInvitation", organization.CreateInvitation)
+	manageGroupV1.POST("/listInvitations", organization.ListInvitations)
+	manageGroupV1.POST("/revokeInvitation", organization.RevokeInvitation)
+}
@@ -0,0 +1,29 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/property"
+)
+
+// RegisterPropertyRoutes 注册房源相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterPropertyRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	propertyGroupV1 := apiV1.Group("/property", auth_middleware.AuthMiddleware())
+	propertyGroupV1.POST("/createProperty", auth_middleware.RequirePermission(rbacModel.PERMISSION_PROPERTY_WRITE), property.CreateProperty)
+	propertyGroupV1.POST("/getProperty", property.GetProperty)
+	propertyGroupV1.POST("/updateProperty", auth_middleware.RequirePermission(rbacModel.PERMISSION_PROPERTY_WRITE), property.UpdateProperty)
+	propertyGroupV1.POST("/deleteProperty", auth_middleware.RequirePermission(rbacModel.PERMISSION_PROPERTY_WRITE), property.DeleteProperty)
+	propertyGroupV1.POST("/listProperties", property.ListProperties)
+	propertyGroupV1.POST("/createUnit", auth_middleware.RequirePermission(rbacModel.PERMISSION_PROPERTY_WRITE), property.CreateUnit)
+	propertyGroupV1.POST("/getUnit", property.GetUnit)
+	propertyGroupV1.POST("/updateUnit", auth_middleware.RequirePermission(rbacModel.PERMISSION_PROPERTY_WRITE), property.UpdateUnit)
+	propertyGroupV1.POST("/deleteUnit", auth_middleware.RequirePermission(rbacModel.PERMISSION_PROPERTY_WRITE), property.DeleteUnit)
+	propertyGroupV1.POST("/listUnits", property.ListUnits)
+}
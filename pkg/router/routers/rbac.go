@@ -0,0 +1,23 @@
+// Package routes 提供路由注册功能
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	rbacModel "lease/internal/model/rbac"
+	"lease/pkg/serve/controller/rbac"
+)
+
+// RegisterRBACRoutes 注册角色权限相关路由
+// 参数：
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterRBACRoutes(r ...*gin.RouterGroup) {
+	// api v1 group
+	apiV1 := r[0]
+	rbacGroupV1 := apiV1.Group("/rbac", auth_middleware.AuthMiddleware(), auth_middleware.RequirePermission(rbacModel.PERMISSION_RBAC_MANAGE))
+	rbacGroupV1.POST("/listRoles", rbac.ListRoles)
+	rbacGroupV1.POST("/getAccountRoles", rbac.GetAccountRoles)
+	rbacGroupV1.POST("/grantRole", rbac.GrantRole)
+	rbacGroupV1.POST("/revokeRole", rbac.RevokeRole)
+}
@@ -4,15 +4,15 @@
 package routes
 
 import (
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 
-	"jank.com/jank_blog/pkg/serve/controller/test"
+	"lease/pkg/serve/controller/test"
 )
 
 // RegisterTestRoutes 注册测试相关路由
 // 参数：
-//   - r: Echo 路由组数组，r[0] 为 API v1 版本组，r[1] 为 API v2 版本组
-func RegisterTestRoutes(r ...*echo.Group) {
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组，r[1] 为 API v2 版本组
+func RegisterTestRoutes(r ...*gin.RouterGroup) {
 	// api v1 group
 	apiV1 := r[0]
 	testGroupV1 := apiV1.Group("/test")
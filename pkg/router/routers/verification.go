@@ -4,18 +4,19 @@
 package routes
 
 import (
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 
-	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"lease/pkg/serve/controller/verification"
 )
 
 // RegisterVerificationRoutes 注册验证码相关路由
 // 参数：
-//   - r: Echo 路由组数组，r[0] 为 API v1 版本组
-func RegisterVerificationRoutes(r ...*echo.Group) {
+//   - r: Gin 路由组数组，r[0] 为 API v1 版本组
+func RegisterVerificationRoutes(r ...*gin.RouterGroup) {
 	// api v1 group
 	apiV1 := r[0]
 	accountGroupV1 := apiV1.Group("/verification")
 	accountGroupV1.GET("/sendImgVerificationCode", verification.SendImgVerificationCode)
 	accountGroupV1.GET("/sendEmailVerificationCode", verification.SendEmailVerificationCode)
+	accountGroupV1.GET("/sendPhoneVerificationCode", verification.SendPhoneVerificationCode)
 }
@@ -4,9 +4,10 @@
 package account
 
 import (
-	"github.com/gin-gonic/gin"
 	"net/http"
 
+	"github.com/gin-gonic/gin"
+
 	bizErr "lease/internal/error"
 	"lease/internal/utils"
 	"lease/pkg/serve/controller/account/dto"
@@ -27,72 +28,79 @@ import (
 // @Failure      404     {object}   vo.Result              "用户不存在"
 // @Router       /account/getAccount [post]
 // 参数：
-//   - c: Echo 上下文
-//
-// 返回值：
-//   - error: 操作过程中的错误
-func GetAccount(c gin.Context) error {
+//   - c: Gin 上下文
+func GetAccount(c *gin.Context) {
 	req := new(dto.GetAccountRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
 	}
 
 	errors := utils.Validator(*req)
 	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
 	}
 
 	response, err := service.GetAccount(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, response))
+	c.JSON(http.StatusOK, vo.Success(c, response))
 }
 
 // RegisterAcc godoc
 // @Summary      用户注册
-// @Description  注册新用户账号，支持图形验证码和邮箱验证码校验
+// @Description  注册新用户账号，支持图形验证码和邮箱验证码校验；未填写邮箱验证码时账户待激活，需通过激活邮件激活后才能登录
 // @Tags         账户
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.RegisterRequest  true  "注册信息"
 // @Param        ImgVerificationCode  query   string  true  "图形验证码"
-// @Param        EmailVerificationCode  query   string  true  "邮箱验证码"
+// @Param        EmailVerificationCode  query   string  false  "邮箱验证码"
 // @Success      200     {object}   vo.Result{data=dto.RegisterRequest}  "注册成功"
-// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败，密码不符合安全策略"
 // @Failure      500     {object}   vo.Result         "服务器错误"
 // @Router       /account/registerAccount [post]
 // 参数：
-//   - c: Echo 上下文
-//
-// 返回值：
-//   - error: 操作过程中的错误
-func RegisterAcc(c echo.Context) error {
+//   - c: Gin 上下文
+func RegisterAcc(c *gin.Context) {
 	req := new(dto.RegisterRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
 	}
 
 	errors := utils.Validator(*req)
 	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
 	}
 
 	if !verification.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		return
 	}
 
-	if !verification.VerifyEmailCode(c, req.EmailVerificationCode, req.Email) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "邮箱验证码校验失败")))
+	// 未填写邮箱验证码时账户待激活，由激活邮件完成邮箱验证
+	if req.EmailVerificationCode != "" && !verification.VerifyEmailCode(c, req.EmailVerificationCode, req.Email) {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "邮箱验证码校验失败")))
+		return
 	}
 
 	acc, err := service.RegisterAcc(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, acc))
+	c.JSON(http.StatusOK, vo.Success(c, acc))
 }
 
 // LoginAccount godoc
@@ -103,36 +111,44 @@ func RegisterAcc(c echo.Context) error {
 // @Produce      json
 // @Param        request  body      dto.LoginRequest  true  "登录信息"
 // @Param        ImgVerificationCode  query   string  true  "图形验证码"
-// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌；已启用两步验证时返回两步验证凭证"
 // @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
 // @Failure      401     {object}   vo.Result         "登录失败，凭证无效"
+// @Failure      403     {object}   vo.Result         "账户待激活、已停用或已注销"
+// @Failure      423     {object}   vo.Result         "账户已被临时锁定"
+// @Failure      429     {object}   vo.Result         "登录失败次数过多，处于退避期"
 // @Router       /account/loginAccount [post]
 // 参数：
-//   - c: Echo 上下文
-//
-// 返回值：
-//   - error: 操作过程中的错误
-func LoginAccount(c echo.Context) error {
+//   - c: Gin 上下文
+func LoginAccount(c *gin.Context) {
 	req := new(dto.LoginRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
 	}
 
 	errors := utils.Validator(*req)
 	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
 	}
 
 	if !verification.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		return
 	}
 
 	response, err := service.LoginAcc(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, response))
+	c.JSON(http.StatusOK, vo.Success(c, response))
 }
 
 // LogoutAccount godoc
@@ -146,55 +162,184 @@ func LoginAccount(c echo.Context) error {
 // @Security     BearerAuth
 // @Router       /account/logoutAccount [post]
 // 参数：
-//   - c: Echo 上下文
-//
-// 返回值：
-//   - error: 操作过程中的错误
-func LogoutAccount(c echo.Context) error {
+//   - c: Gin 上下文
+func LogoutAccount(c *gin.Context) {
 	if err := service.LogoutAcc(c); err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, "用户注销成功"))
+	c.JSON(http.StatusOK, vo.Success(c, "用户注销成功"))
 }
 
 // ResetPassword godoc
-// @Summary      重置密码
-// @Description  重置用户账户密码，支持邮箱验证码校验
+// @Summary      修改密码
+// @Description  已登录用户校验原密码与邮箱验证码后修改密码，忘记密码请使用 /account/forgotPassword
 // @Tags         账户
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.ResetPwdRequest  true  "重置密码信息"
 // @Success      200     {object}   vo.Result{data=string}  "密码重置成功"
-// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败，密码不符合安全策略"
 // @Failure      401     {object}   vo.Result         "未授权，用户未登录"
-// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Failure      500     {object}   vo.Result         "服务器错误，原密码错误"
 // @Security     BearerAuth
 // @Router       /account/resetPassword [post]
 // 参数：
-//   - c: Echo 上下文
-//
-// 返回值：
-//   - error: 操作过程中的错误
-func ResetPassword(c echo.Context) error {
+//   - c: Gin 上下文
+func ResetPassword(c *gin.Context) {
 	req := new(dto.ResetPwdRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
 	}
 
 	errors := utils.Validator(*req)
 	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
 	}
 
 	if !verification.VerifyEmailCode(c, req.EmailVerificationCode, req.Email) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "邮箱验证码校验失败")))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "邮箱验证码校验失败")))
+		return
 	}
 
 	err := service.ResetPassword(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "密码重置成功"))
+}
+
+// ListSessions godoc
+// @Summary      登录会话列表
+// @Description  列出当前账户在各设备上的有效登录会话，按最近活跃时间倒序
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.SessionVO}  "查询会话成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/listSessions [post]
+// 参数：
+//   - c: Gin 上下文
+func ListSessions(c *gin.Context) {
+	response, err := service.ListSessions(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RevokeSession godoc
+// @Summary      注销会话
+// @Description  注销当前账户的指定登录会话，该设备上的 Access Token 与 Refresh Token 随即失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RevokeSessionRequest  true  "注销会话请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "注销会话成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/revokeSession [post]
+// 参数：
+//   - c: Gin 上下文
+func RevokeSession(c *gin.Context) {
+	req := new(dto.RevokeSessionRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.RevokeSession(c, req); err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "注销会话成功"))
+}
+
+// UnlockAccount godoc
+// @Summary      解锁账户
+// @Description  管理员解除账户因连续登录失败导致的锁定，并清除失败计数
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlockAccountRequest  true  "解锁账户请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "解锁账户成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/unlockAccount [post]
+// 参数：
+//   - c: Gin 上下文
+func UnlockAccount(c *gin.Context) {
+	req := new(dto.UnlockAccountRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, "密码重置成功"))
+	if err := service.UnlockAccount(c, req); err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "解锁账户成功"))
+}
+
+// accountErrStatus 账户相关业务错误对应的 HTTP 状态码
+// 参数：
+//   - err: 业务错误
+//
+// 返回值：
+//   - int: HTTP 状态码
+func accountErrStatus(err *bizErr.Err) int {
+	switch err.Code {
+	case bizErr.PASSWORD_POLICY_VIOLATION, bizErr.AVATAR_INVALID, bizErr.PHONE_INVALID, bizErr.OIDC_PROVIDER_NOT_FOUND:
+		return http.StatusBadRequest
+	case bizErr.PHONE_ALREADY_USED:
+		return http.StatusConflict
+	case bizErr.AVATAR_TOO_LARGE:
+		return http.StatusRequestEntityTooLarge
+	case bizErr.ACCOUNT_LOCKED:
+		return http.StatusLocked
+	case bizErr.ACCOUNT_PENDING_VERIFICATION, bizErr.ACCOUNT_SUSPENDED, bizErr.ACCOUNT_CLOSED, bizErr.MFA_ENROLLMENT_REQUIRED:
+		return http.StatusForbidden
+	case bizErr.ACCOUNT_STATUS_CONFLICT, bizErr.OIDC_IDENTITY_NOT_LINKED, bizErr.OIDC_IDENTITY_CONFLICT:
+		return http.StatusConflict
+	case bizErr.LOGIN_TOO_FREQUENT, bizErr.PASSWORD_RESET_TOO_FREQUENT, bizErr.SMS_TOO_FREQUENT, bizErr.ACCOUNT_ACTIVATION_TOO_FREQUENT:
+		return http.StatusTooManyRequests
+	case bizErr.MFA_TOKEN_INVALID, bizErr.MAGIC_LINK_INVALID, bizErr.PASSWORD_RESET_INVALID, bizErr.PHONE_CODE_INVALID,
+		bizErr.ACCOUNT_ACTIVATION_INVALID, bizErr.PASSWORD_INCORRECT, bizErr.OIDC_STATE_INVALID, bizErr.OIDC_LOGIN_FAILED,
+		bizErr.REAUTHENTICATION_REQUIRED:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
 }
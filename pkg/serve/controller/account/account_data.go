@@ -0,0 +1,129 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// ExportAccountData godoc
+// @Summary      导出个人数据
+// @Description  以 JSON 附件形式导出当前账户的个人资料及参与的合同、收款、报修工单与评论
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.AccountExportVO}  "导出成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/exportData [get]
+// 参数：
+//   - c: Gin 上下文
+func ExportAccountData(c *gin.Context) {
+	response, err := service.ExportAccountData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d-export.json"`, response.Account.ID))
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RequestDeletion godoc
+// @Summary      申请注销账户
+// @Description  以密码、邮箱验证码或短信验证码重新验证身份后申请注销当前账户，刚登录的会话可免验证；宽限期结束后匿名化个人信息，合同与财务记录依法保留；宽限期内可撤销
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RequestDeletionRequest  true  "申请注销请求参数"
+// @Success      200     {object}   vo.Result{data=account.AccountDeletionVO}  "申请成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权或身份验证未通过"
+// @Failure      409     {object}   vo.Result              "账户已申请注销"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/requestDeletion [post]
+// 参数：
+//   - c: Gin 上下文
+func RequestDeletion(c *gin.Context) {
+	req := new(dto.RequestDeletionRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RequestDeletion(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// CancelDeletion godoc
+// @Summary      撤销注销申请
+// @Description  在宽限期内撤销当前账户的注销申请
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=string}  "已撤销注销申请"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      409     {object}   vo.Result              "账户未申请注销"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/cancelDeletion [post]
+// 参数：
+//   - c: Gin 上下文
+func CancelDeletion(c *gin.Context) {
+	if err := service.CancelDeletion(c); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "已撤销注销申请"))
+}
+
+// PurgeDeletedAccounts godoc
+// @Summary      执行到期注销
+// @Description  对本组织注销宽限期已届满的账户执行匿名化，需要组织管理权限；账户在宽限期届满后登录时也会自动执行
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.PurgeDeletedAccountsVO}  "执行成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/purgeDeletedAccounts [post]
+// 参数：
+//   - c: Gin 上下文
+func PurgeDeletedAccounts(c *gin.Context) {
+	response, err := service.PurgeDeletedAccounts(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,183 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/controller/verification"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// ResendActivation godoc
+// @Summary      重新发送激活邮件
+// @Description  待激活账户重新获取激活链接，新链接发出后此前的链接随即失效，支持图形验证码校验
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ResendActivationRequest  true  "重新发送激活邮件请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "如该邮箱对应的账户待激活，激活邮件已发送，请注意查收"
+// @Failure      400     {object}   vo.Result              "参数错误，验证码校验失败"
+// @Failure      429     {object}   vo.Result              "请求过于频繁"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/resendActivation [post]
+// 参数：
+//   - c: Gin 上下文
+func ResendActivation(c *gin.Context) {
+	req := new(dto.ResendActivationRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if !verification.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		return
+	}
+
+	if err := service.ResendActivation(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "如该邮箱对应的账户待激活，激活邮件已发送，请注意查收"))
+}
+
+// ActivateAccount godoc
+// @Summary      激活账户
+// @Description  使用激活邮件中的一次性令牌激活账户，激活后即可登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ActivateAccountRequest  true  "激活账户请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "账户激活成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "激活链接无效、已过期或已使用"
+// @Failure      409     {object}   vo.Result              "账户当前状态无需激活"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/activateAccount [post]
+// 参数：
+//   - c: Gin 上下文
+func ActivateAccount(c *gin.Context) {
+	req := new(dto.ActivateAccountRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.ActivateAccount(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "账户激活成功"))
+}
+
+// SuspendAccount godoc
+// @Summary      停用账户
+// @Description  管理员停用本组织账户并注销其全部会话，停用后无法登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SuspendAccountRequest  true  "停用账户请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "停用账户成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      409     {object}   vo.Result              "账户已停用、已注销或为当前账户"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/suspendAccount [post]
+// 参数：
+//   - c: Gin 上下文
+func SuspendAccount(c *gin.Context) {
+	req := new(dto.SuspendAccountRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.SuspendAccount(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "停用账户成功"))
+}
+
+// ReactivateAccount godoc
+// @Summary      重新启用账户
+// @Description  管理员重新启用已停用的本组织账户
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ReactivateAccountRequest  true  "重新启用账户请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "重新启用账户成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      409     {object}   vo.Result              "账户未处于停用状态"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/reactivateAccount [post]
+// 参数：
+//   - c: Gin 上下文
+func ReactivateAccount(c *gin.Context) {
+	req := new(dto.ReactivateAccountRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.ReactivateAccount(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "重新启用账户成功"))
+}
@@ -0,0 +1,13 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// RequestDeletionRequest  申请注销账户请求体
+// @Description	当前账户申请注销，须重新验证身份：密码、邮箱验证码、已绑定手机号的短信验证码三选一，均未填写时要求当前会话为最近登录
+// @Param			password					body	string	false	"当前密码"
+// @Param			email_verification_code		body	string	false	"账户邮箱收到的验证码"
+// @Param			phone_verification_code		body	string	false	"已绑定手机号收到的短信验证码"
+type RequestDeletionRequest struct {
+	Password              string `json:"password" xml:"password" form:"password" query:"password" validate:"omitempty"`
+	EmailVerificationCode string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"omitempty"`
+	PhoneVerificationCode string `json:"phone_verification_code" xml:"phone_verification_code" form:"phone_verification_code" query:"phone_verification_code" validate:"omitempty"`
+}
@@ -0,0 +1,36 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// ResendActivationRequest  重新发送激活邮件请求体
+// @Description	待激活账户重新获取激活链接
+// @Param			email					body	string	true	"用户邮箱"
+// @Param			img_verification_code	body	string	true	"图片验证码"
+type ResendActivationRequest struct {
+	Email               string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ImgVerificationCode string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+}
+
+// ActivateAccountRequest  激活账户请求体
+// @Description	使用激活邮件中的令牌激活账户
+// @Param			email	body	string	true	"用户邮箱"
+// @Param			token	body	string	true	"激活令牌"
+type ActivateAccountRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	Token string `json:"token" xml:"token" form:"token" query:"token" validate:"required,max=128"`
+}
+
+// SuspendAccountRequest  停用账户请求体
+// @Description	管理员停用本组织账户，停用后注销其全部会话且无法登录
+// @Param			account_id	body	int		true	"账户 ID"
+// @Param			reason		body	string	false	"停用原因"
+type SuspendAccountRequest struct {
+	AccountID int64  `json:"account_id" xml:"account_id" form:"account_id" query:"account_id" validate:"required"`
+	Reason    string `json:"reason" xml:"reason" form:"reason" query:"reason" validate:"omitempty,max=255"`
+}
+
+// ReactivateAccountRequest  重新启用账户请求体
+// @Description	管理员重新启用已停用的本组织账户
+// @Param			account_id	body	int	true	"账户 ID"
+type ReactivateAccountRequest struct {
+	AccountID int64 `json:"account_id" xml:"account_id" form:"account_id" query:"account_id" validate:"required"`
+}
@@ -0,0 +1,24 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// ForgotPasswordRequest  找回密码请求体
+// @Description	未登录用户申请通过邮件重置密码
+// @Param			email					body	string	true	"用户邮箱"
+// @Param			img_verification_code	body	string	true	"图片验证码"
+type ForgotPasswordRequest struct {
+	Email               string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ImgVerificationCode string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+}
+
+// ResetForgottenPasswordRequest  通过邮件链接重置密码请求体
+// @Description	使用重置密码邮件中的令牌设置新密码
+// @Param			email				body	string	true	"用户邮箱"
+// @Param			token				body	string	true	"重置令牌"
+// @Param			new_password		body	string	true	"新密码"
+// @Param			again_new_password	body	string	true	"再次输入新密码"
+type ResetForgottenPasswordRequest struct {
+	Email            string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	Token            string `json:"token" xml:"token" form:"token" query:"token" validate:"required,max=128"`
+	NewPassword      string `json:"new_password" xml:"new_password" form:"new_password" query:"new_password" validate:"required,max=72"`
+	AgainNewPassword string `json:"again_new_password" xml:"again_new_password" form:"again_new_password" query:"again_new_password" validate:"required,max=72"`
+}
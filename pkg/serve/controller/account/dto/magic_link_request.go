@@ -0,0 +1,18 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// SendMagicLinkRequest  发送免密登录链接请求体
+// @Description	向账户邮箱发送一次性免密登录链接
+// @Param			email					body	string	true	"用户邮箱"
+// @Param			img_verification_code	body	string	true	"图片验证码"
+type SendMagicLinkRequest struct {
+	Email               string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ImgVerificationCode string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+}
+
+// MagicLoginRequest  免密登录请求体
+// @Description	使用邮件中免密登录链接携带的令牌登录
+// @Param			token	body	string	true	"免密登录令牌"
+type MagicLoginRequest struct {
+	Token string `json:"token" xml:"token" form:"token" query:"token" validate:"required,max=1024"`
+}
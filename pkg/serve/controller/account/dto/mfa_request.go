@@ -0,0 +1,45 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// MFACodeRequest  两步验证动态码请求体
+// @Description	提交身份验证器应用当前显示的动态码
+// @Param			code	body	string	true	"6 位动态码"
+type MFACodeRequest struct {
+	Code string `json:"code" xml:"code" form:"code" query:"code" validate:"required,len=6,numeric"`
+}
+
+// DisableMFARequest  停用两步验证请求体
+// @Description	停用两步验证，动态码与恢复码二选一
+// @Param			code			body	string	false	"6 位动态码"
+// @Param			recovery_code	body	string	false	"恢复码"
+type DisableMFARequest struct {
+	Code         string `json:"code" xml:"code" form:"code" query:"code" validate:"omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" xml:"recovery_code" form:"recovery_code" query:"recovery_code" validate:"omitempty,max=32"`
+}
+
+// VerifyMFARequest  两步验证登录请求体
+// @Description	使用登录返回的两步验证凭证完成登录，动态码与恢复码二选一
+// @Param			mfa_token		body	string	true	"两步验证凭证"
+// @Param			code			body	string	false	"6 位动态码"
+// @Param			recovery_code	body	string	false	"恢复码"
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfa_token" xml:"mfa_token" form:"mfa_token" query:"mfa_token" validate:"required,max=64"`
+	Code         string `json:"code" xml:"code" form:"code" query:"code" validate:"omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" xml:"recovery_code" form:"recovery_code" query:"recovery_code" validate:"omitempty,max=32"`
+}
+
+// MFATokenRequest  强制绑定凭证请求体
+// @Description	角色要求启用两步验证的账户使用登录返回的强制绑定凭证获取密钥
+// @Param			mfa_token	body	string	true	"强制绑定凭证"
+type MFATokenRequest struct {
+	MFAToken string `json:"mfa_token" xml:"mfa_token" form:"mfa_token" query:"mfa_token" validate:"required,max=64"`
+}
+
+// ActivateRequiredMFARequest  强制绑定启用请求体
+// @Description	使用强制绑定凭证提交动态码启用两步验证并完成登录
+// @Param			mfa_token	body	string	true	"强制绑定凭证"
+// @Param			code		body	string	true	"6 位动态码"
+type ActivateRequiredMFARequest struct {
+	MFAToken string `json:"mfa_token" xml:"mfa_token" form:"mfa_token" query:"mfa_token" validate:"required,max=64"`
+	Code     string `json:"code" xml:"code" form:"code" query:"code" validate:"required,len=6,numeric"`
+}
@@ -0,0 +1,29 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// OIDCAuthorizeRequest  发起第三方登录请求体
+// @Description	生成跳转到身份提供方的授权地址，首次登录且允许自动注册时按邀请码加入组织或创建新组织
+// @Param			provider			body	string	true	"身份提供方标识"
+// @Param			invitation_code		body	string	false	"组织邀请码，仅首次登录自动注册时使用"
+// @Param			organization_name	body	string	false	"新建组织名称，仅首次登录自动注册且未填写邀请码时使用"
+type OIDCAuthorizeRequest struct {
+	Provider         string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,max=32"`
+	InvitationCode   string `json:"invitation_code" xml:"invitation_code" form:"invitation_code" query:"invitation_code" validate:"omitempty,max=64"`
+	OrganizationName string `json:"organization_name" xml:"organization_name" form:"organization_name" query:"organization_name" validate:"omitempty,max=100"`
+}
+
+// OIDCCallbackRequest  第三方授权回调请求体
+// @Description	身份提供方重定向回前端回调地址时携带的参数
+// @Param			state	body	string	true	"发起授权时返回的 state"
+// @Param			code	body	string	true	"身份提供方签发的授权码"
+type OIDCCallbackRequest struct {
+	State string `json:"state" xml:"state" form:"state" query:"state" validate:"required,max=128"`
+	Code  string `json:"code" xml:"code" form:"code" query:"code" validate:"required,max=2048"`
+}
+
+// IdentityProviderRequest  第三方身份操作请求体
+// @Description	关联或解除关联第三方身份时指定身份提供方
+// @Param			provider	body	string	true	"身份提供方标识"
+type IdentityProviderRequest struct {
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,max=32"`
+}
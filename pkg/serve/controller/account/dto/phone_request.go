@@ -0,0 +1,20 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// VerifyPhoneRequest  绑定手机号请求体
+// @Description	提交短信验证码，验证通过后将手机号绑定到当前账户
+// @Param			phone	body	string	true	"手机号，未带国家码时按默认国家码补全"
+// @Param			code	body	string	true	"6 位短信验证码"
+type VerifyPhoneRequest struct {
+	Phone string `json:"phone" xml:"phone" form:"phone" query:"phone" validate:"required,max=32"`
+	Code  string `json:"code" xml:"code" form:"code" query:"code" validate:"required,len=6,numeric"`
+}
+
+// PhoneLoginRequest  手机号登录请求体
+// @Description	使用已验证的手机号与短信验证码登录
+// @Param			phone	body	string	true	"手机号，未带国家码时按默认国家码补全"
+// @Param			code	body	string	true	"6 位短信验证码"
+type PhoneLoginRequest struct {
+	Phone string `json:"phone" xml:"phone" form:"phone" query:"phone" validate:"required,max=32"`
+	Code  string `json:"code" xml:"code" form:"code" query:"code" validate:"required,len=6,numeric"`
+}
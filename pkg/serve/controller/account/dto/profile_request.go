@@ -0,0 +1,11 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// UpdateProfileRequest  修改个人资料请求体
+// @Description	修改当前账户的个人资料，未传入的字段保持不变，手机号传入空字符串表示解绑，更换手机号后须重新通过短信验证
+// @Param			nickname	body	string	false	"用户昵称"
+// @Param			phone		body	string	false	"用户手机号"
+type UpdateProfileRequest struct {
+	Nickname *string `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"omitempty,min=1,max=64"`
+	Phone    *string `json:"phone" xml:"phone" form:"phone" query:"phone" validate:"omitempty,max=32"`
+}
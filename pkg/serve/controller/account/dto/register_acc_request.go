@@ -8,14 +8,18 @@ package dto
 // @Param			email		body	string	true	"用户邮箱"
 // @Param			phone		body	string	true	"用户手机号"
 // @Param			nickname	body	string	true	"用户昵称"
-// @Param			password	body	string	true	"用户密码"
-// @Param			email_verification_code	body	string	true	"用户邮箱验证码"
+// @Param			password	body	string	true	"用户密码，须满足 security 配置的密码策略"
+// @Param			email_verification_code	body	string	false	"用户邮箱验证码，填写并校验通过后账户直接生效，未填写时账户待激活并发送激活邮件"
 // @Param			img_verification_code	body	string	true	"用户图片验证码"
+// @Param			invitation_code			body	string	false	"组织邀请码，填写后加入邀请方组织，否则创建新组织"
+// @Param			organization_name		body	string	false	"新建组织名称，未填写时以昵称命名"
 type RegisterRequest struct {
 	Email                 string `json:"email" xml:"email" form:"email" query:"email" validate:"required"`
 	Phone                 string `json:"phone" xml:"phone" form:"phone" query:"phone" default:""`
 	Nickname              string `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"required,min=1,max=20"`
-	Password              string `json:"password" xml:"password" form:"password" query:"password" validate:"required,min=6,max=20"`
-	EmailVerificationCode string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required"`
+	Password              string `json:"password" xml:"password" form:"password" query:"password" validate:"required,max=72"`
+	EmailVerificationCode string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"omitempty"`
 	ImgVerificationCode   string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+	InvitationCode        string `json:"invitation_code" xml:"invitation_code" form:"invitation_code" query:"invitation_code" validate:"omitempty,max=64"`
+	OrganizationName      string `json:"organization_name" xml:"organization_name" form:"organization_name" query:"organization_name" validate:"omitempty,max=100"`
 }
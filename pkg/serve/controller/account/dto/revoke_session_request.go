@@ -0,0 +1,9 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// RevokeSessionRequest  注销会话请求体
+// @Description	注销当前账户在指定设备上的登录会话
+// @Param			session_id	body	string	true	"会话 ID"
+type RevokeSessionRequest struct {
+	SessionID string `json:"session_id" xml:"session_id" form:"session_id" query:"session_id" validate:"required,max=64"`
+}
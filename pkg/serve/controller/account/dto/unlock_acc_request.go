@@ -0,0 +1,9 @@
+// Package dto 提供账户相关的数据传输对象定义
+package dto
+
+// UnlockAccountRequest  解锁账户请求体
+// @Description	管理员解除账户因连续登录失败导致的锁定
+// @Param			account_id	body	int	true	"账户 ID"
+type UnlockAccountRequest struct {
+	AccountID int64 `json:"account_id" xml:"account_id" form:"account_id" query:"account_id" validate:"required"`
+}
@@ -0,0 +1,101 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/controller/verification"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// SendMagicLink godoc
+// @Summary      发送免密登录链接
+// @Description  向账户邮箱发送一次性免密登录链接，新链接发出后此前未使用的链接失效，支持图形验证码校验
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SendMagicLinkRequest  true  "发送免密登录链接请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "登录链接已发送"
+// @Failure      400     {object}   vo.Result              "参数错误，验证码校验失败"
+// @Failure      429     {object}   vo.Result              "发送过于频繁"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/sendMagicLink [post]
+// 参数：
+//   - c: Gin 上下文
+func SendMagicLink(c *gin.Context) {
+	req := new(dto.SendMagicLinkRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if !verification.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		return
+	}
+
+	if err := service.SendMagicLink(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "如该邮箱已注册，登录链接已发送，请注意查收"))
+}
+
+// MagicLogin godoc
+// @Summary      免密登录
+// @Description  使用邮件中免密登录链接携带的令牌换取访问令牌，每个链接只能使用一次
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.MagicLoginRequest  true  "免密登录请求参数"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌；已启用两步验证时返回两步验证凭证"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "登录链接无效、已过期或已使用"
+// @Failure      403     {object}   vo.Result              "账户待激活、已停用或已注销"
+// @Failure      423     {object}   vo.Result              "账户已被临时锁定"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/magicLogin [post]
+// 参数：
+//   - c: Gin 上下文
+func MagicLogin(c *gin.Context) {
+	req := new(dto.MagicLoginRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.MagicLogin(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
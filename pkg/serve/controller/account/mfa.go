@@ -0,0 +1,278 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// EnrollMFA godoc
+// @Summary      获取两步验证密钥
+// @Description  为当前账户生成 TOTP 密钥与 otpauth 绑定 URI，提交动态码启用前不会生效
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.MFAEnrollVO}  "获取密钥成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/enrollMFA [post]
+// 参数：
+//   - c: Gin 上下文
+func EnrollMFA(c *gin.Context) {
+	response, err := service.EnrollMFA(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ActivateMFA godoc
+// @Summary      启用两步验证
+// @Description  提交身份验证器应用显示的动态码确认绑定，启用后返回一次性恢复码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.MFACodeRequest  true  "动态码"
+// @Success      200     {object}   vo.Result{data=account.MFARecoveryCodesVO}  "启用成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/activateMFA [post]
+// 参数：
+//   - c: Gin 上下文
+func ActivateMFA(c *gin.Context) {
+	req := new(dto.MFACodeRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ActivateMFA(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// DisableMFA godoc
+// @Summary      停用两步验证
+// @Description  校验动态码或恢复码后停用两步验证，原有恢复码全部作废
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DisableMFARequest  true  "停用两步验证请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "停用成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "账户角色要求启用两步验证"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/disableMFA [post]
+// 参数：
+//   - c: Gin 上下文
+func DisableMFA(c *gin.Context) {
+	req := new(dto.DisableMFARequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.DisableMFA(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "两步验证已停用"))
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      重新生成恢复码
+// @Description  校验动态码后重新生成一组恢复码，原有恢复码全部作废
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.MFACodeRequest  true  "动态码"
+// @Success      200     {object}   vo.Result{data=account.MFARecoveryCodesVO}  "生成成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/regenerateRecoveryCodes [post]
+// 参数：
+//   - c: Gin 上下文
+func RegenerateRecoveryCodes(c *gin.Context) {
+	req := new(dto.MFACodeRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RegenerateRecoveryCodes(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// VerifyMFA godoc
+// @Summary      两步验证登录
+// @Description  使用登录返回的两步验证凭证提交动态码或恢复码，校验通过后签发访问令牌
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.VerifyMFARequest  true  "两步验证登录请求参数"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "两步验证凭证无效或已过期"
+// @Failure      403     {object}   vo.Result              "账户待激活、已停用或已注销"
+// @Failure      423     {object}   vo.Result              "账户已被临时锁定"
+// @Failure      429     {object}   vo.Result              "登录失败次数过多，处于退避期"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/verifyMFA [post]
+// 参数：
+//   - c: Gin 上下文
+func VerifyMFA(c *gin.Context) {
+	req := new(dto.VerifyMFARequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.VerifyMFA(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// EnrollRequiredMFA godoc
+// @Summary      获取强制绑定的两步验证密钥
+// @Description  角色要求启用两步验证的账户使用登录返回的强制绑定凭证获取 TOTP 密钥与 otpauth 绑定 URI
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.MFATokenRequest  true  "强制绑定凭证"
+// @Success      200     {object}   vo.Result{data=account.MFAEnrollVO}  "获取密钥成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "强制绑定凭证无效或已过期"
+// @Failure      403     {object}   vo.Result              "账户待激活、已停用或已注销"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/enrollRequiredMFA [post]
+// 参数：
+//   - c: Gin 上下文
+func EnrollRequiredMFA(c *gin.Context) {
+	req := new(dto.MFATokenRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.EnrollRequiredMFA(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ActivateRequiredMFA godoc
+// @Summary      完成强制绑定的两步验证
+// @Description  使用强制绑定凭证提交动态码启用两步验证，启用后签发访问令牌并返回一次性恢复码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ActivateRequiredMFARequest  true  "强制绑定启用请求参数"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "启用成功，返回访问令牌与恢复码"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "强制绑定凭证无效或已过期"
+// @Failure      403     {object}   vo.Result              "账户待激活、已停用或已注销"
+// @Failure      423     {object}   vo.Result              "账户已被临时锁定"
+// @Failure      429     {object}   vo.Result              "登录失败次数过多，处于退避期"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/activateRequiredMFA [post]
+// 参数：
+//   - c: Gin 上下文
+func ActivateRequiredMFA(c *gin.Context) {
+	req := new(dto.ActivateRequiredMFARequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ActivateRequiredMFA(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
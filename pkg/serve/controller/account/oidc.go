@@ -0,0 +1,257 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// ListOIDCProviders godoc
+// @Summary      获取第三方登录方式
+// @Description  获取已配置的 OpenID Connect 身份提供方
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.OIDCProviderVO}  "获取成功"
+// @Router       /account/oidcProviders [get]
+// 参数：
+//   - c: Gin 上下文
+func ListOIDCProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, vo.Success(c, service.ListOIDCProviders()))
+}
+
+// OIDCAuthorize godoc
+// @Summary      发起第三方登录
+// @Description  生成跳转到身份提供方的授权地址（授权码模式 + PKCE），身份提供方认证后携带 code 与 state 重定向回配置的回调地址
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.OIDCAuthorizeRequest  true  "发起第三方登录请求参数"
+// @Success      200     {object}   vo.Result{data=account.OIDCAuthorizeVO}  "生成成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误或身份提供方不存在"
+// @Failure      401     {object}   vo.Result              "身份提供方暂时不可用"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/oidcAuthorize [post]
+// 参数：
+//   - c: Gin 上下文
+func OIDCAuthorize(c *gin.Context) {
+	req := new(dto.OIDCAuthorizeRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.OIDCAuthorize(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// OIDCLogin godoc
+// @Summary      第三方登录
+// @Description  提交身份提供方回调携带的 code 与 state 完成登录；未关联的身份在允许自动注册且邮箱已验证时创建新账户，邮箱已注册时须先登录原账户后关联
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.OIDCCallbackRequest  true  "第三方授权回调请求参数"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，已启用两步验证时返回两步验证凭证"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "登录请求无效或第三方认证失败"
+// @Failure      403     {object}   vo.Result              "账户未激活、已停用或已注销"
+// @Failure      409     {object}   vo.Result              "第三方身份尚未关联账户"
+// @Failure      423     {object}   vo.Result              "账户已被临时锁定"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/oidcLogin [post]
+// 参数：
+//   - c: Gin 上下文
+func OIDCLogin(c *gin.Context) {
+	req := new(dto.OIDCCallbackRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.OIDCLogin(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListIdentities godoc
+// @Summary      获取已关联的第三方身份
+// @Description  获取当前账户已关联的第三方身份
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.AccountIdentityVO}  "获取成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/identities [get]
+// 参数：
+//   - c: Gin 上下文
+func ListIdentities(c *gin.Context) {
+	response, err := service.ListIdentities(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// LinkIdentity godoc
+// @Summary      关联第三方身份
+// @Description  为当前账户生成关联第三方身份的授权地址，身份提供方回调后调用确认关联接口完成关联
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.IdentityProviderRequest  true  "第三方身份操作请求参数"
+// @Success      200     {object}   vo.Result{data=account.OIDCAuthorizeVO}  "生成成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误或身份提供方不存在"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      409     {object}   vo.Result              "已关联该身份提供方"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/linkIdentity [post]
+// 参数：
+//   - c: Gin 上下文
+func LinkIdentity(c *gin.Context) {
+	req := new(dto.IdentityProviderRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.LinkIdentity(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ConfirmLinkIdentity godoc
+// @Summary      确认关联第三方身份
+// @Description  提交身份提供方回调携带的 code 与 state，将第三方身份关联到当前账户
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.OIDCCallbackRequest  true  "第三方授权回调请求参数"
+// @Success      200     {object}   vo.Result{data=account.AccountIdentityVO}  "关联成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权、关联请求无效或第三方认证失败"
+// @Failure      409     {object}   vo.Result              "第三方身份已关联其他账户"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/confirmLinkIdentity [post]
+// 参数：
+//   - c: Gin 上下文
+func ConfirmLinkIdentity(c *gin.Context) {
+	req := new(dto.OIDCCallbackRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ConfirmLinkIdentity(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UnlinkIdentity godoc
+// @Summary      解除第三方身份关联
+// @Description  解除当前账户与指定身份提供方的关联；未设置密码的账户不能解除最后一个第三方身份
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.IdentityProviderRequest  true  "第三方身份操作请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "已解除关联"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      409     {object}   vo.Result              "未关联该身份提供方或解除后无法登录"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/unlinkIdentity [post]
+// 参数：
+//   - c: Gin 上下文
+func UnlinkIdentity(c *gin.Context) {
+	req := new(dto.IdentityProviderRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.UnlinkIdentity(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "已解除关联"))
+}
@@ -0,0 +1,98 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/controller/verification"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// ForgotPassword godoc
+// @Summary      找回密码
+// @Description  未登录用户申请找回密码，向账户邮箱发送一次性重置链接，按邮箱与 IP 限制请求频率，支持图形验证码校验
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ForgotPasswordRequest  true  "找回密码请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "重置链接已发送"
+// @Failure      400     {object}   vo.Result              "参数错误，验证码校验失败"
+// @Failure      429     {object}   vo.Result              "请求过于频繁"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/forgotPassword [post]
+// 参数：
+//   - c: Gin 上下文
+func ForgotPassword(c *gin.Context) {
+	req := new(dto.ForgotPasswordRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if !verification.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		return
+	}
+
+	if err := service.ForgotPassword(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "如该邮箱已注册，重置密码链接已发送，请注意查收"))
+}
+
+// ResetForgottenPassword godoc
+// @Summary      通过邮件链接重置密码
+// @Description  使用重置密码邮件中的一次性令牌设置新密码，成功后账户在所有设备上的会话均被注销
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ResetForgottenPasswordRequest  true  "重置密码请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "密码重置成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误，密码不符合安全策略"
+// @Failure      401     {object}   vo.Result              "重置链接无效、已过期或已使用"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/resetForgottenPassword [post]
+// 参数：
+//   - c: Gin 上下文
+func ResetForgottenPassword(c *gin.Context) {
+	req := new(dto.ResetForgottenPasswordRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.ResetForgottenPassword(c, req); err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "密码重置成功，请重新登录"))
+}
@@ -0,0 +1,99 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// VerifyPhone godoc
+// @Summary      绑定手机号
+// @Description  提交短信验证码，验证通过后将手机号绑定到当前账户，已验证的手机号可用于短信登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.VerifyPhoneRequest  true  "手机号与短信验证码"
+// @Success      200     {object}   vo.Result{data=account.ProfileVO}  "绑定成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误或手机号格式无效"
+// @Failure      401     {object}   vo.Result              "未授权或短信验证码错误"
+// @Failure      409     {object}   vo.Result              "手机号已被其他账户使用"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/verifyPhone [post]
+// 参数：
+//   - c: Gin 上下文
+func VerifyPhone(c *gin.Context) {
+	req := new(dto.VerifyPhoneRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.VerifyPhone(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// LoginByPhone godoc
+// @Summary      手机号登录
+// @Description  使用已验证的手机号与短信验证码登录，连续失败会触发退避与临时锁定；已启用两步验证的账户返回两步验证凭证
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.PhoneLoginRequest  true  "手机号与短信验证码"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功或需要两步验证"
+// @Failure      400     {object}   vo.Result              "请求参数错误或手机号格式无效"
+// @Failure      401     {object}   vo.Result              "手机号或短信验证码错误"
+// @Failure      403     {object}   vo.Result              "账户待激活、已停用或已注销"
+// @Failure      423     {object}   vo.Result              "账户已被临时锁定"
+// @Failure      429     {object}   vo.Result              "登录尝试过于频繁"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/loginByPhone [post]
+// 参数：
+//   - c: Gin 上下文
+func LoginByPhone(c *gin.Context) {
+	req := new(dto.PhoneLoginRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.LoginByPhone(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,117 @@
+// Package account 提供账户相关的HTTP接口处理
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	service "lease/pkg/serve/service/account"
+	"lease/pkg/vo"
+)
+
+// AVATAR_FORM_FIELD 头像上传的表单字段名
+const AVATAR_FORM_FIELD = "avatar"
+
+// GetMe godoc
+// @Summary      获取个人资料
+// @Description  获取当前登录账户的个人资料
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.ProfileVO}  "获取成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/me [get]
+// 参数：
+//   - c: Gin 上下文
+func GetMe(c *gin.Context) {
+	response, err := service.GetMe(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UpdateMe godoc
+// @Summary      修改个人资料
+// @Description  修改当前登录账户的昵称与手机号，未传入的字段保持不变
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateProfileRequest  true  "个人资料"
+// @Success      200     {object}   vo.Result{data=account.ProfileVO}  "修改成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      409     {object}   vo.Result              "手机号已被其他账户使用"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/me [patch]
+// 参数：
+//   - c: Gin 上下文
+func UpdateMe(c *gin.Context) {
+	req := new(dto.UpdateProfileRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.UpdateMe(c, req)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UploadAvatar godoc
+// @Summary      上传头像
+// @Description  上传当前登录账户的头像，支持 JPEG、PNG、GIF、WebP 格式，不超过 2 MB，按文件内容识别格式并缩放至 512 像素以内
+// @Tags         账户
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        avatar  formData  file  true  "头像文件"
+// @Success      200     {object}   vo.Result{data=account.ProfileVO}  "上传成功"
+// @Failure      400     {object}   vo.Result              "未选择文件或格式不支持"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      413     {object}   vo.Result              "文件过大"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/uploadAvatar [post]
+// 参数：
+//   - c: Gin 上下文
+func UploadAvatar(c *gin.Context) {
+	file, err := c.FormFile(AVATAR_FORM_FIELD)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, "请选择头像文件")))
+		return
+	}
+
+	response, err := service.UploadAvatar(c, file)
+	if err != nil {
+		if accountErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(accountErrStatus(accountErr), vo.Fail(c, nil, accountErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
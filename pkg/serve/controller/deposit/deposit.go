@@ -0,0 +1,88 @@
+// Package deposit 提供押金收取与结算相关的HTTP接口处理
+package deposit
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/deposit/dto"
+	service "lease/pkg/serve/service/deposit"
+	"lease/pkg/vo"
+)
+
+// HoldDeposit godoc
+// @Summary      收取押金
+// @Description  业主登记租客缴纳的押金，累计代管押金不得超过合同约定押金
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.HoldDepositRequest  true  "收取押金请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositVO}  "收取押金成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/holdDeposit [post]
+// 参数：
+//   - c: Gin 上下文
+func HoldDeposit(c *gin.Context) {
+	req := new(dto.HoldDepositRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.HoldDeposit(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetDeposit godoc
+// @Summary      查询押金
+// @Description  查询合同约定押金、当前代管押金及押金结算单
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ContractDepositRequest  true  "查询押金请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositVO}  "查询押金成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/getDeposit [post]
+// 参数：
+//   - c: Gin 上下文
+func GetDeposit(c *gin.Context) {
+	req := new(dto.ContractDepositRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetDeposit(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
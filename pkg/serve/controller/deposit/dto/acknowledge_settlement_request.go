@@ -0,0 +1,13 @@
+// Package dto 提供押金相关的数据传输对象定义
+package dto
+
+// AcknowledgeSettlementRequest  租客确认押金结算请求体
+// @Description	租客确认扣款明细，或提出异议退回业主修改
+// @Param			id		body	int		true	"结算单 ID"
+// @Param			accept	body	bool	true	"是否同意扣款明细"
+// @Param			comment	body	string	false	"确认或异议意见，提出异议时必填"
+type AcknowledgeSettlementRequest struct {
+	ID      int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Accept  *bool  `json:"accept" xml:"accept" form:"accept" query:"accept" validate:"required"`
+	Comment string `json:"comment" xml:"comment" form:"comment" query:"comment" validate:"max=255"`
+}
@@ -0,0 +1,17 @@
+// Package dto 提供押金相关的数据传输对象定义
+package dto
+
+// AddDeductionRequest  添加押金扣款请求体
+// @Description	业主在结算单中逐项列明扣款原因、金额与证据附件；金额单位为分
+// @Param			settlement_id	body	int			true	"结算单 ID"
+// @Param			category		body	string		true	"扣款类别: damage, unpaid_rent, cleaning, other"
+// @Param			reason			body	string		true	"扣款原因"
+// @Param			amount			body	int			true	"扣款金额"
+// @Param			evidence_ids	body	[]string	false	"证据附件 ID 列表"
+type AddDeductionRequest struct {
+	SettlementID int64    `json:"settlement_id" xml:"settlement_id" form:"settlement_id" query:"settlement_id" validate:"required"`
+	Category     string   `json:"category" xml:"category" form:"category" query:"category" validate:"required,oneof=damage unpaid_rent cleaning other"`
+	Reason       string   `json:"reason" xml:"reason" form:"reason" query:"reason" validate:"required,max=255"`
+	Amount       int64    `json:"amount" xml:"amount" form:"amount" query:"amount" validate:"required,gt=0"`
+	EvidenceIDs  []string `json:"evidence_ids" xml:"evidence_ids" form:"evidence_ids" query:"evidence_ids" validate:"max=20,dive,required,max=64"`
+}
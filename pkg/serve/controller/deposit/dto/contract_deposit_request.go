@@ -0,0 +1,9 @@
+// Package dto 提供押金相关的数据传输对象定义
+package dto
+
+// ContractDepositRequest  合同押金请求体
+// @Description	根据合同 ID 查询押金或发起押金结算
+// @Param			contract_id	body	int	true	"合同 ID"
+type ContractDepositRequest struct {
+	ContractID int64 `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id" validate:"required"`
+}
@@ -0,0 +1,9 @@
+// Package dto 提供押金相关的数据传输对象定义
+package dto
+
+// DeductionIDRequest  押金扣款 ID 请求体
+// @Description	根据扣款明细 ID 操作扣款
+// @Param			id	body	int	true	"扣款明细 ID"
+type DeductionIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
@@ -0,0 +1,19 @@
+// Package dto 提供押金相关的数据传输对象定义
+package dto
+
+// HoldDepositRequest  收取押金请求体
+// @Description	业主登记收到的押金并计入代管押金，累计不超过合同约定押金；金额单位为分
+// @Param			contract_id	body	int		true	"合同 ID"
+// @Param			amount		body	int		true	"收取金额"
+// @Param			method		body	string	true	"收款方式: cash, bank_transfer, alipay, wechat, other"
+// @Param			paid_date	body	string	true	"收款日期，格式 2006-01-02"
+// @Param			reference	body	string	false	"外部流水号"
+// @Param			remark		body	string	false	"备注"
+type HoldDepositRequest struct {
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id" validate:"required"`
+	Amount     int64  `json:"amount" xml:"amount" form:"amount" query:"amount" validate:"required,gt=0"`
+	Method     string `json:"method" xml:"method" form:"method" query:"method" validate:"required,oneof=cash bank_transfer alipay wechat other"`
+	PaidDate   string `json:"paid_date" xml:"paid_date" form:"paid_date" query:"paid_date" validate:"required,datetime=2006-01-02"`
+	Reference  string `json:"reference" xml:"reference" form:"reference" query:"reference" validate:"max=64"`
+	Remark     string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
@@ -0,0 +1,9 @@
+// Package dto 提供押金相关的数据传输对象定义
+package dto
+
+// SettlementIDRequest  押金结算单 ID 请求体
+// @Description	根据结算单 ID 操作押金结算单
+// @Param			id	body	int	true	"结算单 ID"
+type SettlementIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
@@ -0,0 +1,236 @@
+// Package deposit 提供押金收取与结算相关的HTTP接口处理
+package deposit
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/deposit/dto"
+	service "lease/pkg/serve/service/deposit"
+	"lease/pkg/vo"
+)
+
+// CreateSettlement godoc
+// @Summary      发起押金结算
+// @Description  业主在合同解约或到期后发起押金结算单
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ContractDepositRequest  true  "发起押金结算请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositSettlementVO}  "发起押金结算成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/createSettlement [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateSettlement(c *gin.Context) {
+	req := new(dto.ContractDepositRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateSettlement(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// AddDeduction godoc
+// @Summary      添加押金扣款
+// @Description  业主在结算单中添加扣款明细，注明原因并附证据附件 ID
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AddDeductionRequest  true  "添加押金扣款请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositSettlementVO}  "添加押金扣款成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/addDeduction [post]
+// 参数：
+//   - c: Gin 上下文
+func AddDeduction(c *gin.Context) {
+	req := new(dto.AddDeductionRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.AddDeduction(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RemoveDeduction godoc
+// @Summary      删除押金扣款
+// @Description  业主删除草稿或异议状态结算单中的扣款明细
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DeductionIDRequest  true  "删除押金扣款请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositSettlementVO}  "删除押金扣款成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/removeDeduction [post]
+// 参数：
+//   - c: Gin 上下文
+func RemoveDeduction(c *gin.Context) {
+	req := new(dto.DeductionIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RemoveDeduction(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// SubmitSettlement godoc
+// @Summary      提交押金结算
+// @Description  业主提交结算单等待租客确认
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SettlementIDRequest  true  "提交押金结算请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositSettlementVO}  "提交押金结算成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/submitSettlement [post]
+// 参数：
+//   - c: Gin 上下文
+func SubmitSettlement(c *gin.Context) {
+	req := new(dto.SettlementIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.SubmitSettlement(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// AcknowledgeSettlement godoc
+// @Summary      租客确认押金结算
+// @Description  租客确认扣款明细，或填写意见提出异议
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AcknowledgeSettlementRequest  true  "租客确认押金结算请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositSettlementVO}  "确认押金结算成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/acknowledgeSettlement [post]
+// 参数：
+//   - c: Gin 上下文
+func AcknowledgeSettlement(c *gin.Context) {
+	req := new(dto.AcknowledgeSettlementRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.AcknowledgeSettlement(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// FinalizeSettlement godoc
+// @Summary      完成押金结算
+// @Description  业主在租客确认后完成结算，扣款与退款记入账本
+// @Tags         押金
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SettlementIDRequest  true  "完成押金结算请求参数"
+// @Success      200     {object}   vo.Result{data=deposit.DepositSettlementVO}  "完成押金结算成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /deposit/finalizeSettlement [post]
+// 参数：
+//   - c: Gin 上下文
+func FinalizeSettlement(c *gin.Context) {
+	req := new(dto.SettlementIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.FinalizeSettlement(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
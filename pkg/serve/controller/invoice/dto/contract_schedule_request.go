@@ -0,0 +1,9 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// ContractScheduleRequest  合同账期请求体
+// @Description	根据合同 ID 查询账期表或周期性杂费
+// @Param			contract_id	body	int	true	"合同 ID"
+type ContractScheduleRequest struct {
+	ContractID int64 `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id" validate:"required"`
+}
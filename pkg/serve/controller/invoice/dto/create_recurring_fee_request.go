@@ -0,0 +1,15 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// CreateRecurringFeeRequest  创建周期性杂费请求体
+// @Description	为合同添加按月计价的周期性杂费，金额单位为分
+// @Param			contract_id		body	int		true	"合同 ID"
+// @Param			category		body	string	true	"杂费类别: management, parking, other"
+// @Param			name			body	string	true	"杂费名称"
+// @Param			monthly_amount	body	int		true	"月费用"
+type CreateRecurringFeeRequest struct {
+	ContractID    int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id" validate:"required"`
+	Category      string `json:"category" xml:"category" form:"category" query:"category" validate:"required,oneof=management parking other"`
+	Name          string `json:"name" xml:"name" form:"name" query:"name" validate:"required,max=64"`
+	MonthlyAmount int64  `json:"monthly_amount" xml:"monthly_amount" form:"monthly_amount" query:"monthly_amount" validate:"required,gt=0"`
+}
@@ -0,0 +1,11 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// GenerateInvoiceRequest  生成账单请求体
+// @Description	为业主名下生效中的合同生成截至指定日期已开始的各期账单，重复执行不会重复出账
+// @Param			contract_id	body	int		false	"合同 ID，为空时处理全部生效中的合同"
+// @Param			as_of		body	string	false	"出账截止日期，格式 2006-01-02，默认当天"
+type GenerateInvoiceRequest struct {
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id"`
+	AsOf       string `json:"as_of" xml:"as_of" form:"as_of" query:"as_of" validate:"omitempty,datetime=2006-01-02"`
+}
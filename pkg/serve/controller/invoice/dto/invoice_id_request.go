@@ -0,0 +1,9 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// InvoiceIDRequest  账单 ID 请求体
+// @Description	根据账单 ID 操作账单
+// @Param			id	body	int	true	"账单 ID"
+type InvoiceIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
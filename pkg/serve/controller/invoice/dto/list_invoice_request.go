@@ -0,0 +1,17 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// ListInvoiceRequest  分页查询账单请求体
+// @Description	按身份分页查询当前账户相关的账单
+// @Param			role		body	string	true	"查询身份: landlord, tenant"
+// @Param			contract_id	body	int		false	"合同 ID"
+// @Param			status		body	string	false	"账单状态"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListInvoiceRequest struct {
+	Role       string `json:"role" xml:"role" form:"role" query:"role" validate:"required,oneof=landlord tenant"`
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id"`
+	Status     string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=unpaid partially_paid paid void"`
+	PageNo     int    `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize   int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,9 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// RecurringFeeIDRequest  周期性杂费 ID 请求体
+// @Description	根据杂费 ID 操作周期性杂费
+// @Param			id	body	int	true	"杂费 ID"
+type RecurringFeeIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
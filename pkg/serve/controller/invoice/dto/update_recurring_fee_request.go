@@ -0,0 +1,13 @@
+// Package dto 提供租金账单相关的数据传输对象定义
+package dto
+
+// UpdateRecurringFeeRequest  更新周期性杂费请求体
+// @Description	更新周期性杂费，仅影响此后生成的账单
+// @Param			id				body	int		true	"杂费 ID"
+// @Param			name			body	string	false	"杂费名称"
+// @Param			monthly_amount	body	int		false	"月费用"
+type UpdateRecurringFeeRequest struct {
+	ID            int64   `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Name          *string `json:"name" xml:"name" form:"name" query:"name" validate:"omitempty,max=64"`
+	MonthlyAmount *int64  `json:"monthly_amount" xml:"monthly_amount" form:"monthly_amount" query:"monthly_amount" validate:"omitempty,gt=0"`
+}
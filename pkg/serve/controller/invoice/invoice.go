@@ -0,0 +1,162 @@
+// Package invoice 提供租金账单相关的HTTP接口处理
+package invoice
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/invoice/dto"
+	service "lease/pkg/serve/service/invoice"
+	"lease/pkg/vo"
+)
+
+// GenerateInvoices godoc
+// @Summary      生成账单
+// @Description  为业主名下生效中与已续约的合同生成截至出账日期已开始、且不晚于合同到期日的各期账单，同一合同同一账期只出一次账
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.GenerateInvoiceRequest  true  "生成账单请求参数"
+// @Success      200     {object}   vo.Result{data=invoice.GenerateInvoiceVO}  "生成账单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/generateInvoices [post]
+// 参数：
+//   - c: Gin 上下文
+func GenerateInvoices(c *gin.Context) {
+	req := new(dto.GenerateInvoiceRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GenerateInvoices(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetBillingSchedule godoc
+// @Summary      查询合同账期表
+// @Description  获取合同完整账期表及每期测算金额，仅合同双方及持有账务管理权限的组织成员可查看
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ContractScheduleRequest  true  "查询账期表请求参数"
+// @Success      200     {object}   vo.Result{data=[]invoice.BillingPeriodVO}  "查询账期表成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/getBillingSchedule [post]
+// 参数：
+//   - c: Gin 上下文
+func GetBillingSchedule(c *gin.Context) {
+	req := new(dto.ContractScheduleRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetBillingSchedule(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetInvoice godoc
+// @Summary      获取账单
+// @Description  根据账单 ID 获取账单及明细，仅合同双方及持有账务管理权限的组织成员可查看
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.InvoiceIDRequest  true  "获取账单请求参数"
+// @Success      200     {object}   vo.Result{data=invoice.InvoiceVO}  "获取账单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/getInvoice [post]
+// 参数：
+//   - c: Gin 上下文
+func GetInvoice(c *gin.Context) {
+	req := new(dto.InvoiceIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetInvoice(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListInvoices godoc
+// @Summary      分页查询账单
+// @Description  按业主或租客身份分页查询当前账户相关的账单
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListInvoiceRequest  true  "查询账单请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]invoice.InvoiceVO}}  "查询账单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/listInvoices [post]
+// 参数：
+//   - c: Gin 上下文
+func ListInvoices(c *gin.Context) {
+	req := new(dto.ListInvoiceRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListInvoices(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,161 @@
+// Package invoice 提供租金账单相关的HTTP接口处理
+package invoice
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/invoice/dto"
+	service "lease/pkg/serve/service/invoice"
+	"lease/pkg/vo"
+)
+
+// CreateRecurringFee godoc
+// @Summary      添加周期性杂费
+// @Description  业主为合同添加按月计价的物业费、停车费等周期性杂费
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateRecurringFeeRequest  true  "添加杂费请求参数"
+// @Success      200     {object}   vo.Result{data=invoice.RecurringFeeVO}  "添加杂费成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/createRecurringFee [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateRecurringFee(c *gin.Context) {
+	req := new(dto.CreateRecurringFeeRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateRecurringFee(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UpdateRecurringFee godoc
+// @Summary      修改周期性杂费
+// @Description  业主修改周期性杂费，已生成的账单不受影响
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateRecurringFeeRequest  true  "修改杂费请求参数"
+// @Success      200     {object}   vo.Result{data=invoice.RecurringFeeVO}  "修改杂费成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/updateRecurringFee [post]
+// 参数：
+//   - c: Gin 上下文
+func UpdateRecurringFee(c *gin.Context) {
+	req := new(dto.UpdateRecurringFeeRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.UpdateRecurringFee(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// DeleteRecurringFee godoc
+// @Summary      删除周期性杂费
+// @Description  业主删除周期性杂费，已生成的账单不受影响
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RecurringFeeIDRequest  true  "删除杂费请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "删除杂费成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/deleteRecurringFee [post]
+// 参数：
+//   - c: Gin 上下文
+func DeleteRecurringFee(c *gin.Context) {
+	req := new(dto.RecurringFeeIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.DeleteRecurringFee(c, req); err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "周期性杂费删除成功"))
+}
+
+// ListRecurringFees godoc
+// @Summary      查询周期性杂费
+// @Description  获取合同的周期性杂费，仅合同双方及持有账务管理权限的组织成员可查看
+// @Tags         账单
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ContractScheduleRequest  true  "查询杂费请求参数"
+// @Success      200     {object}   vo.Result{data=[]invoice.RecurringFeeVO}  "查询杂费成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /invoice/listRecurringFees [post]
+// 参数：
+//   - c: Gin 上下文
+func ListRecurringFees(c *gin.Context) {
+	req := new(dto.ContractScheduleRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListRecurringFees(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
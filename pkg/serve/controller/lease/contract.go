@@ -0,0 +1,421 @@
+// Package lease 提供租约合同相关的HTTP接口处理
+package lease
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/lease/dto"
+	service "lease/pkg/serve/service/lease"
+	"lease/pkg/vo"
+)
+
+// CreateContract godoc
+// @Summary      创建合同草稿
+// @Description  业主为名下出租单元创建合同草稿
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateContractRequest  true  "创建合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "创建合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/createContract [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateContract(c *gin.Context) {
+	req := new(dto.CreateContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetContract godoc
+// @Summary      获取合同
+// @Description  根据合同 ID 获取详情，仅合同双方及持有合同管理权限的组织成员可查看
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ContractIDRequest  true  "获取合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "获取合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/getContract [post]
+// 参数：
+//   - c: Gin 上下文
+func GetContract(c *gin.Context) {
+	req := new(dto.ContractIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UpdateContract godoc
+// @Summary      修改合同草稿
+// @Description  业主修改草稿状态的合同
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateContractRequest  true  "修改合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "修改合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/updateContract [post]
+// 参数：
+//   - c: Gin 上下文
+func UpdateContract(c *gin.Context) {
+	req := new(dto.UpdateContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.UpdateContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListContracts godoc
+// @Summary      分页查询合同
+// @Description  按业主或租客身份分页查询当前账户相关的合同
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListContractRequest  true  "查询合同请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]lease.LeaseContractVO}}  "查询合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/listContracts [post]
+// 参数：
+//   - c: Gin 上下文
+func ListContracts(c *gin.Context) {
+	req := new(dto.ListContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListContracts(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// SubmitContract godoc
+// @Summary      提交合同
+// @Description  业主提交合同草稿等待租客签署
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionContractRequest  true  "提交合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "提交合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/submitContract [post]
+// 参数：
+//   - c: Gin 上下文
+func SubmitContract(c *gin.Context) {
+	req := new(dto.TransitionContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.SubmitContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// WithdrawContract godoc
+// @Summary      撤回合同
+// @Description  业主将待签署合同撤回至草稿
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionContractRequest  true  "撤回合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "撤回合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/withdrawContract [post]
+// 参数：
+//   - c: Gin 上下文
+func WithdrawContract(c *gin.Context) {
+	req := new(dto.TransitionContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.WithdrawContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// SignContract godoc
+// @Summary      签署合同
+// @Description  租客签署待签署合同使其生效
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionContractRequest  true  "签署合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "签署合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/signContract [post]
+// 参数：
+//   - c: Gin 上下文
+func SignContract(c *gin.Context) {
+	req := new(dto.TransitionContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.SignContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// TerminateContract godoc
+// @Summary      解除合同
+// @Description  合同任一方提前解除生效中的合同
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TerminateContractRequest  true  "解除合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "解除合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/terminateContract [post]
+// 参数：
+//   - c: Gin 上下文
+func TerminateContract(c *gin.Context) {
+	req := new(dto.TerminateContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.TerminateContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ExpireContract godoc
+// @Summary      合同到期
+// @Description  业主将已过到期日的合同标记为到期
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionContractRequest  true  "合同到期请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "标记到期成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/expireContract [post]
+// 参数：
+//   - c: Gin 上下文
+func ExpireContract(c *gin.Context) {
+	req := new(dto.TransitionContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ExpireContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RenewContract godoc
+// @Summary      续约合同
+// @Description  业主基于生效中的合同创建续约草稿
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RenewContractRequest  true  "续约合同请求参数"
+// @Success      200     {object}   vo.Result{data=lease.LeaseContractVO}  "续约合同成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/renewContract [post]
+// 参数：
+//   - c: Gin 上下文
+func RenewContract(c *gin.Context) {
+	req := new(dto.RenewContractRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RenewContract(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListContractAudits godoc
+// @Summary      查询合同审计记录
+// @Description  获取合同状态流转审计记录，仅合同双方及持有合同管理权限的组织成员可查看
+// @Tags         租约
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ContractIDRequest  true  "查询审计记录请求参数"
+// @Success      200     {object}   vo.Result{data=[]lease.LeaseContractAuditVO}  "查询审计记录成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /lease/listContractAudits [post]
+// 参数：
+//   - c: Gin 上下文
+func ListContractAudits(c *gin.Context) {
+	req := new(dto.ContractIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListContractAudits(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
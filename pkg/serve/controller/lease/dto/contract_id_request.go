@@ -0,0 +1,9 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// ContractIDRequest  按 ID 操作合同请求体
+// @Description	获取合同或查询审计记录所需参数
+// @Param			id	body	int	true	"合同 ID"
+type ContractIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
@@ -0,0 +1,21 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// CreateContractRequest  创建合同草稿请求体
+// @Description	业主为名下出租单元创建合同草稿所需参数，金额单位为分
+// @Param			unit_id			body	int		true	"出租单元 ID"
+// @Param			tenant_id		body	int		true	"租客账户 ID"
+// @Param			start_date		body	string	true	"起租日期，格式 2006-01-02"
+// @Param			end_date		body	string	true	"到期日期（含当日），格式 2006-01-02"
+// @Param			monthly_rent	body	int		true	"月租金"
+// @Param			deposit			body	int		false	"押金"
+// @Param			billing_cycle	body	string	true	"计费周期: monthly, quarterly, yearly"
+type CreateContractRequest struct {
+	UnitID       int64  `json:"unit_id" xml:"unit_id" form:"unit_id" query:"unit_id" validate:"required"`
+	TenantID     int64  `json:"tenant_id" xml:"tenant_id" form:"tenant_id" query:"tenant_id" validate:"required"`
+	StartDate    string `json:"start_date" xml:"start_date" form:"start_date" query:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string `json:"end_date" xml:"end_date" form:"end_date" query:"end_date" validate:"required,datetime=2006-01-02"`
+	MonthlyRent  int64  `json:"monthly_rent" xml:"monthly_rent" form:"monthly_rent" query:"monthly_rent" validate:"required,gt=0"`
+	Deposit      int64  `json:"deposit" xml:"deposit" form:"deposit" query:"deposit" validate:"min=0"`
+	BillingCycle string `json:"billing_cycle" xml:"billing_cycle" form:"billing_cycle" query:"billing_cycle" validate:"required,oneof=monthly quarterly yearly"`
+}
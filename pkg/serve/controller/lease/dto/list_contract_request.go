@@ -0,0 +1,17 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// ListContractRequest  分页查询合同请求体
+// @Description	按身份分页查询当前账户相关的合同
+// @Param			role		body	string	true	"查询身份: landlord, tenant"
+// @Param			status		body	string	false	"合同状态"
+// @Param			unit_id		body	int		false	"出租单元 ID"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListContractRequest struct {
+	Role     string `json:"role" xml:"role" form:"role" query:"role" validate:"required,oneof=landlord tenant"`
+	Status   string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=draft pending_signature active terminated expired renewed"`
+	UnitID   int64  `json:"unit_id" xml:"unit_id" form:"unit_id" query:"unit_id"`
+	PageNo   int    `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
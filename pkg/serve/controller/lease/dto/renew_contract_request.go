@@ -0,0 +1,17 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// RenewContractRequest  续约请求体
+// @Description	基于生效中的合同创建续约草稿，新合同自原合同到期次日起租，未传字段沿用原合同
+// @Param			id				body	int		true	"原合同 ID"
+// @Param			end_date		body	string	true	"新合同到期日期，格式 2006-01-02"
+// @Param			monthly_rent	body	int		false	"新月租金"
+// @Param			deposit			body	int		false	"新押金"
+// @Param			billing_cycle	body	string	false	"新计费周期"
+type RenewContractRequest struct {
+	ID           int64   `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	EndDate      string  `json:"end_date" xml:"end_date" form:"end_date" query:"end_date" validate:"required,datetime=2006-01-02"`
+	MonthlyRent  *int64  `json:"monthly_rent" xml:"monthly_rent" form:"monthly_rent" query:"monthly_rent" validate:"omitempty,gt=0"`
+	Deposit      *int64  `json:"deposit" xml:"deposit" form:"deposit" query:"deposit" validate:"omitempty,min=0"`
+	BillingCycle *string `json:"billing_cycle" xml:"billing_cycle" form:"billing_cycle" query:"billing_cycle" validate:"omitempty,oneof=monthly quarterly yearly"`
+}
@@ -0,0 +1,11 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// TerminateContractRequest  提前解约请求体
+// @Description	提前解约所需参数
+// @Param			id		body	int		true	"合同 ID"
+// @Param			reason	body	string	true	"解约原因"
+type TerminateContractRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Reason string `json:"reason" xml:"reason" form:"reason" query:"reason" validate:"required,max=255"`
+}
@@ -0,0 +1,11 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// TransitionContractRequest  合同状态流转请求体
+// @Description	提交、撤回、签署、到期等状态流转所需参数
+// @Param			id		body	int		true	"合同 ID"
+// @Param			remark	body	string	false	"备注"
+type TransitionContractRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Remark string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
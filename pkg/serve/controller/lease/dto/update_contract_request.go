@@ -0,0 +1,19 @@
+// Package dto 提供租约合同相关的数据传输对象定义
+package dto
+
+// UpdateContractRequest  更新合同草稿请求体
+// @Description	仅草稿状态的合同可修改，未传字段保持不变
+// @Param			id				body	int		true	"合同 ID"
+// @Param			start_date		body	string	false	"起租日期，格式 2006-01-02"
+// @Param			end_date		body	string	false	"到期日期（含当日），格式 2006-01-02"
+// @Param			monthly_rent	body	int		false	"月租金"
+// @Param			deposit			body	int		false	"押金"
+// @Param			billing_cycle	body	string	false	"计费周期: monthly, quarterly, yearly"
+type UpdateContractRequest struct {
+	ID           int64   `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	StartDate    *string `json:"start_date" xml:"start_date" form:"start_date" query:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate      *string `json:"end_date" xml:"end_date" form:"end_date" query:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	MonthlyRent  *int64  `json:"monthly_rent" xml:"monthly_rent" form:"monthly_rent" query:"monthly_rent" validate:"omitempty,gt=0"`
+	Deposit      *int64  `json:"deposit" xml:"deposit" form:"deposit" query:"deposit" validate:"omitempty,min=0"`
+	BillingCycle *string `json:"billing_cycle" xml:"billing_cycle" form:"billing_cycle" query:"billing_cycle" validate:"omitempty,oneof=monthly quarterly yearly"`
+}
@@ -0,0 +1,15 @@
+// Package dto 提供账本与收款相关的数据传输对象定义
+package dto
+
+// ListPaymentRequest  分页查询收款请求体
+// @Description	按身份分页查询当前账户相关的收款记录
+// @Param			role		body	string	true	"查询身份: landlord, tenant"
+// @Param			contract_id	body	int		false	"合同 ID"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListPaymentRequest struct {
+	Role       string `json:"role" xml:"role" form:"role" query:"role" validate:"required,oneof=landlord tenant"`
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id"`
+	PageNo     int    `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize   int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,23 @@
+// Package dto 提供账本与收款相关的数据传输对象定义
+package dto
+
+// RecordPaymentRequest  登记收款请求体
+// @Description	业主登记租客付款，租金款项按账期先后核销未结清账单，多付部分结转为预收余额；金额单位为分
+// @Param			contract_id	body	int		true	"合同 ID"
+// @Param			purpose		body	string	true	"收款用途: rent, deposit"
+// @Param			method		body	string	true	"收款方式: cash, bank_transfer, alipay, wechat, other"
+// @Param			amount		body	int		true	"收款金额"
+// @Param			paid_date	body	string	true	"收款日期，格式 2006-01-02"
+// @Param			invoice_id	body	int		false	"优先核销的账单 ID"
+// @Param			reference	body	string	false	"外部流水号"
+// @Param			remark		body	string	false	"备注"
+type RecordPaymentRequest struct {
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id" validate:"required"`
+	Purpose    string `json:"purpose" xml:"purpose" form:"purpose" query:"purpose" validate:"required,oneof=rent deposit"`
+	Method     string `json:"method" xml:"method" form:"method" query:"method" validate:"required,oneof=cash bank_transfer alipay wechat other"`
+	Amount     int64  `json:"amount" xml:"amount" form:"amount" query:"amount" validate:"required,gt=0"`
+	PaidDate   string `json:"paid_date" xml:"paid_date" form:"paid_date" query:"paid_date" validate:"required,datetime=2006-01-02"`
+	InvoiceID  int64  `json:"invoice_id" xml:"invoice_id" form:"invoice_id" query:"invoice_id"`
+	Reference  string `json:"reference" xml:"reference" form:"reference" query:"reference" validate:"max=64"`
+	Remark     string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
@@ -0,0 +1,17 @@
+// Package dto 提供账本与收款相关的数据传输对象定义
+package dto
+
+// RefundCreditRequest  退还预收余额请求体
+// @Description	业主将合同下租客的预收余额退还给租客；金额单位为分
+// @Param			contract_id	body	int		true	"合同 ID"
+// @Param			amount		body	int		true	"退款金额，不超过预收余额"
+// @Param			method		body	string	true	"退款方式: cash, bank_transfer, alipay, wechat, other"
+// @Param			reference	body	string	false	"外部流水号"
+// @Param			remark		body	string	false	"备注"
+type RefundCreditRequest struct {
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id" validate:"required"`
+	Amount     int64  `json:"amount" xml:"amount" form:"amount" query:"amount" validate:"required,gt=0"`
+	Method     string `json:"method" xml:"method" form:"method" query:"method" validate:"required,oneof=cash bank_transfer alipay wechat other"`
+	Reference  string `json:"reference" xml:"reference" form:"reference" query:"reference" validate:"max=64"`
+	Remark     string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
@@ -0,0 +1,13 @@
+// Package dto 提供账本与收款相关的数据传输对象定义
+package dto
+
+// StatementRequest  租客对账单请求体
+// @Description	租客查询本人对账单，业主查询名下合同某租客的对账单
+// @Param			role		body	string	true	"查询身份: landlord, tenant"
+// @Param			tenant_id	body	int		false	"租客账户 ID，业主查询时必填"
+// @Param			contract_id	body	int		false	"合同 ID，为空时汇总全部合同"
+type StatementRequest struct {
+	Role       string `json:"role" xml:"role" form:"role" query:"role" validate:"required,oneof=landlord tenant"`
+	TenantID   int64  `json:"tenant_id" xml:"tenant_id" form:"tenant_id" query:"tenant_id" validate:"required_if=Role landlord"`
+	ContractID int64  `json:"contract_id" xml:"contract_id" form:"contract_id" query:"contract_id"`
+}
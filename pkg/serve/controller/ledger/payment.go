@@ -0,0 +1,130 @@
+// Package ledger 提供账本与收款相关的HTTP接口处理
+package ledger
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/ledger/dto"
+	service "lease/pkg/serve/service/ledger"
+	"lease/pkg/vo"
+)
+
+// RecordPayment godoc
+// @Summary      登记收款
+// @Description  业主登记租客付款，租金款项核销未结清账单，多付部分结转为预收余额
+// @Tags         账本
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RecordPaymentRequest  true  "登记收款请求参数"
+// @Success      200     {object}   vo.Result{data=ledger.PaymentVO}  "登记收款成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /ledger/recordPayment [post]
+// 参数：
+//   - c: Gin 上下文
+func RecordPayment(c *gin.Context) {
+	req := new(dto.RecordPaymentRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RecordPayment(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RefundCredit godoc
+// @Summary      退还预收余额
+// @Description  业主将合同下租客的预收余额退还给租客
+// @Tags         账本
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RefundCreditRequest  true  "退还预收余额请求参数"
+// @Success      200     {object}   vo.Result{data=ledger.LedgerTransactionVO}  "退款成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      409     {object}   vo.Result              "预收余额不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /ledger/refundCredit [post]
+// 参数：
+//   - c: Gin 上下文
+func RefundCredit(c *gin.Context) {
+	req := new(dto.RefundCreditRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RefundCredit(c, req)
+	if err != nil {
+		if ledgerErr, ok := err.(*bizErr.Err); ok {
+			c.JSON(http.StatusConflict, vo.Fail(c, nil, ledgerErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListPayments godoc
+// @Summary      分页查询收款
+// @Description  按业主或租客身份分页查询当前账户相关的收款记录
+// @Tags         账本
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListPaymentRequest  true  "查询收款请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]ledger.PaymentVO}}  "查询收款成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /ledger/listPayments [post]
+// 参数：
+//   - c: Gin 上下文
+func ListPayments(c *gin.Context) {
+	req := new(dto.ListPaymentRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListPayments(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,51 @@
+// Package ledger 提供账本与收款相关的HTTP接口处理
+package ledger
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/ledger/dto"
+	service "lease/pkg/serve/service/ledger"
+	"lease/pkg/vo"
+)
+
+// GetStatement godoc
+// @Summary      租客对账单
+// @Description  逐笔列示租客应收、预收与押金往来并汇总余额
+// @Tags         账本
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.StatementRequest  true  "对账单请求参数"
+// @Success      200     {object}   vo.Result{data=ledger.StatementVO}  "获取对账单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /ledger/getStatement [post]
+// 参数：
+//   - c: Gin 上下文
+func GetStatement(c *gin.Context) {
+	req := new(dto.StatementRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetStatement(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
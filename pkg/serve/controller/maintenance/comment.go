@@ -0,0 +1,51 @@
+// Package maintenance 提供报修工单相关的HTTP接口处理
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/maintenance/dto"
+	service "lease/pkg/serve/service/maintenance"
+	"lease/pkg/vo"
+)
+
+// AddComment godoc
+// @Summary      发表工单评论
+// @Description  工单相关人员在工单下发表评论
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AddCommentRequest  true  "工单评论请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceCommentVO}  "评论成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/addComment [post]
+// 参数：
+//   - c: Gin 上下文
+func AddComment(c *gin.Context) {
+	req := new(dto.AddCommentRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.AddComment(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
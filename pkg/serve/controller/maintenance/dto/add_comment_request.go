@@ -0,0 +1,11 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// AddCommentRequest  工单评论请求体
+// @Description	工单相关人员在工单下发表评论
+// @Param			ticket_id	body	int		true	"工单 ID"
+// @Param			content		body	string	true	"评论内容"
+type AddCommentRequest struct {
+	TicketID int64  `json:"ticket_id" xml:"ticket_id" form:"ticket_id" query:"ticket_id" validate:"required"`
+	Content  string `json:"content" xml:"content" form:"content" query:"content" validate:"required,max=1000"`
+}
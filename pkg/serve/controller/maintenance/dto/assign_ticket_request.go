@@ -0,0 +1,13 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// AssignTicketRequest  指派维修人员请求体
+// @Description	业主将工单指派或改派给维修人员
+// @Param			id			body	int		true	"工单 ID"
+// @Param			assignee_id	body	int		true	"维修人员账户 ID"
+// @Param			remark		body	string	false	"备注"
+type AssignTicketRequest struct {
+	ID         int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	AssigneeID int64  `json:"assignee_id" xml:"assignee_id" form:"assignee_id" query:"assignee_id" validate:"required"`
+	Remark     string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
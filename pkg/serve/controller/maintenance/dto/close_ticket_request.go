@@ -0,0 +1,13 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// CloseTicketRequest  关闭工单请求体
+// @Description	业主核定维修费用并关闭工单，金额单位为分
+// @Param			id		body	int		true	"工单 ID"
+// @Param			cost	body	int		true	"维修费用"
+// @Param			remark	body	string	false	"备注"
+type CloseTicketRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Cost   int64  `json:"cost" xml:"cost" form:"cost" query:"cost" validate:"min=0"`
+	Remark string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
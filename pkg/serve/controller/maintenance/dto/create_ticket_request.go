@@ -0,0 +1,17 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// CreateTicketRequest  提交报修请求体
+// @Description	租客为承租中的出租单元、或业主为名下出租单元提交报修
+// @Param			unit_id		body	int		true	"出租单元 ID"
+// @Param			title		body	string	true	"标题"
+// @Param			description	body	string	false	"问题描述"
+// @Param			category	body	string	true	"类别: plumbing, electrical, heating, appliance, structural, pest, other"
+// @Param			priority	body	string	true	"优先级: low, medium, high, urgent"
+type CreateTicketRequest struct {
+	UnitID      int64  `json:"unit_id" xml:"unit_id" form:"unit_id" query:"unit_id" validate:"required"`
+	Title       string `json:"title" xml:"title" form:"title" query:"title" validate:"required,max=100"`
+	Description string `json:"description" xml:"description" form:"description" query:"description" validate:"max=2000"`
+	Category    string `json:"category" xml:"category" form:"category" query:"category" validate:"required,oneof=plumbing electrical heating appliance structural pest other"`
+	Priority    string `json:"priority" xml:"priority" form:"priority" query:"priority" validate:"required,oneof=low medium high urgent"`
+}
@@ -0,0 +1,19 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// ListTicketRequest  分页查询报修工单请求体
+// @Description	按身份分页查询当前账户相关的报修工单
+// @Param			role		body	string	true	"查询身份: landlord, tenant, assignee"
+// @Param			status		body	string	false	"工单状态"
+// @Param			priority	body	string	false	"优先级"
+// @Param			unit_id		body	int		false	"出租单元 ID"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListTicketRequest struct {
+	Role     string `json:"role" xml:"role" form:"role" query:"role" validate:"required,oneof=landlord tenant assignee"`
+	Status   string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=open assigned scheduled in_progress resolved closed cancelled"`
+	Priority string `json:"priority" xml:"priority" form:"priority" query:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	UnitID   int64  `json:"unit_id" xml:"unit_id" form:"unit_id" query:"unit_id"`
+	PageNo   int    `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,13 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// ScheduleTicketRequest  预约上门请求体
+// @Description	业主或维修人员预约上门维修时间
+// @Param			id				body	int		true	"工单 ID"
+// @Param			scheduled_at	body	string	true	"上门时间，格式 2006-01-02 15:04"
+// @Param			remark			body	string	false	"备注"
+type ScheduleTicketRequest struct {
+	ID          int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	ScheduledAt string `json:"scheduled_at" xml:"scheduled_at" form:"scheduled_at" query:"scheduled_at" validate:"required,datetime=2006-01-02 15:04"`
+	Remark      string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
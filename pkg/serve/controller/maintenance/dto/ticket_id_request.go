@@ -0,0 +1,9 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// TicketIDRequest  工单 ID 请求体
+// @Description	按 ID 查询报修工单
+// @Param			id	body	int	true	"工单 ID"
+type TicketIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
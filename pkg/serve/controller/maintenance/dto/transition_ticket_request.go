@@ -0,0 +1,11 @@
+// Package dto 提供报修工单相关的数据传输对象定义
+package dto
+
+// TransitionTicketRequest  工单状态流转请求体
+// @Description	开始维修、完成维修、重新打开、取消等状态流转所需参数
+// @Param			id		body	int		true	"工单 ID"
+// @Param			remark	body	string	false	"备注"
+type TransitionTicketRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Remark string `json:"remark" xml:"remark" form:"remark" query:"remark" validate:"max=255"`
+}
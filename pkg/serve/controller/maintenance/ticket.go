@@ -0,0 +1,384 @@
+// Package maintenance 提供报修工单相关的HTTP接口处理
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/maintenance/dto"
+	service "lease/pkg/serve/service/maintenance"
+	"lease/pkg/vo"
+)
+
+// CreateTicket godoc
+// @Summary      提交报修
+// @Description  租客为承租中的出租单元、或业主为名下出租单元提交报修
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateTicketRequest  true  "提交报修请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "提交报修成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/createTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateTicket(c *gin.Context) {
+	req := new(dto.CreateTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetTicket godoc
+// @Summary      获取工单详情
+// @Description  获取报修工单及其评论和状态流转记录，仅租客、业主和维修人员可查看
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TicketIDRequest  true  "工单 ID 请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "获取工单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/getTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func GetTicket(c *gin.Context) {
+	req := new(dto.TicketIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListTickets godoc
+// @Summary      分页查询工单
+// @Description  按业主、租客或维修人员身份分页查询报修工单
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListTicketRequest  true  "查询工单请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]maintenance.MaintenanceTicketVO}}  "查询工单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/listTickets [post]
+// 参数：
+//   - c: Gin 上下文
+func ListTickets(c *gin.Context) {
+	req := new(dto.ListTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListTickets(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// AssignTicket godoc
+// @Summary      指派维修人员
+// @Description  业主将工单指派或改派给维修人员
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AssignTicketRequest  true  "指派请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "指派成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/assignTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func AssignTicket(c *gin.Context) {
+	req := new(dto.AssignTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.AssignTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ScheduleTicket godoc
+// @Summary      预约上门
+// @Description  业主或维修人员预约上门维修时间
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ScheduleTicketRequest  true  "预约上门请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "预约成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/scheduleTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func ScheduleTicket(c *gin.Context) {
+	req := new(dto.ScheduleTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ScheduleTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// StartTicket godoc
+// @Summary      开始维修
+// @Description  业主或维修人员开始维修
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionTicketRequest  true  "状态流转请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "开始维修成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/startTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func StartTicket(c *gin.Context) {
+	req := new(dto.TransitionTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.StartTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ResolveTicket godoc
+// @Summary      完成维修
+// @Description  业主或维修人员完成维修并填写处理结果
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionTicketRequest  true  "状态流转请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "完成维修成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/resolveTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func ResolveTicket(c *gin.Context) {
+	req := new(dto.TransitionTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ResolveTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ReopenTicket godoc
+// @Summary      重新打开工单
+// @Description  报修人或业主认为未修好时重新打开工单
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionTicketRequest  true  "状态流转请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "重新打开成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/reopenTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func ReopenTicket(c *gin.Context) {
+	req := new(dto.TransitionTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ReopenTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// CloseTicket godoc
+// @Summary      关闭工单
+// @Description  业主核定维修费用并关闭工单
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CloseTicketRequest  true  "关闭工单请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "关闭工单成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/closeTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func CloseTicket(c *gin.Context) {
+	req := new(dto.CloseTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CloseTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// CancelTicket godoc
+// @Summary      取消报修
+// @Description  报修人或业主在开始维修前取消工单
+// @Tags         报修
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TransitionTicketRequest  true  "状态流转请求参数"
+// @Success      200     {object}   vo.Result{data=maintenance.MaintenanceTicketVO}  "取消报修成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /maintenance/cancelTicket [post]
+// 参数：
+//   - c: Gin 上下文
+func CancelTicket(c *gin.Context) {
+	req := new(dto.TransitionTicketRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CancelTicket(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
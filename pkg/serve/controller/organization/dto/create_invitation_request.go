@@ -0,0 +1,11 @@
+// Package dto 提供组织相关的数据传输对象定义
+package dto
+
+// CreateInvitationRequest  邀请成员请求体
+// @Description	组织管理员邀请邮箱加入组织，受邀人凭邀请码注册后获得指定角色
+// @Param			email		body	string	true	"受邀邮箱"
+// @Param			role_code	body	string	true	"加入后授予的角色编码: tenant, landlord, property_manager, admin"
+type CreateInvitationRequest struct {
+	Email    string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email,max=64"`
+	RoleCode string `json:"role_code" xml:"role_code" form:"role_code" query:"role_code" validate:"required,max=32"`
+}
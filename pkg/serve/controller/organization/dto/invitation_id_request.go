@@ -0,0 +1,9 @@
+// Package dto 提供组织相关的数据传输对象定义
+package dto
+
+// InvitationIDRequest  邀请 ID 请求体
+// @Description	按 ID 操作组织邀请
+// @Param			id	body	int	true	"邀请 ID"
+type InvitationIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
@@ -0,0 +1,13 @@
+// Package dto 提供组织相关的数据传输对象定义
+package dto
+
+// ListInvitationRequest  分页查询组织邀请请求体
+// @Description	分页查询当前组织发出的邀请
+// @Param			status		body	string	false	"邀请状态: pending, accepted, revoked"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListInvitationRequest struct {
+	Status   string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=pending accepted revoked"`
+	PageNo   int    `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,11 @@
+// Package dto 提供组织相关的数据传输对象定义
+package dto
+
+// ListMemberRequest  分页查询组织成员请求体
+// @Description	分页查询当前组织成员所需参数
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListMemberRequest struct {
+	PageNo   int `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize int `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
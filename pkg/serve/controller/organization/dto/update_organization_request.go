@@ -0,0 +1,11 @@
+// Package dto 提供组织相关的数据传输对象定义
+package dto
+
+// UpdateOrganizationRequest  更新组织信息请求体
+// @Description	组织管理员修改组织名称或席位上限，未传字段保持不变
+// @Param			name		body	string	false	"组织名称"
+// @Param			seat_limit	body	int		false	"账户席位上限，0 表示不限，不得低于当前成员数"
+type UpdateOrganizationRequest struct {
+	Name      *string `json:"name" xml:"name" form:"name" query:"name" validate:"omitempty,min=1,max=100"`
+	SeatLimit *int    `json:"seat_limit" xml:"seat_limit" form:"seat_limit" query:"seat_limit" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,128 @@
+// Package organization 提供组织与成员邀请相关的HTTP接口处理
+package organization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/organization/dto"
+	service "lease/pkg/serve/service/organization"
+	"lease/pkg/vo"
+)
+
+// CreateInvitation godoc
+// @Summary      邀请成员
+// @Description  组织管理员邀请邮箱加入组织，待接受的邀请同样占用席位，邀请码通过邮件发送
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateInvitationRequest  true  "邀请成员请求参数"
+// @Success      200     {object}   vo.Result{data=organization.InvitationVO}  "邀请成员成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /organization/createInvitation [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateInvitation(c *gin.Context) {
+	req := new(dto.CreateInvitationRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateInvitation(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListInvitations godoc
+// @Summary      邀请列表
+// @Description  组织管理员分页查询当前组织发出的邀请
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListInvitationRequest  true  "分页查询邀请请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]organization.InvitationVO}}  "查询邀请成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /organization/listInvitations [post]
+// 参数：
+//   - c: Gin 上下文
+func ListInvitations(c *gin.Context) {
+	req := new(dto.ListInvitationRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListInvitations(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RevokeInvitation godoc
+// @Summary      撤销邀请
+// @Description  组织管理员撤销尚未接受的邀请
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.InvitationIDRequest  true  "邀请 ID 请求参数"
+// @Success      200     {object}   vo.Result{data=organization.InvitationVO}  "撤销邀请成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /organization/revokeInvitation [post]
+// 参数：
+//   - c: Gin 上下文
+func RevokeInvitation(c *gin.Context) {
+	req := new(dto.InvitationIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RevokeInvitation(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,112 @@
+// Package organization 提供组织与成员邀请相关的HTTP接口处理
+package organization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/organization/dto"
+	service "lease/pkg/serve/service/organization"
+	"lease/pkg/vo"
+)
+
+// GetOrganization godoc
+// @Summary      当前组织
+// @Description  查询当前账户所属组织及席位占用情况
+// @Tags         组织
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=organization.OrganizationVO}  "查询组织成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /organization/getOrganization [post]
+// 参数：
+//   - c: Gin 上下文
+func GetOrganization(c *gin.Context) {
+	response, err := service.GetOrganization(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UpdateOrganization godoc
+// @Summary      更新组织
+// @Description  组织管理员修改组织名称或席位上限，席位上限为 0 表示不限且不得低于当前成员数
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateOrganizationRequest  true  "更新组织请求参数"
+// @Success      200     {object}   vo.Result{data=organization.OrganizationVO}  "更新组织成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /organization/updateOrganization [post]
+// 参数：
+//   - c: Gin 上下文
+func UpdateOrganization(c *gin.Context) {
+	req := new(dto.UpdateOrganizationRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.UpdateOrganization(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListMembers godoc
+// @Summary      组织成员列表
+// @Description  组织管理员分页查询当前组织的成员
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListMemberRequest  true  "分页查询成员请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]organization.MemberVO}}  "查询组织成员成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /organization/listMembers [post]
+// 参数：
+//   - c: Gin 上下文
+func ListMembers(c *gin.Context) {
+	req := new(dto.ListMemberRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListMembers(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,23 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// CreatePropertyRequest  创建房源请求体
+// @Description	创建房源所需参数
+// @Param			name		body	string	true	"房源名称"
+// @Param			province	body	string	false	"省份"
+// @Param			city		body	string	true	"城市"
+// @Param			district	body	string	false	"区县"
+// @Param			address		body	string	true	"详细地址"
+// @Param			latitude	body	number	false	"纬度"
+// @Param			longitude	body	number	false	"经度"
+// @Param			description	body	string	false	"房源描述"
+type CreatePropertyRequest struct {
+	Name        string  `json:"name" xml:"name" form:"name" query:"name" validate:"required,min=1,max=128"`
+	Province    string  `json:"province" xml:"province" form:"province" query:"province" validate:"max=64"`
+	City        string  `json:"city" xml:"city" form:"city" query:"city" validate:"required,max=64"`
+	District    string  `json:"district" xml:"district" form:"district" query:"district" validate:"max=64"`
+	Address     string  `json:"address" xml:"address" form:"address" query:"address" validate:"required,max=255"`
+	Latitude    float64 `json:"latitude" xml:"latitude" form:"latitude" query:"latitude" validate:"latitude"`
+	Longitude   float64 `json:"longitude" xml:"longitude" form:"longitude" query:"longitude" validate:"longitude"`
+	Description string  `json:"description" xml:"description" form:"description" query:"description" validate:"max=1024"`
+}
@@ -0,0 +1,21 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// CreateUnitRequest  创建出租单元请求体
+// @Description	在房源下创建出租单元所需参数
+// @Param			property_id		body	int		true	"所属房源 ID"
+// @Param			unit_no			body	string	true	"房间号"
+// @Param			floor			body	int		true	"楼层"
+// @Param			area			body	number	true	"面积（平方米）"
+// @Param			rooms			body	int		true	"房间数"
+// @Param			rent_list_price	body	int		true	"挂牌月租金（分）"
+// @Param			status			body	string	false	"房间状态: vacant, occupied, maintenance"
+type CreateUnitRequest struct {
+	PropertyID    int64   `json:"property_id" xml:"property_id" form:"property_id" query:"property_id" validate:"required"`
+	UnitNo        string  `json:"unit_no" xml:"unit_no" form:"unit_no" query:"unit_no" validate:"required,min=1,max=32"`
+	Floor         int     `json:"floor" xml:"floor" form:"floor" query:"floor"`
+	Area          float64 `json:"area" xml:"area" form:"area" query:"area" validate:"required,gt=0"`
+	Rooms         int     `json:"rooms" xml:"rooms" form:"rooms" query:"rooms" validate:"required,min=1"`
+	RentListPrice int64   `json:"rent_list_price" xml:"rent_list_price" form:"rent_list_price" query:"rent_list_price" validate:"required,gt=0"`
+	Status        string  `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=vacant occupied maintenance"`
+}
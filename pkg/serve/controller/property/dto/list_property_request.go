@@ -0,0 +1,19 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// ListPropertyRequest  分页搜索房源请求体
+// @Description	分页搜索房源所需参数
+// @Param			keyword		body	string	false	"名称或地址关键字"
+// @Param			city		body	string	false	"城市"
+// @Param			district	body	string	false	"区县"
+// @Param			owner_id	body	int		false	"业主账户 ID"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListPropertyRequest struct {
+	Keyword  string `json:"keyword" xml:"keyword" form:"keyword" query:"keyword" validate:"max=64"`
+	City     string `json:"city" xml:"city" form:"city" query:"city" validate:"max=64"`
+	District string `json:"district" xml:"district" form:"district" query:"district" validate:"max=64"`
+	OwnerID  int64  `json:"owner_id" xml:"owner_id" form:"owner_id" query:"owner_id"`
+	PageNo   int    `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,25 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// ListUnitRequest  分页搜索出租单元请求体
+// @Description	分页搜索出租单元所需参数，租金单位为分
+// @Param			property_id	body	int		false	"所属房源 ID"
+// @Param			city		body	string	false	"城市"
+// @Param			status		body	string	false	"房间状态"
+// @Param			min_rent	body	int		false	"最低挂牌租金"
+// @Param			max_rent	body	int		false	"最高挂牌租金"
+// @Param			min_rooms	body	int		false	"最少房间数"
+// @Param			min_area	body	number	false	"最小面积"
+// @Param			page_no		body	int		true	"页码，从 1 开始"
+// @Param			page_size	body	int		true	"每页条数，最大 100"
+type ListUnitRequest struct {
+	PropertyID int64   `json:"property_id" xml:"property_id" form:"property_id" query:"property_id"`
+	City       string  `json:"city" xml:"city" form:"city" query:"city" validate:"max=64"`
+	Status     string  `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=vacant occupied maintenance"`
+	MinRent    int64   `json:"min_rent" xml:"min_rent" form:"min_rent" query:"min_rent" validate:"min=0"`
+	MaxRent    int64   `json:"max_rent" xml:"max_rent" form:"max_rent" query:"max_rent" validate:"min=0"`
+	MinRooms   int     `json:"min_rooms" xml:"min_rooms" form:"min_rooms" query:"min_rooms" validate:"min=0"`
+	MinArea    float64 `json:"min_area" xml:"min_area" form:"min_area" query:"min_area" validate:"min=0"`
+	PageNo     int     `json:"page_no" xml:"page_no" form:"page_no" query:"page_no" validate:"required,min=1"`
+	PageSize   int     `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,9 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// PropertyIDRequest  按 ID 操作房源请求体
+// @Description	获取或删除房源所需参数
+// @Param			id	body	int	true	"房源 ID"
+type PropertyIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
@@ -0,0 +1,9 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// UnitIDRequest  按 ID 操作出租单元请求体
+// @Description	获取或删除出租单元所需参数
+// @Param			id	body	int	true	"出租单元 ID"
+type UnitIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+}
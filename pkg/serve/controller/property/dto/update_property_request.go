@@ -0,0 +1,25 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// UpdatePropertyRequest  更新房源请求体
+// @Description	更新房源所需参数，未传字段保持不变
+// @Param			id			body	int		true	"房源 ID"
+// @Param			name		body	string	false	"房源名称"
+// @Param			province	body	string	false	"省份"
+// @Param			city		body	string	false	"城市"
+// @Param			district	body	string	false	"区县"
+// @Param			address		body	string	false	"详细地址"
+// @Param			latitude	body	number	false	"纬度"
+// @Param			longitude	body	number	false	"经度"
+// @Param			description	body	string	false	"房源描述"
+type UpdatePropertyRequest struct {
+	ID          int64    `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	Name        *string  `json:"name" xml:"name" form:"name" query:"name" validate:"omitempty,min=1,max=128"`
+	Province    *string  `json:"province" xml:"province" form:"province" query:"province" validate:"omitempty,max=64"`
+	City        *string  `json:"city" xml:"city" form:"city" query:"city" validate:"omitempty,min=1,max=64"`
+	District    *string  `json:"district" xml:"district" form:"district" query:"district" validate:"omitempty,max=64"`
+	Address     *string  `json:"address" xml:"address" form:"address" query:"address" validate:"omitempty,min=1,max=255"`
+	Latitude    *float64 `json:"latitude" xml:"latitude" form:"latitude" query:"latitude" validate:"omitempty,latitude"`
+	Longitude   *float64 `json:"longitude" xml:"longitude" form:"longitude" query:"longitude" validate:"omitempty,longitude"`
+	Description *string  `json:"description" xml:"description" form:"description" query:"description" validate:"omitempty,max=1024"`
+}
@@ -0,0 +1,21 @@
+// Package dto 提供房源相关的数据传输对象定义
+package dto
+
+// UpdateUnitRequest  更新出租单元请求体
+// @Description	更新出租单元所需参数，未传字段保持不变
+// @Param			id				body	int		true	"出租单元 ID"
+// @Param			unit_no			body	string	false	"房间号"
+// @Param			floor			body	int		false	"楼层"
+// @Param			area			body	number	false	"面积（平方米）"
+// @Param			rooms			body	int		false	"房间数"
+// @Param			rent_list_price	body	int		false	"挂牌月租金（分）"
+// @Param			status			body	string	false	"房间状态: vacant, occupied, maintenance"
+type UpdateUnitRequest struct {
+	ID            int64    `json:"id" xml:"id" form:"id" query:"id" validate:"required"`
+	UnitNo        *string  `json:"unit_no" xml:"unit_no" form:"unit_no" query:"unit_no" validate:"omitempty,min=1,max=32"`
+	Floor         *int     `json:"floor" xml:"floor" form:"floor" query:"floor"`
+	Area          *float64 `json:"area" xml:"area" form:"area" query:"area" validate:"omitempty,gt=0"`
+	Rooms         *int     `json:"rooms" xml:"rooms" form:"rooms" query:"rooms" validate:"omitempty,min=1"`
+	RentListPrice *int64   `json:"rent_list_price" xml:"rent_list_price" form:"rent_list_price" query:"rent_list_price" validate:"omitempty,gt=0"`
+	Status        *string  `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=vacant occupied maintenance"`
+}
@@ -0,0 +1,198 @@
+// Package property 提供房源与出租单元相关的HTTP接口处理
+package property
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/property/dto"
+	service "lease/pkg/serve/service/property"
+	"lease/pkg/vo"
+)
+
+// CreateProperty godoc
+// @Summary      创建房源
+// @Description  以当前登录账户为业主创建房源
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreatePropertyRequest  true  "创建房源请求参数"
+// @Success      200     {object}   vo.Result{data=property.PropertyVO}  "创建房源成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/createProperty [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateProperty(c *gin.Context) {
+	req := new(dto.CreatePropertyRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateProperty(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetProperty godoc
+// @Summary      获取房源
+// @Description  根据房源 ID 获取房源详情
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.PropertyIDRequest  true  "获取房源请求参数"
+// @Success      200     {object}   vo.Result{data=property.PropertyVO}  "获取房源成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/getProperty [post]
+// 参数：
+//   - c: Gin 上下文
+func GetProperty(c *gin.Context) {
+	req := new(dto.PropertyIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetProperty(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UpdateProperty godoc
+// @Summary      更新房源
+// @Description  更新当前账户名下的房源信息，未传字段保持不变
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdatePropertyRequest  true  "更新房源请求参数"
+// @Success      200     {object}   vo.Result{data=property.PropertyVO}  "更新房源成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/updateProperty [post]
+// 参数：
+//   - c: Gin 上下文
+func UpdateProperty(c *gin.Context) {
+	req := new(dto.UpdatePropertyRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.UpdateProperty(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// DeleteProperty godoc
+// @Summary      删除房源
+// @Description  逻辑删除当前账户名下的房源及其出租单元，存在已出租单元时拒绝删除
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.PropertyIDRequest  true  "删除房源请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "删除房源成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/deleteProperty [post]
+// 参数：
+//   - c: Gin 上下文
+func DeleteProperty(c *gin.Context) {
+	req := new(dto.PropertyIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.DeleteProperty(c, req); err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "房源删除成功"))
+}
+
+// ListProperties godoc
+// @Summary      搜索房源
+// @Description  按关键字、城市、区县、业主分页搜索房源
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListPropertyRequest  true  "搜索房源请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]property.PropertyVO}}  "搜索房源成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/listProperties [post]
+// 参数：
+//   - c: Gin 上下文
+func ListProperties(c *gin.Context) {
+	req := new(dto.ListPropertyRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListProperties(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
@@ -0,0 +1,198 @@
+// Package property 提供房源与出租单元相关的HTTP接口处理
+package property
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/property/dto"
+	service "lease/pkg/serve/service/property"
+	"lease/pkg/vo"
+)
+
+// CreateUnit godoc
+// @Summary      创建出租单元
+// @Description  在当前账户名下的房源中创建出租单元
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateUnitRequest  true  "创建出租单元请求参数"
+// @Success      200     {object}   vo.Result{data=property.UnitVO}  "创建出租单元成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/createUnit [post]
+// 参数：
+//   - c: Gin 上下文
+func CreateUnit(c *gin.Context) {
+	req := new(dto.CreateUnitRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.CreateUnit(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetUnit godoc
+// @Summary      获取出租单元
+// @Description  根据出租单元 ID 获取详情
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnitIDRequest  true  "获取出租单元请求参数"
+// @Success      200     {object}   vo.Result{data=property.UnitVO}  "获取出租单元成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/getUnit [post]
+// 参数：
+//   - c: Gin 上下文
+func GetUnit(c *gin.Context) {
+	req := new(dto.UnitIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetUnit(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UpdateUnit godoc
+// @Summary      更新出租单元
+// @Description  更新当前账户名下的出租单元，未传字段保持不变
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateUnitRequest  true  "更新出租单元请求参数"
+// @Success      200     {object}   vo.Result{data=property.UnitVO}  "更新出租单元成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/updateUnit [post]
+// 参数：
+//   - c: Gin 上下文
+func UpdateUnit(c *gin.Context) {
+	req := new(dto.UpdateUnitRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.UpdateUnit(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// DeleteUnit godoc
+// @Summary      删除出租单元
+// @Description  逻辑删除当前账户名下的出租单元，已出租单元不可删除
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnitIDRequest  true  "删除出租单元请求参数"
+// @Success      200     {object}   vo.Result{data=string}  "删除出租单元成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/deleteUnit [post]
+// 参数：
+//   - c: Gin 上下文
+func DeleteUnit(c *gin.Context) {
+	req := new(dto.UnitIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	if err := service.DeleteUnit(c, req); err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "出租单元删除成功"))
+}
+
+// ListUnits godoc
+// @Summary      搜索出租单元
+// @Description  按房源、城市、状态、租金区间、房间数、面积分页搜索出租单元
+// @Tags         房源
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ListUnitRequest  true  "搜索出租单元请求参数"
+// @Success      200     {object}   vo.Result{data=vo.PageVO{list=[]property.UnitVO}}  "搜索出租单元成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /property/listUnits [post]
+// 参数：
+//   - c: Gin 上下文
+func ListUnits(c *gin.Context) {
+	req := new(dto.ListUnitRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.ListUnits(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
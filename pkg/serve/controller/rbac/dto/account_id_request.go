@@ -0,0 +1,9 @@
+// Package dto 提供角色权限相关的数据传输对象定义
+package dto
+
+// AccountIDRequest  账户 ID 请求体
+// @Description	按账户 ID 查询角色与权限
+// @Param			account_id	body	int	true	"账户 ID"
+type AccountIDRequest struct {
+	AccountID int64 `json:"account_id" xml:"account_id" form:"account_id" query:"account_id" validate:"required"`
+}
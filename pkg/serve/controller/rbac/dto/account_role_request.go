@@ -0,0 +1,11 @@
+// Package dto 提供角色权限相关的数据传输对象定义
+package dto
+
+// AccountRoleRequest  授予或撤销角色请求体
+// @Description	管理员为账户授予或撤销角色
+// @Param			account_id	body	int		true	"账户 ID"
+// @Param			role_code	body	string	true	"角色编码: tenant, landlord, property_manager, admin"
+type AccountRoleRequest struct {
+	AccountID int64  `json:"account_id" xml:"account_id" form:"account_id" query:"account_id" validate:"required"`
+	RoleCode  string `json:"role_code" xml:"role_code" form:"role_code" query:"role_code" validate:"required,max=32"`
+}
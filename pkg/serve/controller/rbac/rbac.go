@@ -0,0 +1,151 @@
+// Package rbac 提供角色权限管理相关的HTTP接口处理
+package rbac
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/rbac/dto"
+	service "lease/pkg/serve/service/rbac"
+	"lease/pkg/vo"
+)
+
+// ListRoles godoc
+// @Summary      角色列表
+// @Description  管理员查询全部角色及其权限编码
+// @Tags         角色权限
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]rbac.RoleVO}  "查询角色成功"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /rbac/listRoles [post]
+// 参数：
+//   - c: Gin 上下文
+func ListRoles(c *gin.Context) {
+	response, err := service.ListRoles(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetAccountRoles godoc
+// @Summary      查询账户角色
+// @Description  管理员查询账户拥有的角色及汇总后的权限
+// @Tags         角色权限
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AccountIDRequest  true  "账户 ID 请求参数"
+// @Success      200     {object}   vo.Result{data=rbac.AccountRoleVO}  "查询账户角色成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /rbac/getAccountRoles [post]
+// 参数：
+//   - c: Gin 上下文
+func GetAccountRoles(c *gin.Context) {
+	req := new(dto.AccountIDRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GetAccountRoles(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GrantRole godoc
+// @Summary      授予角色
+// @Description  管理员为账户授予角色，账户须重新登录后生效
+// @Tags         角色权限
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AccountRoleRequest  true  "授予角色请求参数"
+// @Success      200     {object}   vo.Result{data=rbac.AccountRoleVO}  "授予角色成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /rbac/grantRole [post]
+// 参数：
+//   - c: Gin 上下文
+func GrantRole(c *gin.Context) {
+	req := new(dto.AccountRoleRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.GrantRole(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RevokeRole godoc
+// @Summary      撤销角色
+// @Description  管理员撤销账户角色，账户须重新登录后生效，组织须至少保留一名管理员
+// @Tags         角色权限
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AccountRoleRequest  true  "撤销角色请求参数"
+// @Success      200     {object}   vo.Result{data=rbac.AccountRoleVO}  "撤销角色成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "权限不足"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /rbac/revokeRole [post]
+// 参数：
+//   - c: Gin 上下文
+func RevokeRole(c *gin.Context) {
+	req := new(dto.AccountRoleRequest)
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+		return
+	}
+
+	errors := utils.Validator(*req)
+	if errors != nil {
+		c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+		return
+	}
+
+	response, err := service.RevokeRole(c, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, response))
+}
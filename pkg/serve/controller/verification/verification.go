@@ -5,19 +5,22 @@ package verification
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 
-	bizErr "jank.com/jank_blog/internal/error"
-	"jank.com/jank_blog/internal/global"
-	"jank.com/jank_blog/internal/utils"
-	"jank.com/jank_blog/pkg/vo"
-	"jank.com/jank_blog/pkg/vo/verification"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	"lease/internal/sms"
+	"lease/internal/utils"
+	"lease/pkg/vo"
+	"lease/pkg/vo/verification"
 )
 
 const (
@@ -25,6 +28,11 @@ const (
 	EMAIL_VERIFICATION_CODE_CACHE_EXPIRATION = 3 * time.Minute                // 邮箱验证码缓存过期时间
 	IMG_VERIFICATION_CODE_CACHE_PREFIX       = "IMG:VERIFICATION:CODE:CACHE:" // 图形验证码缓存前缀
 	IMG_VERIFICATION_CODE_CACHE_EXPIRATION   = 3 * time.Minute                // 图形验证码缓存过期时间
+	PHONE_VERIFICATION_CODE_CACHE_KEY_PREFIX = "PHONE:VERIFICATION:CODE:"     // 短信验证码缓存前缀，完整键为前缀加 E.164 格式手机号
+	PHONE_VERIFICATION_CODE_CACHE_EXPIRATION = 3 * time.Minute                // 短信验证码缓存过期时间
+	PHONE_VERIFICATION_LIMIT_IP_PREFIX       = "PHONE:VERIFICATION:LIMIT:IP:" // 短信验证码 IP 发送计数前缀
+	PHONE_VERIFICATION_LIMIT_WINDOW          = time.Hour                      // 短信验证码 IP 发送计数窗口
+	PHONE_VERIFICATION_IP_LIMIT              = 10                             // 同一 IP 在计数窗口内允许发送的短信数
 )
 
 // SendImgVerificationCode godoc
@@ -38,11 +46,12 @@ const (
 // @Failure      400   {object} vo.Result{data=string} "请求参数错误，邮箱地址为空"
 // @Failure      500   {object} vo.Result{data=string} "服务器错误，生成验证码失败"
 // @Router       /verification/sendImgVerificationCode [get]
-func SendImgVerificationCode(c echo.Context) error {
-	email := c.QueryParam("email")
+func SendImgVerificationCode(c *gin.Context) {
+	email := c.Query("email")
 	if email == "" {
 		utils.BizLogger(c).Errorf("请求参数错误，邮箱地址为空")
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, "请求参数错误，邮箱地址为空", bizErr.New(bizErr.BAD_REQUEST)))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, "请求参数错误，邮箱地址为空", bizErr.New(bizErr.BAD_REQUEST)))
+		return
 	}
 
 	key := IMG_VERIFICATION_CODE_CACHE_PREFIX + email
@@ -51,16 +60,18 @@ func SendImgVerificationCode(c echo.Context) error {
 	imgBase64, answer, err := utils.GenImgVerificationCode()
 	if err != nil {
 		utils.BizLogger(c).Errorf("生成图片验证码失败: %v", err)
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
 	}
 
 	err = global.RedisClient.Set(context.Background(), key, answer, IMG_VERIFICATION_CODE_CACHE_EXPIRATION).Err()
 	if err != nil {
 		utils.BizLogger(c).Errorf("图形验证码写入缓存失败，key: %v, 错误: %v", key, err)
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, verification.ImgVerificationVO{ImgBase64: imgBase64}))
+	c.JSON(http.StatusOK, vo.Success(c, verification.ImgVerificationVO{ImgBase64: imgBase64}))
 }
 
 // SendEmailVerificationCode godoc
@@ -74,16 +85,18 @@ func SendImgVerificationCode(c echo.Context) error {
 // @Failure 400 {object} vo.Result "请求参数错误，邮箱地址为空"
 // @Failure 500 {object} vo.Result "服务器错误，邮箱验证码发送失败"
 // @Router /verification/sendEmailVerificationCode [get]
-func SendEmailVerificationCode(c echo.Context) error {
-	email := c.QueryParam("email")
+func SendEmailVerificationCode(c *gin.Context) {
+	email := c.Query("email")
 	if email == "" {
 		utils.BizLogger(c).Errorf("请求参数错误，邮箱地址为空")
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, "请求参数错误，邮箱地址为空", bizErr.New(bizErr.BAD_REQUEST)))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, "请求参数错误，邮箱地址为空", bizErr.New(bizErr.BAD_REQUEST)))
+		return
 	}
 
 	if !utils.ValidEmail(email) {
 		utils.BizLogger(c).Errorf("邮箱格式无效: %s", email)
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, "邮箱格式无效", bizErr.New(bizErr.BAD_REQUEST)))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, "邮箱格式无效", bizErr.New(bizErr.BAD_REQUEST)))
+		return
 	}
 
 	key := EMAIL_VERIFICATION_CODE_CACHE_KEY_PREFIX + email
@@ -92,10 +105,12 @@ func SendEmailVerificationCode(c echo.Context) error {
 	exists, err := global.RedisClient.Exists(context.Background(), key).Result()
 	if err != nil {
 		utils.BizLogger(c).Errorf("检查邮箱验证码是否有效失败: %v", err)
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
 	}
 	if exists > 0 {
-		return c.JSON(http.StatusBadRequest, vo.Fail(c, "邮箱验证码已存在", bizErr.New(bizErr.SERVER_ERR)))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, "邮箱验证码已存在", bizErr.New(bizErr.SERVER_ERR)))
+		return
 	}
 
 	// 生成并缓存验证码
@@ -103,7 +118,8 @@ func SendEmailVerificationCode(c echo.Context) error {
 	err = global.RedisClient.Set(context.Background(), key, strconv.Itoa(code), EMAIL_VERIFICATION_CODE_CACHE_EXPIRATION).Err()
 	if err != nil {
 		utils.BizLogger(c).Errorf("邮箱验证码写入缓存失败: %v", err)
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
 	}
 
 	// 发送验证码邮件
@@ -113,51 +129,138 @@ func SendEmailVerificationCode(c echo.Context) error {
 	if !success {
 		utils.BizLogger(c).Errorf("邮箱验证码发送失败，邮箱地址: %s, 错误: %v", email, err)
 		global.RedisClient.Del(context.Background(), key)
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SEND_EMAIL_VERIFICATION_CODE_FAIL)))
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SEND_EMAIL_VERIFICATION_CODE_FAIL)))
+		return
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(c, "邮箱验证码发送成功, 请注意查收！"))
+	c.JSON(http.StatusOK, vo.Success(c, "邮箱验证码发送成功, 请注意查收！"))
+}
+
+// SendPhoneVerificationCode godoc
+// @Summary 发送短信验证码
+// @Description 向指定手机号发送验证码，用于绑定手机号与手机号登录，验证码有效期为3分钟，有效期内不重复发送
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param phone query string true "手机号，未带国家码时按默认国家码补全"
+// @Success 200 {object} vo.Result "短信验证码发送成功"
+// @Failure 400 {object} vo.Result "请求参数错误，手机号为空或格式无效"
+// @Failure 429 {object} vo.Result "短信发送过于频繁"
+// @Failure 500 {object} vo.Result "服务器错误，短信验证码发送失败"
+// @Router /verification/sendPhoneVerificationCode [get]
+func SendPhoneVerificationCode(c *gin.Context) {
+	phone, err := sms.NormalizePhone(c.Query("phone"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("手机号格式无效: %s", c.Query("phone"))
+		c.JSON(http.StatusBadRequest, vo.Fail(c, "手机号格式无效", bizErr.New(bizErr.PHONE_INVALID)))
+		return
+	}
+
+	ctx := c.Request.Context()
+	key := PHONE_VERIFICATION_CODE_CACHE_KEY_PREFIX + phone
+
+	// 检查验证码是否存在
+	exists, err := global.RedisClient.Exists(ctx, key).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("检查短信验证码是否有效失败: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
+	}
+	if exists > 0 {
+		c.JSON(http.StatusTooManyRequests, vo.Fail(c, "短信验证码已发送，请稍后再试", bizErr.New(bizErr.SMS_TOO_FREQUENT)))
+		return
+	}
+
+	// 短信按条计费，限制同一 IP 的发送量
+	limitKey := PHONE_VERIFICATION_LIMIT_IP_PREFIX + c.ClientIP()
+	count, err := global.RedisClient.Incr(ctx, limitKey).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录短信发送次数失败: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
+	}
+	if count == 1 {
+		global.RedisClient.Expire(ctx, limitKey, PHONE_VERIFICATION_LIMIT_WINDOW)
+	}
+	if count > PHONE_VERIFICATION_IP_LIMIT {
+		utils.BizLogger(c).Warnf("短信发送过于频繁: %s", limitKey)
+		c.JSON(http.StatusTooManyRequests, vo.Fail(c, "短信发送过于频繁，请稍后再试", bizErr.New(bizErr.SMS_TOO_FREQUENT)))
+		return
+	}
+
+	// 生成并缓存验证码
+	code := utils.NewRand()
+	err = global.RedisClient.Set(ctx, key, strconv.Itoa(code), PHONE_VERIFICATION_CODE_CACHE_EXPIRATION).Err()
+	if err != nil {
+		utils.BizLogger(c).Errorf("短信验证码写入缓存失败: %v", err)
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+		return
+	}
+
+	// 发送验证码短信
+	expirationInMinutes := int(PHONE_VERIFICATION_CODE_CACHE_EXPIRATION.Round(time.Minute).Minutes())
+	content := fmt.Sprintf("您的验证码是: %d , 有效期为 %d 分钟，请勿泄露给他人。", code, expirationInMinutes)
+	if err := sms.Send(ctx, phone, content); err != nil {
+		utils.BizLogger(c).Errorf("短信验证码发送失败，手机号: %s, 错误: %v", phone, err)
+		global.RedisClient.Del(ctx, key)
+		c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SEND_SMS_VERIFICATION_CODE_FAIL)))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.Success(c, "短信验证码发送成功, 请注意查收！"))
 }
 
 // VerifyEmailCode 校验邮箱验证码
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - code: 验证码
 //   - email: 邮箱地址
 //
 // 返回值：
 //   - bool: 验证成功返回 true，失败返回 false
-func VerifyEmailCode(c echo.Context, code, email string) bool {
+func VerifyEmailCode(c *gin.Context, code, email string) bool {
 	return verifyCode(c, code, email, EMAIL_VERIFICATION_CODE_CACHE_KEY_PREFIX)
 }
 
 // VerifyImgCode 校验图形验证码
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - code: 验证码
 //   - email: 邮箱地址
 //
 // 返回值：
 //   - bool: 验证成功返回 true，失败返回 false
-func VerifyImgCode(c echo.Context, code, email string) bool {
+func VerifyImgCode(c *gin.Context, code, email string) bool {
 	return verifyCode(c, code, email, IMG_VERIFICATION_CODE_CACHE_PREFIX)
 }
 
+// VerifyPhoneCode 校验短信验证码
+// 参数：
+//   - c: Gin 上下文
+//   - code: 验证码
+//   - phone: E.164 格式手机号
+//
+// 返回值：
+//   - bool: 验证成功返回 true，失败返回 false
+func VerifyPhoneCode(c *gin.Context, code, phone string) bool {
+	return verifyCode(c, code, phone, PHONE_VERIFICATION_CODE_CACHE_KEY_PREFIX)
+}
+
 // verifyCode 通用验证码校验
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - code: 验证码
 //   - email: 邮箱地址
 //   - prefix: 缓存键前缀
 //
 // 返回值：
 //   - bool: 验证成功返回 true，失败返回 false
-func verifyCode(c echo.Context, code, email, prefix string) bool {
+func verifyCode(c *gin.Context, code, email, prefix string) bool {
 	key := prefix + email
 
-	storedCode, err := global.RedisClient.Get(c.Request().Context(), key).Result()
+	storedCode, err := global.RedisClient.Get(c.Request.Context(), key).Result()
 	if err != nil {
-		if err.Error() == "redis: nil" {
+		if errors.Is(err, redis.Nil) {
 			utils.BizLogger(c).Error("验证码不存在或已过期")
 		} else {
 			utils.BizLogger(c).Errorf("验证码校验失败: %v", err)
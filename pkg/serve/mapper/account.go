@@ -0,0 +1,209 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+)
+
+// CountAccountsByOrganizationID 统计组织内未删除的账户数量
+// 参数：
+//   - c: Gin 上下文
+//   - organizationID: 组织 ID
+//
+// 返回值：
+//   - int64: 账户数量
+//   - error: 操作过程中的错误
+func CountAccountsByOrganizationID(c *gin.Context, organizationID int64) (int64, error) {
+	var count int64
+	if err := utils.GetDBFromContext(c).Model(&model.Account{}).
+		Where("organization_id = ? AND deleted = ?", organizationID, false).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计组织账户数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// ListAccountsByOrganizationID 分页查询组织内的账户
+// 参数：
+//   - c: Gin 上下文
+//   - organizationID: 组织 ID
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.Account: 账户列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListAccountsByOrganizationID(c *gin.Context, organizationID int64, pageNo, pageSize int) ([]*model.Account, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.Account{}).Where("organization_id = ? AND deleted = ?", organizationID, false)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计组织账户数量失败: %w", err)
+	}
+
+	var accounts []*model.Account
+	if err := query.Order("gmt_create ASC").Scopes(paginate(pageNo, pageSize)).Find(&accounts).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询组织账户列表失败: %w", err)
+	}
+	return accounts, total, nil
+}
+
+// GetAccountByEmail 根据邮箱获取账户
+// 参数：
+//   - c: Gin 上下文
+//   - email: 邮箱
+//
+// 返回值：
+//   - *model.Account: 账户信息
+//   - error: 操作过程中的错误
+func GetAccountByEmail(c *gin.Context, email string) (*model.Account, error) {
+	var acc model.Account
+	if err := utils.GetDBFromContext(c).Where("email = ? AND deleted = ?", email, false).First(&acc).Error; err != nil {
+		return nil, fmt.Errorf("获取账户失败: %w", err)
+	}
+	return &acc, nil
+}
+
+// GetAccountByAccountID 根据账户 ID 获取账户
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - *model.Account: 账户信息
+//   - error: 操作过程中的错误
+func GetAccountByAccountID(c *gin.Context, accountID int64) (*model.Account, error) {
+	var acc model.Account
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", accountID, false).First(&acc).Error; err != nil {
+		return nil, fmt.Errorf("获取账户失败: %w", err)
+	}
+	return &acc, nil
+}
+
+// GetAccountByPhone 根据 E.164 格式手机号获取账户
+// 参数：
+//   - c: Gin 上下文
+//   - phone: 手机号
+//
+// 返回值：
+//   - *model.Account: 账户信息
+//   - error: 操作过程中的错误
+func GetAccountByPhone(c *gin.Context, phone string) (*model.Account, error) {
+	var acc model.Account
+	if err := utils.GetDBFromContext(c).Where("phone = ? AND deleted = ?", phone, false).First(&acc).Error; err != nil {
+		return nil, fmt.Errorf("获取账户失败: %w", err)
+	}
+	return &acc, nil
+}
+
+// CreateAccount 创建账户
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccount(c *gin.Context, acc *model.Account) error {
+	if err := utils.GetDBFromContext(c).Create(acc).Error; err != nil {
+		return fmt.Errorf("创建账户失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateAccount 更新账户
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateAccount(c *gin.Context, acc *model.Account) error {
+	db := utils.GetDBFromContext(c)
+	// 未绑定手机号的账户保持 NULL，避免多个空字符串触发唯一约束
+	if acc.Phone == "" {
+		db = db.Omit("phone")
+	}
+	if err := db.Save(acc).Error; err != nil {
+		return fmt.Errorf("更新账户失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateAccountColumns 按列更新账户，值为 nil 的列写入 NULL
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - columns: 列名与新值
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateAccountColumns(c *gin.Context, accountID int64, columns map[string]interface{}) error {
+	if err := utils.GetDBFromContext(c).Model(&model.Account{}).
+		Where("id = ? AND deleted = ?", accountID, false).Updates(columns).Error; err != nil {
+		return fmt.Errorf("更新账户失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateAccountStatus 账户处于指定状态之一时将其改为目标状态，用于并发安全的状态流转
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - from: 允许流转的当前状态
+//   - to: 目标状态
+//
+// 返回值：
+//   - bool: 状态已流转返回 true，当前状态不在 from 中返回 false
+//   - error: 操作过程中的错误
+func UpdateAccountStatus(c *gin.Context, accountID int64, from []string, to string) (bool, error) {
+	result := utils.GetDBFromContext(c).Model(&model.Account{}).
+		Where("id = ? AND deleted = ? AND status IN ?", accountID, false, from).
+		Updates(map[string]interface{}{"status": to, "gmt_modified": time.Now().Unix()})
+	if result.Error != nil {
+		return false, fmt.Errorf("更新账户状态失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ExistsAccountByPhone 判断手机号是否已被其他账户使用；手机号唯一约束覆盖全部组织及已删除账户，查询时同样不加限定
+// 参数：
+//   - c: Gin 上下文
+//   - phone: 手机号
+//   - excludeAccountID: 排除的账户 ID
+//
+// 返回值：
+//   - bool: 已被使用返回 true
+//   - error: 操作过程中的错误
+func ExistsAccountByPhone(c *gin.Context, phone string, excludeAccountID int64) (bool, error) {
+	var count int64
+	if err := utils.GetDBFromContext(c).WithContext(context.Background()).Model(&model.Account{}).
+		Where("phone = ? AND id <> ?", phone, excludeAccountID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("查询手机号失败: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListAccountsDueForDeletion 查询注销宽限期已届满、尚未匿名化的账户
+// 参数：
+//   - c: Gin 上下文
+//   - now: 当前时间戳
+//
+// 返回值：
+//   - []*model.Account: 账户列表
+//   - error: 操作过程中的错误
+func ListAccountsDueForDeletion(c *gin.Context, now int64) ([]*model.Account, error) {
+	var accounts []*model.Account
+	if err := utils.GetDBFromContext(c).
+		Where("deletion_scheduled_at > ? AND deletion_scheduled_at <= ? AND deleted = ?", 0, now, false).
+		Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("查询待注销账户失败: %w", err)
+	}
+	return accounts, nil
+}
@@ -0,0 +1,124 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+)
+
+// GetAccountIdentityByProviderSubject 按身份提供方与用户标识获取第三方身份；外部身份在全部组织内唯一，查询时不加组织限定
+// 参数：
+//   - c: Gin 上下文
+//   - provider: 身份提供方标识
+//   - subject: 身份提供方的用户标识
+//
+// 返回值：
+//   - *model.AccountIdentity: 第三方身份
+//   - error: 操作过程中的错误
+func GetAccountIdentityByProviderSubject(c *gin.Context, provider, subject string) (*model.AccountIdentity, error) {
+	var identity model.AccountIdentity
+	if err := utils.GetDBFromContext(c).WithContext(context.Background()).
+		Where("provider = ? AND subject = ? AND deleted = ?", provider, subject, false).First(&identity).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方身份失败: %w", err)
+	}
+	return &identity, nil
+}
+
+// GetAccountIdentityByAccountProvider 获取账户在指定身份提供方的关联身份
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - provider: 身份提供方标识
+//
+// 返回值：
+//   - *model.AccountIdentity: 第三方身份
+//   - error: 操作过程中的错误
+func GetAccountIdentityByAccountProvider(c *gin.Context, accountID int64, provider string) (*model.AccountIdentity, error) {
+	var identity model.AccountIdentity
+	if err := utils.GetDBFromContext(c).
+		Where("account_id = ? AND provider = ? AND deleted = ?", accountID, provider, false).First(&identity).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方身份失败: %w", err)
+	}
+	return &identity, nil
+}
+
+// ListAccountIdentitiesByAccountID 获取账户关联的全部第三方身份
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []*model.AccountIdentity: 第三方身份列表
+//   - error: 操作过程中的错误
+func ListAccountIdentitiesByAccountID(c *gin.Context, accountID int64) ([]*model.AccountIdentity, error) {
+	var identities []*model.AccountIdentity
+	if err := utils.GetDBFromContext(c).
+		Where("account_id = ? AND deleted = ?", accountID, false).Order("id ASC").Find(&identities).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方身份列表失败: %w", err)
+	}
+	return identities, nil
+}
+
+// CreateAccountIdentity 创建第三方身份
+// 参数：
+//   - c: Gin 上下文
+//   - identity: 第三方身份
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccountIdentity(c *gin.Context, identity *model.AccountIdentity) error {
+	if err := utils.GetDBFromContext(c).Create(identity).Error; err != nil {
+		return fmt.Errorf("创建第三方身份失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateAccountIdentityLastLogin 记录通过第三方身份登录的时间；登录时尚未确定组织，更新时不加组织限定
+// 参数：
+//   - c: Gin 上下文
+//   - identity: 第三方身份
+//   - loginAt: 登录时间
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateAccountIdentityLastLogin(c *gin.Context, identity *model.AccountIdentity, loginAt int64) error {
+	if err := utils.GetDBFromContext(c).WithContext(context.Background()).Model(identity).
+		Update("last_login_at", loginAt).Error; err != nil {
+		return fmt.Errorf("记录第三方身份登录时间失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccountIdentity 删除第三方身份；(provider, subject) 为唯一索引，物理删除以便外部身份重新关联
+// 参数：
+//   - c: Gin 上下文
+//   - identity: 第三方身份
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountIdentity(c *gin.Context, identity *model.AccountIdentity) error {
+	if err := utils.GetDBFromContext(c).Delete(identity).Error; err != nil {
+		return fmt.Errorf("删除第三方身份失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccountIdentitiesByAccountID 删除账户关联的全部第三方身份，与 DeleteAccountIdentity 一样物理删除
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountIdentitiesByAccountID(c *gin.Context, accountID int64) error {
+	if err := utils.GetDBFromContext(c).
+		Where("account_id = ?", accountID).Delete(&model.AccountIdentity{}).Error; err != nil {
+		return fmt.Errorf("删除第三方身份失败: %w", err)
+	}
+	return nil
+}
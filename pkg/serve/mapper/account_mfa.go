@@ -0,0 +1,146 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+)
+
+// GetAccountMFAByAccountID 获取账户的两步验证配置
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - *model.AccountMFA: 两步验证配置
+//   - error: 操作过程中的错误
+func GetAccountMFAByAccountID(c *gin.Context, accountID int64) (*model.AccountMFA, error) {
+	var mfa model.AccountMFA
+	if err := utils.GetDBFromContext(c).Where("account_id = ? AND deleted = ?", accountID, false).First(&mfa).Error; err != nil {
+		return nil, fmt.Errorf("获取两步验证配置失败: %w", err)
+	}
+	return &mfa, nil
+}
+
+// SaveAccountMFA 创建或更新账户的两步验证配置
+// 参数：
+//   - c: Gin 上下文
+//   - mfa: 两步验证配置
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func SaveAccountMFA(c *gin.Context, mfa *model.AccountMFA) error {
+	if err := utils.GetDBFromContext(c).Save(mfa).Error; err != nil {
+		return fmt.Errorf("保存两步验证配置失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateAccountMFAUsedStep 以比较并交换的方式记录通过校验的时间步，同一时间步的动态码只能使用一次
+// 参数：
+//   - c: Gin 上下文
+//   - mfa: 两步验证配置
+//   - step: 通过校验的时间步
+//
+// 返回值：
+//   - error: 时间步不晚于已记录的时间步或更新失败时返回错误
+func UpdateAccountMFAUsedStep(c *gin.Context, mfa *model.AccountMFA, step int64) error {
+	result := utils.GetDBFromContext(c).Model(mfa).
+		Where("last_used_step < ? AND deleted = ?", step, false).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return fmt.Errorf("记录动态码使用失败: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("动态码已被使用")
+	}
+	return nil
+}
+
+// CreateAccountRecoveryCodes 批量创建恢复码
+// 参数：
+//   - c: Gin 上下文
+//   - codes: 恢复码列表
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccountRecoveryCodes(c *gin.Context, codes []*model.AccountRecoveryCode) error {
+	if err := utils.GetDBFromContext(c).Create(&codes).Error; err != nil {
+		return fmt.Errorf("创建恢复码失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccountRecoveryCodesByAccountID 逻辑删除账户的全部恢复码
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountRecoveryCodesByAccountID(c *gin.Context, accountID int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.AccountRecoveryCode{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除恢复码失败: %w", err)
+	}
+	return nil
+}
+
+// CountUnusedAccountRecoveryCodes 统计账户剩余可用的恢复码数量
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - int64: 恢复码数量
+//   - error: 操作过程中的错误
+func CountUnusedAccountRecoveryCodes(c *gin.Context, accountID int64) (int64, error) {
+	var count int64
+	if err := utils.GetDBFromContext(c).Model(&model.AccountRecoveryCode{}).
+		Where("account_id = ? AND used_at = ? AND deleted = ?", accountID, 0, false).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计恢复码数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// UseAccountRecoveryCode 核销一个未使用的恢复码
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - codeHash: 恢复码哈希
+//   - usedAt: 使用时间
+//
+// 返回值：
+//   - error: 恢复码不存在、已使用或更新失败时返回错误
+func UseAccountRecoveryCode(c *gin.Context, accountID int64, codeHash string, usedAt int64) error {
+	result := utils.GetDBFromContext(c).Model(&model.AccountRecoveryCode{}).
+		Where("account_id = ? AND code_hash = ? AND used_at = ? AND deleted = ?", accountID, codeHash, 0, false).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return fmt.Errorf("核销恢复码失败: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("恢复码无效或已使用")
+	}
+	return nil
+}
+
+// DeleteAccountMFAByAccountID 逻辑删除账户的两步验证配置并清空密钥
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountMFAByAccountID(c *gin.Context, accountID int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.AccountMFA{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Updates(map[string]interface{}{"secret": "", "enabled": false, "deleted": true}).Error; err != nil {
+		return fmt.Errorf("删除两步验证配置失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+)
+
+// CreateAccountPasswordHistory 记录一次密码设置
+// 参数：
+//   - c: Gin 上下文
+//   - history: 密码历史
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccountPasswordHistory(c *gin.Context, history *model.AccountPasswordHistory) error {
+	if err := utils.GetDBFromContext(c).Create(history).Error; err != nil {
+		return fmt.Errorf("记录密码历史失败: %w", err)
+	}
+	return nil
+}
+
+// ListRecentAccountPasswordHistories 查询账户最近的密码历史，按设置时间倒序（雪花 ID 随时间递增）
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - limit: 查询条数
+//
+// 返回值：
+//   - []*model.AccountPasswordHistory: 密码历史列表
+//   - error: 操作过程中的错误
+func ListRecentAccountPasswordHistories(c *gin.Context, accountID int64, limit int) ([]*model.AccountPasswordHistory, error) {
+	var histories []*model.AccountPasswordHistory
+	if err := utils.GetDBFromContext(c).Where("account_id = ? AND deleted = ?", accountID, false).
+		Order("id DESC").Limit(limit).Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("查询密码历史失败: %w", err)
+	}
+	return histories, nil
+}
+
+// DeleteAccountPasswordHistoriesExcept 逻辑删除账户除指定记录外的密码历史，用于只保留最近 N 条
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - keepIDs: 保留的记录 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountPasswordHistoriesExcept(c *gin.Context, accountID int64, keepIDs []int64) error {
+	query := utils.GetDBFromContext(c).Model(&model.AccountPasswordHistory{}).Where("account_id = ? AND deleted = ?", accountID, false)
+	if len(keepIDs) > 0 {
+		query = query.Where("id NOT IN ?", keepIDs)
+	}
+	if err := query.Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("清理密码历史失败: %w", err)
+	}
+	return nil
+}
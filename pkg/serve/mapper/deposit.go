@@ -0,0 +1,150 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+
+	model "lease/internal/model/deposit"
+	"lease/internal/utils"
+)
+
+// CreateDepositSettlement 创建押金结算单
+// 参数：
+//   - c: Gin 上下文
+//   - settlement: 结算单信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateDepositSettlement(c *gin.Context, settlement *model.DepositSettlement) error {
+	if err := utils.GetDBFromContext(c).Create(settlement).Error; err != nil {
+		return fmt.Errorf("创建押金结算单失败: %w", err)
+	}
+	return nil
+}
+
+// GetDepositSettlementByID 根据 ID 获取押金结算单
+// 参数：
+//   - c: Gin 上下文
+//   - id: 结算单 ID
+//
+// 返回值：
+//   - *model.DepositSettlement: 结算单信息
+//   - error: 操作过程中的错误
+func GetDepositSettlementByID(c *gin.Context, id int64) (*model.DepositSettlement, error) {
+	var settlement model.DepositSettlement
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&settlement).Error; err != nil {
+		return nil, fmt.Errorf("获取押金结算单失败: %w", err)
+	}
+	return &settlement, nil
+}
+
+// LockDepositSettlementByID 在事务中以 SELECT ... FOR UPDATE 锁定押金结算单，SQLite 忽略锁定子句
+// 参数：
+//   - c: Gin 上下文
+//   - id: 结算单 ID
+//
+// 返回值：
+//   - *model.DepositSettlement: 结算单信息
+//   - error: 操作过程中的错误
+func LockDepositSettlementByID(c *gin.Context, id int64) (*model.DepositSettlement, error) {
+	var settlement model.DepositSettlement
+	if err := utils.GetDBFromContext(c).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ? AND deleted = ?", id, false).First(&settlement).Error; err != nil {
+		return nil, fmt.Errorf("锁定押金结算单失败: %w", err)
+	}
+	return &settlement, nil
+}
+
+// GetDepositSettlementByContractID 获取合同的押金结算单
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - *model.DepositSettlement: 结算单信息
+//   - error: 操作过程中的错误
+func GetDepositSettlementByContractID(c *gin.Context, contractID int64) (*model.DepositSettlement, error) {
+	var settlement model.DepositSettlement
+	if err := utils.GetDBFromContext(c).Where("contract_id = ? AND deleted = ?", contractID, false).First(&settlement).Error; err != nil {
+		return nil, fmt.Errorf("获取押金结算单失败: %w", err)
+	}
+	return &settlement, nil
+}
+
+// UpdateDepositSettlement 更新押金结算单
+// 参数：
+//   - c: Gin 上下文
+//   - settlement: 结算单信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateDepositSettlement(c *gin.Context, settlement *model.DepositSettlement) error {
+	if err := utils.GetDBFromContext(c).Save(settlement).Error; err != nil {
+		return fmt.Errorf("更新押金结算单失败: %w", err)
+	}
+	return nil
+}
+
+// CreateDepositDeduction 创建押金扣款明细
+// 参数：
+//   - c: Gin 上下文
+//   - deduction: 扣款明细
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateDepositDeduction(c *gin.Context, deduction *model.DepositDeduction) error {
+	if err := utils.GetDBFromContext(c).Create(deduction).Error; err != nil {
+		return fmt.Errorf("创建押金扣款明细失败: %w", err)
+	}
+	return nil
+}
+
+// GetDepositDeductionByID 根据 ID 获取押金扣款明细
+// 参数：
+//   - c: Gin 上下文
+//   - id: 扣款明细 ID
+//
+// 返回值：
+//   - *model.DepositDeduction: 扣款明细
+//   - error: 操作过程中的错误
+func GetDepositDeductionByID(c *gin.Context, id int64) (*model.DepositDeduction, error) {
+	var deduction model.DepositDeduction
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&deduction).Error; err != nil {
+		return nil, fmt.Errorf("获取押金扣款明细失败: %w", err)
+	}
+	return &deduction, nil
+}
+
+// DeleteDepositDeductionByID 逻辑删除押金扣款明细
+// 参数：
+//   - c: Gin 上下文
+//   - id: 扣款明细 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteDepositDeductionByID(c *gin.Context, id int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.DepositDeduction{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除押金扣款明细失败: %w", err)
+	}
+	return nil
+}
+
+// GetDepositDeductionsBySettlementID 获取结算单的全部扣款明细
+// 参数：
+//   - c: Gin 上下文
+//   - settlementID: 结算单 ID
+//
+// 返回值：
+//   - []*model.DepositDeduction: 扣款明细列表
+//   - error: 操作过程中的错误
+func GetDepositDeductionsBySettlementID(c *gin.Context, settlementID int64) ([]*model.DepositDeduction, error) {
+	var deductions []*model.DepositDeduction
+	if err := utils.GetDBFromContext(c).Where("settlement_id = ? AND deleted = ?", settlementID, false).
+		Order("id ASC").Find(&deductions).Error; err != nil {
+		return nil, fmt.Errorf("查询押金扣款明细失败: %w", err)
+	}
+	return deductions, nil
+}
@@ -0,0 +1,221 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	model "lease/internal/model/invoice"
+	"lease/internal/utils"
+)
+
+// InvoiceFilter 账单查询条件，零值字段不参与过滤
+type InvoiceFilter struct {
+	LandlordID int64  // 业主账户 ID
+	TenantID   int64  // 租客账户 ID
+	ContractID int64  // 合同 ID
+	Status     string // 账单状态
+}
+
+// CreateInvoice 创建账单及其明细
+// 参数：
+//   - c: Gin 上下文
+//   - invoice: 账单信息
+//   - items: 账单明细，InvoiceID 由本方法回填
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateInvoice(c *gin.Context, invoice *model.Invoice, items []*model.InvoiceItem) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(invoice).Error; err != nil {
+		return fmt.Errorf("创建账单失败: %w", err)
+	}
+
+	for _, item := range items {
+		item.InvoiceID = invoice.ID
+	}
+	if len(items) > 0 {
+		if err := db.Create(&items).Error; err != nil {
+			return fmt.Errorf("创建账单明细失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetInvoiceByID 根据 ID 获取账单
+// 参数：
+//   - c: Gin 上下文
+//   - id: 账单 ID
+//
+// 返回值：
+//   - *model.Invoice: 账单信息
+//   - error: 操作过程中的错误
+func GetInvoiceByID(c *gin.Context, id int64) (*model.Invoice, error) {
+	var invoice model.Invoice
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&invoice).Error; err != nil {
+		return nil, fmt.Errorf("获取账单失败: %w", err)
+	}
+	return &invoice, nil
+}
+
+// GetInvoicedPeriodStarts 获取合同已出账的计费周期开始日期集合
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - map[string]int64: 周期开始日期到账单 ID 的映射
+//   - error: 操作过程中的错误
+func GetInvoicedPeriodStarts(c *gin.Context, contractID int64) (map[string]int64, error) {
+	var invoices []*model.Invoice
+	if err := utils.GetDBFromContext(c).Select("id", "period_start").
+		Where("contract_id = ? AND deleted = ?", contractID, false).Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("查询合同账单失败: %w", err)
+	}
+
+	periods := make(map[string]int64, len(invoices))
+	for _, invoice := range invoices {
+		periods[invoice.PeriodStart] = invoice.ID
+	}
+	return periods, nil
+}
+
+// GetOpenInvoicesByContractID 获取合同未结清的账单，按计费周期正序
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - []*model.Invoice: 账单列表
+//   - error: 操作过程中的错误
+func GetOpenInvoicesByContractID(c *gin.Context, contractID int64) ([]*model.Invoice, error) {
+	var invoices []*model.Invoice
+	if err := utils.GetDBFromContext(c).
+		Where("contract_id = ? AND status IN ? AND deleted = ?", contractID,
+			[]string{model.INVOICE_STATUS_UNPAID, model.INVOICE_STATUS_PARTIALLY_PAID}, false).
+		Order("period_start ASC").Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("查询未结清账单失败: %w", err)
+	}
+	return invoices, nil
+}
+
+// SumInvoiceOutstanding 汇总未结清账单的待付金额
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件，Status 字段不生效
+//
+// 返回值：
+//   - int64: 待付金额合计（分）
+//   - error: 操作过程中的错误
+func SumInvoiceOutstanding(c *gin.Context, filter InvoiceFilter) (int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.Invoice{}).
+		Where("status IN ? AND deleted = ?", []string{model.INVOICE_STATUS_UNPAID, model.INVOICE_STATUS_PARTIALLY_PAID}, false)
+	if filter.LandlordID != 0 {
+		query = query.Where("landlord_id = ?", filter.LandlordID)
+	}
+	if filter.TenantID != 0 {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.ContractID != 0 {
+		query = query.Where("contract_id = ?", filter.ContractID)
+	}
+
+	var outstanding int64
+	if err := query.Select("COALESCE(SUM(amount - paid_amount), 0)").Scan(&outstanding).Error; err != nil {
+		return 0, fmt.Errorf("汇总账单待付金额失败: %w", err)
+	}
+	return outstanding, nil
+}
+
+// ApplyInvoicePayment 以比较并交换的方式累加账单已付金额并更新状态，已付金额须仍为读取时的值，防止并发核销重复计入
+// 参数：
+//   - c: Gin 上下文
+//   - invoice: 读取时的账单，更新成功后回写已付金额与状态
+//   - amount: 本次核销金额（分）
+//
+// 返回值：
+//   - error: 账单已被其他请求修改或更新失败时返回错误
+func ApplyInvoicePayment(c *gin.Context, invoice *model.Invoice, amount int64) error {
+	paidAmount := invoice.PaidAmount + amount
+	status := model.INVOICE_STATUS_PARTIALLY_PAID
+	if paidAmount == invoice.Amount {
+		status = model.INVOICE_STATUS_PAID
+	}
+
+	result := utils.GetDBFromContext(c).Model(&model.Invoice{}).
+		Where("id = ? AND paid_amount = ? AND status IN ? AND deleted = ?", invoice.ID, invoice.PaidAmount,
+			[]string{model.INVOICE_STATUS_UNPAID, model.INVOICE_STATUS_PARTIALLY_PAID}, false).
+		Updates(map[string]interface{}{
+			"paid_amount":  gorm.Expr("paid_amount + ?", amount),
+			"status":       status,
+			"gmt_modified": time.Now().Unix(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("更新账单已付金额失败: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("账单已被其他操作更新，请刷新后重试")
+	}
+
+	invoice.PaidAmount = paidAmount
+	invoice.Status = status
+	return nil
+}
+
+// GetInvoiceItemsByInvoiceID 获取账单明细
+// 参数：
+//   - c: Gin 上下文
+//   - invoiceID: 账单 ID
+//
+// 返回值：
+//   - []*model.InvoiceItem: 账单明细列表
+//   - error: 操作过程中的错误
+func GetInvoiceItemsByInvoiceID(c *gin.Context, invoiceID int64) ([]*model.InvoiceItem, error) {
+	var items []*model.InvoiceItem
+	if err := utils.GetDBFromContext(c).Where("invoice_id = ? AND deleted = ?", invoiceID, false).
+		Order("id ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("查询账单明细失败: %w", err)
+	}
+	return items, nil
+}
+
+// ListInvoices 分页查询账单，按计费周期倒序
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.Invoice: 账单列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListInvoices(c *gin.Context, filter InvoiceFilter, pageNo, pageSize int) ([]*model.Invoice, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.Invoice{}).Where("deleted = ?", false)
+	if filter.LandlordID != 0 {
+		query = query.Where("landlord_id = ?", filter.LandlordID)
+	}
+	if filter.TenantID != 0 {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.ContractID != 0 {
+		query = query.Where("contract_id = ?", filter.ContractID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计账单数量失败: %w", err)
+	}
+
+	var invoices []*model.Invoice
+	if err := query.Order("period_start DESC").Scopes(paginate(pageNo, pageSize)).Find(&invoices).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询账单列表失败: %w", err)
+	}
+	return invoices, total, nil
+}
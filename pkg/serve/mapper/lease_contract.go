@@ -0,0 +1,269 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+
+	model "lease/internal/model/lease"
+	"lease/internal/utils"
+)
+
+// LeaseContractFilter 合同查询条件，零值字段不参与过滤
+type LeaseContractFilter struct {
+	LandlordID int64  // 业主账户 ID
+	TenantID   int64  // 租客账户 ID
+	UnitID     int64  // 出租单元 ID
+	Status     string // 合同状态
+}
+
+// CreateLeaseContract 创建合同
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateLeaseContract(c *gin.Context, contract *model.LeaseContract) error {
+	if err := utils.GetDBFromContext(c).Create(contract).Error; err != nil {
+		return fmt.Errorf("创建合同失败: %w", err)
+	}
+	return nil
+}
+
+// GetLeaseContractByID 根据 ID 获取合同
+// 参数：
+//   - c: Gin 上下文
+//   - id: 合同 ID
+//
+// 返回值：
+//   - *model.LeaseContract: 合同信息
+//   - error: 操作过程中的错误
+func GetLeaseContractByID(c *gin.Context, id int64) (*model.LeaseContract, error) {
+	var contract model.LeaseContract
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&contract).Error; err != nil {
+		return nil, fmt.Errorf("获取合同失败: %w", err)
+	}
+	return &contract, nil
+}
+
+// LockLeaseContractByID 在事务中以 SELECT ... FOR UPDATE 锁定合同行，用于串行化同一合同下的记账；
+// SQLite 不支持行锁，忽略锁定子句，其写事务本身互斥
+// 参数：
+//   - c: Gin 上下文
+//   - id: 合同 ID
+//
+// 返回值：
+//   - *model.LeaseContract: 合同信息
+//   - error: 操作过程中的错误
+func LockLeaseContractByID(c *gin.Context, id int64) (*model.LeaseContract, error) {
+	var contract model.LeaseContract
+	if err := utils.GetDBFromContext(c).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ? AND deleted = ?", id, false).First(&contract).Error; err != nil {
+		return nil, fmt.Errorf("锁定合同失败: %w", err)
+	}
+	return &contract, nil
+}
+
+// UpdateLeaseContract 更新合同
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateLeaseContract(c *gin.Context, contract *model.LeaseContract) error {
+	if err := utils.GetDBFromContext(c).Save(contract).Error; err != nil {
+		return fmt.Errorf("更新合同失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateLeaseContractStatus 以比较并交换的方式更新合同状态，防止并发流转
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 已修改状态及相关字段的合同
+//   - fromStatus: 期望的当前状态
+//
+// 返回值：
+//   - error: 状态已被其他请求修改或更新失败时返回错误
+func UpdateLeaseContractStatus(c *gin.Context, contract *model.LeaseContract, fromStatus string) error {
+	result := utils.GetDBFromContext(c).Model(contract).
+		Where("status = ? AND deleted = ?", fromStatus, false).
+		Select("status", "signed_at", "terminated_at", "termination_reason", "gmt_modified").
+		Updates(contract)
+	if result.Error != nil {
+		return fmt.Errorf("更新合同状态失败: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("合同状态已变更，请刷新后重试")
+	}
+	return nil
+}
+
+// CountOverlappingLeaseContracts 统计出租单元在指定日期区间内与之重叠的合同数量
+// 参数：
+//   - c: Gin 上下文
+//   - unitID: 出租单元 ID
+//   - startDate: 起始日期
+//   - endDate: 结束日期
+//   - statuses: 参与比较的合同状态
+//   - excludeIDs: 需要排除的合同 ID
+//
+// 返回值：
+//   - int64: 重叠合同数量
+//   - error: 操作过程中的错误
+func CountOverlappingLeaseContracts(c *gin.Context, unitID int64, startDate, endDate string, statuses []string, excludeIDs ...int64) (int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.LeaseContract{}).
+		Where("unit_id = ? AND deleted = ? AND status IN ?", unitID, false, statuses).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计重叠合同失败: %w", err)
+	}
+	return count, nil
+}
+
+// GetLeaseContractsByStatus 获取指定状态的全部合同
+// 参数：
+//   - c: Gin 上下文
+//   - status: 合同状态
+//
+// 返回值：
+//   - []*model.LeaseContract: 合同列表
+//   - error: 操作过程中的错误
+func GetLeaseContractsByStatus(c *gin.Context, status string) ([]*model.LeaseContract, error) {
+	var contracts []*model.LeaseContract
+	if err := utils.GetDBFromContext(c).Where("status = ? AND deleted = ?", status, false).Find(&contracts).Error; err != nil {
+		return nil, fmt.Errorf("查询合同失败: %w", err)
+	}
+	return contracts, nil
+}
+
+// GetLeaseContractsByLandlordAndStatuses 获取业主名下处于指定状态之一的全部合同
+// 参数：
+//   - c: Gin 上下文
+//   - landlordID: 业主账户 ID
+//   - statuses: 合同状态列表
+//
+// 返回值：
+//   - []*model.LeaseContract: 合同列表
+//   - error: 操作过程中的错误
+func GetLeaseContractsByLandlordAndStatuses(c *gin.Context, landlordID int64, statuses []string) ([]*model.LeaseContract, error) {
+	var contracts []*model.LeaseContract
+	if err := utils.GetDBFromContext(c).Where("landlord_id = ? AND status IN ? AND deleted = ?", landlordID, statuses, false).
+		Find(&contracts).Error; err != nil {
+		return nil, fmt.Errorf("查询合同失败: %w", err)
+	}
+	return contracts, nil
+}
+
+// GetRenewalLeaseContract 获取由指定合同续约产生且尚未结束的合同
+// 参数：
+//   - c: Gin 上下文
+//   - renewedFromID: 原合同 ID
+//
+// 返回值：
+//   - *model.LeaseContract: 续约合同
+//   - error: 操作过程中的错误
+func GetRenewalLeaseContract(c *gin.Context, renewedFromID int64) (*model.LeaseContract, error) {
+	var contract model.LeaseContract
+	if err := utils.GetDBFromContext(c).
+		Where("renewed_from_id = ? AND status IN ? AND deleted = ?", renewedFromID,
+			[]string{model.CONTRACT_STATUS_DRAFT, model.CONTRACT_STATUS_PENDING_SIGNATURE}, false).
+		First(&contract).Error; err != nil {
+		return nil, fmt.Errorf("获取续约合同失败: %w", err)
+	}
+	return &contract, nil
+}
+
+// ListLeaseContracts 分页查询合同
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.LeaseContract: 合同列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListLeaseContracts(c *gin.Context, filter LeaseContractFilter, pageNo, pageSize int) ([]*model.LeaseContract, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.LeaseContract{}).Where("deleted = ?", false)
+	if filter.LandlordID != 0 {
+		query = query.Where("landlord_id = ?", filter.LandlordID)
+	}
+	if filter.TenantID != 0 {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.UnitID != 0 {
+		query = query.Where("unit_id = ?", filter.UnitID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计合同数量失败: %w", err)
+	}
+
+	var contracts []*model.LeaseContract
+	if err := query.Order("gmt_create DESC").Scopes(paginate(pageNo, pageSize)).Find(&contracts).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询合同列表失败: %w", err)
+	}
+	return contracts, total, nil
+}
+
+// CreateLeaseContractAudit 写入合同状态流转审计记录
+// 参数：
+//   - c: Gin 上下文
+//   - audit: 审计记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateLeaseContractAudit(c *gin.Context, audit *model.LeaseContractAudit) error {
+	if err := utils.GetDBFromContext(c).Create(audit).Error; err != nil {
+		return fmt.Errorf("写入合同审计记录失败: %w", err)
+	}
+	return nil
+}
+
+// GetLeaseContractAuditsByContractID 获取合同的全部审计记录，按时间正序
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - []*model.LeaseContractAudit: 审计记录列表
+//   - error: 操作过程中的错误
+func GetLeaseContractAuditsByContractID(c *gin.Context, contractID int64) ([]*model.LeaseContractAudit, error) {
+	var audits []*model.LeaseContractAudit
+	if err := utils.GetDBFromContext(c).Where("contract_id = ?", contractID).Order("id ASC").Find(&audits).Error; err != nil {
+		return nil, fmt.Errorf("查询合同审计记录失败: %w", err)
+	}
+	return audits, nil
+}
+
+// GetLeaseContractsByAccountID 获取账户以租客或业主身份参与的全部合同，按创建时间排序
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []*model.LeaseContract: 合同列表
+//   - error: 操作过程中的错误
+func GetLeaseContractsByAccountID(c *gin.Context, accountID int64) ([]*model.LeaseContract, error) {
+	var contracts []*model.LeaseContract
+	if err := utils.GetDBFromContext(c).Where("(tenant_id = ? OR landlord_id = ?) AND deleted = ?", accountID, accountID, false).
+		Order("gmt_create ASC, id ASC").Find(&contracts).Error; err != nil {
+		return nil, fmt.Errorf("查询合同失败: %w", err)
+	}
+	return contracts, nil
+}
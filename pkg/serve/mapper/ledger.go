@@ -0,0 +1,145 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	model "lease/internal/model/ledger"
+	"lease/internal/utils"
+)
+
+// LedgerFilter 账本查询条件，零值字段不参与过滤
+type LedgerFilter struct {
+	LandlordID int64    // 业主账户 ID
+	TenantID   int64    // 租客账户 ID
+	ContractID int64    // 合同 ID
+	Accounts   []string // 会计科目
+}
+
+// LedgerBalance 科目余额汇总
+type LedgerBalance struct {
+	Account string // 会计科目
+	Debit   int64  // 借方合计（分）
+	Credit  int64  // 贷方合计（分）
+}
+
+// CreateLedgerTransaction 写入账本凭证及其分录
+// 参数：
+//   - c: Gin 上下文
+//   - txn: 凭证信息
+//   - entries: 分录列表，TransactionID 由本方法回填
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateLedgerTransaction(c *gin.Context, txn *model.LedgerTransaction, entries []*model.LedgerEntry) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(txn).Error; err != nil {
+		return fmt.Errorf("写入账本凭证失败: %w", err)
+	}
+
+	for _, entry := range entries {
+		entry.TransactionID = txn.ID
+	}
+	if err := db.Create(&entries).Error; err != nil {
+		return fmt.Errorf("写入账本分录失败: %w", err)
+	}
+	return nil
+}
+
+// GetLedgerBalances 按科目汇总借贷金额
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//
+// 返回值：
+//   - map[string]*LedgerBalance: 科目到余额汇总的映射
+//   - error: 操作过程中的错误
+func GetLedgerBalances(c *gin.Context, filter LedgerFilter) (map[string]*LedgerBalance, error) {
+	var rows []struct {
+		Account   string
+		Direction string
+		Total     int64
+	}
+	if err := ledgerEntryQuery(c, filter).
+		Select("account, direction, COALESCE(SUM(amount), 0) AS total").
+		Group("account, direction").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("汇总账本余额失败: %w", err)
+	}
+
+	balances := make(map[string]*LedgerBalance)
+	for _, row := range rows {
+		balance, ok := balances[row.Account]
+		if !ok {
+			balance = &LedgerBalance{Account: row.Account}
+			balances[row.Account] = balance
+		}
+		if row.Direction == model.ENTRY_DIRECTION_DEBIT {
+			balance.Debit += row.Total
+		} else {
+			balance.Credit += row.Total
+		}
+	}
+	return balances, nil
+}
+
+// GetLedgerEntries 按入账顺序获取分录
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//
+// 返回值：
+//   - []*model.LedgerEntry: 分录列表
+//   - error: 操作过程中的错误
+func GetLedgerEntries(c *gin.Context, filter LedgerFilter) ([]*model.LedgerEntry, error) {
+	var entries []*model.LedgerEntry
+	if err := ledgerEntryQuery(c, filter).Order("id ASC").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("查询账本分录失败: %w", err)
+	}
+	return entries, nil
+}
+
+// GetLedgerTransactionsByIDs 批量获取账本凭证
+// 参数：
+//   - c: Gin 上下文
+//   - ids: 凭证 ID 列表
+//
+// 返回值：
+//   - []*model.LedgerTransaction: 凭证列表，按入账顺序
+//   - error: 操作过程中的错误
+func GetLedgerTransactionsByIDs(c *gin.Context, ids []int64) ([]*model.LedgerTransaction, error) {
+	var txns []*model.LedgerTransaction
+	if len(ids) == 0 {
+		return txns, nil
+	}
+	if err := utils.GetDBFromContext(c).Where("id IN ?", ids).Order("id ASC").Find(&txns).Error; err != nil {
+		return nil, fmt.Errorf("查询账本凭证失败: %w", err)
+	}
+	return txns, nil
+}
+
+// ledgerEntryQuery 根据查询条件构造分录查询
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//
+// 返回值：
+//   - *gorm.DB: 分录查询
+func ledgerEntryQuery(c *gin.Context, filter LedgerFilter) *gorm.DB {
+	query := utils.GetDBFromContext(c).Model(&model.LedgerEntry{}).Where("deleted = ?", false)
+	if filter.LandlordID != 0 {
+		query = query.Where("landlord_id = ?", filter.LandlordID)
+	}
+	if filter.TenantID != 0 {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.ContractID != 0 {
+		query = query.Where("contract_id = ?", filter.ContractID)
+	}
+	if len(filter.Accounts) > 0 {
+		query = query.Where("account IN ?", filter.Accounts)
+	}
+	return query
+}
@@ -0,0 +1,240 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/maintenance"
+	"lease/internal/utils"
+)
+
+// MaintenanceTicketFilter 报修工单查询条件，零值字段不参与过滤
+type MaintenanceTicketFilter struct {
+	LandlordID int64  // 业主账户 ID
+	TenantID   int64  // 租客账户 ID
+	AssigneeID int64  // 维修人员账户 ID
+	UnitID     int64  // 出租单元 ID
+	Status     string // 工单状态
+	Priority   string // 优先级
+}
+
+// CreateMaintenanceTicket 创建报修工单
+// 参数：
+//   - c: Gin 上下文
+//   - ticket: 工单信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateMaintenanceTicket(c *gin.Context, ticket *model.MaintenanceTicket) error {
+	if err := utils.GetDBFromContext(c).Create(ticket).Error; err != nil {
+		return fmt.Errorf("创建报修工单失败: %w", err)
+	}
+	return nil
+}
+
+// GetMaintenanceTicketByID 根据 ID 获取报修工单
+// 参数：
+//   - c: Gin 上下文
+//   - id: 工单 ID
+//
+// 返回值：
+//   - *model.MaintenanceTicket: 工单信息
+//   - error: 操作过程中的错误
+func GetMaintenanceTicketByID(c *gin.Context, id int64) (*model.MaintenanceTicket, error) {
+	var ticket model.MaintenanceTicket
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&ticket).Error; err != nil {
+		return nil, fmt.Errorf("获取报修工单失败: %w", err)
+	}
+	return &ticket, nil
+}
+
+// UpdateMaintenanceTicketStatus 以比较并交换的方式更新工单状态及处理信息，防止并发流转
+// 参数：
+//   - c: Gin 上下文
+//   - ticket: 已修改状态及相关字段的工单
+//   - fromStatus: 期望的当前状态
+//
+// 返回值：
+//   - error: 状态已被其他请求修改或更新失败时返回错误
+func UpdateMaintenanceTicketStatus(c *gin.Context, ticket *model.MaintenanceTicket, fromStatus string) error {
+	result := utils.GetDBFromContext(c).Model(ticket).
+		Where("status = ? AND deleted = ?", fromStatus, false).
+		Select("status", "assignee_id", "scheduled_at", "resolved_at", "closed_at", "cost", "resolution", "gmt_modified").
+		Updates(ticket)
+	if result.Error != nil {
+		return fmt.Errorf("更新报修工单状态失败: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("报修工单状态已变更，请刷新后重试")
+	}
+	return nil
+}
+
+// ListMaintenanceTickets 分页查询报修工单，按优先级与创建时间倒序
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.MaintenanceTicket: 工单列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListMaintenanceTickets(c *gin.Context, filter MaintenanceTicketFilter, pageNo, pageSize int) ([]*model.MaintenanceTicket, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.MaintenanceTicket{}).Where("deleted = ?", false)
+	if filter.LandlordID != 0 {
+		query = query.Where("landlord_id = ?", filter.LandlordID)
+	}
+	if filter.TenantID != 0 {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.AssigneeID != 0 {
+		query = query.Where("assignee_id = ?", filter.AssigneeID)
+	}
+	if filter.UnitID != 0 {
+		query = query.Where("unit_id = ?", filter.UnitID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Priority != "" {
+		query = query.Where("priority = ?", filter.Priority)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计报修工单数量失败: %w", err)
+	}
+
+	var tickets []*model.MaintenanceTicket
+	priorityOrder := fmt.Sprintf("CASE priority WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 ELSE 3 END",
+		model.TICKET_PRIORITY_URGENT, model.TICKET_PRIORITY_HIGH, model.TICKET_PRIORITY_MEDIUM)
+	if err := query.Order(priorityOrder).Order("gmt_create DESC").
+		Scopes(paginate(pageNo, pageSize)).Find(&tickets).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询报修工单列表失败: %w", err)
+	}
+	return tickets, total, nil
+}
+
+// CreateMaintenanceComment 创建工单评论
+// 参数：
+//   - c: Gin 上下文
+//   - comment: 评论信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateMaintenanceComment(c *gin.Context, comment *model.MaintenanceComment) error {
+	if err := utils.GetDBFromContext(c).Create(comment).Error; err != nil {
+		return fmt.Errorf("创建工单评论失败: %w", err)
+	}
+	return nil
+}
+
+// GetMaintenanceCommentsByTicketID 获取工单的全部评论，按时间正序
+// 参数：
+//   - c: Gin 上下文
+//   - ticketID: 工单 ID
+//
+// 返回值：
+//   - []*model.MaintenanceComment: 评论列表
+//   - error: 操作过程中的错误
+func GetMaintenanceCommentsByTicketID(c *gin.Context, ticketID int64) ([]*model.MaintenanceComment, error) {
+	var comments []*model.MaintenanceComment
+	if err := utils.GetDBFromContext(c).Where("ticket_id = ? AND deleted = ?", ticketID, false).
+		Order("id ASC").Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("查询工单评论失败: %w", err)
+	}
+	return comments, nil
+}
+
+// CreateMaintenanceTicketEvent 写入工单状态流转记录
+// 参数：
+//   - c: Gin 上下文
+//   - event: 流转记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateMaintenanceTicketEvent(c *gin.Context, event *model.MaintenanceTicketEvent) error {
+	if err := utils.GetDBFromContext(c).Create(event).Error; err != nil {
+		return fmt.Errorf("写入工单流转记录失败: %w", err)
+	}
+	return nil
+}
+
+// GetMaintenanceTicketEventsByTicketID 获取工单的全部状态流转记录，按时间正序
+// 参数：
+//   - c: Gin 上下文
+//   - ticketID: 工单 ID
+//
+// 返回值：
+//   - []*model.MaintenanceTicketEvent: 流转记录列表
+//   - error: 操作过程中的错误
+func GetMaintenanceTicketEventsByTicketID(c *gin.Context, ticketID int64) ([]*model.MaintenanceTicketEvent, error) {
+	var events []*model.MaintenanceTicketEvent
+	if err := utils.GetDBFromContext(c).Where("ticket_id = ?", ticketID).Order("id ASC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("查询工单流转记录失败: %w", err)
+	}
+	return events, nil
+}
+
+// GetMaintenanceTicketsByAccountID 获取账户作为报修人、租客或维修人员参与的全部工单，按创建时间排序
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []*model.MaintenanceTicket: 工单列表
+//   - error: 操作过程中的错误
+func GetMaintenanceTicketsByAccountID(c *gin.Context, accountID int64) ([]*model.MaintenanceTicket, error) {
+	var tickets []*model.MaintenanceTicket
+	if err := utils.GetDBFromContext(c).
+		Where("(reporter_id = ? OR tenant_id = ? OR assignee_id = ?) AND deleted = ?", accountID, accountID, accountID, false).
+		Order("gmt_create ASC, id ASC").Find(&tickets).Error; err != nil {
+		return nil, fmt.Errorf("查询报修工单失败: %w", err)
+	}
+	return tickets, nil
+}
+
+// GetMaintenanceCommentsByAuthorID 获取账户发表的全部工单评论
+// 参数：
+//   - c: Gin 上下文
+//   - authorID: 评论人账户 ID
+//
+// 返回值：
+//   - []*model.MaintenanceComment: 评论列表
+//   - error: 操作过程中的错误
+func GetMaintenanceCommentsByAuthorID(c *gin.Context, authorID int64) ([]*model.MaintenanceComment, error) {
+	var comments []*model.MaintenanceComment
+	if err := utils.GetDBFromContext(c).Where("author_id = ? AND deleted = ?", authorID, false).
+		Order("id ASC").Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("查询工单评论失败: %w", err)
+	}
+	return comments, nil
+}
+
+// RedactMaintenanceContentByAccountID 将账户在报修工单中留下的自由文本替换为占位内容：其报修的工单描述及发表的评论；
+// 工单标题、状态、费用等维修记录及只增不改的流转记录保持不变
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - placeholder: 占位内容
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RedactMaintenanceContentByAccountID(c *gin.Context, accountID int64, placeholder string) error {
+	db := utils.GetDBFromContext(c)
+	now := time.Now().Unix()
+	if err := db.Model(&model.MaintenanceTicket{}).Where("reporter_id = ? AND description <> ''", accountID).
+		Updates(map[string]interface{}{"description": placeholder, "gmt_modified": now}).Error; err != nil {
+		return fmt.Errorf("清除报修工单描述失败: %w", err)
+	}
+	if err := db.Model(&model.MaintenanceComment{}).Where("author_id = ?", accountID).
+		Updates(map[string]interface{}{"content": placeholder, "gmt_modified": now}).Error; err != nil {
+		return fmt.Errorf("清除工单评论失败: %w", err)
+	}
+	return nil
+}
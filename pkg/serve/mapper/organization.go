@@ -0,0 +1,173 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/organization"
+	"lease/internal/utils"
+)
+
+// CreateOrganization 创建组织
+// 参数：
+//   - c: Gin 上下文
+//   - organization: 组织信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateOrganization(c *gin.Context, organization *model.Organization) error {
+	if err := utils.GetDBFromContext(c).Create(organization).Error; err != nil {
+		return fmt.Errorf("创建组织失败: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizationByID 根据 ID 获取组织
+// 参数：
+//   - c: Gin 上下文
+//   - id: 组织 ID
+//
+// 返回值：
+//   - *model.Organization: 组织信息
+//   - error: 操作过程中的错误
+func GetOrganizationByID(c *gin.Context, id int64) (*model.Organization, error) {
+	var organization model.Organization
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&organization).Error; err != nil {
+		return nil, fmt.Errorf("获取组织失败: %w", err)
+	}
+	return &organization, nil
+}
+
+// UpdateOrganization 更新组织
+// 参数：
+//   - c: Gin 上下文
+//   - organization: 组织信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateOrganization(c *gin.Context, organization *model.Organization) error {
+	if err := utils.GetDBFromContext(c).Save(organization).Error; err != nil {
+		return fmt.Errorf("更新组织失败: %w", err)
+	}
+	return nil
+}
+
+// CreateOrganizationInvitation 创建组织邀请
+// 参数：
+//   - c: Gin 上下文
+//   - invitation: 邀请信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateOrganizationInvitation(c *gin.Context, invitation *model.OrganizationInvitation) error {
+	if err := utils.GetDBFromContext(c).Create(invitation).Error; err != nil {
+		return fmt.Errorf("创建组织邀请失败: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizationInvitationByID 根据 ID 获取组织邀请
+// 参数：
+//   - c: Gin 上下文
+//   - id: 邀请 ID
+//
+// 返回值：
+//   - *model.OrganizationInvitation: 邀请信息
+//   - error: 操作过程中的错误
+func GetOrganizationInvitationByID(c *gin.Context, id int64) (*model.OrganizationInvitation, error) {
+	var invitation model.OrganizationInvitation
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&invitation).Error; err != nil {
+		return nil, fmt.Errorf("获取组织邀请失败: %w", err)
+	}
+	return &invitation, nil
+}
+
+// GetOrganizationInvitationByCode 根据邀请码获取组织邀请
+// 参数：
+//   - c: Gin 上下文
+//   - code: 邀请码
+//
+// 返回值：
+//   - *model.OrganizationInvitation: 邀请信息
+//   - error: 操作过程中的错误
+func GetOrganizationInvitationByCode(c *gin.Context, code string) (*model.OrganizationInvitation, error) {
+	var invitation model.OrganizationInvitation
+	if err := utils.GetDBFromContext(c).Where("code = ? AND deleted = ?", code, false).First(&invitation).Error; err != nil {
+		return nil, fmt.Errorf("获取组织邀请失败: %w", err)
+	}
+	return &invitation, nil
+}
+
+// UpdateOrganizationInvitationStatus 以比较并交换的方式更新邀请状态，防止邀请码被重复使用
+// 参数：
+//   - c: Gin 上下文
+//   - invitation: 已修改状态及相关字段的邀请
+//   - fromStatus: 期望的当前状态
+//
+// 返回值：
+//   - error: 状态已被其他请求修改或更新失败时返回错误
+func UpdateOrganizationInvitationStatus(c *gin.Context, invitation *model.OrganizationInvitation, fromStatus string) error {
+	result := utils.GetDBFromContext(c).Model(invitation).
+		Where("status = ? AND deleted = ?", fromStatus, false).
+		Select("status", "accepted_by", "accepted_at", "gmt_modified").
+		Updates(invitation)
+	if result.Error != nil {
+		return fmt.Errorf("更新组织邀请失败: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("邀请状态已变更，请刷新后重试")
+	}
+	return nil
+}
+
+// CountPendingOrganizationInvitations 统计组织内尚未过期的待接受邀请数量
+// 参数：
+//   - c: Gin 上下文
+//   - organizationID: 组织 ID
+//   - now: 当前时间戳
+//
+// 返回值：
+//   - int64: 邀请数量
+//   - error: 操作过程中的错误
+func CountPendingOrganizationInvitations(c *gin.Context, organizationID, now int64) (int64, error) {
+	var count int64
+	if err := utils.GetDBFromContext(c).Model(&model.OrganizationInvitation{}).
+		Where("organization_id = ? AND status = ? AND expires_at > ? AND deleted = ?", organizationID, model.INVITATION_STATUS_PENDING, now, false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计组织邀请数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// ListOrganizationInvitations 分页查询组织邀请
+// 参数：
+//   - c: Gin 上下文
+//   - organizationID: 组织 ID
+//   - status: 邀请状态，为空时不过滤
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.OrganizationInvitation: 邀请列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListOrganizationInvitations(c *gin.Context, organizationID int64, status string, pageNo, pageSize int) ([]*model.OrganizationInvitation, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.OrganizationInvitation{}).
+		Where("organization_id = ? AND deleted = ?", organizationID, false)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计组织邀请数量失败: %w", err)
+	}
+
+	var invitations []*model.OrganizationInvitation
+	if err := query.Order("gmt_create DESC").Scopes(paginate(pageNo, pageSize)).Find(&invitations).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询组织邀请列表失败: %w", err)
+	}
+	return invitations, total, nil
+}
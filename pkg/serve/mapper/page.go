@@ -0,0 +1,20 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import "gorm.io/gorm"
+
+// paginate 生成分页查询作用域
+// 参数：
+//   - pageNo: 页码，从 1 开始
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - func(*gorm.DB) *gorm.DB: GORM 作用域函数
+func paginate(pageNo, pageSize int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if pageNo < 1 {
+			pageNo = 1
+		}
+		return db.Offset((pageNo - 1) * pageSize).Limit(pageSize)
+	}
+}
@@ -0,0 +1,84 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/ledger"
+	"lease/internal/utils"
+)
+
+// PaymentFilter 收款查询条件，零值字段不参与过滤
+type PaymentFilter struct {
+	LandlordID int64 // 业主账户 ID
+	TenantID   int64 // 租客账户 ID
+	ContractID int64 // 合同 ID
+}
+
+// CreatePayment 创建收款记录
+// 参数：
+//   - c: Gin 上下文
+//   - payment: 收款信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreatePayment(c *gin.Context, payment *model.Payment) error {
+	if err := utils.GetDBFromContext(c).Create(payment).Error; err != nil {
+		return fmt.Errorf("创建收款记录失败: %w", err)
+	}
+	return nil
+}
+
+// ListPayments 分页查询收款记录，按收款日期倒序
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 查询条件
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.Payment: 收款列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListPayments(c *gin.Context, filter PaymentFilter, pageNo, pageSize int) ([]*model.Payment, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.Payment{}).Where("deleted = ?", false)
+	if filter.LandlordID != 0 {
+		query = query.Where("landlord_id = ?", filter.LandlordID)
+	}
+	if filter.TenantID != 0 {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.ContractID != 0 {
+		query = query.Where("contract_id = ?", filter.ContractID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计收款数量失败: %w", err)
+	}
+
+	var payments []*model.Payment
+	if err := query.Order("paid_date DESC, id DESC").Scopes(paginate(pageNo, pageSize)).Find(&payments).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询收款列表失败: %w", err)
+	}
+	return payments, total, nil
+}
+
+// GetPaymentsByAccountID 获取账户以租客或业主身份关联的全部收款记录，按收款日期排序
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []*model.Payment: 收款列表
+//   - error: 操作过程中的错误
+func GetPaymentsByAccountID(c *gin.Context, accountID int64) ([]*model.Payment, error) {
+	var payments []*model.Payment
+	if err := utils.GetDBFromContext(c).Where("(tenant_id = ? OR landlord_id = ?) AND deleted = ?", accountID, accountID, false).
+		Order("paid_date ASC, id ASC").Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("查询收款记录失败: %w", err)
+	}
+	return payments, nil
+}
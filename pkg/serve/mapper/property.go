@@ -0,0 +1,116 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/property"
+	"lease/internal/utils"
+)
+
+// PropertyFilter 房源搜索条件，零值字段不参与过滤
+type PropertyFilter struct {
+	Keyword  string // 名称或地址关键字
+	City     string // 城市
+	District string // 区县
+	OwnerID  int64  // 业主账户 ID
+}
+
+// CreateProperty 创建房源
+// 参数：
+//   - c: Gin 上下文
+//   - property: 房源信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateProperty(c *gin.Context, property *model.Property) error {
+	if err := utils.GetDBFromContext(c).Create(property).Error; err != nil {
+		return fmt.Errorf("创建房源失败: %w", err)
+	}
+	return nil
+}
+
+// GetPropertyByID 根据 ID 获取房源
+// 参数：
+//   - c: Gin 上下文
+//   - id: 房源 ID
+//
+// 返回值：
+//   - *model.Property: 房源信息
+//   - error: 操作过程中的错误
+func GetPropertyByID(c *gin.Context, id int64) (*model.Property, error) {
+	var property model.Property
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&property).Error; err != nil {
+		return nil, fmt.Errorf("获取房源失败: %w", err)
+	}
+	return &property, nil
+}
+
+// UpdateProperty 更新房源
+// 参数：
+//   - c: Gin 上下文
+//   - property: 房源信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateProperty(c *gin.Context, property *model.Property) error {
+	if err := utils.GetDBFromContext(c).Save(property).Error; err != nil {
+		return fmt.Errorf("更新房源失败: %w", err)
+	}
+	return nil
+}
+
+// DeletePropertyByID 逻辑删除房源
+// 参数：
+//   - c: Gin 上下文
+//   - id: 房源 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeletePropertyByID(c *gin.Context, id int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.Property{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除房源失败: %w", err)
+	}
+	return nil
+}
+
+// ListProperties 分页搜索房源
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 搜索条件
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.Property: 房源列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListProperties(c *gin.Context, filter PropertyFilter, pageNo, pageSize int) ([]*model.Property, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.Property{}).Where("deleted = ?", false)
+	if filter.Keyword != "" {
+		like := "%" + filter.Keyword + "%"
+		query = query.Where("name LIKE ? OR address LIKE ?", like, like)
+	}
+	if filter.City != "" {
+		query = query.Where("city = ?", filter.City)
+	}
+	if filter.District != "" {
+		query = query.Where("district = ?", filter.District)
+	}
+	if filter.OwnerID != 0 {
+		query = query.Where("owner_id = ?", filter.OwnerID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计房源数量失败: %w", err)
+	}
+
+	var properties []*model.Property
+	if err := query.Order("gmt_create DESC").Scopes(paginate(pageNo, pageSize)).Find(&properties).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询房源列表失败: %w", err)
+	}
+	return properties, total, nil
+}
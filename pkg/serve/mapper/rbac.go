@@ -0,0 +1,182 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/rbac"
+	"lease/internal/utils"
+)
+
+// GetRoleByCode 根据编码获取角色
+// 参数：
+//   - c: Gin 上下文
+//   - code: 角色编码
+//
+// 返回值：
+//   - *model.Role: 角色信息
+//   - error: 操作过程中的错误
+func GetRoleByCode(c *gin.Context, code string) (*model.Role, error) {
+	var role model.Role
+	if err := utils.GetDBFromContext(c).Where("code = ? AND deleted = ?", code, false).First(&role).Error; err != nil {
+		return nil, fmt.Errorf("获取角色失败: %w", err)
+	}
+	return &role, nil
+}
+
+// ListRoles 获取全部角色
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - []*model.Role: 角色列表
+//   - error: 操作过程中的错误
+func ListRoles(c *gin.Context) ([]*model.Role, error) {
+	var roles []*model.Role
+	if err := utils.GetDBFromContext(c).Where("deleted = ?", false).Order("id ASC").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("查询角色列表失败: %w", err)
+	}
+	return roles, nil
+}
+
+// GetPermissionCodesByRoleID 获取角色拥有的权限编码
+// 参数：
+//   - c: Gin 上下文
+//   - roleID: 角色 ID
+//
+// 返回值：
+//   - []string: 权限编码
+//   - error: 操作过程中的错误
+func GetPermissionCodesByRoleID(c *gin.Context, roleID int64) ([]string, error) {
+	var codes []string
+	if err := utils.GetDBFromContext(c).Model(&model.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id AND role_permissions.deleted = ?", false).
+		Where("role_permissions.role_id = ? AND permissions.deleted = ?", roleID, false).
+		Order("permissions.code ASC").
+		Pluck("permissions.code", &codes).Error; err != nil {
+		return nil, fmt.Errorf("查询角色权限失败: %w", err)
+	}
+	return codes, nil
+}
+
+// GetRolesByAccountID 获取账户拥有的全部角色
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []*model.Role: 角色列表
+//   - error: 操作过程中的错误
+func GetRolesByAccountID(c *gin.Context, accountID int64) ([]*model.Role, error) {
+	var roles []*model.Role
+	if err := utils.GetDBFromContext(c).
+		Joins("JOIN account_roles ON account_roles.role_id = roles.id AND account_roles.deleted = ?", false).
+		Where("account_roles.account_id = ? AND roles.deleted = ?", accountID, false).
+		Order("roles.id ASC").
+		Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("查询账户角色失败: %w", err)
+	}
+	return roles, nil
+}
+
+// GetPermissionCodesByAccountID 获取账户通过全部角色获得的权限编码，已去重
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []string: 权限编码
+//   - error: 操作过程中的错误
+func GetPermissionCodesByAccountID(c *gin.Context, accountID int64) ([]string, error) {
+	var codes []string
+	if err := utils.GetDBFromContext(c).Model(&model.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id AND role_permissions.deleted = ?", false).
+		Joins("JOIN account_roles ON account_roles.role_id = role_permissions.role_id AND account_roles.deleted = ?", false).
+		Where("account_roles.account_id = ? AND permissions.deleted = ?", accountID, false).
+		Distinct("permissions.code").
+		Order("permissions.code ASC").
+		Pluck("permissions.code", &codes).Error; err != nil {
+		return nil, fmt.Errorf("查询账户权限失败: %w", err)
+	}
+	return codes, nil
+}
+
+// GetAccountRole 获取账户与角色的关联
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - roleID: 角色 ID
+//
+// 返回值：
+//   - *model.AccountRole: 关联信息
+//   - error: 操作过程中的错误
+func GetAccountRole(c *gin.Context, accountID, roleID int64) (*model.AccountRole, error) {
+	var accountRole model.AccountRole
+	if err := utils.GetDBFromContext(c).Where("account_id = ? AND role_id = ? AND deleted = ?", accountID, roleID, false).
+		First(&accountRole).Error; err != nil {
+		return nil, fmt.Errorf("获取账户角色失败: %w", err)
+	}
+	return &accountRole, nil
+}
+
+// CreateAccountRole 为账户授予角色
+// 参数：
+//   - c: Gin 上下文
+//   - accountRole: 关联信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccountRole(c *gin.Context, accountRole *model.AccountRole) error {
+	if err := utils.GetDBFromContext(c).Create(accountRole).Error; err != nil {
+		return fmt.Errorf("授予账户角色失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccountRoleByID 撤销账户角色；(organization_id, account_id, role_id) 为唯一索引，物理删除以便重新授予
+// 参数：
+//   - c: Gin 上下文
+//   - id: 关联 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountRoleByID(c *gin.Context, id int64) error {
+	if err := utils.GetDBFromContext(c).Where("id = ?", id).Delete(&model.AccountRole{}).Error; err != nil {
+		return fmt.Errorf("撤销账户角色失败: %w", err)
+	}
+	return nil
+}
+
+// CountAccountsByRoleID 统计拥有指定角色的账户数量
+// 参数：
+//   - c: Gin 上下文
+//   - roleID: 角色 ID
+//
+// 返回值：
+//   - int64: 账户数量
+//   - error: 操作过程中的错误
+func CountAccountsByRoleID(c *gin.Context, roleID int64) (int64, error) {
+	var count int64
+	if err := utils.GetDBFromContext(c).Model(&model.AccountRole{}).
+		Where("role_id = ? AND deleted = ?", roleID, false).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计角色账户数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteAccountRolesByAccountID 撤销账户的全部角色，与 DeleteAccountRoleByID 一样物理删除
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccountRolesByAccountID(c *gin.Context, accountID int64) error {
+	if err := utils.GetDBFromContext(c).
+		Where("account_id = ?", accountID).Delete(&model.AccountRole{}).Error; err != nil {
+		return fmt.Errorf("撤销账户角色失败: %w", err)
+	}
+	return nil
+}
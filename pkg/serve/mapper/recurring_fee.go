@@ -0,0 +1,86 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/invoice"
+	"lease/internal/utils"
+)
+
+// CreateRecurringFee 创建周期性杂费
+// 参数：
+//   - c: Gin 上下文
+//   - fee: 杂费信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateRecurringFee(c *gin.Context, fee *model.RecurringFee) error {
+	if err := utils.GetDBFromContext(c).Create(fee).Error; err != nil {
+		return fmt.Errorf("创建周期性杂费失败: %w", err)
+	}
+	return nil
+}
+
+// GetRecurringFeeByID 根据 ID 获取周期性杂费
+// 参数：
+//   - c: Gin 上下文
+//   - id: 杂费 ID
+//
+// 返回值：
+//   - *model.RecurringFee: 杂费信息
+//   - error: 操作过程中的错误
+func GetRecurringFeeByID(c *gin.Context, id int64) (*model.RecurringFee, error) {
+	var fee model.RecurringFee
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&fee).Error; err != nil {
+		return nil, fmt.Errorf("获取周期性杂费失败: %w", err)
+	}
+	return &fee, nil
+}
+
+// UpdateRecurringFee 更新周期性杂费
+// 参数：
+//   - c: Gin 上下文
+//   - fee: 杂费信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateRecurringFee(c *gin.Context, fee *model.RecurringFee) error {
+	if err := utils.GetDBFromContext(c).Save(fee).Error; err != nil {
+		return fmt.Errorf("更新周期性杂费失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteRecurringFeeByID 逻辑删除周期性杂费
+// 参数：
+//   - c: Gin 上下文
+//   - id: 杂费 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteRecurringFeeByID(c *gin.Context, id int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.RecurringFee{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除周期性杂费失败: %w", err)
+	}
+	return nil
+}
+
+// GetRecurringFeesByContractID 获取合同的全部周期性杂费
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - []*model.RecurringFee: 杂费列表
+//   - error: 操作过程中的错误
+func GetRecurringFeesByContractID(c *gin.Context, contractID int64) ([]*model.RecurringFee, error) {
+	var fees []*model.RecurringFee
+	if err := utils.GetDBFromContext(c).Where("contract_id = ? AND deleted = ?", contractID, false).
+		Order("id ASC").Find(&fees).Error; err != nil {
+		return nil, fmt.Errorf("查询周期性杂费失败: %w", err)
+	}
+	return fees, nil
+}
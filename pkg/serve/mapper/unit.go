@@ -0,0 +1,178 @@
+// Package mapper 提供数据库访问层，封装各业务模型的持久化操作
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/property"
+	"lease/internal/utils"
+)
+
+// UnitFilter 出租单元搜索条件，零值字段不参与过滤
+type UnitFilter struct {
+	PropertyID int64   // 所属房源 ID
+	City       string  // 房源所在城市
+	Status     string  // 房间状态
+	MinRent    int64   // 最低挂牌租金（分）
+	MaxRent    int64   // 最高挂牌租金（分）
+	MinRooms   int     // 最少房间数
+	MinArea    float64 // 最小面积
+}
+
+// CreateUnit 创建出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - unit: 出租单元信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateUnit(c *gin.Context, unit *model.Unit) error {
+	if err := utils.GetDBFromContext(c).Create(unit).Error; err != nil {
+		return fmt.Errorf("创建出租单元失败: %w", err)
+	}
+	return nil
+}
+
+// GetUnitByID 根据 ID 获取出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - id: 出租单元 ID
+//
+// 返回值：
+//   - *model.Unit: 出租单元信息
+//   - error: 操作过程中的错误
+func GetUnitByID(c *gin.Context, id int64) (*model.Unit, error) {
+	var unit model.Unit
+	if err := utils.GetDBFromContext(c).Where("id = ? AND deleted = ?", id, false).First(&unit).Error; err != nil {
+		return nil, fmt.Errorf("获取出租单元失败: %w", err)
+	}
+	return &unit, nil
+}
+
+// GetUnitByPropertyAndNo 根据房源与房间号获取出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - propertyID: 房源 ID
+//   - unitNo: 房间号
+//
+// 返回值：
+//   - *model.Unit: 出租单元信息
+//   - error: 操作过程中的错误
+func GetUnitByPropertyAndNo(c *gin.Context, propertyID int64, unitNo string) (*model.Unit, error) {
+	var unit model.Unit
+	if err := utils.GetDBFromContext(c).Where("property_id = ? AND unit_no = ? AND deleted = ?", propertyID, unitNo, false).First(&unit).Error; err != nil {
+		return nil, fmt.Errorf("获取出租单元失败: %w", err)
+	}
+	return &unit, nil
+}
+
+// UpdateUnit 更新出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - unit: 出租单元信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateUnit(c *gin.Context, unit *model.Unit) error {
+	if err := utils.GetDBFromContext(c).Save(unit).Error; err != nil {
+		return fmt.Errorf("更新出租单元失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteUnitByID 逻辑删除出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - id: 出租单元 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteUnitByID(c *gin.Context, id int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.Unit{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除出租单元失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteUnitsByPropertyID 逻辑删除房源下的全部出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - propertyID: 房源 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteUnitsByPropertyID(c *gin.Context, propertyID int64) error {
+	if err := utils.GetDBFromContext(c).Model(&model.Unit{}).Where("property_id = ?", propertyID).Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除房源下的出租单元失败: %w", err)
+	}
+	return nil
+}
+
+// CountUnitsByStatus 统计房源下指定状态的出租单元数量
+// 参数：
+//   - c: Gin 上下文
+//   - propertyID: 房源 ID
+//   - status: 房间状态
+//
+// 返回值：
+//   - int64: 数量
+//   - error: 操作过程中的错误
+func CountUnitsByStatus(c *gin.Context, propertyID int64, status string) (int64, error) {
+	var count int64
+	if err := utils.GetDBFromContext(c).Model(&model.Unit{}).
+		Where("property_id = ? AND status = ? AND deleted = ?", propertyID, status, false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计出租单元数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// ListUnits 分页搜索出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 搜索条件
+//   - pageNo: 页码
+//   - pageSize: 每页条数
+//
+// 返回值：
+//   - []*model.Unit: 出租单元列表
+//   - int64: 符合条件的总条数
+//   - error: 操作过程中的错误
+func ListUnits(c *gin.Context, filter UnitFilter, pageNo, pageSize int) ([]*model.Unit, int64, error) {
+	query := utils.GetDBFromContext(c).Model(&model.Unit{}).Where("units.deleted = ?", false)
+	if filter.PropertyID != 0 {
+		query = query.Where("units.property_id = ?", filter.PropertyID)
+	}
+	if filter.City != "" {
+		query = query.Joins("JOIN properties ON properties.id = units.property_id AND properties.deleted = ?", false).
+			Where("properties.city = ?", filter.City)
+	}
+	if filter.Status != "" {
+		query = query.Where("units.status = ?", filter.Status)
+	}
+	if filter.MinRent > 0 {
+		query = query.Where("units.rent_list_price >= ?", filter.MinRent)
+	}
+	if filter.MaxRent > 0 {
+		query = query.Where("units.rent_list_price <= ?", filter.MaxRent)
+	}
+	if filter.MinRooms > 0 {
+		query = query.Where("units.rooms >= ?", filter.MinRooms)
+	}
+	if filter.MinArea > 0 {
+		query = query.Where("units.area >= ?", filter.MinArea)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计出租单元数量失败: %w", err)
+	}
+
+	var units []*model.Unit
+	if err := query.Select("units.*").Order("units.gmt_create DESC").Scopes(paginate(pageNo, pageSize)).Find(&units).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询出租单元列表失败: %w", err)
+	}
+	return units, total, nil
+}
@@ -4,20 +4,25 @@
 package service
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
-	"time"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 
-	"jank.com/jank_blog/internal/global"
-	model "jank.com/jank_blog/internal/model/account"
-	"jank.com/jank_blog/internal/utils"
-	"jank.com/jank_blog/pkg/serve/controller/account/dto"
-	"jank.com/jank_blog/pkg/serve/mapper"
-	"jank.com/jank_blog/pkg/vo/account"
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/account"
+	organizationModel "lease/internal/model/organization"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+	organizationService "lease/pkg/serve/service/organization"
+	rbacService "lease/pkg/serve/service/rbac"
+	"lease/pkg/vo/account"
 )
 
 var (
@@ -26,20 +31,15 @@ var (
 	logoutLock        sync.Mutex // 用户登出锁，保护并发用户登出操作
 )
 
-const (
-	USER_CACHE             = "USER_CACHE"
-	USER_CACHE_EXPIRE_TIME = time.Hour * 2 // Access Token 有效期
-)
-
 // GetAccount 获取用户信息逻辑
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - req: 获取账户请求
 //
 // 返回值：
 //   - *account.GetAccountVO: 用户账户视图对象
 //   - error: 操作过程中的错误
-func GetAccount(c echo.Context, req *dto.GetAccountRequest) (*account.GetAccountVO, error) {
+func GetAccount(c *gin.Context, req *dto.GetAccountRequest) (*account.GetAccountVO, error) {
 	userInfo, err := mapper.GetAccountByEmail(c, req.Email)
 	if err != nil {
 		utils.BizLogger(c).Errorf("「%s」邮箱不存在", req.Email)
@@ -55,49 +55,74 @@ func GetAccount(c echo.Context, req *dto.GetAccountRequest) (*account.GetAccount
 	return vo.(*account.GetAccountVO), nil
 }
 
-// RegisterAcc 用户注册逻辑
+// RegisterAcc 用户注册逻辑，注册时已校验邮箱验证码的账户直接生效，否则账户待激活并发送激活邮件
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - req: 注册账户请求
 //
 // 返回值：
 //   - *account.RegisterAccountVO: 注册后的账户视图对象
 //   - error: 操作过程中的错误
-func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAccountVO, error) {
+func RegisterAcc(c *gin.Context, req *dto.RegisterRequest) (*account.RegisterAccountVO, error) {
 	registerLock.Lock()
 	defer registerLock.Unlock()
 
 	var registerVO *account.RegisterAccountVO
+	var pending *model.Account
 
 	err := utils.RunDBTransaction(c, func(tx error) error {
-		totalAccounts, err := mapper.GetTotalAccounts(c)
-		if err != nil {
-			utils.BizLogger(c).Errorf("获取用户总数失败: %v", err)
-			return fmt.Errorf("获取用户总数失败: %w", err)
-		}
-
-		if totalAccounts > 0 {
-			utils.BizLogger(c).Error("系统限制: 当前为单用户独立部署版本，已达到账户数量上限 (1/1)")
-			return fmt.Errorf("系统限制: 当前为单用户独立部署版本，已达到账户数量上限 (1/1)")
-		}
-
 		existingUser, _ := mapper.GetAccountByEmail(c, req.Email)
 		if existingUser != nil {
 			utils.BizLogger(c).Errorf("「%s」邮箱已被注册", req.Email)
 			return fmt.Errorf("「%s」邮箱已被注册", req.Email)
 		}
 
+		if err := checkPasswordPolicy(c, req.Password); err != nil {
+			return err
+		}
+
+		// 注册时填写的手机号仅作资料保存，通过短信验证后才可用于登录
+		var phone string
+		if strings.TrimSpace(req.Phone) != "" {
+			normalized, err := normalizePhone(c, req.Phone)
+			if err != nil {
+				return err
+			}
+			phone = normalized
+			if err := checkPhoneAvailable(c, phone, 0); err != nil {
+				return err
+			}
+		}
+
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 		if err != nil {
 			utils.BizLogger(c).Errorf("哈希加密失败: %v", err)
 			return fmt.Errorf("哈希加密失败: %w", err)
 		}
 
+		var invitation *organizationModel.OrganizationInvitation
+		if req.InvitationCode != "" {
+			invitation, err = organizationService.CheckInvitation(c, req.InvitationCode, req.Email)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 控制器已在填写邮箱验证码时完成校验
+		status := model.ACCOUNT_STATUS_ACTIVE
+		if req.EmailVerificationCode == "" {
+			status = model.ACCOUNT_STATUS_PENDING_VERIFICATION
+		}
+
 		acc := &model.Account{
 			Email:    req.Email,
 			Password: string(hashedPassword),
 			Nickname: req.Nickname,
-			Phone:    req.Phone,
+			Phone:    phone,
+			Status:   status,
+		}
+		if invitation != nil {
+			acc.OrganizationID = invitation.OrganizationID
 		}
 
 		if err := mapper.CreateAccount(c, acc); err != nil {
@@ -105,6 +130,14 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 			return fmt.Errorf("「%s」用户注册失败: %w", req.Email, err)
 		}
 
+		if err := joinOrganization(c, acc, invitation, req.OrganizationName); err != nil {
+			return err
+		}
+
+		if err := recordPasswordHistory(c, acc); err != nil {
+			return err
+		}
+
 		vo, err := utils.MapModelToVO(acc, &account.RegisterAccountVO{})
 		if err != nil {
 			utils.BizLogger(c).Errorf("用户注册时映射 VO 失败: %v", err)
@@ -112,6 +145,7 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 		}
 
 		registerVO = vo.(*account.RegisterAccountVO)
+		pending = acc
 		return nil
 	})
 
@@ -119,47 +153,175 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 		return nil, err
 	}
 
+	// 激活邮件发送失败不影响注册结果，用户可重新获取
+	if pending.Status == model.ACCOUNT_STATUS_PENDING_VERIFICATION {
+		if err := sendActivationEmail(c, pending); err != nil {
+			utils.BizLogger(c).Warnf("「%s」注册后发送激活邮件失败: %v", pending.Email, err)
+		}
+	}
+
 	return registerVO, nil
 }
 
-// LoginAcc 登录用户逻辑
+// joinOrganization 新注册账户加入组织：受邀账户加入邀请方组织并获得邀请指定的角色，
+// 自行注册的账户创建新组织并成为其管理员，须在 utils.RunDBTransaction 中调用
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
+//   - acc: 新注册账户
+//   - invitation: 已校验的邀请，自行注册时为 nil
+//   - organizationName: 新建组织名称，为空时以昵称命名
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func joinOrganization(c *gin.Context, acc *model.Account, invitation *organizationModel.OrganizationInvitation, organizationName string) error {
+	if invitation != nil {
+		if err := organizationService.AcceptInvitation(c, invitation, acc.ID); err != nil {
+			return err
+		}
+		return rbacService.GrantInitialRoles(c, acc.ID, acc.OrganizationID, []string{invitation.RoleCode})
+	}
+
+	if organizationName == "" {
+		organizationName = fmt.Sprintf("%s的组织", acc.Nickname)
+	}
+	org, err := organizationService.CreateOwnedOrganization(c, organizationName, acc.ID)
+	if err != nil {
+		return err
+	}
+
+	acc.OrganizationID = org.ID
+	if err := mapper.UpdateAccount(c, acc); err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户关联组织失败: %v", acc.Email, err)
+		return fmt.Errorf("「%s」用户关联组织失败: %w", acc.Email, err)
+	}
+
+	roles := append(slices.Clone(rbacModel.DefaultAccountRoles), rbacModel.ROLE_ADMIN)
+	return rbacService.GrantInitialRoles(c, acc.ID, org.ID, roles)
+}
+
+// LoginAcc 登录用户逻辑，连续失败会触发指数退避，达到上限后临时锁定账户；已启用两步验证的账户返回两步验证凭证
+// 参数：
+//   - c: Gin 上下文
 //   - req: 登录请求
 //
 // 返回值：
 //   - *account.LoginVO: 登录成功后的令牌视图对象
 //   - error: 操作过程中的错误
-func LoginAcc(c echo.Context, req *dto.LoginRequest) (*account.LoginVO, error) {
+func LoginAcc(c *gin.Context, req *dto.LoginRequest) (*account.LoginVO, error) {
+	if err := checkLoginAllowed(c, req.Email); err != nil {
+		return nil, err
+	}
+
 	acc, err := mapper.GetAccountByEmail(c, req.Email)
 	if err != nil {
 		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
+		if lockErr := recordLoginFailure(c, req.Email); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, fmt.Errorf("「%s」用户不存在: %w", req.Email, err)
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password))
 	if err != nil {
 		utils.BizLogger(c).Errorf("密码输入错误: %v", err)
+		if lockErr := recordLoginFailure(c, req.Email); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, fmt.Errorf("密码输入错误: %w", err)
 	}
 
-	accessTokenString, refreshTokenString, err := utils.GenerateJWT(acc.ID)
+	return completeLogin(c, acc)
+}
+
+// completeLogin 首要凭证校验通过后完成登录：非生效状态或注销宽限期已届满的账户拒绝登录，已启用两步验证的账户仅签发短期凭证，校验动态码通过后才签发正式令牌；
+// 角色要求启用两步验证但尚未启用的账户仅签发强制绑定凭证，完成绑定后才签发正式令牌；
+// 失败计数留到动态码校验通过后再清除，避免借助正确密码反复重置动态码的尝试次数
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 登录账户
+//
+// 返回值：
+//   - *account.LoginVO: 令牌视图对象，需要两步验证或强制绑定时仅包含对应凭证
+//   - error: 账户非生效状态时返回对应的账户状态错误，其余为操作过程中的错误
+func completeLogin(c *gin.Context, acc *model.Account) (*account.LoginVO, error) {
+	if err := closeDueAccount(c, acc); err != nil {
+		return nil, err
+	}
+	if err := checkAccountActive(c, acc); err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := isMFAEnabled(c, acc.ID)
 	if err != nil {
-		utils.BizLogger(c).Errorf("token 生成失败: %v", err)
-		return nil, fmt.Errorf("token 生成失败: %w", err)
+		utils.BizLogger(c).Errorf("查询「%s」两步验证配置失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("查询两步验证配置失败: %w", err)
+	}
+	if mfaEnabled {
+		mfaToken, err := createMFAToken(c, MFA_PENDING_CACHE, acc.ID, MFA_PENDING_EXPIRE_TIME)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」签发两步验证凭证失败: %v", acc.Email, err)
+			return nil, err
+		}
+		return &account.LoginVO{MFARequired: true, MFAToken: mfaToken}, nil
 	}
 
-	cacheKey := fmt.Sprintf("%s:%d", USER_CACHE, acc.ID)
+	mfaRequired, err := isMFARequired(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询「%s」角色失败: %v", acc.Email, err)
+		return nil, err
+	}
+	if mfaRequired {
+		enrollToken, err := createMFAToken(c, MFA_ENROLL_CACHE, acc.ID, MFA_ENROLL_EXPIRE_TIME)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」签发两步验证绑定凭证失败: %v", acc.Email, err)
+			return nil, err
+		}
+		return &account.LoginVO{MFAEnrollmentRequired: true, MFAToken: enrollToken}, nil
+	}
+	resetLoginFailures(c, acc.Email)
+
+	return issueLoginTokens(c, acc)
+}
 
-	err = global.RedisClient.Set(context.Background(), cacheKey, accessTokenString, USER_CACHE_EXPIRE_TIME).Err()
+// issueLoginTokens 为通过全部认证步骤的账户登记会话并签发访问令牌与刷新令牌
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 登录账户
+//
+// 返回值：
+//   - *account.LoginVO: 登录成功后的令牌视图对象
+//   - error: 操作过程中的错误
+func issueLoginTokens(c *gin.Context, acc *model.Account) (*account.LoginVO, error) {
+	// 两步验证期间账户可能已被停用，签发令牌前再次校验
+	if err := checkAccountActive(c, acc); err != nil {
+		return nil, err
+	}
+
+	subject, err := auth_middleware.LoadTokenSubject(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载「%s」用户权限失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("加载用户权限失败: %w", err)
+	}
+
+	// 每次登录登记一个独立会话，不同设备的登录互不影响
+	session, err := utils.CreateSession(c.Request.Context(), acc.ID, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		utils.BizLogger(c).Errorf("登录时登记会话失败: %v", err)
+		return nil, fmt.Errorf("登录时登记会话失败: %w", err)
+	}
+	subject.SessionID = session.SessionID
+	subject.RefreshID = session.RefreshID
+
+	accessTokenString, refreshTokenString, err := utils.GenerateJWT(*subject)
 	if err != nil {
-		utils.BizLogger(c).Errorf("登录时设置缓存失败: %v", err)
-		return nil, fmt.Errorf("登录时设置缓存失败: %w", err)
+		utils.BizLogger(c).Errorf("token 生成失败: %v", err)
+		return nil, fmt.Errorf("token 生成失败: %w", err)
 	}
 
 	token := &account.LoginVO{
 		AccessToken:  accessTokenString,
 		RefreshToken: refreshTokenString,
+		SessionID:    session.SessionID,
 	}
 
 	vo, err := utils.MapModelToVO(token, &account.LoginVO{})
@@ -171,40 +333,40 @@ func LoginAcc(c echo.Context, req *dto.LoginRequest) (*account.LoginVO, error) {
 	return vo.(*account.LoginVO), nil
 }
 
-// LogoutAcc 处理用户登出逻辑
+// LogoutAcc 处理用户登出逻辑，仅注销当前设备的会话
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //
 // 返回值：
 //   - error: 操作过程中的错误
-func LogoutAcc(c echo.Context) error {
+func LogoutAcc(c *gin.Context) error {
 	logoutLock.Lock()
 	defer logoutLock.Unlock()
 
-	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
 	if err != nil {
 		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
 		return fmt.Errorf("解析 access token 失败: %w", err)
 	}
 
-	cacheKey := fmt.Sprintf("%s:%d", USER_CACHE, accountID)
-	err = global.RedisClient.Del(c.Request().Context(), cacheKey).Err()
-	if err != nil {
-		utils.BizLogger(c).Errorf("删除 Redis 缓存失败: %v", err)
-		return fmt.Errorf("删除 Redis 缓存失败: %w", err)
+	sessionID, _ := auth_middleware.GetSessionID(c)
+	err = utils.RevokeSession(c.Request.Context(), accountID, sessionID)
+	if err != nil && !errors.Is(err, utils.ErrSessionRevoked) {
+		utils.BizLogger(c).Errorf("注销会话失败: %v", err)
+		return fmt.Errorf("注销会话失败: %w", err)
 	}
 
 	return nil
 }
 
-// ResetPassword 重置密码逻辑
+// ResetPassword 已登录用户修改密码逻辑，需校验原密码
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - req: 重置密码请求
 //
 // 返回值：
 //   - error: 操作过程中的错误
-func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
+func ResetPassword(c *gin.Context, req *dto.ResetPwdRequest) error {
 	passwordResetLock.Lock()
 	defer passwordResetLock.Unlock()
 
@@ -214,7 +376,7 @@ func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
 			return fmt.Errorf("两次密码输入不一致")
 		}
 
-		accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+		accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
 		if err != nil {
 			utils.BizLogger(c).Errorf("解析 token 失败: %v", err)
 			return fmt.Errorf("解析 token 失败: %w", err)
@@ -225,6 +387,22 @@ func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
 			utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
 			return fmt.Errorf("「%s」用户不存在: %w", req.Email, err)
 		}
+		if !strings.EqualFold(acc.Email, req.Email) {
+			utils.BizLogger(c).Errorf("「%s」与当前登录账户邮箱不一致", req.Email)
+			return fmt.Errorf("邮箱与当前登录账户不一致")
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.OldPassword)); err != nil {
+			utils.BizLogger(c).Errorf("「%s」原密码输入错误: %v", acc.Email, err)
+			return fmt.Errorf("原密码输入错误")
+		}
+
+		if err := checkPasswordPolicy(c, req.NewPassword); err != nil {
+			return err
+		}
+		if err := checkPasswordReuse(c, acc, req.NewPassword); err != nil {
+			return err
+		}
 
 		newPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 		if err != nil {
@@ -238,6 +416,6 @@ func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
 			return fmt.Errorf("密码修改失败: %w", err)
 		}
 
-		return nil
+		return recordPasswordHistory(c, acc)
 	})
 }
@@ -0,0 +1,380 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/account"
+	"lease/internal/model/base"
+	"lease/internal/storage"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/controller/verification"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/account"
+	"lease/pkg/vo/lease"
+	"lease/pkg/vo/ledger"
+	"lease/pkg/vo/maintenance"
+)
+
+// 账户注销相关常量
+const (
+	ACCOUNT_DELETION_DEFAULT_GRACE_DAYS = 30                           // 未配置宽限天数时的默认值
+	ACCOUNT_DELETED_NICKNAME            = "已注销用户"                      // 匿名化后的昵称
+	ACCOUNT_DELETED_EMAIL_FORMAT        = "deleted-%d@deleted.invalid" // 匿名化后的邮箱，保留唯一性且释放原邮箱供重新注册
+	ACCOUNT_DELETED_CONTENT             = "（内容已随账户注销清除）"               // 匿名化后报修描述与评论等自由文本的占位内容
+	ACCOUNT_DELETION_REAUTH_WINDOW      = 10 * time.Minute             // 未提供凭据时，当前会话须在该时长内登录
+)
+
+// ExportAccountData 导出当前账户的个人数据档案
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *account.AccountExportVO: 个人数据档案
+//   - error: 操作过程中的错误
+func ExportAccountData(c *gin.Context) (*account.AccountExportVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &account.AccountExportVO{
+		ExportedAt:     time.Now().Unix(),
+		Account:        profileVO(acc),
+		Leases:         []*lease.LeaseContractVO{},
+		Payments:       []*ledger.PaymentVO{},
+		Tickets:        []*maintenance.MaintenanceTicketVO{},
+		TicketComments: []*maintenance.MaintenanceCommentVO{},
+		Identities:     []*account.AccountIdentityVO{},
+	}
+
+	contracts, err := mapper.GetLeaseContractsByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」合同失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出合同失败: %w", err)
+	}
+	for _, contract := range contracts {
+		contractVO, err := utils.MapModelToVO(contract, &lease.LeaseContractVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("合同映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("合同映射 VO 失败: %w", err)
+		}
+		export.Leases = append(export.Leases, contractVO.(*lease.LeaseContractVO))
+	}
+
+	payments, err := mapper.GetPaymentsByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」收款记录失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出收款记录失败: %w", err)
+	}
+	for _, payment := range payments {
+		paymentVO, err := utils.MapModelToVO(payment, &ledger.PaymentVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("收款记录映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("收款记录映射 VO 失败: %w", err)
+		}
+		export.Payments = append(export.Payments, paymentVO.(*ledger.PaymentVO))
+	}
+
+	tickets, err := mapper.GetMaintenanceTicketsByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」报修工单失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出报修工单失败: %w", err)
+	}
+	for _, ticket := range tickets {
+		ticketVO, err := utils.MapModelToVO(ticket, &maintenance.MaintenanceTicketVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("报修工单映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("报修工单映射 VO 失败: %w", err)
+		}
+		export.Tickets = append(export.Tickets, ticketVO.(*maintenance.MaintenanceTicketVO))
+	}
+
+	comments, err := mapper.GetMaintenanceCommentsByAuthorID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」工单评论失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出工单评论失败: %w", err)
+	}
+	for _, comment := range comments {
+		commentVO, err := utils.MapModelToVO(comment, &maintenance.MaintenanceCommentVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("工单评论映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("工单评论映射 VO 失败: %w", err)
+		}
+		export.TicketComments = append(export.TicketComments, commentVO.(*maintenance.MaintenanceCommentVO))
+	}
+
+	identities, err := mapper.ListAccountIdentitiesByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」第三方身份失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出第三方身份失败: %w", err)
+	}
+	for _, identity := range identities {
+		export.Identities = append(export.Identities, identityVO(identity))
+	}
+
+	utils.BizLogger(c).Infof("「%s」已导出个人数据", acc.Email)
+	return export, nil
+}
+
+// RequestDeletion 当前账户申请注销，宽限期结束后匿名化个人信息，宽限期内账户照常可用且可撤销
+// 参数：
+//   - c: Gin 上下文
+//   - req: 申请注销请求
+//
+// 返回值：
+//   - *account.AccountDeletionVO: 注销申请状态
+//   - error: 身份验证未通过时返回 PASSWORD_INCORRECT、PHONE_CODE_INVALID 或 REAUTHENTICATION_REQUIRED，已申请注销时返回 ACCOUNT_STATUS_CONFLICT，其余为操作过程中的错误
+func RequestDeletion(c *gin.Context, req *dto.RequestDeletionRequest) (*account.AccountDeletionVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reauthenticateForDeletion(c, acc, req); err != nil {
+		return nil, err
+	}
+	if acc.DeletionScheduledAt != 0 {
+		utils.BizLogger(c).Errorf("「%s」已申请注销，无需重复申请", acc.Email)
+		return nil, bizErr.New(bizErr.ACCOUNT_STATUS_CONFLICT, "账户已申请注销，无需重复申请")
+	}
+
+	gracePeriod, err := deletionGracePeriod(c)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	acc.DeletionRequestedAt = now.Unix()
+	acc.DeletionScheduledAt = now.Add(gracePeriod).Unix()
+
+	if err := mapper.UpdateAccountColumns(c, acc.ID, map[string]interface{}{
+		"deletion_requested_at": acc.DeletionRequestedAt,
+		"deletion_scheduled_at": acc.DeletionScheduledAt,
+		"gmt_modified":          acc.DeletionRequestedAt,
+	}); err != nil {
+		utils.BizLogger(c).Errorf("登记「%s」注销申请失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("登记注销申请失败: %w", err)
+	}
+
+	// 通知邮件发送失败不影响注销申请，仅记录日志
+	content := fmt.Sprintf("您的账户已申请注销，将于 %s 起匿名化个人信息且无法恢复。如非本人操作或改变主意，请在此之前登录并撤销注销申请。",
+		time.Unix(acc.DeletionScheduledAt, 0).Format("2006-01-02 15:04"))
+	if _, err := utils.SendEmail(content, []string{acc.Email}); err != nil {
+		utils.BizLogger(c).Warnf("「%s」注销通知邮件发送失败: %v", acc.Email, err)
+	}
+
+	utils.BizLogger(c).Infof("「%s」已申请注销，计划于 %d 执行", acc.Email, acc.DeletionScheduledAt)
+	return &account.AccountDeletionVO{
+		DeletionRequestedAt: acc.DeletionRequestedAt,
+		DeletionScheduledAt: acc.DeletionScheduledAt,
+	}, nil
+}
+
+// reauthenticateForDeletion 申请注销前重新验证身份：依次接受密码、账户邮箱验证码与已绑定手机号的短信验证码，
+// 均未填写时要求当前会话在 ACCOUNT_DELETION_REAUTH_WINDOW 内登录，使未设置密码的第三方登录、免密登录与短信登录账户同样可以注销
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 当前账户
+//   - req: 申请注销请求
+//
+// 返回值：
+//   - error: 验证未通过时返回对应的业务错误
+func reauthenticateForDeletion(c *gin.Context, acc *model.Account, req *dto.RequestDeletionRequest) error {
+	switch {
+	case req.Password != "":
+		if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password)); err != nil {
+			utils.BizLogger(c).Errorf("「%s」申请注销时密码输入错误: %v", acc.Email, err)
+			return bizErr.New(bizErr.PASSWORD_INCORRECT, "密码错误")
+		}
+	case req.EmailVerificationCode != "":
+		if !verification.VerifyEmailCode(c, req.EmailVerificationCode, acc.Email) {
+			utils.BizLogger(c).Errorf("「%s」申请注销时邮箱验证码错误", acc.Email)
+			return bizErr.New(bizErr.REAUTHENTICATION_REQUIRED, "邮箱验证码错误或已过期")
+		}
+	case req.PhoneVerificationCode != "":
+		if acc.Phone == "" || !acc.PhoneVerified || !verification.VerifyPhoneCode(c, req.PhoneVerificationCode, acc.Phone) {
+			utils.BizLogger(c).Errorf("「%s」申请注销时短信验证码错误或未绑定手机号", acc.Email)
+			return bizErr.New(bizErr.PHONE_CODE_INVALID, "短信验证码错误或已过期")
+		}
+	default:
+		sessionID, _ := auth_middleware.GetSessionID(c)
+		createdAt, err := utils.GetSessionCreatedAt(c.Request.Context(), acc.ID, sessionID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询「%s」当前会话失败: %v", acc.Email, err)
+			return bizErr.New(bizErr.REAUTHENTICATION_REQUIRED, "请重新登录后再申请注销")
+		}
+		if time.Since(time.Unix(createdAt, 0)) > ACCOUNT_DELETION_REAUTH_WINDOW {
+			utils.BizLogger(c).Errorf("「%s」申请注销时未提供凭据且当前会话登录于 %d", acc.Email, createdAt)
+			return bizErr.New(bizErr.REAUTHENTICATION_REQUIRED, "请输入密码或验证码，或重新登录后再申请注销")
+		}
+	}
+	return nil
+}
+
+// CancelDeletion 在宽限期内撤销当前账户的注销申请
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - error: 未申请注销时返回 ACCOUNT_STATUS_CONFLICT，其余为操作过程中的错误
+func CancelDeletion(c *gin.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+	if acc.DeletionScheduledAt == 0 {
+		utils.BizLogger(c).Errorf("「%s」未申请注销", acc.Email)
+		return bizErr.New(bizErr.ACCOUNT_STATUS_CONFLICT, "账户未申请注销")
+	}
+
+	if err := mapper.UpdateAccountColumns(c, acc.ID, map[string]interface{}{
+		"deletion_requested_at": 0,
+		"deletion_scheduled_at": 0,
+		"gmt_modified":          time.Now().Unix(),
+	}); err != nil {
+		utils.BizLogger(c).Errorf("撤销「%s」注销申请失败: %v", acc.Email, err)
+		return fmt.Errorf("撤销注销申请失败: %w", err)
+	}
+
+	utils.BizLogger(c).Infof("「%s」已撤销注销申请", acc.Email)
+	return nil
+}
+
+// PurgeDeletedAccounts 对注销宽限期已届满的账户执行匿名化；管理员调用时限定本组织，定时任务以不携带组织的上下文调用时覆盖全部组织
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *account.PurgeDeletedAccountsVO: 执行结果
+//   - error: 操作过程中的错误
+func PurgeDeletedAccounts(c *gin.Context) (*account.PurgeDeletedAccountsVO, error) {
+	accounts, err := mapper.ListAccountsDueForDeletion(c, time.Now().Unix())
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询待注销账户失败: %v", err)
+		return nil, err
+	}
+
+	result := &account.PurgeDeletedAccountsVO{}
+	for _, acc := range accounts {
+		if err := eraseAccount(c, acc); err != nil {
+			return nil, err
+		}
+		result.Purged++
+	}
+	return result, nil
+}
+
+// closeDueAccount 账户注销宽限期已届满时立即执行匿名化，使登录入口不依赖管理员批量执行即可完成注销
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户信息
+//
+// 返回值：
+//   - error: 已执行匿名化时返回 ACCOUNT_CLOSED，未到期返回 nil，其余为操作过程中的错误
+func closeDueAccount(c *gin.Context, acc *model.Account) error {
+	if acc.DeletionScheduledAt == 0 || acc.DeletionScheduledAt > time.Now().Unix() {
+		return nil
+	}
+	if err := eraseAccount(c, acc); err != nil {
+		return err
+	}
+	return bizErr.New(bizErr.ACCOUNT_CLOSED, "账户已注销")
+}
+
+// eraseAccount 匿名化账户个人信息并将其置为已注销：清除邮箱、手机号、昵称、头像与密码，删除两步验证、密码历史、第三方身份及角色授权，
+// 并清除其报修描述与工单评论等自由文本；合同、账单、收款与账本记录属于法定留存的财务数据，保持不变，其中的账户 ID 此后只指向已匿名化的账户
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 待注销账户
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func eraseAccount(c *gin.Context, acc *model.Account) error {
+	avatar := acc.Avatar
+	if err := utils.RunDBTransaction(c, func(tx error) error {
+		if err := mapper.UpdateAccountColumns(c, acc.ID, map[string]interface{}{
+			"email":          fmt.Sprintf(ACCOUNT_DELETED_EMAIL_FORMAT, acc.ID),
+			"nickname":       ACCOUNT_DELETED_NICKNAME,
+			"phone":          nil,
+			"phone_verified": false,
+			"avatar":         nil,
+			"password":       "",
+			"ext":            base.JSONMap{},
+			"status":         model.ACCOUNT_STATUS_CLOSED,
+			"deleted":        true,
+			"gmt_modified":   time.Now().Unix(),
+		}); err != nil {
+			utils.BizLogger(c).Errorf("匿名化「%d」账户失败: %v", acc.ID, err)
+			return fmt.Errorf("匿名化账户失败: %w", err)
+		}
+		if err := mapper.DeleteAccountMFAByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除「%d」两步验证配置失败: %v", acc.ID, err)
+			return err
+		}
+		if err := mapper.DeleteAccountRecoveryCodesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除「%d」恢复码失败: %v", acc.ID, err)
+			return err
+		}
+		if err := mapper.DeleteAccountPasswordHistoriesExcept(c, acc.ID, nil); err != nil {
+			utils.BizLogger(c).Errorf("删除「%d」密码历史失败: %v", acc.ID, err)
+			return err
+		}
+		if err := mapper.DeleteAccountIdentitiesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除「%d」第三方身份失败: %v", acc.ID, err)
+			return err
+		}
+		if err := mapper.DeleteAccountRolesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("撤销「%d」角色失败: %v", acc.ID, err)
+			return err
+		}
+		if err := mapper.RedactMaintenanceContentByAccountID(c, acc.ID, ACCOUNT_DELETED_CONTENT); err != nil {
+			utils.BizLogger(c).Errorf("清除「%d」报修内容失败: %v", acc.ID, err)
+			return err
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 以下清理失败不影响匿名化结果，仅记录日志
+	if err := utils.RevokeAllSessions(c.Request.Context(), acc.ID); err != nil {
+		utils.BizLogger(c).Warnf("注销后清理「%d」全部会话失败: %v", acc.ID, err)
+	}
+	if store := storage.Default(); store != nil && isStoredAvatar(avatar) {
+		if err := store.Delete(c.Request.Context(), avatar); err != nil {
+			utils.BizLogger(c).Warnf("注销后删除「%d」头像失败: %v", acc.ID, err)
+		}
+	}
+
+	utils.BizLogger(c).Infof("「%d」账户注销宽限期已届满，个人信息已匿名化", acc.ID)
+	return nil
+}
+
+// deletionGracePeriod 读取注销宽限期
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - time.Duration: 宽限期时长
+//   - error: 操作过程中的错误
+func deletionGracePeriod(c *gin.Context) (time.Duration, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return 0, fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	days := cfg.SecurityConfig.AccountDeletionGraceDays
+	if days <= 0 {
+		days = ACCOUNT_DELETION_DEFAULT_GRACE_DAYS
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
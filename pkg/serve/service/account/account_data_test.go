@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	maintenanceModel "lease/internal/model/maintenance"
+	"lease/internal/utils"
+	service "lease/pkg/serve/service/account"
+)
+
+func TestPurgeDeletedAccountsAcrossOrganizations(t *testing.T) {
+	emails := []string{"purge-a@example.com", "purge-b@example.com"}
+	for _, email := range emails {
+		register(t, email)
+		mustSucceed(t, "POST", "/api/v1/account/requestDeletion", map[string]string{"password": testPassword}, login(t, email), nil)
+	}
+
+	var accounts []*model.Account
+	global.DB.Where("email IN ?", emails).Find(&accounts)
+	if len(accounts) != 2 || accounts[0].OrganizationID == accounts[1].OrganizationID {
+		t.Fatalf("测试账户应属于不同组织: %+v", accounts)
+	}
+	ids := []int64{accounts[0].ID, accounts[1].ID}
+	global.DB.Model(&model.Account{}).Where("id IN ?", ids).Update("deletion_scheduled_at", time.Now().Add(-time.Minute).Unix())
+
+	// 定时任务以不携带组织的上下文执行
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("JOB", "/job/purgeDeletedAccounts", nil)
+	result, err := service.PurgeDeletedAccounts(c)
+	if err != nil {
+		t.Fatalf("清理注销账户失败: %v", err)
+	}
+	if result.Purged < 2 {
+		t.Fatalf("清理账户数 = %d，期望至少 2", result.Purged)
+	}
+
+	global.DB.Where("id IN ?", ids).Find(&accounts)
+	for _, acc := range accounts {
+		if acc.Status != model.ACCOUNT_STATUS_CLOSED || acc.Email == emails[0] || acc.Email == emails[1] {
+			t.Fatalf("账户「%d」未匿名化: status=%s email=%s", acc.ID, acc.Status, acc.Email)
+		}
+	}
+}
+
+func TestRequestDeletionReauthentication(t *testing.T) {
+	email := "deletion-reauth@example.com"
+	register(t, email)
+	token := login(t, email)
+
+	var acc model.Account
+	if err := global.DB.Where("email = ?", email).First(&acc).Error; err != nil {
+		t.Fatalf("查询账户失败: %v", err)
+	}
+
+	expectCode(t, "POST", "/api/v1/account/requestDeletion", map[string]string{"password": testPassword + "-wrong"}, token, bizErr.PASSWORD_INCORRECT)
+
+	// 会话登录已超过免验证时长，未提供凭据时须重新验证身份
+	sessionID, _ := utils.ParseSessionIDFromJWT(token)
+	sessionKey := fmt.Sprintf("%s:%d:%s", utils.SESSION_CACHE_PREFIX, acc.ID, sessionID)
+	mr.HSet(sessionKey, utils.SESSION_FIELD_CREATED_AT, strconv.FormatInt(time.Now().Add(-service.ACCOUNT_DELETION_REAUTH_WINDOW-time.Minute).Unix(), 10))
+	expectCode(t, "POST", "/api/v1/account/requestDeletion", map[string]string{}, token, bizErr.REAUTHENTICATION_REQUIRED)
+	expectCode(t, "POST", "/api/v1/account/requestDeletion", map[string]string{"email_verification_code": "000000"}, token, bizErr.REAUTHENTICATION_REQUIRED)
+
+	// 未使用密码的账户可以邮箱验证码代替密码确认
+	mr.Set("EMAIL:VERIFICATION:CODE:"+email, testEmailCode)
+	mustSucceed(t, "POST", "/api/v1/account/requestDeletion", map[string]string{"email_verification_code": testEmailCode}, token, nil)
+}
+
+func TestEraseAccountRedactsMaintenanceContent(t *testing.T) {
+	email := "deletion-tickets@example.com"
+	register(t, email)
+
+	var acc model.Account
+	if err := global.DB.Where("email = ?", email).First(&acc).Error; err != nil {
+		t.Fatalf("查询账户失败: %v", err)
+	}
+	ticket := &maintenanceModel.MaintenanceTicket{
+		UnitID: 1, PropertyID: 1, LandlordID: acc.ID, ReporterID: acc.ID, Title: "厨房漏水",
+		Description: "联系电话 13800000000", Category: maintenanceModel.TICKET_CATEGORY_PLUMBING,
+		Priority: maintenanceModel.TICKET_PRIORITY_MEDIUM, Status: maintenanceModel.TICKET_STATUS_OPEN,
+	}
+	ticket.OrganizationID = acc.OrganizationID
+	if err := global.DB.Create(ticket).Error; err != nil {
+		t.Fatalf("创建报修工单失败: %v", err)
+	}
+	comment := &maintenanceModel.MaintenanceComment{TicketID: ticket.ID, AuthorID: acc.ID, Content: "门禁密码 1234"}
+	comment.OrganizationID = acc.OrganizationID
+	if err := global.DB.Create(comment).Error; err != nil {
+		t.Fatalf("创建工单评论失败: %v", err)
+	}
+
+	// 刚登录的会话无需再次输入密码即可申请注销
+	mustSucceed(t, "POST", "/api/v1/account/requestDeletion", map[string]string{}, login(t, email), nil)
+	global.DB.Model(&model.Account{}).Where("id = ?", acc.ID).Update("deletion_scheduled_at", time.Now().Add(-time.Minute).Unix())
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("JOB", "/job/purgeDeletedAccounts", nil)
+	if _, err := service.PurgeDeletedAccounts(c); err != nil {
+		t.Fatalf("清理注销账户失败: %v", err)
+	}
+
+	global.DB.First(ticket, ticket.ID)
+	global.DB.First(comment, comment.ID)
+	if ticket.Description != service.ACCOUNT_DELETED_CONTENT || comment.Content != service.ACCOUNT_DELETED_CONTENT {
+		t.Fatalf("报修内容未清除: description=%q comment=%q", ticket.Description, comment.Content)
+	}
+	if ticket.Title != "厨房漏水" {
+		t.Fatalf("工单标题 = %q，不应随账户注销修改", ticket.Title)
+	}
+}
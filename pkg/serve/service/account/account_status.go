@@ -0,0 +1,252 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+)
+
+// 账户激活相关常量
+const (
+	ACCOUNT_ACTIVATION_CACHE          = "ACCOUNT:ACTIVATION"          // 当前有效的激活令牌哈希键前缀，完整键为 ACCOUNT:ACTIVATION:<账户 ID>
+	ACCOUNT_ACTIVATION_COOLDOWN_CACHE = "ACCOUNT:ACTIVATION:COOLDOWN" // 激活邮件发送冷却键前缀
+	ACCOUNT_ACTIVATION_EXPIRE_TIME    = time.Hour * 24                // 激活令牌有效期
+	ACCOUNT_ACTIVATION_SEND_INTERVAL  = time.Minute                   // 同一邮箱两次发送的最小间隔
+	ACCOUNT_ACTIVATION_TOKEN_BYTES    = 32                            // 激活令牌随机字节数
+)
+
+// checkAccountActive 校验账户处于可登录的生效状态
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户信息
+//
+// 返回值：
+//   - error: 待激活返回 ACCOUNT_PENDING_VERIFICATION，已停用返回 ACCOUNT_SUSPENDED，已注销返回 ACCOUNT_CLOSED
+func checkAccountActive(c *gin.Context, acc *model.Account) error {
+	switch acc.Status {
+	case model.ACCOUNT_STATUS_ACTIVE:
+		return nil
+	case model.ACCOUNT_STATUS_PENDING_VERIFICATION:
+		utils.BizLogger(c).Warnf("「%s」账户尚未激活，拒绝登录", acc.Email)
+		return bizErr.New(bizErr.ACCOUNT_PENDING_VERIFICATION, "账户尚未激活，请通过激活邮件完成邮箱验证")
+	case model.ACCOUNT_STATUS_CLOSED:
+		utils.BizLogger(c).Warnf("「%s」账户已注销，拒绝登录", acc.Email)
+		return bizErr.New(bizErr.ACCOUNT_CLOSED, "账户已注销")
+	default:
+		utils.BizLogger(c).Warnf("「%s」账户状态为 %s，拒绝登录", acc.Email, acc.Status)
+		return bizErr.New(bizErr.ACCOUNT_SUSPENDED, "账户已被停用，请联系管理员")
+	}
+}
+
+// ResendActivation 向待激活账户重新发送激活邮件，新链接发出后此前未使用的链接随即失效；
+// 邮箱未注册或账户无需激活时同样返回成功，避免借此探测账户状态
+// 参数：
+//   - c: Gin 上下文
+//   - req: 重新发送激活邮件请求
+//
+// 返回值：
+//   - error: 发送过于频繁时返回 ACCOUNT_ACTIVATION_TOO_FREQUENT，其余为操作过程中的错误
+func ResendActivation(c *gin.Context, req *dto.ResendActivationRequest) error {
+	ctx := c.Request.Context()
+	cooldownKey := loginCacheKey(ACCOUNT_ACTIVATION_COOLDOWN_CACHE, strings.ToLower(req.Email))
+
+	ok, err := global.RedisClient.SetNX(ctx, cooldownKey, 1, ACCOUNT_ACTIVATION_SEND_INTERVAL).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询激活邮件发送状态失败: %v", err)
+		return fmt.Errorf("查询激活邮件发送状态失败: %w", err)
+	}
+	if !ok {
+		return bizErr.New(bizErr.ACCOUNT_ACTIVATION_TOO_FREQUENT, fmt.Sprintf("激活邮件发送过于频繁，请 %d 秒后再试", int(ACCOUNT_ACTIVATION_SEND_INTERVAL/time.Second)))
+	}
+
+	acc, err := mapper.GetAccountByEmail(c, req.Email)
+	if err != nil {
+		utils.BizLogger(c).Warnf("「%s」用户不存在，未发送激活邮件: %v", req.Email, err)
+		return nil
+	}
+	if acc.Status != model.ACCOUNT_STATUS_PENDING_VERIFICATION {
+		utils.BizLogger(c).Warnf("「%s」账户状态为 %s，无需激活", acc.Email, acc.Status)
+		return nil
+	}
+
+	if err := sendActivationEmail(c, acc); err != nil {
+		global.RedisClient.Del(ctx, cooldownKey)
+		return err
+	}
+	return nil
+}
+
+// ActivateAccount 使用激活邮件中的令牌激活账户
+// 参数：
+//   - c: Gin 上下文
+//   - req: 激活账户请求
+//
+// 返回值：
+//   - error: 令牌无效、已过期或已使用时返回 ACCOUNT_ACTIVATION_INVALID，其余为操作过程中的错误
+func ActivateAccount(c *gin.Context, req *dto.ActivateAccountRequest) error {
+	acc, err := mapper.GetAccountByEmail(c, req.Email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
+		return bizErr.New(bizErr.ACCOUNT_ACTIVATION_INVALID, "激活链接无效或已过期，请重新获取")
+	}
+
+	consumed, err := consumeOneTimeTokenScript.Run(c.Request.Context(), global.RedisClient,
+		[]string{activationKey(acc.ID)}, hashResetToken(req.Token)).Int()
+	if err != nil {
+		utils.BizLogger(c).Errorf("核销激活令牌失败: %v", err)
+		return fmt.Errorf("核销激活令牌失败: %w", err)
+	}
+	if consumed != 1 {
+		utils.BizLogger(c).Errorf("「%s」激活令牌无效或已使用", req.Email)
+		return bizErr.New(bizErr.ACCOUNT_ACTIVATION_INVALID, "激活链接无效或已过期，请重新获取")
+	}
+
+	activated, err := mapper.UpdateAccountStatus(c, acc.ID,
+		[]string{model.ACCOUNT_STATUS_PENDING_VERIFICATION}, model.ACCOUNT_STATUS_ACTIVE)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」账户激活失败: %v", acc.Email, err)
+		return fmt.Errorf("账户激活失败: %w", err)
+	}
+	if !activated {
+		utils.BizLogger(c).Errorf("「%s」账户状态已变更，无法激活", acc.Email)
+		return bizErr.New(bizErr.ACCOUNT_STATUS_CONFLICT, "账户当前状态无需激活")
+	}
+
+	utils.BizLogger(c).Infof("「%s」账户已通过邮件激活", acc.Email)
+	return nil
+}
+
+// SuspendAccount 管理员停用本组织账户并注销其全部会话
+// 参数：
+//   - c: Gin 上下文
+//   - req: 停用账户请求
+//
+// 返回值：
+//   - error: 停用自身或账户已注销、已停用时返回 ACCOUNT_STATUS_CONFLICT，其余为操作过程中的错误
+func SuspendAccount(c *gin.Context, req *dto.SuspendAccountRequest) error {
+	if operatorID, ok := auth_middleware.GetAccountID(c); ok && operatorID == req.AccountID {
+		utils.BizLogger(c).Errorf("「%d」管理员不能停用自己的账户", operatorID)
+		return bizErr.New(bizErr.ACCOUNT_STATUS_CONFLICT, "不能停用自己的账户")
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, req.AccountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", req.AccountID, err)
+		return fmt.Errorf("「%d」账户不存在: %w", req.AccountID, err)
+	}
+
+	suspended, err := mapper.UpdateAccountStatus(c, acc.ID,
+		[]string{model.ACCOUNT_STATUS_ACTIVE, model.ACCOUNT_STATUS_PENDING_VERIFICATION}, model.ACCOUNT_STATUS_SUSPENDED)
+	if err != nil {
+		utils.BizLogger(c).Errorf("停用「%s」账户失败: %v", acc.Email, err)
+		return fmt.Errorf("停用账户失败: %w", err)
+	}
+	if !suspended {
+		utils.BizLogger(c).Errorf("「%s」账户状态为 %s，无法停用", acc.Email, acc.Status)
+		return bizErr.New(bizErr.ACCOUNT_STATUS_CONFLICT, "账户已停用或已注销")
+	}
+
+	if err := utils.RevokeAllSessions(c.Request.Context(), acc.ID); err != nil {
+		utils.BizLogger(c).Errorf("停用后注销「%s」全部会话失败: %v", acc.Email, err)
+		return fmt.Errorf("注销全部会话失败: %w", err)
+	}
+
+	utils.BizLogger(c).Infof("「%s」账户已由管理员停用，原因: %s", acc.Email, req.Reason)
+	return nil
+}
+
+// ReactivateAccount 管理员重新启用已停用的本组织账户
+// 参数：
+//   - c: Gin 上下文
+//   - req: 重新启用账户请求
+//
+// 返回值：
+//   - error: 账户未处于停用状态时返回 ACCOUNT_STATUS_CONFLICT，其余为操作过程中的错误
+func ReactivateAccount(c *gin.Context, req *dto.ReactivateAccountRequest) error {
+	acc, err := mapper.GetAccountByAccountID(c, req.AccountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", req.AccountID, err)
+		return fmt.Errorf("「%d」账户不存在: %w", req.AccountID, err)
+	}
+
+	reactivated, err := mapper.UpdateAccountStatus(c, acc.ID,
+		[]string{model.ACCOUNT_STATUS_SUSPENDED}, model.ACCOUNT_STATUS_ACTIVE)
+	if err != nil {
+		utils.BizLogger(c).Errorf("重新启用「%s」账户失败: %v", acc.Email, err)
+		return fmt.Errorf("重新启用账户失败: %w", err)
+	}
+	if !reactivated {
+		utils.BizLogger(c).Errorf("「%s」账户状态为 %s，无法重新启用", acc.Email, acc.Status)
+		return bizErr.New(bizErr.ACCOUNT_STATUS_CONFLICT, "仅已停用的账户可以重新启用")
+	}
+
+	utils.BizLogger(c).Infof("「%s」账户已由管理员重新启用", acc.Email)
+	return nil
+}
+
+// sendActivationEmail 生成激活令牌并发送激活邮件，仅保存令牌哈希
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 待激活账户
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func sendActivationEmail(c *gin.Context, acc *model.Account) error {
+	ctx := c.Request.Context()
+
+	buf := make([]byte, ACCOUNT_ACTIVATION_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		utils.BizLogger(c).Errorf("生成激活令牌失败: %v", err)
+		return fmt.Errorf("生成激活令牌失败: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	key := activationKey(acc.ID)
+	if err := global.RedisClient.Set(ctx, key, hashResetToken(token), ACCOUNT_ACTIVATION_EXPIRE_TIME).Err(); err != nil {
+		utils.BizLogger(c).Errorf("登记「%s」激活令牌失败: %v", acc.Email, err)
+		return fmt.Errorf("登记激活令牌失败: %w", err)
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("email", acc.Email)
+	query.Set("token", token)
+	content := fmt.Sprintf("欢迎注册%s，请在 %d 小时内点击以下链接激活账户：\n%s?%s\n如非本人操作，请忽略本邮件。",
+		cfg.AppConfig.AppName, int(ACCOUNT_ACTIVATION_EXPIRE_TIME/time.Hour), cfg.AppConfig.ActivationURL, query.Encode())
+	if _, err := utils.SendEmail(content, []string{acc.Email}); err != nil {
+		utils.BizLogger(c).Errorf("「%s」激活邮件发送失败: %v", acc.Email, err)
+		global.RedisClient.Del(ctx, key)
+		return fmt.Errorf("激活邮件发送失败: %w", err)
+	}
+	return nil
+}
+
+// activationKey 生成激活令牌登记键
+// 参数：
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - string: 缓存键
+func activationKey(accountID int64) string {
+	return fmt.Sprintf("%s:%d", ACCOUNT_ACTIVATION_CACHE, accountID)
+}
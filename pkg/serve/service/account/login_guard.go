@@ -0,0 +1,240 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+)
+
+// 登录防爆破相关常量
+const (
+	LOGIN_FAIL_ACCOUNT_CACHE    = "LOGIN:FAIL:ACCOUNT"    // 账户登录失败计数键前缀
+	LOGIN_FAIL_IP_CACHE         = "LOGIN:FAIL:IP"         // IP 登录失败计数键前缀
+	LOGIN_BACKOFF_ACCOUNT_CACHE = "LOGIN:BACKOFF:ACCOUNT" // 账户退避等待键前缀
+	LOGIN_BACKOFF_IP_CACHE      = "LOGIN:BACKOFF:IP"      // IP 退避等待键前缀
+	LOGIN_LOCK_CACHE            = "LOGIN:LOCK"            // 账户锁定键前缀
+
+	LOGIN_FAIL_WINDOW          = time.Minute * 15 // 失败计数滑动窗口，超过该时长无新的失败则计数清零
+	LOGIN_BACKOFF_THRESHOLD    = 3                // 账户连续失败达到该次数后开始退避
+	LOGIN_IP_BACKOFF_THRESHOLD = 20               // 同一 IP 失败达到该次数后开始退避
+	LOGIN_BACKOFF_BASE         = time.Second      // 首次退避时长，此后每次失败翻倍
+	LOGIN_BACKOFF_MAX          = time.Minute * 5  // 单次退避时长上限
+	LOGIN_LOCK_THRESHOLD       = 10               // 账户连续失败达到该次数后锁定
+	LOGIN_LOCK_DURATION        = time.Minute * 30 // 锁定时长
+)
+
+// checkLoginAllowed 登录前校验账户是否被锁定、账户或 IP 是否处于退避期
+// 参数：
+//   - c: Gin 上下文
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - error: 被锁定时返回 ACCOUNT_LOCKED，处于退避期时返回 LOGIN_TOO_FREQUENT
+func checkLoginAllowed(c *gin.Context, email string) error {
+	ctx := c.Request.Context()
+	email = strings.ToLower(email)
+
+	lockTTL, err := global.RedisClient.TTL(ctx, loginCacheKey(LOGIN_LOCK_CACHE, email)).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账户锁定状态失败: %v", err)
+		return fmt.Errorf("查询账户锁定状态失败: %w", err)
+	}
+	if lockTTL > 0 {
+		return lockedError(lockTTL)
+	}
+
+	for _, key := range []string{loginCacheKey(LOGIN_BACKOFF_ACCOUNT_CACHE, email), loginCacheKey(LOGIN_BACKOFF_IP_CACHE, c.ClientIP())} {
+		backoffTTL, err := global.RedisClient.PTTL(ctx, key).Result()
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询登录退避状态失败: %v", err)
+			return fmt.Errorf("查询登录退避状态失败: %w", err)
+		}
+		if backoffTTL > 0 {
+			seconds := int64((backoffTTL + time.Second - 1) / time.Second)
+			return bizErr.New(bizErr.LOGIN_TOO_FREQUENT, fmt.Sprintf("登录失败次数过多，请 %d 秒后再试", seconds))
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure 记录一次登录失败：累加账户与 IP 的失败计数，超过阈值后按指数退避，账户失败达到上限时锁定并邮件通知；
+// 失败计数、退避或锁定未能写入 Redis 时返回错误，调用方据此拒绝本次登录，避免 Redis 故障期间绕过防爆破限制
+// 参数：
+//   - c: Gin 上下文
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - error: 触发锁定时返回 ACCOUNT_LOCKED，写入 Redis 失败时返回对应错误，否则为 nil
+func recordLoginFailure(c *gin.Context, email string) error {
+	ctx := c.Request.Context()
+	subject := strings.ToLower(email)
+
+	accountFailures, err := incrLoginFailure(c, loginCacheKey(LOGIN_FAIL_ACCOUNT_CACHE, subject))
+	if err != nil {
+		return err
+	}
+	ipFailures, err := incrLoginFailure(c, loginCacheKey(LOGIN_FAIL_IP_CACHE, c.ClientIP()))
+	if err != nil {
+		return err
+	}
+
+	if accountFailures >= LOGIN_LOCK_THRESHOLD {
+		pipe := global.RedisClient.TxPipeline()
+		pipe.Set(ctx, loginCacheKey(LOGIN_LOCK_CACHE, subject), accountFailures, LOGIN_LOCK_DURATION)
+		pipe.Del(ctx, loginCacheKey(LOGIN_FAIL_ACCOUNT_CACHE, subject), loginCacheKey(LOGIN_BACKOFF_ACCOUNT_CACHE, subject))
+		if _, err := pipe.Exec(ctx); err != nil {
+			utils.BizLogger(c).Errorf("锁定「%s」账户失败: %v", email, err)
+			return fmt.Errorf("锁定账户失败: %w", err)
+		}
+		utils.BizLogger(c).Warnf("「%s」账户连续登录失败 %d 次，已锁定 %s", email, accountFailures, LOGIN_LOCK_DURATION)
+		sendLockoutEmail(c, email)
+		return lockedError(LOGIN_LOCK_DURATION)
+	}
+
+	if err := setLoginBackoff(c, loginCacheKey(LOGIN_BACKOFF_ACCOUNT_CACHE, subject), accountFailures-LOGIN_BACKOFF_THRESHOLD); err != nil {
+		return err
+	}
+	return setLoginBackoff(c, loginCacheKey(LOGIN_BACKOFF_IP_CACHE, c.ClientIP()), ipFailures-LOGIN_IP_BACKOFF_THRESHOLD)
+}
+
+// resetLoginFailures 登录成功后清除账户的失败计数与退避，IP 计数保留以防借助自有账户重置
+// 参数：
+//   - c: Gin 上下文
+//   - email: 登录邮箱
+func resetLoginFailures(c *gin.Context, email string) {
+	email = strings.ToLower(email)
+	if err := global.RedisClient.Del(c.Request.Context(),
+		loginCacheKey(LOGIN_FAIL_ACCOUNT_CACHE, email), loginCacheKey(LOGIN_BACKOFF_ACCOUNT_CACHE, email)).Err(); err != nil {
+		utils.BizLogger(c).Warnf("清除「%s」登录失败计数失败: %v", email, err)
+	}
+}
+
+// UnlockAccount 管理员解除账户的登录锁定并清除失败计数
+// 参数：
+//   - c: Gin 上下文
+//   - req: 解锁账户请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UnlockAccount(c *gin.Context, req *dto.UnlockAccountRequest) error {
+	acc, err := mapper.GetAccountByAccountID(c, req.AccountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", req.AccountID, err)
+		return fmt.Errorf("「%d」账户不存在: %w", req.AccountID, err)
+	}
+
+	email := strings.ToLower(acc.Email)
+	if err := global.RedisClient.Del(c.Request.Context(),
+		loginCacheKey(LOGIN_LOCK_CACHE, email),
+		loginCacheKey(LOGIN_FAIL_ACCOUNT_CACHE, email),
+		loginCacheKey(LOGIN_BACKOFF_ACCOUNT_CACHE, email)).Err(); err != nil {
+		utils.BizLogger(c).Errorf("解锁「%s」账户失败: %v", acc.Email, err)
+		return fmt.Errorf("解锁账户失败: %w", err)
+	}
+
+	utils.BizLogger(c).Infof("「%s」账户已由管理员解锁", acc.Email)
+	return nil
+}
+
+// incrLoginFailure 累加失败计数并顺延计数窗口，二者在同一事务中执行，避免计数键失去过期时间后永久累积
+// 参数：
+//   - c: Gin 上下文
+//   - key: 计数键
+//
+// 返回值：
+//   - int64: 累加后的失败次数
+//   - error: 操作过程中的错误
+func incrLoginFailure(c *gin.Context, key string) (int64, error) {
+	ctx := c.Request.Context()
+	pipe := global.RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	expire := pipe.Expire(ctx, key, LOGIN_FAIL_WINDOW)
+	if _, err := pipe.Exec(ctx); err != nil {
+		utils.BizLogger(c).Errorf("记录登录失败次数失败: %v", err)
+		return 0, fmt.Errorf("记录登录失败次数失败: %w", err)
+	}
+	if !expire.Val() {
+		utils.BizLogger(c).Errorf("设置登录失败计数「%s」有效期失败", key)
+		return 0, fmt.Errorf("设置登录失败计数有效期失败")
+	}
+	return incr.Val(), nil
+}
+
+// setLoginBackoff 按超出阈值的失败次数设置指数退避，退避时长为 LOGIN_BACKOFF_BASE * 2^exceeded
+// 参数：
+//   - c: Gin 上下文
+//   - key: 退避键
+//   - exceeded: 超出阈值的失败次数，小于 0 时不退避
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func setLoginBackoff(c *gin.Context, key string, exceeded int64) error {
+	if exceeded < 0 {
+		return nil
+	}
+
+	delay := LOGIN_BACKOFF_MAX
+	if exceeded < 16 {
+		delay = min(LOGIN_BACKOFF_BASE<<exceeded, LOGIN_BACKOFF_MAX)
+	}
+	if err := global.RedisClient.Set(c.Request.Context(), key, exceeded, delay).Err(); err != nil {
+		utils.BizLogger(c).Errorf("设置登录退避失败: %v", err)
+		return fmt.Errorf("设置登录退避失败: %w", err)
+	}
+	return nil
+}
+
+// sendLockoutEmail 向被锁定账户发送通知邮件，发送失败仅记录日志
+// 参数：
+//   - c: Gin 上下文
+//   - email: 账户邮箱
+func sendLockoutEmail(c *gin.Context, email string) {
+	acc, err := mapper.GetAccountByEmail(c, email)
+	if err != nil {
+		return
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Warnf("加载配置失败，未发送「%s」锁定通知: %v", acc.Email, err)
+		return
+	}
+
+	content := fmt.Sprintf("您的%s账户因连续 %d 次登录失败已被临时锁定 %d 分钟（来源 IP：%s）。如非本人操作，请尽快修改密码或联系管理员。",
+		cfg.AppConfig.AppName, LOGIN_LOCK_THRESHOLD, int(LOGIN_LOCK_DURATION/time.Minute), c.ClientIP())
+	if _, err := utils.SendEmail(content, []string{acc.Email}); err != nil {
+		utils.BizLogger(c).Warnf("「%s」锁定通知邮件发送失败: %v", acc.Email, err)
+	}
+}
+
+// lockedError 构造账户锁定错误
+// 参数：
+//   - remaining: 剩余锁定时长
+//
+// 返回值：
+//   - error: ACCOUNT_LOCKED 业务错误
+func lockedError(remaining time.Duration) error {
+	minutes := int64((remaining + time.Minute - 1) / time.Minute)
+	return bizErr.New(bizErr.ACCOUNT_LOCKED, fmt.Sprintf("账户已被临时锁定，请 %d 分钟后再试或联系管理员解锁", minutes))
+}
+
+// loginCacheKey 生成登录防爆破缓存键
+// 参数：
+//   - prefix: 键前缀
+//   - subject: 邮箱或 IP
+//
+// 返回值：
+//   - string: 缓存键
+func loginCacheKey(prefix, subject string) string {
+	return fmt.Sprintf("%s:%s", prefix, subject)
+}
@@ -0,0 +1,78 @@
+package service_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	bizErr "lease/internal/error"
+	service "lease/pkg/serve/service/account"
+)
+
+// testClientIP httptest 请求的来源 IP
+const testClientIP = "192.0.2.1"
+
+// clearIPLoginFailures 用例结束后清除来源 IP 的登录失败计数与退避，避免影响其他用例
+func clearIPLoginFailures(t *testing.T) {
+	t.Cleanup(func() {
+		mr.Del(service.LOGIN_FAIL_IP_CACHE + ":" + testClientIP)
+		mr.Del(service.LOGIN_BACKOFF_IP_CACHE + ":" + testClientIP)
+	})
+}
+
+// wrongPasswordLogin 以错误的密码登录
+func wrongPasswordLogin(t *testing.T, email string) response {
+	t.Helper()
+	clearIPLoginFailures(t)
+
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	return call(t, "POST", "/api/v1/account/loginAccount", map[string]interface{}{
+		"email":                 email,
+		"password":              testPassword + "-wrong",
+		"img_verification_code": testCaptcha,
+	}, "")
+}
+
+func TestLoginFailureCountExpires(t *testing.T) {
+	email := "guard-expire@example.com"
+	register(t, email)
+
+	wrongPasswordLogin(t, email)
+	key := service.LOGIN_FAIL_ACCOUNT_CACHE + ":" + email
+	if count, _ := mr.Get(key); count != "1" {
+		t.Fatalf("失败计数 = %q，期望 1", count)
+	}
+	if ttl := mr.TTL(key); ttl <= 0 || ttl > service.LOGIN_FAIL_WINDOW {
+		t.Fatalf("失败计数有效期 = %s，期望在 (0, %s] 内", ttl, service.LOGIN_FAIL_WINDOW)
+	}
+}
+
+func TestLoginFailureLocksAccount(t *testing.T) {
+	email := "guard-lock@example.com"
+	register(t, email)
+	mr.Set(service.LOGIN_FAIL_ACCOUNT_CACHE+":"+email, strconv.Itoa(service.LOGIN_LOCK_THRESHOLD-1))
+
+	if resp := wrongPasswordLogin(t, email); resp.Code != bizErr.ACCOUNT_LOCKED {
+		t.Fatalf("达到失败上限的错误码 = %d (%s)，期望 %d", resp.Code, resp.Msg, bizErr.ACCOUNT_LOCKED)
+	}
+
+	// 锁定期间正确的密码同样被拒绝
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	expectCode(t, "POST", "/api/v1/account/loginAccount", map[string]interface{}{
+		"email":                 email,
+		"password":              testPassword,
+		"img_verification_code": testCaptcha,
+	}, "", bizErr.ACCOUNT_LOCKED)
+}
+
+func TestLoginFailureFailsClosedOnRedisError(t *testing.T) {
+	email := "guard-closed@example.com"
+	register(t, email)
+
+	// 失败计数无法累加时拒绝登录并返回记录失败的错误，而不是当作未计数的普通失败
+	mr.Set(service.LOGIN_FAIL_ACCOUNT_CACHE+":"+email, "not-a-number")
+	resp := wrongPasswordLogin(t, email)
+	if resp.Code != bizErr.SERVER_ERR || !strings.Contains(resp.Msg, "记录登录失败次数失败") {
+		t.Fatalf("失败计数写入失败时的响应 = %d (%s)，期望记录失败的服务端错误", resp.Code, resp.Msg)
+	}
+}
@@ -0,0 +1,147 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/account"
+)
+
+// 免密登录相关常量
+const (
+	MAGIC_LINK_CACHE          = "MAGIC:LINK"          // 当前有效的免密登录令牌 ID 键前缀，完整键为 MAGIC:LINK:<账户 ID>
+	MAGIC_LINK_COOLDOWN_CACHE = "MAGIC:LINK:COOLDOWN" // 免密登录链接发送冷却键前缀
+	MAGIC_LINK_DEFAULT_TTL    = time.Minute * 15      // 未配置 MAGIC_LINK_TTL 时的链接有效期
+	MAGIC_LINK_SEND_INTERVAL  = time.Minute           // 同一邮箱两次发送的最小间隔
+)
+
+// consumeOneTimeTokenScript 令牌标识与当前登记的一致时删除并返回 1，否则返回 0，保证免密登录、重置密码等链接只能兑换一次
+var consumeOneTimeTokenScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+return 0
+`)
+
+// SendMagicLink 向账户邮箱发送免密登录链接，新链接发出后此前未使用的链接随即失效；
+// 邮箱未注册时同样返回成功，避免借此探测已注册邮箱
+// 参数：
+//   - c: Gin 上下文
+//   - req: 发送免密登录链接请求
+//
+// 返回值：
+//   - error: 发送过于频繁时返回 LOGIN_TOO_FREQUENT，其余为操作过程中的错误
+func SendMagicLink(c *gin.Context, req *dto.SendMagicLinkRequest) error {
+	ctx := c.Request.Context()
+	email := strings.ToLower(req.Email)
+
+	ok, err := global.RedisClient.SetNX(ctx, loginCacheKey(MAGIC_LINK_COOLDOWN_CACHE, email), 1, MAGIC_LINK_SEND_INTERVAL).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询免密登录发送状态失败: %v", err)
+		return fmt.Errorf("查询免密登录发送状态失败: %w", err)
+	}
+	if !ok {
+		return bizErr.New(bizErr.LOGIN_TOO_FREQUENT, fmt.Sprintf("登录链接发送过于频繁，请 %d 秒后再试", int(MAGIC_LINK_SEND_INTERVAL/time.Second)))
+	}
+
+	acc, err := mapper.GetAccountByEmail(c, req.Email)
+	if err != nil {
+		utils.BizLogger(c).Warnf("「%s」用户不存在，未发送免密登录链接: %v", req.Email, err)
+		return nil
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	ttl := MAGIC_LINK_DEFAULT_TTL
+	if cfg.AppConfig.MagicLinkTTL > 0 {
+		ttl = time.Duration(cfg.AppConfig.MagicLinkTTL) * time.Minute
+	}
+
+	token, tokenID, err := utils.GenerateMagicLinkToken(acc.ID, ttl)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成「%s」免密登录令牌失败: %v", req.Email, err)
+		return err
+	}
+
+	key := magicLinkKey(acc.ID)
+	if err := global.RedisClient.Set(ctx, key, tokenID, ttl).Err(); err != nil {
+		utils.BizLogger(c).Errorf("登记「%s」免密登录令牌失败: %v", req.Email, err)
+		return fmt.Errorf("登记免密登录令牌失败: %w", err)
+	}
+
+	link := cfg.AppConfig.MagicLinkURL + "?token=" + url.QueryEscape(token)
+	content := fmt.Sprintf("点击以下链接登录%s，链接 %d 分钟内有效且只能使用一次：\n%s\n如非本人操作，请忽略本邮件。",
+		cfg.AppConfig.AppName, int(ttl/time.Minute), link)
+	if _, err := utils.SendEmail(content, []string{acc.Email}); err != nil {
+		utils.BizLogger(c).Errorf("「%s」免密登录邮件发送失败: %v", acc.Email, err)
+		global.RedisClient.Del(ctx, key, loginCacheKey(MAGIC_LINK_COOLDOWN_CACHE, email))
+		return fmt.Errorf("免密登录邮件发送失败: %w", err)
+	}
+
+	return nil
+}
+
+// MagicLogin 兑换免密登录链接中的令牌，校验签名与有效期，确认账户允许登录后核销令牌并完成登录
+// 参数：
+//   - c: Gin 上下文
+//   - req: 免密登录请求
+//
+// 返回值：
+//   - *account.LoginVO: 令牌视图对象，已启用两步验证时仅包含两步验证凭证
+//   - error: 链接无效、已过期或已使用时返回 MAGIC_LINK_INVALID
+func MagicLogin(c *gin.Context, req *dto.MagicLoginRequest) (*account.LoginVO, error) {
+	accountID, tokenID, err := utils.ParseMagicLinkToken(req.Token)
+	if err != nil {
+		utils.BizLogger(c).Errorf("免密登录令牌校验失败: %v", err)
+		return nil, bizErr.New(bizErr.MAGIC_LINK_INVALID, "登录链接无效或已过期，请重新获取")
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", accountID, err)
+		return nil, fmt.Errorf("「%d」账户不存在: %w", accountID, err)
+	}
+
+	// 账户被锁定或限流时保留令牌，解除后链接在有效期内仍可使用
+	if err := checkLoginAllowed(c, acc.Email); err != nil {
+		return nil, err
+	}
+
+	consumed, err := consumeOneTimeTokenScript.Run(c.Request.Context(), global.RedisClient, []string{magicLinkKey(accountID)}, tokenID).Int()
+	if err != nil {
+		utils.BizLogger(c).Errorf("核销免密登录令牌失败: %v", err)
+		return nil, fmt.Errorf("核销免密登录令牌失败: %w", err)
+	}
+	if consumed != 1 {
+		utils.BizLogger(c).Errorf("账户「%d」免密登录令牌已使用或已被新链接替代", accountID)
+		return nil, bizErr.New(bizErr.MAGIC_LINK_INVALID, "登录链接已使用或已失效，请重新获取")
+	}
+
+	return completeLogin(c, acc)
+}
+
+// magicLinkKey 生成免密登录令牌登记键
+// 参数：
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - string: 缓存键
+func magicLinkKey(accountID int64) string {
+	return fmt.Sprintf("%s:%d", MAGIC_LINK_CACHE, accountID)
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+	service "lease/pkg/serve/service/account"
+)
+
+// issueMagicLink 签发免密登录令牌并登记为账户当前有效的链接，等同于邮件中的链接
+func issueMagicLink(t *testing.T, email string) (int64, string) {
+	t.Helper()
+
+	var acc model.Account
+	if err := global.DB.Where("email = ?", email).First(&acc).Error; err != nil {
+		t.Fatalf("查询账户失败: %v", err)
+	}
+	token, tokenID, err := utils.GenerateMagicLinkToken(acc.ID, time.Minute)
+	if err != nil {
+		t.Fatalf("签发免密登录令牌失败: %v", err)
+	}
+	mr.Set(fmt.Sprintf("%s:%d", service.MAGIC_LINK_CACHE, acc.ID), tokenID)
+	return acc.ID, token
+}
+
+func TestMagicLoginSingleUse(t *testing.T) {
+	email := "magic-login@example.com"
+	register(t, email)
+	_, token := issueMagicLink(t, email)
+
+	var result loginResult
+	mustSucceed(t, "POST", "/api/v1/account/magicLogin", map[string]string{"token": token}, "", &result)
+	if result.AccessToken == "" {
+		t.Fatal("免密登录未返回访问令牌")
+	}
+	expectCode(t, "POST", "/api/v1/account/magicLogin", map[string]string{"token": token}, "", bizErr.MAGIC_LINK_INVALID)
+}
+
+func TestMagicLoginRejectsLegacySecret(t *testing.T) {
+	email := "magic-legacy@example.com"
+	register(t, email)
+	accountID, token := issueMagicLink(t, email)
+
+	// 以曾随源码公开的默认密钥伪造的令牌不能登录
+	claims, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("解析免密登录令牌失败: %v", err)
+	}
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims.Claims).SignedString([]byte("lease-magic-link-secret"))
+	if err != nil {
+		t.Fatalf("签名伪造令牌失败: %v", err)
+	}
+	expectCode(t, "POST", "/api/v1/account/magicLogin", map[string]string{"token": forged}, "", bizErr.MAGIC_LINK_INVALID)
+
+	if _, err := mr.Get(fmt.Sprintf("%s:%d", service.MAGIC_LINK_CACHE, accountID)); err != nil {
+		t.Fatal("伪造令牌不应核销账户当前有效的链接")
+	}
+}
+
+func TestMagicLoginKeepsTokenWhileLocked(t *testing.T) {
+	email := "magic-locked@example.com"
+	register(t, email)
+	_, token := issueMagicLink(t, email)
+
+	// 账户锁定期间拒绝登录且不核销链接，解除锁定后同一链接仍可登录
+	lockKey := service.LOGIN_LOCK_CACHE + ":" + email
+	mr.Set(lockKey, "1")
+	mr.SetTTL(lockKey, time.Minute)
+	expectCode(t, "POST", "/api/v1/account/magicLogin", map[string]string{"token": token}, "", bizErr.ACCOUNT_LOCKED)
+
+	mr.Del(lockKey)
+	mustSucceed(t, "POST", "/api/v1/account/magicLogin", map[string]string{"token": token}, "", nil)
+}
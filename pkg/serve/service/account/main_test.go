@@ -0,0 +1,253 @@
+package service_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+
+	"lease/configs"
+	"lease/internal/db"
+	"lease/internal/logger"
+	"lease/internal/middleware"
+	"lease/internal/oidc"
+	"lease/internal/oidc/oidctest"
+	"lease/internal/redis"
+	"lease/internal/sms"
+	"lease/internal/storage"
+	"lease/internal/utils"
+	"lease/pkg/router"
+)
+
+// 测试环境常量
+const (
+	testPassword     = "Lease2468x"
+	testCaptcha      = "1234"
+	testEmailCode    = "111111"
+	testOIDCProvider = "mock"
+)
+
+// testConfig 测试使用的配置，占位符依次为 Redis 端口、临时目录、模拟身份提供方地址
+const testConfig = `app:
+  APP_NAME: "Lease"
+  APP_HOST: "127.0.0.1"
+  APP_PORT: "9010"
+  EMAIL_TYPE: "qq"
+  FROM_EMAIL: "test@example.com"
+  EMAIL_SMTP: "test"
+database:
+  DB_DIALECT: "sqlite"
+  DB_NAME: "lease"
+  DB_PATH: "%[2]s/db"
+  DB_AUTO_MIGRATE: true
+redis:
+  REDIS_HOST: "127.0.0.1"
+  REDIS_PORT: "%[1]s"
+  REDIS_DB: "0"
+  REDIS_PSW: ""
+log:
+  LOG_FILE_PATH: "%[2]s/logs/"
+  LOG_FILE_NAME: "app.log"
+  LOG_TIMESTAMP_FMT: "2006-01-02 15:04:05"
+  LOG_MAX_AGE: 72
+  LOG_ROTATION_TIME: 24
+  LOG_LEVEL: "ERROR"
+swagger:
+  SWAGGER_HOST: "localhost:9010"
+  SWAGGER_ENABLED: "false"
+security:
+  JWT_ACCESS_SECRET: "test-access-secret-0123456789abcdef"
+  JWT_REFRESH_SECRET: "test-refresh-secret-0123456789abcdef"
+  MAGIC_LINK_SECRET: "test-magic-link-secret-0123456789abcdef"
+  PASSWORD_CHECK_BREACHED: false
+  MFA_REQUIRED_ROLES: ["property_manager"] # 自助注册的账户持有 landlord 与 admin，仅对单独授予的角色强制绑定，其余用例照常登录
+storage:
+  STORAGE_TYPE: "local"
+  STORAGE_LOCAL_PATH: "%[2]s/uploads"
+  STORAGE_PUBLIC_URL: "/uploads"
+sms:
+  SMS_PROVIDER: "file"
+  SMS_FILE_PATH: "%[2]s/sms.log"
+  SMS_SIGN_NAME: "Lease"
+  SMS_DEFAULT_COUNTRY_CODE: "86"
+oidc:
+  OIDC_AUTO_REGISTER: true
+  OIDC_STATE_TTL: 600
+  OIDC_PROVIDERS:
+    - NAME: Mock
+      DISPLAY_NAME: 模拟登录
+      ISSUER: "%[3]s"
+      CLIENT_ID: lease
+      CLIENT_SECRET: secret
+      REDIRECT_URL: http://localhost:3000/oidc/callback
+`
+
+var (
+	app      *gin.Engine
+	mr       *miniredis.Miniredis
+	mockIdP  *oidctest.Provider
+	tempDir  string
+	smsLogFn string
+)
+
+// response 接口响应，成功时不包含错误码
+type response struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// TestMain 以内存 Redis、临时 SQLite 数据库与模拟身份提供方启动完整的路由
+func TestMain(m *testing.M) {
+	code, err := setup(m)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "初始化测试环境失败: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// setup 初始化测试环境并执行测试
+func setup(m *testing.M) (int, error) {
+	var err error
+	if tempDir, err = os.MkdirTemp("", "lease-account-test"); err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tempDir)
+	smsLogFn = filepath.Join(tempDir, "sms.log")
+
+	if mr, err = miniredis.Run(); err != nil {
+		return 0, err
+	}
+	defer mr.Close()
+
+	if mockIdP, err = oidctest.NewProvider("lease", "secret"); err != nil {
+		return 0, err
+	}
+	idpServer := httptest.NewServer(mockIdP)
+	defer idpServer.Close()
+	mockIdP.SetIssuer(idpServer.URL + "/mock")
+
+	configPath := filepath.Join(tempDir, "config.yml")
+	content := fmt.Sprintf(testConfig, mr.Port(), tempDir, idpServer.URL+"/mock")
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		return 0, err
+	}
+	if err := configs.Init(configPath); err != nil {
+		return 0, err
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	if err := utils.InitJWTSecrets(config.SecurityConfig); err != nil {
+		return 0, err
+	}
+
+	logger.New()
+	gin.SetMode(gin.TestMode)
+	app = gin.New()
+	middleware.New(app)
+	db.New(config)
+	redis.New(config)
+	storage.New(config)
+	sms.New(config)
+	oidc.New(config)
+	router.New(app)
+
+	return m.Run(), nil
+}
+
+// call 以 JSON 请求体调用接口
+func call(t *testing.T, method, path string, body interface{}, token string) response {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("编码请求体失败: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+
+	var resp response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s %s 响应无法解析: %d %s", method, path, w.Code, w.Body.String())
+	}
+	return resp
+}
+
+// mustSucceed 调用接口，失败时终止测试，成功时将响应数据解析到 out
+func mustSucceed(t *testing.T, method, path string, body interface{}, token string, out interface{}) {
+	t.Helper()
+
+	resp := call(t, method, path, body, token)
+	if resp.Code != 0 {
+		t.Fatalf("%s %s 失败: %d %s", method, path, resp.Code, resp.Msg)
+	}
+	if out != nil {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			t.Fatalf("%s %s 响应数据无法解析: %v", method, path, err)
+		}
+	}
+}
+
+// expectCode 调用接口并断言返回的错误码
+func expectCode(t *testing.T, method, path string, body interface{}, token string, code int) {
+	t.Helper()
+
+	if resp := call(t, method, path, body, token); resp.Code != code {
+		t.Fatalf("%s %s 错误码 = %d (%s)，期望 %d", method, path, resp.Code, resp.Msg, code)
+	}
+}
+
+// loginResult 登录接口返回的令牌
+type loginResult struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	SessionID    string `json:"session_id"`
+}
+
+// register 以邮箱与密码注册账户
+func register(t *testing.T, email string) {
+	t.Helper()
+
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	mr.Set("EMAIL:VERIFICATION:CODE:"+email, testEmailCode)
+	mustSucceed(t, "POST", "/api/v1/account/registerAccount", map[string]interface{}{
+		"email":                   email,
+		"nickname":                "tester",
+		"password":                testPassword,
+		"email_verification_code": testEmailCode,
+		"img_verification_code":   testCaptcha,
+	}, "", nil)
+}
+
+// login 以邮箱与密码登录，返回访问令牌
+func login(t *testing.T, email string) string {
+	t.Helper()
+
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	var result loginResult
+	mustSucceed(t, "POST", "/api/v1/account/loginAccount", map[string]interface{}{
+		"email":                 email,
+		"password":              testPassword,
+		"img_verification_code": testCaptcha,
+	}, "", &result)
+	if result.AccessToken == "" {
+		t.Fatalf("「%s」登录未返回访问令牌", email)
+	}
+	return result.AccessToken
+}
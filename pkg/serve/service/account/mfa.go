@@ -0,0 +1,610 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/account"
+)
+
+// 两步验证相关常量
+const (
+	MFA_PENDING_CACHE        = "MFA:PENDING"    // 两步验证凭证键前缀，完整键为 MFA:PENDING:<凭证>
+	MFA_PENDING_EXPIRE_TIME  = time.Minute * 5  // 两步验证凭证有效期
+	MFA_PENDING_MAX_ATTEMPTS = 5                // 单个凭证允许的动态码校验次数，用尽后须重新输入密码登录
+	MFA_ENROLL_CACHE         = "MFA:ENROLL"     // 强制绑定凭证键前缀，完整键为 MFA:ENROLL:<凭证>
+	MFA_ENROLL_EXPIRE_TIME   = time.Minute * 15 // 强制绑定凭证有效期，留出安装身份验证器应用的时间
+	MFA_TOKEN_BYTES          = 32               // 两步验证凭证随机字节数
+	MFA_RECOVERY_CODE_COUNT  = 10               // 每次生成的恢复码数量
+	MFA_RECOVERY_CODE_BYTES  = 5                // 单个恢复码随机字节数，编码为 xxxxx-xxxxx 形式
+)
+
+// 两步验证凭证哈希字段
+const (
+	mfaPendingFieldAccountID = "account_id"
+	mfaPendingFieldAttempts  = "attempts"
+)
+
+// EnrollMFA 为当前账户生成新的 TOTP 密钥，需调用 ActivateMFA 校验动态码后才会启用
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *account.MFAEnrollVO: 密钥与 otpauth 绑定 URI
+//   - error: 操作过程中的错误
+func EnrollMFA(c *gin.Context) (*account.MFAEnrollVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	return enrollMFA(c, acc)
+}
+
+// ActivateMFA 校验动态码确认绑定并启用两步验证，同时生成一组恢复码
+// 参数：
+//   - c: Gin 上下文
+//   - req: 动态码请求
+//
+// 返回值：
+//   - *account.MFARecoveryCodesVO: 恢复码，仅此一次返回明文
+//   - error: 操作过程中的错误
+func ActivateMFA(c *gin.Context, req *dto.MFACodeRequest) (*account.MFARecoveryCodesVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := activateMFA(c, acc, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &account.MFARecoveryCodesVO{RecoveryCodes: codes}, nil
+}
+
+// EnrollRequiredMFA 角色要求启用两步验证的账户凭登录返回的强制绑定凭证获取 TOTP 密钥
+// 参数：
+//   - c: Gin 上下文
+//   - req: 强制绑定凭证请求
+//
+// 返回值：
+//   - *account.MFAEnrollVO: 密钥与 otpauth 绑定 URI
+//   - error: 凭证无效时返回 MFA_TOKEN_INVALID，其余为操作过程中的错误
+func EnrollRequiredMFA(c *gin.Context, req *dto.MFATokenRequest) (*account.MFAEnrollVO, error) {
+	acc, err := mfaEnrollAccount(c, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	return enrollMFA(c, acc)
+}
+
+// ActivateRequiredMFA 凭强制绑定凭证校验动态码并启用两步验证，启用后签发正式令牌并一并返回恢复码
+// 参数：
+//   - c: Gin 上下文
+//   - req: 强制绑定启用请求
+//
+// 返回值：
+//   - *account.LoginVO: 登录成功后的令牌视图对象，包含恢复码
+//   - error: 凭证无效时返回 MFA_TOKEN_INVALID，处于锁定或退避期时返回对应业务错误
+func ActivateRequiredMFA(c *gin.Context, req *dto.ActivateRequiredMFARequest) (*account.LoginVO, error) {
+	acc, err := mfaEnrollAccount(c, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLoginAllowed(c, acc.Email); err != nil {
+		return nil, err
+	}
+
+	ctx := c.Request.Context()
+	key := loginCacheKey(MFA_ENROLL_CACHE, req.MFAToken)
+	codes, err := activateMFA(c, acc, req.Code)
+	if err != nil {
+		// 尝试次数无法记录时同样作废凭证，避免绕过次数上限
+		attempts, incrErr := global.RedisClient.HIncrBy(ctx, key, mfaPendingFieldAttempts, 1).Result()
+		if incrErr != nil || attempts >= MFA_PENDING_MAX_ATTEMPTS {
+			global.RedisClient.Del(ctx, key)
+		}
+		return nil, err
+	}
+
+	// 两步验证已启用，凭证随之作废；并发提交时仅有一方能启用成功
+	if err := global.RedisClient.Del(ctx, key).Err(); err != nil {
+		utils.BizLogger(c).Warnf("删除「%s」强制绑定凭证失败: %v", acc.Email, err)
+	}
+	resetLoginFailures(c, acc.Email)
+
+	response, err := issueLoginTokens(c, acc)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = codes
+	return response, nil
+}
+
+// enrollMFA 为账户生成新的 TOTP 密钥，需调用 activateMFA 校验动态码后才会启用
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户
+//
+// 返回值：
+//   - *account.MFAEnrollVO: 密钥与 otpauth 绑定 URI
+//   - error: 操作过程中的错误
+func enrollMFA(c *gin.Context, acc *model.Account) (*account.MFAEnrollVO, error) {
+	mfa, err := mapper.GetAccountMFAByAccountID(c, acc.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.BizLogger(c).Errorf("查询「%s」两步验证配置失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("查询两步验证配置失败: %w", err)
+	}
+	if mfa != nil && mfa.Enabled {
+		utils.BizLogger(c).Errorf("「%s」已启用两步验证", acc.Email)
+		return nil, fmt.Errorf("已启用两步验证，如需更换设备请先停用")
+	}
+	if mfa == nil {
+		mfa = &model.AccountMFA{AccountID: acc.ID}
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成 TOTP 密钥失败: %v", err)
+		return nil, err
+	}
+	mfa.Secret = secret
+	mfa.LastUsedStep = 0
+
+	if err := mapper.SaveAccountMFA(c, mfa); err != nil {
+		utils.BizLogger(c).Errorf("保存「%s」两步验证密钥失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("保存两步验证密钥失败: %w", err)
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	return &account.MFAEnrollVO{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(cfg.AppConfig.AppName, acc.Email, secret),
+	}, nil
+}
+
+// activateMFA 校验动态码确认绑定并启用两步验证，同时生成一组恢复码
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户
+//   - code: 动态码
+//
+// 返回值：
+//   - []string: 恢复码，仅此一次返回明文
+//   - error: 操作过程中的错误
+func activateMFA(c *gin.Context, acc *model.Account, code string) ([]string, error) {
+	var codes []string
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		mfa, err := mapper.GetAccountMFAByAccountID(c, acc.ID)
+		if err != nil || mfa.Secret == "" {
+			utils.BizLogger(c).Errorf("「%s」尚未生成两步验证密钥: %v", acc.Email, err)
+			return fmt.Errorf("请先获取两步验证密钥")
+		}
+		if mfa.Enabled {
+			utils.BizLogger(c).Errorf("「%s」已启用两步验证", acc.Email)
+			return fmt.Errorf("已启用两步验证")
+		}
+
+		if err := consumeTOTP(c, mfa, code); err != nil {
+			return err
+		}
+
+		mfa.Enabled = true
+		mfa.EnabledAt = time.Now().Unix()
+		if err := mapper.SaveAccountMFA(c, mfa); err != nil {
+			utils.BizLogger(c).Errorf("启用「%s」两步验证失败: %v", acc.Email, err)
+			return fmt.Errorf("启用两步验证失败: %w", err)
+		}
+
+		codes, err = replaceRecoveryCodes(c, acc.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("「%s」已启用两步验证", acc.Email)
+	return codes, nil
+}
+
+// DisableMFA 校验动态码或恢复码后停用两步验证，并作废全部恢复码；角色要求启用两步验证的账户不能停用
+// 参数：
+//   - c: Gin 上下文
+//   - req: 停用两步验证请求
+//
+// 返回值：
+//   - error: 角色要求启用两步验证时返回 MFA_ENROLLMENT_REQUIRED，其余为操作过程中的错误
+func DisableMFA(c *gin.Context, req *dto.DisableMFARequest) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+
+	required, err := isMFARequired(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询「%s」角色失败: %v", acc.Email, err)
+		return err
+	}
+	if required {
+		utils.BizLogger(c).Errorf("「%s」的角色要求启用两步验证，拒绝停用", acc.Email)
+		return bizErr.New(bizErr.MFA_ENROLLMENT_REQUIRED, "当前账户的角色要求启用两步验证，不能停用")
+	}
+
+	return utils.RunDBTransaction(c, func(tx error) error {
+		mfa, err := enabledMFA(c, acc)
+		if err != nil {
+			return err
+		}
+
+		if err := checkSecondFactor(c, mfa, req.Code, req.RecoveryCode); err != nil {
+			return err
+		}
+
+		mfa.Enabled = false
+		mfa.EnabledAt = 0
+		mfa.Secret = ""
+		if err := mapper.SaveAccountMFA(c, mfa); err != nil {
+			utils.BizLogger(c).Errorf("停用「%s」两步验证失败: %v", acc.Email, err)
+			return fmt.Errorf("停用两步验证失败: %w", err)
+		}
+
+		if err := mapper.DeleteAccountRecoveryCodesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("作废「%s」恢复码失败: %v", acc.Email, err)
+			return err
+		}
+
+		utils.BizLogger(c).Infof("「%s」已停用两步验证", acc.Email)
+		return nil
+	})
+}
+
+// RegenerateRecoveryCodes 校验动态码后重新生成恢复码，原有恢复码全部作废
+// 参数：
+//   - c: Gin 上下文
+//   - req: 动态码请求
+//
+// 返回值：
+//   - *account.MFARecoveryCodesVO: 新的恢复码，仅此一次返回明文
+//   - error: 操作过程中的错误
+func RegenerateRecoveryCodes(c *gin.Context, req *dto.MFACodeRequest) (*account.MFARecoveryCodesVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		mfa, err := enabledMFA(c, acc)
+		if err != nil {
+			return err
+		}
+
+		if err := consumeTOTP(c, mfa, req.Code); err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(c, acc.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account.MFARecoveryCodesVO{RecoveryCodes: codes}, nil
+}
+
+// VerifyMFA 校验两步验证凭证与动态码（或恢复码），通过后签发正式的访问令牌与刷新令牌
+// 参数：
+//   - c: Gin 上下文
+//   - req: 两步验证登录请求
+//
+// 返回值：
+//   - *account.LoginVO: 登录成功后的令牌视图对象
+//   - error: 凭证无效时返回 MFA_TOKEN_INVALID，处于锁定或退避期时返回对应业务错误
+func VerifyMFA(c *gin.Context, req *dto.VerifyMFARequest) (*account.LoginVO, error) {
+	ctx := c.Request.Context()
+	key := loginCacheKey(MFA_PENDING_CACHE, req.MFAToken)
+
+	accountID, err := global.RedisClient.HGet(ctx, key, mfaPendingFieldAccountID).Int64()
+	if err != nil {
+		utils.BizLogger(c).Errorf("两步验证凭证无效: %v", err)
+		return nil, bizErr.New(bizErr.MFA_TOKEN_INVALID, "两步验证凭证无效或已过期，请重新登录")
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", accountID, err)
+		return nil, fmt.Errorf("「%d」账户不存在: %w", accountID, err)
+	}
+
+	// 动态码校验同样计入登录失败次数，防止拿到密码后对动态码进行爆破
+	if err := checkLoginAllowed(c, acc.Email); err != nil {
+		return nil, err
+	}
+
+	mfa, err := enabledMFA(c, acc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkSecondFactor(c, mfa, req.Code, req.RecoveryCode); err != nil {
+		// 尝试次数无法记录时同样作废凭证，避免绕过次数上限
+		attempts, incrErr := global.RedisClient.HIncrBy(ctx, key, mfaPendingFieldAttempts, 1).Result()
+		if incrErr != nil || attempts >= MFA_PENDING_MAX_ATTEMPTS {
+			global.RedisClient.Del(ctx, key)
+		}
+		if lockErr := recordLoginFailure(c, acc.Email); lockErr != nil {
+			global.RedisClient.Del(ctx, key)
+			return nil, lockErr
+		}
+		return nil, err
+	}
+
+	// 凭证只能使用一次，并发提交时仅删除成功的一方签发令牌
+	deleted, err := global.RedisClient.Del(ctx, key).Result()
+	if err != nil || deleted != 1 {
+		utils.BizLogger(c).Errorf("两步验证凭证已被使用: %v", err)
+		return nil, bizErr.New(bizErr.MFA_TOKEN_INVALID, "两步验证凭证无效或已过期，请重新登录")
+	}
+	resetLoginFailures(c, acc.Email)
+
+	return issueLoginTokens(c, acc)
+}
+
+// isMFAEnabled 查询账户是否已启用两步验证
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - bool: 是否已启用
+//   - error: 操作过程中的错误
+func isMFAEnabled(c *gin.Context, accountID int64) (bool, error) {
+	mfa, err := mapper.GetAccountMFAByAccountID(c, accountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return mfa.Enabled, nil
+}
+
+// isMFARequired 判断账户是否持有 security.MFA_REQUIRED_ROLES 中的角色，持有时必须启用两步验证
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - bool: 是否必须启用两步验证
+//   - error: 操作过程中的错误
+func isMFARequired(c *gin.Context, accountID int64) (bool, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return false, fmt.Errorf("加载配置失败: %w", err)
+	}
+	if len(cfg.SecurityConfig.MFARequiredRoles) == 0 {
+		return false, nil
+	}
+
+	roles, err := mapper.GetRolesByAccountID(c, accountID)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(roles, func(role *rbacModel.Role) bool {
+		return slices.Contains(cfg.SecurityConfig.MFARequiredRoles, role.Code)
+	}), nil
+}
+
+// createMFAToken 首要凭证校验通过后签发两步验证或强制绑定使用的短期凭证
+// 参数：
+//   - c: Gin 上下文
+//   - prefix: 凭证键前缀，MFA_PENDING_CACHE 或 MFA_ENROLL_CACHE
+//   - accountID: 账户 ID
+//   - expire: 凭证有效期
+//
+// 返回值：
+//   - string: 凭证
+//   - error: 操作过程中的错误
+func createMFAToken(c *gin.Context, prefix string, accountID int64, expire time.Duration) (string, error) {
+	buf := make([]byte, MFA_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成两步验证凭证失败: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	ctx := c.Request.Context()
+	key := loginCacheKey(prefix, token)
+	pipe := global.RedisClient.TxPipeline()
+	pipe.HSet(ctx, key, mfaPendingFieldAccountID, accountID, mfaPendingFieldAttempts, 0)
+	pipe.Expire(ctx, key, expire)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("保存两步验证凭证失败: %w", err)
+	}
+	return token, nil
+}
+
+// mfaEnrollAccount 解析强制绑定凭证对应的账户，账户须仍处于生效状态
+// 参数：
+//   - c: Gin 上下文
+//   - token: 强制绑定凭证
+//
+// 返回值：
+//   - *model.Account: 账户
+//   - error: 凭证无效时返回 MFA_TOKEN_INVALID，账户非生效状态时返回对应的账户状态错误
+func mfaEnrollAccount(c *gin.Context, token string) (*model.Account, error) {
+	key := loginCacheKey(MFA_ENROLL_CACHE, token)
+	accountID, err := global.RedisClient.HGet(c.Request.Context(), key, mfaPendingFieldAccountID).Int64()
+	if err != nil {
+		utils.BizLogger(c).Errorf("强制绑定凭证无效: %v", err)
+		return nil, bizErr.New(bizErr.MFA_TOKEN_INVALID, "两步验证绑定凭证无效或已过期，请重新登录")
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", accountID, err)
+		return nil, fmt.Errorf("「%d」账户不存在: %w", accountID, err)
+	}
+	if err := checkAccountActive(c, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// checkSecondFactor 校验动态码或恢复码，二者均提供时以动态码为准
+// 参数：
+//   - c: Gin 上下文
+//   - mfa: 已启用的两步验证配置
+//   - code: 动态码
+//   - recoveryCode: 恢复码
+//
+// 返回值：
+//   - error: 校验失败时返回错误
+func checkSecondFactor(c *gin.Context, mfa *model.AccountMFA, code, recoveryCode string) error {
+	switch {
+	case code != "":
+		return consumeTOTP(c, mfa, code)
+	case recoveryCode != "":
+		if err := mapper.UseAccountRecoveryCode(c, mfa.AccountID, hashRecoveryCode(recoveryCode), time.Now().Unix()); err != nil {
+			utils.BizLogger(c).Errorf("账户「%d」恢复码校验失败: %v", mfa.AccountID, err)
+			return fmt.Errorf("恢复码无效或已使用")
+		}
+		utils.BizLogger(c).Warnf("账户「%d」使用恢复码完成两步验证", mfa.AccountID)
+		return nil
+	default:
+		return fmt.Errorf("请输入动态码或恢复码")
+	}
+}
+
+// consumeTOTP 校验动态码并记录其时间步，同一动态码不能重复使用
+// 参数：
+//   - c: Gin 上下文
+//   - mfa: 两步验证配置
+//   - code: 动态码
+//
+// 返回值：
+//   - error: 校验失败或动态码已被使用时返回错误
+func consumeTOTP(c *gin.Context, mfa *model.AccountMFA, code string) error {
+	step, ok := utils.ValidateTOTP(mfa.Secret, code, time.Now())
+	if !ok {
+		utils.BizLogger(c).Errorf("账户「%d」动态码校验失败", mfa.AccountID)
+		return fmt.Errorf("动态码错误")
+	}
+	if step <= mfa.LastUsedStep {
+		utils.BizLogger(c).Errorf("账户「%d」动态码重复使用", mfa.AccountID)
+		return fmt.Errorf("动态码已被使用，请等待下一个动态码")
+	}
+	if err := mapper.UpdateAccountMFAUsedStep(c, mfa, step); err != nil {
+		utils.BizLogger(c).Errorf("账户「%d」记录动态码使用失败: %v", mfa.AccountID, err)
+		return fmt.Errorf("动态码已被使用，请等待下一个动态码")
+	}
+	mfa.LastUsedStep = step
+	return nil
+}
+
+// replaceRecoveryCodes 作废账户原有恢复码并生成新的一组，须在 utils.RunDBTransaction 中调用
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []string: 恢复码明文
+//   - error: 操作过程中的错误
+func replaceRecoveryCodes(c *gin.Context, accountID int64) ([]string, error) {
+	if err := mapper.DeleteAccountRecoveryCodesByAccountID(c, accountID); err != nil {
+		utils.BizLogger(c).Errorf("作废账户「%d」恢复码失败: %v", accountID, err)
+		return nil, err
+	}
+
+	codes := make([]string, 0, MFA_RECOVERY_CODE_COUNT)
+	records := make([]*model.AccountRecoveryCode, 0, MFA_RECOVERY_CODE_COUNT)
+	for range MFA_RECOVERY_CODE_COUNT {
+		buf := make([]byte, MFA_RECOVERY_CODE_BYTES)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("生成恢复码失败: %w", err)
+		}
+		raw := hex.EncodeToString(buf)
+		code := raw[:MFA_RECOVERY_CODE_BYTES] + "-" + raw[MFA_RECOVERY_CODE_BYTES:]
+		codes = append(codes, code)
+		records = append(records, &model.AccountRecoveryCode{AccountID: accountID, CodeHash: hashRecoveryCode(code)})
+	}
+
+	if err := mapper.CreateAccountRecoveryCodes(c, records); err != nil {
+		utils.BizLogger(c).Errorf("保存账户「%d」恢复码失败: %v", accountID, err)
+		return nil, err
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode 计算恢复码哈希，忽略大小写、空格与连字符
+// 参数：
+//   - code: 恢复码
+//
+// 返回值：
+//   - string: SHA-256 十六进制哈希
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// enabledMFA 获取账户已启用的两步验证配置
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户
+//
+// 返回值：
+//   - *model.AccountMFA: 两步验证配置
+//   - error: 未启用时返回错误
+func enabledMFA(c *gin.Context, acc *model.Account) (*model.AccountMFA, error) {
+	mfa, err := mapper.GetAccountMFAByAccountID(c, acc.ID)
+	if err != nil || !mfa.Enabled {
+		utils.BizLogger(c).Errorf("「%s」未启用两步验证: %v", acc.Email, err)
+		return nil, fmt.Errorf("未启用两步验证")
+	}
+	return mfa, nil
+}
+
+// currentAccount 获取当前登录账户
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *model.Account: 账户信息
+//   - error: 操作过程中的错误
+func currentAccount(c *gin.Context) (*model.Account, error) {
+	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %w", err)
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", accountID, err)
+		return nil, fmt.Errorf("账户不存在: %w", err)
+	}
+	return acc, nil
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	rbacModel "lease/internal/model/rbac"
+)
+
+// mfaLoginResult 登录接口返回的两步验证状态
+type mfaLoginResult struct {
+	loginResult
+	MFARequired           bool     `json:"mfa_required"`
+	MFAEnrollmentRequired bool     `json:"mfa_enrollment_required"`
+	MFAToken              string   `json:"mfa_token"`
+	RecoveryCodes         []string `json:"recovery_codes"`
+}
+
+// totp 按 RFC 6238 计算指定时刻的动态码
+func totp(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("解码 TOTP 密钥失败: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff)%1000000)
+}
+
+// grantRole 直接为账户授予角色
+func grantRole(t *testing.T, email, code string) {
+	t.Helper()
+
+	var acc model.Account
+	if err := global.DB.Where("email = ?", email).First(&acc).Error; err != nil {
+		t.Fatalf("查询账户失败: %v", err)
+	}
+	var role rbacModel.Role
+	if err := global.DB.Where("code = ?", code).First(&role).Error; err != nil {
+		t.Fatalf("查询角色失败: %v", err)
+	}
+	accountRole := &rbacModel.AccountRole{AccountID: acc.ID, RoleID: role.ID}
+	accountRole.OrganizationID = acc.OrganizationID
+	if err := global.DB.Create(accountRole).Error; err != nil {
+		t.Fatalf("授予角色失败: %v", err)
+	}
+}
+
+// passwordLogin 以邮箱与密码登录，返回包含两步验证状态的结果
+func passwordLogin(t *testing.T, email string) mfaLoginResult {
+	t.Helper()
+
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	var result mfaLoginResult
+	mustSucceed(t, "POST", "/api/v1/account/loginAccount", map[string]interface{}{
+		"email":                 email,
+		"password":              testPassword,
+		"img_verification_code": testCaptcha,
+	}, "", &result)
+	return result
+}
+
+func TestMFAEnrollmentRequiredForConfiguredRole(t *testing.T) {
+	email := "mfa-required@example.com"
+	register(t, email)
+	grantRole(t, email, rbacModel.ROLE_PROPERTY_MANAGER)
+
+	// 尚未启用两步验证时仅返回强制绑定凭证
+	result := passwordLogin(t, email)
+	if !result.MFAEnrollmentRequired || result.MFAToken == "" || result.AccessToken != "" {
+		t.Fatalf("未绑定两步验证的登录结果 = %+v，期望仅返回强制绑定凭证", result)
+	}
+	enrollToken := result.MFAToken
+
+	// 强制绑定凭证不能用于两步验证登录，两步验证凭证也不能用于绑定
+	expectCode(t, "POST", "/api/v1/account/verifyMFA", map[string]string{"mfa_token": enrollToken, "code": "000000"}, "", bizErr.MFA_TOKEN_INVALID)
+	expectCode(t, "POST", "/api/v1/account/enrollRequiredMFA", map[string]string{"mfa_token": "forged"}, "", bizErr.MFA_TOKEN_INVALID)
+
+	var enroll struct {
+		Secret string `json:"secret"`
+	}
+	mustSucceed(t, "POST", "/api/v1/account/enrollRequiredMFA", map[string]string{"mfa_token": enrollToken}, "", &enroll)
+
+	if resp := call(t, "POST", "/api/v1/account/activateRequiredMFA", map[string]string{"mfa_token": enrollToken, "code": "000000"}, ""); resp.Code == 0 {
+		t.Fatal("错误的动态码不应启用两步验证")
+	}
+
+	var activated mfaLoginResult
+	mustSucceed(t, "POST", "/api/v1/account/activateRequiredMFA", map[string]string{
+		"mfa_token": enrollToken,
+		"code":      totp(t, enroll.Secret, time.Now()),
+	}, "", &activated)
+	if activated.AccessToken == "" || len(activated.RecoveryCodes) == 0 {
+		t.Fatalf("完成强制绑定的结果 = %+v，期望返回访问令牌与恢复码", activated)
+	}
+
+	// 凭证只能使用一次
+	expectCode(t, "POST", "/api/v1/account/enrollRequiredMFA", map[string]string{"mfa_token": enrollToken}, "", bizErr.MFA_TOKEN_INVALID)
+
+	// 绑定后按常规两步验证登录
+	result = passwordLogin(t, email)
+	if !result.MFARequired || result.MFAEnrollmentRequired || result.AccessToken != "" {
+		t.Fatalf("绑定后的登录结果 = %+v，期望要求两步验证", result)
+	}
+
+	// 角色要求启用两步验证时不能停用
+	expectCode(t, "POST", "/api/v1/account/disableMFA", map[string]string{"recovery_code": activated.RecoveryCodes[0]}, activated.AccessToken, bizErr.MFA_ENROLLMENT_REQUIRED)
+}
+
+func TestMFAEnrollmentNotRequiredForOtherRoles(t *testing.T) {
+	email := "mfa-optional@example.com"
+	register(t, email)
+
+	if result := passwordLogin(t, email); result.MFAEnrollmentRequired || result.AccessToken == "" {
+		t.Fatalf("未持有强制角色的登录结果 = %+v，期望直接签发令牌", result)
+	}
+}
@@ -0,0 +1,524 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	organizationModel "lease/internal/model/organization"
+	"lease/internal/oidc"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+	organizationService "lease/pkg/serve/service/organization"
+	"lease/pkg/vo/account"
+)
+
+// 第三方登录相关常量
+const (
+	OIDC_STATE_CACHE         = "OIDC:STATE"     // 授权请求上下文键前缀，完整键为 OIDC:STATE:<state>
+	OIDC_STATE_DEFAULT_TTL   = time.Minute * 10 // 未配置 OIDC_STATE_TTL 时授权请求的有效期
+	OIDC_PURPOSE_LOGIN       = "login"          // 授权用途：登录
+	OIDC_PURPOSE_LINK        = "link"           // 授权用途：关联到当前账户
+	OIDC_NICKNAME_MAX_LENGTH = 20               // 自动注册账户的昵称最大长度，与注册接口一致
+)
+
+var identityLock sync.Mutex // 第三方身份关联锁，保护并发关联同一外部身份的操作
+
+// oidcState 发起授权时登记的请求上下文，回调时凭 state 取回且只能使用一次
+type oidcState struct {
+	Provider         string `json:"provider"`
+	Purpose          string `json:"purpose"`
+	Nonce            string `json:"nonce"`
+	Verifier         string `json:"verifier"`
+	AccountID        int64  `json:"account_id,omitempty"`
+	InvitationCode   string `json:"invitation_code,omitempty"`
+	OrganizationName string `json:"organization_name,omitempty"`
+}
+
+// ListOIDCProviders 获取可用于登录的身份提供方
+// 返回值：
+//   - []*account.OIDCProviderVO: 身份提供方列表
+func ListOIDCProviders() []*account.OIDCProviderVO {
+	list := make([]*account.OIDCProviderVO, 0, len(oidc.Providers()))
+	for _, provider := range oidc.Providers() {
+		list = append(list, &account.OIDCProviderVO{Name: provider.Name, DisplayName: provider.DisplayName})
+	}
+	return list
+}
+
+// OIDCAuthorize 发起第三方登录，生成携带 PKCE 与 nonce 的授权地址
+// 参数：
+//   - c: Gin 上下文
+//   - req: 发起第三方登录请求
+//
+// 返回值：
+//   - *account.OIDCAuthorizeVO: 授权地址与 state
+//   - error: 身份提供方未配置时返回 OIDC_PROVIDER_NOT_FOUND，其余为操作过程中的错误
+func OIDCAuthorize(c *gin.Context, req *dto.OIDCAuthorizeRequest) (*account.OIDCAuthorizeVO, error) {
+	return beginOIDCAuthorization(c, req.Provider, &oidcState{
+		Purpose:          OIDC_PURPOSE_LOGIN,
+		InvitationCode:   req.InvitationCode,
+		OrganizationName: req.OrganizationName,
+	})
+}
+
+// OIDCLogin 兑换授权码完成第三方登录：已关联的身份登录对应账户，未关联的身份在允许自动注册且邮箱已验证时创建新账户；
+// 邮箱已被注册的账户不会自动关联，须由账户本人登录后主动关联，避免借助第三方身份接管账户
+// 参数：
+//   - c: Gin 上下文
+//   - req: 第三方授权回调请求
+//
+// 返回值：
+//   - *account.LoginVO: 令牌视图对象，已启用两步验证时仅包含两步验证凭证
+//   - error: 授权请求无效时返回 OIDC_STATE_INVALID，身份未关联时返回 OIDC_IDENTITY_NOT_LINKED，其余为操作过程中的错误
+func OIDCLogin(c *gin.Context, req *dto.OIDCCallbackRequest) (*account.LoginVO, error) {
+	state, provider, claims, err := finishOIDCAuthorization(c, req, OIDC_PURPOSE_LOGIN)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := mapper.GetAccountIdentityByProviderSubject(c, provider.Name, claims.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.BizLogger(c).Errorf("查询「%s」第三方身份失败: %v", provider.Name, err)
+		return nil, fmt.Errorf("查询第三方身份失败: %w", err)
+	}
+	if identity == nil {
+		acc, err := registerOIDCAccount(c, provider, claims, state)
+		if err != nil {
+			return nil, err
+		}
+		return completeLogin(c, acc)
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, identity.AccountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", identity.AccountID, err)
+		return nil, fmt.Errorf("「%d」账户不存在: %w", identity.AccountID, err)
+	}
+	if err := checkLoginAllowed(c, acc.Email); err != nil {
+		return nil, err
+	}
+	if err := mapper.UpdateAccountIdentityLastLogin(c, identity, time.Now().Unix()); err != nil {
+		utils.BizLogger(c).Warnf("记录「%s」第三方登录时间失败: %v", acc.Email, err)
+	}
+
+	return completeLogin(c, acc)
+}
+
+// LinkIdentity 为当前账户发起第三方身份关联，生成授权地址
+// 参数：
+//   - c: Gin 上下文
+//   - req: 第三方身份操作请求
+//
+// 返回值：
+//   - *account.OIDCAuthorizeVO: 授权地址与 state
+//   - error: 已关联该提供方时返回 OIDC_IDENTITY_CONFLICT，其余为操作过程中的错误
+func LinkIdentity(c *gin.Context, req *dto.IdentityProviderRequest) (*account.OIDCAuthorizeVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := mapper.GetAccountIdentityByAccountProvider(c, acc.ID, strings.ToLower(req.Provider)); err == nil {
+		return nil, bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "当前账户已关联该身份提供方，请先解除关联")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.BizLogger(c).Errorf("查询「%s」第三方身份失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("查询第三方身份失败: %w", err)
+	}
+
+	return beginOIDCAuthorization(c, req.Provider, &oidcState{Purpose: OIDC_PURPOSE_LINK, AccountID: acc.ID})
+}
+
+// ConfirmLinkIdentity 兑换授权码，将第三方身份关联到发起关联的当前账户
+// 参数：
+//   - c: Gin 上下文
+//   - req: 第三方授权回调请求
+//
+// 返回值：
+//   - *account.AccountIdentityVO: 已关联的第三方身份
+//   - error: 授权请求无效时返回 OIDC_STATE_INVALID，身份已关联时返回 OIDC_IDENTITY_CONFLICT，其余为操作过程中的错误
+func ConfirmLinkIdentity(c *gin.Context, req *dto.OIDCCallbackRequest) (*account.AccountIdentityVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	state, provider, claims, err := finishOIDCAuthorization(c, req, OIDC_PURPOSE_LINK)
+	if err != nil {
+		return nil, err
+	}
+	if state.AccountID != acc.ID {
+		utils.BizLogger(c).Errorf("「%s」提交的关联请求由账户「%d」发起", acc.Email, state.AccountID)
+		return nil, bizErr.New(bizErr.OIDC_STATE_INVALID, "关联请求无效，请重新发起")
+	}
+
+	identityLock.Lock()
+	defer identityLock.Unlock()
+
+	identity := &model.AccountIdentity{
+		AccountID: acc.ID,
+		Provider:  provider.Name,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		LinkedAt:  time.Now().Unix(),
+	}
+	identity.OrganizationID = acc.OrganizationID
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 外部身份在全部组织内唯一，须跨组织检查是否已关联其他账户
+		existing, err := mapper.GetAccountIdentityByProviderSubject(c, provider.Name, claims.Subject)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("查询「%s」第三方身份失败: %v", provider.Name, err)
+			return fmt.Errorf("查询第三方身份失败: %w", err)
+		}
+		if existing != nil {
+			utils.BizLogger(c).Errorf("「%s」第三方身份已关联账户「%d」", provider.Name, existing.AccountID)
+			return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "该第三方身份已关联其他账户")
+		}
+
+		if _, err := mapper.GetAccountIdentityByAccountProvider(c, acc.ID, provider.Name); err == nil {
+			return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "当前账户已关联该身份提供方，请先解除关联")
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("查询「%s」第三方身份失败: %v", acc.Email, err)
+			return fmt.Errorf("查询第三方身份失败: %w", err)
+		}
+
+		if err := mapper.CreateAccountIdentity(c, identity); errors.Is(err, gorm.ErrDuplicatedKey) {
+			utils.BizLogger(c).Errorf("「%s」第三方身份已被并发关联", provider.Name)
+			return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "该第三方身份已关联其他账户")
+		} else if err != nil {
+			utils.BizLogger(c).Errorf("「%s」关联第三方身份失败: %v", acc.Email, err)
+			return fmt.Errorf("关联第三方身份失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("「%s」已关联「%s」第三方身份", acc.Email, provider.Name)
+	return identityVO(identity), nil
+}
+
+// UnlinkIdentity 解除当前账户与第三方身份的关联；未设置密码的账户不能解除最后一个第三方身份，避免无法再登录
+// 参数：
+//   - c: Gin 上下文
+//   - req: 第三方身份操作请求
+//
+// 返回值：
+//   - error: 未关联该提供方或解除后无法登录时返回 OIDC_IDENTITY_CONFLICT，其余为操作过程中的错误
+func UnlinkIdentity(c *gin.Context, req *dto.IdentityProviderRequest) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+
+	identityLock.Lock()
+	defer identityLock.Unlock()
+
+	identities, err := mapper.ListAccountIdentitiesByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询「%s」第三方身份失败: %v", acc.Email, err)
+		return fmt.Errorf("查询第三方身份失败: %w", err)
+	}
+
+	var target *model.AccountIdentity
+	for _, identity := range identities {
+		if identity.Provider == strings.ToLower(req.Provider) {
+			target = identity
+			break
+		}
+	}
+	if target == nil {
+		return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "当前账户未关联该身份提供方")
+	}
+	if acc.Password == "" && len(identities) == 1 {
+		return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "账户尚未设置密码，请先通过找回密码设置密码后再解除关联")
+	}
+
+	if err := mapper.DeleteAccountIdentity(c, target); err != nil {
+		utils.BizLogger(c).Errorf("「%s」解除第三方身份关联失败: %v", acc.Email, err)
+		return fmt.Errorf("解除第三方身份关联失败: %w", err)
+	}
+
+	utils.BizLogger(c).Infof("「%s」已解除「%s」第三方身份关联", acc.Email, target.Provider)
+	return nil
+}
+
+// ListIdentities 获取当前账户已关联的第三方身份
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - []*account.AccountIdentityVO: 第三方身份列表
+//   - error: 操作过程中的错误
+func ListIdentities(c *gin.Context) ([]*account.AccountIdentityVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := mapper.ListAccountIdentitiesByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询「%s」第三方身份失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("查询第三方身份失败: %w", err)
+	}
+
+	list := make([]*account.AccountIdentityVO, 0, len(identities))
+	for _, identity := range identities {
+		list = append(list, identityVO(identity))
+	}
+	return list, nil
+}
+
+// beginOIDCAuthorization 生成 state、nonce 与 PKCE code_verifier，登记授权请求上下文后返回授权地址
+// 参数：
+//   - c: Gin 上下文
+//   - providerName: 身份提供方标识
+//   - state: 授权请求上下文，由本函数补全提供方与随机值
+//
+// 返回值：
+//   - *account.OIDCAuthorizeVO: 授权地址与 state
+//   - error: 身份提供方未配置时返回 OIDC_PROVIDER_NOT_FOUND，其余为操作过程中的错误
+func beginOIDCAuthorization(c *gin.Context, providerName string, state *oidcState) (*account.OIDCAuthorizeVO, error) {
+	provider, err := oidc.Get(providerName)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取身份提供方失败: %v", err)
+		return nil, bizErr.New(bizErr.OIDC_PROVIDER_NOT_FOUND, fmt.Sprintf("身份提供方「%s」不存在", providerName))
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+	ttl := OIDC_STATE_DEFAULT_TTL
+	if cfg.OIDCConfig.StateTTL > 0 {
+		ttl = time.Duration(cfg.OIDCConfig.StateTTL) * time.Second
+	}
+
+	stateKey, err := oidc.NewState()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成第三方登录 state 失败: %v", err)
+		return nil, err
+	}
+	if state.Nonce, err = oidc.NewState(); err != nil {
+		utils.BizLogger(c).Errorf("生成第三方登录 nonce 失败: %v", err)
+		return nil, err
+	}
+	if state.Verifier, err = oidc.NewCodeVerifier(); err != nil {
+		utils.BizLogger(c).Errorf("生成 PKCE code_verifier 失败: %v", err)
+		return nil, err
+	}
+	state.Provider = provider.Name
+
+	authorizationURL, err := provider.AuthCodeURL(c.Request.Context(), stateKey, state.Nonce, state.Verifier)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成「%s」授权地址失败: %v", provider.Name, err)
+		return nil, bizErr.New(bizErr.OIDC_LOGIN_FAILED, "身份提供方暂时不可用，请稍后再试")
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		utils.BizLogger(c).Errorf("序列化第三方登录请求失败: %v", err)
+		return nil, fmt.Errorf("序列化第三方登录请求失败: %w", err)
+	}
+	if err := global.RedisClient.Set(c.Request.Context(), oidcStateKey(stateKey), payload, ttl).Err(); err != nil {
+		utils.BizLogger(c).Errorf("登记第三方登录请求失败: %v", err)
+		return nil, fmt.Errorf("登记第三方登录请求失败: %w", err)
+	}
+
+	return &account.OIDCAuthorizeVO{AuthorizationURL: authorizationURL, State: stateKey}, nil
+}
+
+// finishOIDCAuthorization 核销 state 取回授权请求上下文，兑换授权码并校验 ID Token
+// 参数：
+//   - c: Gin 上下文
+//   - req: 第三方授权回调请求
+//   - purpose: 期望的授权用途
+//
+// 返回值：
+//   - *oidcState: 授权请求上下文
+//   - *oidc.Provider: 身份提供方
+//   - *oidc.Claims: ID Token 中的用户信息
+//   - error: state 无效、已使用或用途不符时返回 OIDC_STATE_INVALID，兑换或校验失败时返回 OIDC_LOGIN_FAILED
+func finishOIDCAuthorization(c *gin.Context, req *dto.OIDCCallbackRequest, purpose string) (*oidcState, *oidc.Provider, *oidc.Claims, error) {
+	payload, err := global.RedisClient.GetDel(c.Request.Context(), oidcStateKey(req.State)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		utils.BizLogger(c).Errorf("第三方登录 state 不存在、已过期或已使用")
+		return nil, nil, nil, bizErr.New(bizErr.OIDC_STATE_INVALID, "登录请求已过期或已使用，请重新发起")
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("核销第三方登录请求失败: %v", err)
+		return nil, nil, nil, fmt.Errorf("核销第三方登录请求失败: %w", err)
+	}
+
+	state := new(oidcState)
+	if err := json.Unmarshal(payload, state); err != nil {
+		utils.BizLogger(c).Errorf("解析第三方登录请求失败: %v", err)
+		return nil, nil, nil, fmt.Errorf("解析第三方登录请求失败: %w", err)
+	}
+	if state.Purpose != purpose {
+		utils.BizLogger(c).Errorf("第三方登录请求用途「%s」与接口「%s」不符", state.Purpose, purpose)
+		return nil, nil, nil, bizErr.New(bizErr.OIDC_STATE_INVALID, "登录请求无效，请重新发起")
+	}
+
+	provider, err := oidc.Get(state.Provider)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取身份提供方失败: %v", err)
+		return nil, nil, nil, bizErr.New(bizErr.OIDC_PROVIDER_NOT_FOUND, fmt.Sprintf("身份提供方「%s」不存在", state.Provider))
+	}
+
+	claims, err := provider.Exchange(c.Request.Context(), req.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」授权码兑换失败: %v", provider.Name, err)
+		return nil, nil, nil, bizErr.New(bizErr.OIDC_LOGIN_FAILED, "第三方身份认证失败，请重新登录")
+	}
+
+	return state, provider, claims, nil
+}
+
+// registerOIDCAccount 为未关联的第三方身份自动注册账户并建立关联，账户直接生效且不设密码
+// 参数：
+//   - c: Gin 上下文
+//   - provider: 身份提供方
+//   - claims: ID Token 中的用户信息
+//   - state: 授权请求上下文，携带邀请码与新建组织名称
+//
+// 返回值：
+//   - *model.Account: 新注册的账户
+//   - error: 不允许自动注册或邮箱已注册时返回 OIDC_IDENTITY_NOT_LINKED，邮箱未验证时返回 OIDC_LOGIN_FAILED，其余为操作过程中的错误
+func registerOIDCAccount(c *gin.Context, provider *oidc.Provider, claims *oidc.Claims, state *oidcState) (*model.Account, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+	if !cfg.OIDCConfig.AutoRegister {
+		return nil, bizErr.New(bizErr.OIDC_IDENTITY_NOT_LINKED, "该第三方身份尚未关联账户，请先登录已有账户后关联")
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		utils.BizLogger(c).Errorf("「%s」身份提供方未返回已验证的邮箱，无法自动注册", provider.Name)
+		return nil, bizErr.New(bizErr.OIDC_LOGIN_FAILED, "第三方账户邮箱未验证，无法自动注册")
+	}
+
+	registerLock.Lock()
+	defer registerLock.Unlock()
+
+	var acc *model.Account
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		if existingUser, _ := mapper.GetAccountByEmail(c, claims.Email); existingUser != nil {
+			utils.BizLogger(c).Errorf("「%s」邮箱已被注册，拒绝自动关联第三方身份", claims.Email)
+			return bizErr.New(bizErr.OIDC_IDENTITY_NOT_LINKED, "该邮箱已注册，请使用原账户登录后关联第三方身份")
+		}
+		if _, err := mapper.GetAccountIdentityByProviderSubject(c, provider.Name, claims.Subject); err == nil {
+			return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "该第三方身份已关联其他账户")
+		}
+
+		var invitation *organizationModel.OrganizationInvitation
+		if state.InvitationCode != "" {
+			invitation, err = organizationService.CheckInvitation(c, state.InvitationCode, claims.Email)
+			if err != nil {
+				return err
+			}
+		}
+
+		acc = &model.Account{
+			Email:    claims.Email,
+			Nickname: oidcNickname(claims),
+			Status:   model.ACCOUNT_STATUS_ACTIVE,
+		}
+		if invitation != nil {
+			acc.OrganizationID = invitation.OrganizationID
+		}
+		if err := mapper.CreateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("「%s」第三方登录自动注册失败: %v", claims.Email, err)
+			return fmt.Errorf("「%s」第三方登录自动注册失败: %w", claims.Email, err)
+		}
+
+		if err := joinOrganization(c, acc, invitation, state.OrganizationName); err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+		identity := &model.AccountIdentity{
+			AccountID:   acc.ID,
+			Provider:    provider.Name,
+			Subject:     claims.Subject,
+			Email:       claims.Email,
+			LinkedAt:    now,
+			LastLoginAt: now,
+		}
+		identity.OrganizationID = acc.OrganizationID
+		if err := mapper.CreateAccountIdentity(c, identity); errors.Is(err, gorm.ErrDuplicatedKey) {
+			utils.BizLogger(c).Errorf("「%s」第三方身份已被并发关联", provider.Name)
+			return bizErr.New(bizErr.OIDC_IDENTITY_CONFLICT, "该第三方身份已关联其他账户")
+		} else if err != nil {
+			utils.BizLogger(c).Errorf("「%s」关联第三方身份失败: %v", claims.Email, err)
+			return fmt.Errorf("关联第三方身份失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("「%s」通过「%s」第三方登录自动注册", acc.Email, provider.Name)
+	return acc, nil
+}
+
+// oidcNickname 取第三方身份的显示名称作为昵称，未提供时取邮箱用户名，超长部分截断
+// 参数：
+//   - claims: ID Token 中的用户信息
+//
+// 返回值：
+//   - string: 昵称
+func oidcNickname(claims *oidc.Claims) string {
+	nickname := strings.TrimSpace(claims.Name)
+	if nickname == "" {
+		nickname, _, _ = strings.Cut(claims.Email, "@")
+	}
+	if utf8.RuneCountInString(nickname) > OIDC_NICKNAME_MAX_LENGTH {
+		nickname = string([]rune(nickname)[:OIDC_NICKNAME_MAX_LENGTH])
+	}
+	return nickname
+}
+
+// identityVO 将第三方身份映射为视图对象
+// 参数：
+//   - identity: 第三方身份
+//
+// 返回值：
+//   - *account.AccountIdentityVO: 第三方身份视图对象
+func identityVO(identity *model.AccountIdentity) *account.AccountIdentityVO {
+	return &account.AccountIdentityVO{
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		LinkedAt:    identity.LinkedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
+
+// oidcStateKey 生成授权请求上下文缓存键
+// 参数：
+//   - state: 授权请求的 state
+//
+// 返回值：
+//   - string: 缓存键
+func oidcStateKey(state string) string {
+	return fmt.Sprintf("%s:%s", OIDC_STATE_CACHE, state)
+}
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	"lease/internal/oidc/oidctest"
+	service "lease/pkg/serve/service/account"
+)
+
+// authorizeResult 发起授权接口返回的授权地址与 state
+type authorizeResult struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// oidcCallback 模拟浏览器在身份提供方完成授权，返回回调参数
+func oidcCallback(t *testing.T, result authorizeResult) map[string]string {
+	t.Helper()
+
+	code, state, err := oidctest.Authorize(result.AuthorizationURL)
+	if err != nil {
+		t.Fatalf("授权失败: %v", err)
+	}
+	if state != result.State {
+		t.Fatalf("回调 state = %q，期望 %q", state, result.State)
+	}
+	return map[string]string{"state": state, "code": code}
+}
+
+// oidcAuthorize 发起第三方登录并完成授权，返回回调参数
+func oidcAuthorize(t *testing.T, user oidctest.User) map[string]string {
+	t.Helper()
+
+	mockIdP.SetUser(user)
+	var result authorizeResult
+	mustSucceed(t, "POST", "/api/v1/account/oidcAuthorize", map[string]string{"provider": testOIDCProvider}, "", &result)
+	return oidcCallback(t, result)
+}
+
+// oidcLogin 完成第三方登录，返回访问令牌
+func oidcLogin(t *testing.T, user oidctest.User) string {
+	t.Helper()
+
+	var result loginResult
+	mustSucceed(t, "POST", "/api/v1/account/oidcLogin", oidcAuthorize(t, user), "", &result)
+	if result.AccessToken == "" {
+		t.Fatalf("「%s」第三方登录未返回访问令牌", user.Email)
+	}
+	return result.AccessToken
+}
+
+// tamperOIDCState 修改服务端登记的授权请求上下文
+func tamperOIDCState(t *testing.T, state string, update func(map[string]interface{})) {
+	t.Helper()
+
+	key := service.OIDC_STATE_CACHE + ":" + state
+	raw, err := mr.Get(key)
+	if err != nil {
+		t.Fatalf("读取授权请求上下文失败: %v", err)
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("解析授权请求上下文失败: %v", err)
+	}
+	update(payload)
+	tampered, _ := json.Marshal(payload)
+	mr.Set(key, string(tampered))
+}
+
+// identityCount 统计外部身份的关联记录数
+func identityCount(t *testing.T, subject string) int64 {
+	t.Helper()
+
+	var count int64
+	if err := global.DB.Model(&model.AccountIdentity{}).Where("provider = ? AND subject = ?", testOIDCProvider, subject).Count(&count).Error; err != nil {
+		t.Fatalf("查询第三方身份失败: %v", err)
+	}
+	return count
+}
+
+func TestOIDCAutoRegisterAndLogin(t *testing.T) {
+	user := oidctest.User{Subject: "auto-register", Email: "oidc-auto@example.com", EmailVerified: true, Name: "自动注册"}
+
+	token := oidcLogin(t, user)
+
+	var identities []map[string]interface{}
+	mustSucceed(t, "GET", "/api/v1/account/identities", nil, token, &identities)
+	if len(identities) != 1 || identities[0]["provider"] != testOIDCProvider {
+		t.Fatalf("自动注册后的第三方身份 = %v", identities)
+	}
+
+	// 再次登录使用已关联的账户，不重复注册
+	oidcLogin(t, user)
+	var accounts int64
+	global.DB.Model(&model.Account{}).Where("email = ?", user.Email).Count(&accounts)
+	if accounts != 1 || identityCount(t, user.Subject) != 1 {
+		t.Fatalf("重复登录后账户数 = %d，身份数 = %d，期望均为 1", accounts, identityCount(t, user.Subject))
+	}
+}
+
+func TestOIDCAutoRegisterRequiresVerifiedEmail(t *testing.T) {
+	user := oidctest.User{Subject: "unverified", Email: "oidc-unverified@example.com", Name: "未验证"}
+
+	expectCode(t, "POST", "/api/v1/account/oidcLogin", oidcAuthorize(t, user), "", bizErr.OIDC_LOGIN_FAILED)
+	if identityCount(t, user.Subject) != 0 {
+		t.Fatal("邮箱未验证的身份不应自动注册")
+	}
+}
+
+func TestOIDCLoginDoesNotTakeOverRegisteredEmail(t *testing.T) {
+	email := "oidc-existing@example.com"
+	register(t, email)
+
+	user := oidctest.User{Subject: "takeover", Email: email, EmailVerified: true}
+	expectCode(t, "POST", "/api/v1/account/oidcLogin", oidcAuthorize(t, user), "", bizErr.OIDC_IDENTITY_NOT_LINKED)
+}
+
+func TestOIDCStateSingleUse(t *testing.T) {
+	user := oidctest.User{Subject: "single-use", Email: "oidc-single-use@example.com", EmailVerified: true}
+	callback := oidcAuthorize(t, user)
+
+	mustSucceed(t, "POST", "/api/v1/account/oidcLogin", callback, "", nil)
+	expectCode(t, "POST", "/api/v1/account/oidcLogin", callback, "", bizErr.OIDC_STATE_INVALID)
+}
+
+func TestOIDCNonceMismatch(t *testing.T) {
+	user := oidctest.User{Subject: "nonce", Email: "oidc-nonce@example.com", EmailVerified: true}
+	callback := oidcAuthorize(t, user)
+	tamperOIDCState(t, callback["state"], func(state map[string]interface{}) { state["nonce"] = "forged" })
+
+	expectCode(t, "POST", "/api/v1/account/oidcLogin", callback, "", bizErr.OIDC_LOGIN_FAILED)
+	if identityCount(t, user.Subject) != 0 {
+		t.Fatal("nonce 不匹配时不应注册账户")
+	}
+}
+
+func TestOIDCVerifierMismatch(t *testing.T) {
+	user := oidctest.User{Subject: "pkce", Email: "oidc-pkce@example.com", EmailVerified: true}
+	callback := oidcAuthorize(t, user)
+	tamperOIDCState(t, callback["state"], func(state map[string]interface{}) {
+		state["verifier"] = "forged-verifier-forged-verifier-forged-verifier"
+	})
+
+	expectCode(t, "POST", "/api/v1/account/oidcLogin", callback, "", bizErr.OIDC_LOGIN_FAILED)
+	if identityCount(t, user.Subject) != 0 {
+		t.Fatal("PKCE 校验失败时不应注册账户")
+	}
+}
+
+func TestOIDCLinkIdentity(t *testing.T) {
+	owner, other := "oidc-link-owner@example.com", "oidc-link-other@example.com"
+	register(t, owner)
+	register(t, other)
+	ownerToken, otherToken := login(t, owner), login(t, other)
+	user := oidctest.User{Subject: "link", Email: "oidc-link-idp@example.com", EmailVerified: true}
+
+	// 关联到当前账户
+	mockIdP.SetUser(user)
+	var result authorizeResult
+	mustSucceed(t, "POST", "/api/v1/account/linkIdentity", map[string]string{"provider": testOIDCProvider}, ownerToken, &result)
+	mustSucceed(t, "POST", "/api/v1/account/confirmLinkIdentity", oidcCallback(t, result), ownerToken, nil)
+
+	// 已关联的身份登录到关联的账户
+	token := oidcLogin(t, user)
+	var identities []map[string]interface{}
+	mustSucceed(t, "GET", "/api/v1/account/identities", nil, token, &identities)
+	if len(identities) != 1 || identities[0]["email"] != user.Email {
+		t.Fatalf("第三方登录后的第三方身份 = %v", identities)
+	}
+	var me map[string]interface{}
+	mustSucceed(t, "GET", "/api/v1/account/me", nil, token, &me)
+	if me["email"] != owner {
+		t.Fatalf("第三方登录的账户 = %v，期望 %s", me["email"], owner)
+	}
+
+	// 同一外部身份不能再关联到其他账户
+	mustSucceed(t, "POST", "/api/v1/account/linkIdentity", map[string]string{"provider": testOIDCProvider}, otherToken, &result)
+	expectCode(t, "POST", "/api/v1/account/confirmLinkIdentity", oidcCallback(t, result), otherToken, bizErr.OIDC_IDENTITY_CONFLICT)
+	if identityCount(t, user.Subject) != 1 {
+		t.Fatalf("外部身份关联记录数 = %d，期望 1", identityCount(t, user.Subject))
+	}
+
+	// 关联请求不能由其他账户确认
+	mustSucceed(t, "POST", "/api/v1/account/linkIdentity", map[string]string{"provider": testOIDCProvider}, otherToken, &result)
+	expectCode(t, "POST", "/api/v1/account/confirmLinkIdentity", oidcCallback(t, result), ownerToken, bizErr.OIDC_STATE_INVALID)
+}
+
+func TestOIDCIdentityUniqueAcrossOrganizations(t *testing.T) {
+	user := oidctest.User{Subject: "unique", Email: "oidc-unique@example.com", EmailVerified: true}
+	oidcLogin(t, user)
+
+	duplicate := &model.AccountIdentity{AccountID: 1, Provider: testOIDCProvider, Subject: user.Subject}
+	duplicate.OrganizationID = 1
+	if err := global.DB.Create(duplicate).Error; !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("其他组织重复关联外部身份的错误 = %v，期望 ErrDuplicatedKey", err)
+	}
+}
+
+func TestOIDCRelinkAfterUnlink(t *testing.T) {
+	email := "oidc-relink@example.com"
+	register(t, email)
+	token := login(t, email)
+	mockIdP.SetUser(oidctest.User{Subject: "relink", Email: "oidc-relink-idp@example.com", EmailVerified: true})
+
+	for i := 0; i < 2; i++ {
+		var result authorizeResult
+		mustSucceed(t, "POST", "/api/v1/account/linkIdentity", map[string]string{"provider": testOIDCProvider}, token, &result)
+		mustSucceed(t, "POST", "/api/v1/account/confirmLinkIdentity", oidcCallback(t, result), token, nil)
+		mustSucceed(t, "POST", "/api/v1/account/unlinkIdentity", map[string]string{"provider": testOIDCProvider}, token, nil)
+	}
+	if identityCount(t, "relink") != 0 {
+		t.Fatal("解除关联后不应保留第三方身份记录")
+	}
+}
@@ -0,0 +1,117 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	model "lease/internal/model/account"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+)
+
+// checkPasswordPolicy 按 security 配置校验新密码的长度、字符类型以及是否属于常见或已泄露密码
+// 参数：
+//   - c: Gin 上下文
+//   - password: 新密码
+//
+// 返回值：
+//   - error: 不满足策略时返回 PASSWORD_POLICY_VIOLATION
+func checkPasswordPolicy(c *gin.Context, password string) error {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	if err := utils.CheckPasswordPolicy(password, cfg.SecurityConfig); err != nil {
+		utils.BizLogger(c).Errorf("新密码不符合安全策略: %v", err)
+		return bizErr.New(bizErr.PASSWORD_POLICY_VIOLATION, err.Error())
+	}
+	return nil
+}
+
+// checkPasswordReuse 校验新密码是否与当前密码或最近 PASSWORD_HISTORY_SIZE 次设置的密码相同
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 账户
+//   - password: 新密码
+//
+// 返回值：
+//   - error: 重复使用时返回 PASSWORD_POLICY_VIOLATION
+func checkPasswordReuse(c *gin.Context, acc *model.Account, password string) error {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	historySize := cfg.SecurityConfig.PasswordHistorySize
+	if historySize <= 0 {
+		return nil
+	}
+
+	histories, err := mapper.ListRecentAccountPasswordHistories(c, acc.ID, historySize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询「%s」密码历史失败: %v", acc.Email, err)
+		return err
+	}
+
+	// 启用密码历史前设置的密码没有历史记录，当前密码单独比对
+	hashes := make([]string, 0, len(histories)+1)
+	hashes = append(hashes, acc.Password)
+	for _, history := range histories {
+		hashes = append(hashes, history.PasswordHash)
+	}
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			utils.BizLogger(c).Errorf("「%s」新密码与最近使用过的密码相同", acc.Email)
+			return bizErr.New(bizErr.PASSWORD_POLICY_VIOLATION, fmt.Sprintf("新密码不能与最近 %d 次使用过的密码相同", historySize))
+		}
+	}
+	return nil
+}
+
+// recordPasswordHistory 记录账户当前密码，并只保留最近 PASSWORD_HISTORY_SIZE 条历史
+// 参数：
+//   - c: Gin 上下文
+//   - acc: 已更新密码的账户
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func recordPasswordHistory(c *gin.Context, acc *model.Account) error {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	historySize := cfg.SecurityConfig.PasswordHistorySize
+	if historySize <= 0 {
+		return nil
+	}
+
+	history := &model.AccountPasswordHistory{AccountID: acc.ID, PasswordHash: acc.Password}
+	history.OrganizationID = acc.OrganizationID
+	if err := mapper.CreateAccountPasswordHistory(c, history); err != nil {
+		utils.BizLogger(c).Errorf("记录「%s」密码历史失败: %v", acc.Email, err)
+		return err
+	}
+
+	recent, err := mapper.ListRecentAccountPasswordHistories(c, acc.ID, historySize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询「%s」密码历史失败: %v", acc.Email, err)
+		return err
+	}
+	keepIDs := make([]int64, 0, len(recent))
+	for _, h := range recent {
+		keepIDs = append(keepIDs, h.ID)
+	}
+	if err := mapper.DeleteAccountPasswordHistoriesExcept(c, acc.ID, keepIDs); err != nil {
+		utils.BizLogger(c).Errorf("清理「%s」密码历史失败: %v", acc.Email, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"lease/configs"
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+)
+
+// 找回密码相关常量
+const (
+	PASSWORD_RESET_CACHE        = "PASSWORD:RESET"             // 当前有效的重置令牌哈希键前缀，完整键为 PASSWORD:RESET:<账户 ID>
+	PASSWORD_RESET_LIMIT_EMAIL  = "PASSWORD:RESET:LIMIT:EMAIL" // 邮箱请求计数键前缀
+	PASSWORD_RESET_LIMIT_IP     = "PASSWORD:RESET:LIMIT:IP"    // IP 请求计数键前缀
+	PASSWORD_RESET_EXPIRE_TIME  = time.Minute * 30             // 重置令牌有效期
+	PASSWORD_RESET_LIMIT_WINDOW = time.Hour                    // 请求计数窗口
+	PASSWORD_RESET_EMAIL_LIMIT  = 3                            // 同一邮箱在计数窗口内允许的请求次数
+	PASSWORD_RESET_IP_LIMIT     = 10                           // 同一 IP 在计数窗口内允许的请求次数
+	PASSWORD_RESET_TOKEN_BYTES  = 32                           // 重置令牌随机字节数
+)
+
+// ForgotPassword 未登录用户申请找回密码，向账户邮箱发送一次性重置链接，新链接发出后此前未使用的链接随即失效；
+// 邮箱未注册时同样返回成功，避免借此探测已注册邮箱
+// 参数：
+//   - c: Gin 上下文
+//   - req: 找回密码请求
+//
+// 返回值：
+//   - error: 超过频率限制时返回 PASSWORD_RESET_TOO_FREQUENT，其余为操作过程中的错误
+func ForgotPassword(c *gin.Context, req *dto.ForgotPasswordRequest) error {
+	ctx := c.Request.Context()
+	email := strings.ToLower(req.Email)
+
+	limits := []struct {
+		key   string
+		limit int64
+	}{
+		{loginCacheKey(PASSWORD_RESET_LIMIT_EMAIL, email), PASSWORD_RESET_EMAIL_LIMIT},
+		{loginCacheKey(PASSWORD_RESET_LIMIT_IP, c.ClientIP()), PASSWORD_RESET_IP_LIMIT},
+	}
+	for _, l := range limits {
+		count, err := incrPasswordResetCount(c, l.key)
+		if err != nil {
+			return err
+		}
+		if count > l.limit {
+			utils.BizLogger(c).Warnf("找回密码请求过于频繁: %s", l.key)
+			return bizErr.New(bizErr.PASSWORD_RESET_TOO_FREQUENT, "找回密码请求过于频繁，请稍后再试")
+		}
+	}
+
+	acc, err := mapper.GetAccountByEmail(c, req.Email)
+	if err != nil {
+		utils.BizLogger(c).Warnf("「%s」用户不存在，未发送重置密码链接: %v", req.Email, err)
+		return nil
+	}
+
+	buf := make([]byte, PASSWORD_RESET_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		utils.BizLogger(c).Errorf("生成重置令牌失败: %v", err)
+		return fmt.Errorf("生成重置令牌失败: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	// 仅保存令牌哈希，缓存泄露也无法据此重置密码
+	key := passwordResetKey(acc.ID)
+	if err := global.RedisClient.Set(ctx, key, hashResetToken(token), PASSWORD_RESET_EXPIRE_TIME).Err(); err != nil {
+		utils.BizLogger(c).Errorf("登记「%s」重置令牌失败: %v", req.Email, err)
+		return fmt.Errorf("登记重置令牌失败: %w", err)
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("email", acc.Email)
+	query.Set("token", token)
+	content := fmt.Sprintf("您正在找回%s账户密码，请在 %d 分钟内点击以下链接设置新密码，链接只能使用一次：\n%s?%s\n如非本人操作，请忽略本邮件。",
+		cfg.AppConfig.AppName, int(PASSWORD_RESET_EXPIRE_TIME/time.Minute), cfg.AppConfig.PasswordResetURL, query.Encode())
+	if _, err := utils.SendEmail(content, []string{acc.Email}); err != nil {
+		utils.BizLogger(c).Errorf("「%s」重置密码邮件发送失败: %v", acc.Email, err)
+		global.RedisClient.Del(ctx, key)
+		return fmt.Errorf("重置密码邮件发送失败: %w", err)
+	}
+
+	return nil
+}
+
+// ResetForgottenPassword 使用重置链接中的令牌设置新密码，成功后注销账户全部会话并清除登录失败计数
+// 参数：
+//   - c: Gin 上下文
+//   - req: 重置密码请求
+//
+// 返回值：
+//   - error: 令牌无效、已过期或已使用时返回 PASSWORD_RESET_INVALID，其余为操作过程中的错误
+func ResetForgottenPassword(c *gin.Context, req *dto.ResetForgottenPasswordRequest) error {
+	if req.NewPassword != req.AgainNewPassword {
+		utils.BizLogger(c).Errorf("两次密码输入不一致")
+		return fmt.Errorf("两次密码输入不一致")
+	}
+
+	if err := checkPasswordPolicy(c, req.NewPassword); err != nil {
+		return err
+	}
+
+	acc, err := mapper.GetAccountByEmail(c, req.Email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
+		return bizErr.New(bizErr.PASSWORD_RESET_INVALID, "重置密码链接无效或已过期，请重新申请")
+	}
+
+	// 先确认令牌有效再比对密码历史，避免无令牌时借此探测历史密码；比对未通过时令牌保留，可换个密码重试
+	ctx := c.Request.Context()
+	key, tokenHash := passwordResetKey(acc.ID), hashResetToken(req.Token)
+	if stored, err := global.RedisClient.Get(ctx, key).Result(); err != nil || stored != tokenHash {
+		utils.BizLogger(c).Errorf("「%s」重置令牌无效或已使用: %v", req.Email, err)
+		return bizErr.New(bizErr.PASSWORD_RESET_INVALID, "重置密码链接无效或已过期，请重新申请")
+	}
+	if err := checkPasswordReuse(c, acc, req.NewPassword); err != nil {
+		return err
+	}
+
+	consumed, err := consumeOneTimeTokenScript.Run(ctx, global.RedisClient, []string{key}, tokenHash).Int()
+	if err != nil {
+		utils.BizLogger(c).Errorf("核销重置令牌失败: %v", err)
+		return fmt.Errorf("核销重置令牌失败: %w", err)
+	}
+	if consumed != 1 {
+		utils.BizLogger(c).Errorf("「%s」重置令牌无效或已使用", req.Email)
+		return bizErr.New(bizErr.PASSWORD_RESET_INVALID, "重置密码链接无效或已过期，请重新申请")
+	}
+
+	passwordResetLock.Lock()
+	defer passwordResetLock.Unlock()
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		newPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			utils.BizLogger(c).Errorf("密码加密失败: %v", err)
+			return fmt.Errorf("密码加密失败: %w", err)
+		}
+		acc.Password = string(newPassword)
+
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("密码重置失败: %v", err)
+			return fmt.Errorf("密码重置失败: %w", err)
+		}
+		return recordPasswordHistory(c, acc)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 密码可能已泄露，注销全部设备上的会话
+	if err := utils.RevokeAllSessions(ctx, acc.ID); err != nil {
+		utils.BizLogger(c).Errorf("重置密码后注销「%s」全部会话失败: %v", acc.Email, err)
+		return fmt.Errorf("注销全部会话失败: %w", err)
+	}
+	resetLoginFailures(c, acc.Email)
+
+	utils.BizLogger(c).Infof("「%s」已通过邮件链接重置密码", acc.Email)
+	return nil
+}
+
+// hashResetToken 计算重置令牌哈希
+// 参数：
+//   - token: 重置令牌
+//
+// 返回值：
+//   - string: SHA-256 十六进制哈希
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// passwordResetKey 生成重置令牌登记键
+// 参数：
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - string: 缓存键
+func passwordResetKey(accountID int64) string {
+	return fmt.Sprintf("%s:%d", PASSWORD_RESET_CACHE, accountID)
+}
+
+// incrPasswordResetCount 在同一事务管道中累加找回密码请求次数并设置计数窗口，避免计数键因有效期未设置而永久存在
+// 参数：
+//   - c: Gin 上下文
+//   - key: 计数键
+//
+// 返回值：
+//   - int64: 累加后的请求次数
+//   - error: 操作过程中的错误
+func incrPasswordResetCount(c *gin.Context, key string) (int64, error) {
+	ctx := c.Request.Context()
+	pipe := global.RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	expire := pipe.Expire(ctx, key, PASSWORD_RESET_LIMIT_WINDOW)
+	if _, err := pipe.Exec(ctx); err != nil {
+		utils.BizLogger(c).Errorf("记录找回密码请求次数失败: %v", err)
+		return 0, fmt.Errorf("记录找回密码请求次数失败: %w", err)
+	}
+	if !expire.Val() {
+		utils.BizLogger(c).Errorf("设置找回密码请求计数「%s」有效期失败", key)
+		return 0, fmt.Errorf("设置找回密码请求计数有效期失败")
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,46 @@
+package service_test
+
+import (
+	"testing"
+
+	bizErr "lease/internal/error"
+	service "lease/pkg/serve/service/account"
+)
+
+// forgotPassword 申请找回密码
+func forgotPassword(t *testing.T, email string) response {
+	t.Helper()
+
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	return call(t, "POST", "/api/v1/account/forgotPassword", map[string]interface{}{
+		"email":                 email,
+		"img_verification_code": testCaptcha,
+	}, "")
+}
+
+// TestForgotPasswordRateLimit 找回密码请求计数带有效期，超过同一邮箱的请求次数上限后拒绝
+func TestForgotPasswordRateLimit(t *testing.T) {
+	// 未注册的邮箱不发送邮件，仅累加请求计数
+	email := "reset-limit@example.com"
+	emailKey := service.PASSWORD_RESET_LIMIT_EMAIL + ":" + email
+	ipKey := service.PASSWORD_RESET_LIMIT_IP + ":" + testClientIP
+	t.Cleanup(func() {
+		mr.Del(emailKey)
+		mr.Del(ipKey)
+	})
+
+	for i := 0; i < service.PASSWORD_RESET_EMAIL_LIMIT; i++ {
+		if resp := forgotPassword(t, email); resp.Code != 0 {
+			t.Fatalf("第 %d 次找回密码失败: %d %s", i+1, resp.Code, resp.Msg)
+		}
+	}
+	for _, key := range []string{emailKey, ipKey} {
+		if ttl := mr.TTL(key); ttl <= 0 || ttl > service.PASSWORD_RESET_LIMIT_WINDOW {
+			t.Fatalf("请求计数「%s」有效期 = %s，期望在 (0, %s] 内", key, ttl, service.PASSWORD_RESET_LIMIT_WINDOW)
+		}
+	}
+
+	if resp := forgotPassword(t, email); resp.Code != bizErr.PASSWORD_RESET_TOO_FREQUENT {
+		t.Fatalf("超过请求次数上限的错误码 = %d (%s)，期望 %d", resp.Code, resp.Msg, bizErr.PASSWORD_RESET_TOO_FREQUENT)
+	}
+}
@@ -0,0 +1,132 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	"lease/internal/sms"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/controller/verification"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/account"
+)
+
+// VerifyPhone 校验短信验证码后将手机号绑定到当前账户并标记为已验证
+// 参数：
+//   - c: Gin 上下文
+//   - req: 绑定手机号请求
+//
+// 返回值：
+//   - *account.ProfileVO: 绑定后的个人资料
+//   - error: 手机号无效返回 PHONE_INVALID，验证码错误返回 PHONE_CODE_INVALID，已被使用返回 PHONE_ALREADY_USED
+func VerifyPhone(c *gin.Context, req *dto.VerifyPhoneRequest) (*account.ProfileVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := normalizePhone(c, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPhoneAvailable(c, phone, acc.ID); err != nil {
+		return nil, err
+	}
+	if !verification.VerifyPhoneCode(c, req.Code, phone) {
+		return nil, bizErr.New(bizErr.PHONE_CODE_INVALID, "短信验证码错误或已过期")
+	}
+
+	if err := mapper.UpdateAccountColumns(c, acc.ID, map[string]interface{}{
+		"phone":          phone,
+		"phone_verified": true,
+		"gmt_modified":   time.Now().Unix(),
+	}); err != nil {
+		utils.BizLogger(c).Errorf("「%s」绑定手机号失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("绑定手机号失败: %w", err)
+	}
+	acc.Phone, acc.PhoneVerified = phone, true
+
+	utils.BizLogger(c).Infof("「%s」已绑定手机号 %s", acc.Email, phone)
+	return profileVO(acc), nil
+}
+
+// LoginByPhone 使用已验证的手机号与短信验证码登录，与密码登录共用失败退避与锁定；已启用两步验证的账户返回两步验证凭证
+// 参数：
+//   - c: Gin 上下文
+//   - req: 手机号登录请求
+//
+// 返回值：
+//   - *account.LoginVO: 令牌视图对象
+//   - error: 手机号未绑定或验证码错误时均返回 PHONE_CODE_INVALID，避免借此探测已绑定手机号
+func LoginByPhone(c *gin.Context, req *dto.PhoneLoginRequest) (*account.LoginVO, error) {
+	phone, err := normalizePhone(c, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已绑定账户按邮箱计数，与密码登录共享锁定状态；未绑定的手机号按号码计数
+	subject := phone
+	acc, err := mapper.GetAccountByPhone(c, phone)
+	if err == nil && acc.PhoneVerified {
+		subject = acc.Email
+	} else {
+		acc = nil
+	}
+
+	if err := checkLoginAllowed(c, subject); err != nil {
+		return nil, err
+	}
+
+	if acc == nil || !verification.VerifyPhoneCode(c, req.Code, phone) {
+		utils.BizLogger(c).Errorf("手机号「%s」登录失败：未绑定或验证码错误", phone)
+		if lockErr := recordLoginFailure(c, subject); lockErr != nil {
+			return nil, lockErr
+		}
+		return nil, bizErr.New(bizErr.PHONE_CODE_INVALID, "手机号或短信验证码错误")
+	}
+
+	return completeLogin(c, acc)
+}
+
+// normalizePhone 将用户输入的手机号规范化为 E.164 格式
+// 参数：
+//   - c: Gin 上下文
+//   - phone: 用户输入的手机号
+//
+// 返回值：
+//   - string: E.164 格式手机号
+//   - error: 格式无效时返回 PHONE_INVALID
+func normalizePhone(c *gin.Context, phone string) (string, error) {
+	normalized, err := sms.NormalizePhone(phone)
+	if err != nil {
+		utils.BizLogger(c).Errorf("手机号「%s」格式无效: %v", phone, err)
+		return "", bizErr.New(bizErr.PHONE_INVALID, "手机号格式无效")
+	}
+	return normalized, nil
+}
+
+// checkPhoneAvailable 校验手机号未被其他账户使用
+// 参数：
+//   - c: Gin 上下文
+//   - phone: E.164 格式手机号
+//   - accountID: 当前账户 ID，新注册账户传 0
+//
+// 返回值：
+//   - error: 已被使用返回 PHONE_ALREADY_USED，其余为操作过程中的错误
+func checkPhoneAvailable(c *gin.Context, phone string, accountID int64) error {
+	used, err := mapper.ExistsAccountByPhone(c, phone, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询手机号「%s」失败: %v", phone, err)
+		return fmt.Errorf("查询手机号失败: %w", err)
+	}
+	if used {
+		utils.BizLogger(c).Errorf("手机号「%s」已被其他账户使用", phone)
+		return bizErr.New(bizErr.PHONE_ALREADY_USED, "手机号已被其他账户使用")
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	bizErr "lease/internal/error"
+	"lease/pkg/serve/controller/verification"
+)
+
+// smsCodePattern 短信内容中的 6 位验证码
+var smsCodePattern = regexp.MustCompile(`\d{6}`)
+
+// profileResult 个人资料接口返回的手机号信息
+type profileResult struct {
+	Phone         string `json:"phone"`
+	PhoneVerified bool   `json:"phone_verified"`
+}
+
+// sendPhoneCode 请求发送短信验证码，从 file 发送方的输出文件读取发送给 E.164 号码 want 的最新验证码
+func sendPhoneCode(t *testing.T, phone, want string) string {
+	t.Helper()
+	t.Cleanup(func() { mr.Del(verification.PHONE_VERIFICATION_LIMIT_IP_PREFIX + testClientIP) })
+
+	mustSucceed(t, "GET", "/api/v1/verification/sendPhoneVerificationCode?phone="+url.QueryEscape(phone), nil, "", nil)
+
+	f, err := os.Open(smsLogFn)
+	if err != nil {
+		t.Fatalf("读取短信输出文件失败: %v", err)
+	}
+	defer f.Close()
+
+	var code string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var message struct {
+			Phone   string `json:"phone"`
+			Content string `json:"content"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatalf("解析短信记录失败: %v", err)
+		}
+		if message.Phone == want {
+			code = smsCodePattern.FindString(message.Content)
+		}
+	}
+	if code == "" {
+		t.Fatalf("未找到发送给 %s 的短信验证码", want)
+	}
+	return code
+}
+
+// bindPhone 为账户绑定并验证手机号
+func bindPhone(t *testing.T, token, phone, want string) {
+	t.Helper()
+
+	code := sendPhoneCode(t, phone, want)
+	mustSucceed(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": phone, "code": code}, token, nil)
+}
+
+func TestVerifyPhoneNormalizesToE164(t *testing.T) {
+	email := "phone-e164@example.com"
+	register(t, email)
+	token := login(t, email)
+
+	// 发送与绑定时的号码写法不同，均规范化为同一 E.164 号码
+	code := sendPhoneCode(t, "138-0013-8001", "+8613800138001")
+	var profile profileResult
+	mustSucceed(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": "+86 138 0013 8001", "code": code}, token, &profile)
+	if profile.Phone != "+8613800138001" || !profile.PhoneVerified {
+		t.Fatalf("绑定后的手机号 = %+v，期望已验证的 +8613800138001", profile)
+	}
+
+	expectCode(t, "GET", "/api/v1/verification/sendPhoneVerificationCode?phone=not-a-phone", nil, "", bizErr.PHONE_INVALID)
+	expectCode(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": "abc", "code": "123456"}, token, bizErr.PHONE_INVALID)
+}
+
+func TestPhoneUniqueAcrossAccounts(t *testing.T) {
+	owner, other := "phone-owner@example.com", "phone-other@example.com"
+	register(t, owner)
+	register(t, other)
+	bindPhone(t, login(t, owner), "13800138002", "+8613800138002")
+
+	// 其他写法的同一号码同样视为已被使用
+	otherToken := login(t, other)
+	code := sendPhoneCode(t, "0086 138 0013 8002", "+8613800138002")
+	expectCode(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": "0086 138 0013 8002", "code": code}, otherToken, bizErr.PHONE_ALREADY_USED)
+	expectCode(t, "PATCH", "/api/v1/account/me", map[string]string{"phone": "+8613800138002"}, otherToken, bizErr.PHONE_ALREADY_USED)
+
+	email := "phone-register@example.com"
+	mr.Set("IMG:VERIFICATION:CODE:CACHE:"+email, testCaptcha)
+	mr.Set("EMAIL:VERIFICATION:CODE:"+email, testEmailCode)
+	expectCode(t, "POST", "/api/v1/account/registerAccount", map[string]interface{}{
+		"email":                   email,
+		"nickname":                "tester",
+		"password":                testPassword,
+		"phone":                   "138 0013 8002",
+		"email_verification_code": testEmailCode,
+		"img_verification_code":   testCaptcha,
+	}, "", bizErr.PHONE_ALREADY_USED)
+}
+
+func TestPhoneCodeExpiresAndCannotBeReused(t *testing.T) {
+	email := "phone-code@example.com"
+	register(t, email)
+	token := login(t, email)
+	phone := "13800138003"
+
+	// 有效期内不重复发送
+	code := sendPhoneCode(t, phone, "+8613800138003")
+	expectCode(t, "GET", "/api/v1/verification/sendPhoneVerificationCode?phone="+phone, nil, "", bizErr.SMS_TOO_FREQUENT)
+
+	// 过期后验证码失效，可重新发送
+	mr.FastForward(verification.PHONE_VERIFICATION_CODE_CACHE_EXPIRATION + time.Second)
+	expectCode(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": phone, "code": code}, token, bizErr.PHONE_CODE_INVALID)
+
+	code = sendPhoneCode(t, phone, "+8613800138003")
+	expectCode(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": phone, "code": "000000"}, token, bizErr.PHONE_CODE_INVALID)
+	mustSucceed(t, "POST", "/api/v1/account/verifyPhone", map[string]string{"phone": phone, "code": code}, token, nil)
+
+	// 验证码校验通过后即作废
+	clearIPLoginFailures(t)
+	expectCode(t, "POST", "/api/v1/account/loginByPhone", map[string]string{"phone": phone, "code": code}, "", bizErr.PHONE_CODE_INVALID)
+}
+
+func TestLoginByPhone(t *testing.T) {
+	email := "phone-login@example.com"
+	register(t, email)
+	bindPhone(t, login(t, email), "+86 138 0013 8004", "+8613800138004")
+	clearIPLoginFailures(t)
+
+	code := sendPhoneCode(t, "138 0013 8004", "+8613800138004")
+	var result loginResult
+	mustSucceed(t, "POST", "/api/v1/account/loginByPhone", map[string]string{"phone": "138 0013 8004", "code": code}, "", &result)
+	if result.AccessToken == "" {
+		t.Fatal("手机号登录未返回访问令牌")
+	}
+	var me map[string]interface{}
+	mustSucceed(t, "GET", "/api/v1/account/me", nil, result.AccessToken, &me)
+	if me["email"] != email {
+		t.Fatalf("手机号登录的账户 = %v，期望 %s", me["email"], email)
+	}
+
+	// 同一验证码不能再次登录
+	expectCode(t, "POST", "/api/v1/account/loginByPhone", map[string]string{"phone": "138 0013 8004", "code": code}, "", bizErr.PHONE_CODE_INVALID)
+
+	// 未绑定的号码与验证码错误返回相同的错误，避免探测已绑定的号码
+	unbound := "13800138005"
+	code = sendPhoneCode(t, unbound, "+8613800138005")
+	expectCode(t, "POST", "/api/v1/account/loginByPhone", map[string]string{"phone": unbound, "code": code}, "", bizErr.PHONE_CODE_INVALID)
+}
+
+func TestLoginByUnverifiedPhone(t *testing.T) {
+	email := "phone-unverified@example.com"
+	register(t, email)
+	token := login(t, email)
+	clearIPLoginFailures(t)
+
+	// 个人资料中填写但未经短信验证的号码不能用于登录
+	phone := "13800138006"
+	mustSucceed(t, "PATCH", "/api/v1/account/me", map[string]string{"phone": phone}, token, nil)
+	code := sendPhoneCode(t, phone, "+8613800138006")
+	expectCode(t, "POST", "/api/v1/account/loginByPhone", map[string]string{"phone": phone, "code": code}, "", bizErr.PHONE_CODE_INVALID)
+}
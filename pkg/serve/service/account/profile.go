@@ -0,0 +1,242 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	model "lease/internal/model/account"
+	"lease/internal/storage"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/account"
+)
+
+// 头像上传相关常量
+const (
+	AVATAR_MAX_SIZE    = 2 << 20   // 头像文件大小上限，2 MB
+	AVATAR_MAX_SIDE    = 512       // 头像缩放后长边的最大像素数
+	AVATAR_STORAGE_DIR = "avatars" // 头像对象键前缀，完整键为 avatars/<账户 ID>/<文件 ID>.<扩展名>
+)
+
+// GetMe 获取当前账户的个人资料
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *account.ProfileVO: 个人资料
+//   - error: 操作过程中的错误
+func GetMe(c *gin.Context) (*account.ProfileVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	return profileVO(acc), nil
+}
+
+// UpdateMe 修改当前账户的个人资料，仅更新请求中传入的字段
+// 参数：
+//   - c: Gin 上下文
+//   - req: 修改个人资料请求
+//
+// 返回值：
+//   - *account.ProfileVO: 修改后的个人资料
+//   - error: 手机号无效时返回 PHONE_INVALID，已被使用时返回 PHONE_ALREADY_USED，其余为操作过程中的错误
+func UpdateMe(c *gin.Context, req *dto.UpdateProfileRequest) (*account.ProfileVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]interface{}{}
+	if req.Nickname != nil {
+		nickname := strings.TrimSpace(*req.Nickname)
+		if nickname == "" {
+			utils.BizLogger(c).Errorf("昵称不能为空")
+			return nil, fmt.Errorf("昵称不能为空")
+		}
+		columns["nickname"] = nickname
+		acc.Nickname = nickname
+	}
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if phone != "" {
+			if phone, err = normalizePhone(c, phone); err != nil {
+				return nil, err
+			}
+		}
+		// 更换或解绑手机号后须重新验证才能用于登录；解绑时写入 NULL，避免多个空字符串触发唯一约束
+		if phone != acc.Phone {
+			if phone == "" {
+				columns["phone"] = nil
+			} else {
+				if err := checkPhoneAvailable(c, phone, acc.ID); err != nil {
+					return nil, err
+				}
+				columns["phone"] = phone
+			}
+			columns["phone_verified"] = false
+			acc.Phone, acc.PhoneVerified = phone, false
+		}
+	}
+	if len(columns) == 0 {
+		return profileVO(acc), nil
+	}
+	columns["gmt_modified"] = time.Now().Unix()
+
+	if err := mapper.UpdateAccountColumns(c, acc.ID, columns); err != nil {
+		utils.BizLogger(c).Errorf("「%s」个人资料修改失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("个人资料修改失败: %w", err)
+	}
+
+	utils.BizLogger(c).Infof("「%s」已修改个人资料", acc.Email)
+	return profileVO(acc), nil
+}
+
+// UploadAvatar 上传当前账户的头像：按文件内容识别格式，缩放并重新编码后写入存储，随后删除旧头像
+// 参数：
+//   - c: Gin 上下文
+//   - file: 上传的头像文件
+//
+// 返回值：
+//   - *account.ProfileVO: 更新后的个人资料
+//   - error: 文件过大时返回 AVATAR_TOO_LARGE，格式不支持时返回 AVATAR_INVALID，其余为操作过程中的错误
+func UploadAvatar(c *gin.Context, file *multipart.FileHeader) (*account.ProfileVO, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	store := storage.Default()
+	if store == nil {
+		utils.BizLogger(c).Errorf("文件存储未初始化")
+		return nil, fmt.Errorf("文件存储未初始化")
+	}
+
+	if file.Size > AVATAR_MAX_SIZE {
+		utils.BizLogger(c).Errorf("「%s」头像文件过大: %d 字节", acc.Email, file.Size)
+		return nil, avatarTooLargeError()
+	}
+	src, err := file.Open()
+	if err != nil {
+		utils.BizLogger(c).Errorf("读取头像文件失败: %v", err)
+		return nil, fmt.Errorf("读取头像文件失败: %w", err)
+	}
+	defer src.Close()
+
+	// 多读一个字节判断是否超限，不信任客户端声明的文件大小
+	data, err := io.ReadAll(io.LimitReader(src, AVATAR_MAX_SIZE+1))
+	if err != nil {
+		utils.BizLogger(c).Errorf("读取头像文件失败: %v", err)
+		return nil, fmt.Errorf("读取头像文件失败: %w", err)
+	}
+	if len(data) > AVATAR_MAX_SIZE {
+		utils.BizLogger(c).Errorf("「%s」头像文件过大", acc.Email)
+		return nil, avatarTooLargeError()
+	}
+
+	content, contentType, ext, err := utils.NormalizeImage(data, AVATAR_MAX_SIDE)
+	if err != nil {
+		if errors.Is(err, utils.ErrImageUnsupported) {
+			utils.BizLogger(c).Errorf("「%s」头像文件格式不支持: %v", acc.Email, err)
+			return nil, bizErr.New(bizErr.AVATAR_INVALID, "头像仅支持 JPEG、PNG、GIF、WebP 格式的图片")
+		}
+		utils.BizLogger(c).Errorf("处理头像失败: %v", err)
+		return nil, fmt.Errorf("处理头像失败: %w", err)
+	}
+
+	fileID, err := utils.GenerateID()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成头像文件 ID 失败: %v", err)
+		return nil, fmt.Errorf("生成头像文件 ID 失败: %w", err)
+	}
+	key := fmt.Sprintf("%s/%d/%d%s", AVATAR_STORAGE_DIR, acc.ID, fileID, ext)
+
+	ctx := c.Request.Context()
+	if err := store.Put(ctx, key, content, contentType); err != nil {
+		utils.BizLogger(c).Errorf("保存头像失败: %v", err)
+		return nil, fmt.Errorf("保存头像失败: %w", err)
+	}
+
+	if err := mapper.UpdateAccountColumns(c, acc.ID, map[string]interface{}{
+		"avatar":       key,
+		"gmt_modified": time.Now().Unix(),
+	}); err != nil {
+		utils.BizLogger(c).Errorf("「%s」头像更新失败: %v", acc.Email, err)
+		store.Delete(ctx, key)
+		return nil, fmt.Errorf("头像更新失败: %w", err)
+	}
+
+	// 旧头像删除失败不影响本次上传，仅记录日志
+	if previous := acc.Avatar; isStoredAvatar(previous) {
+		if err := store.Delete(ctx, previous); err != nil {
+			utils.BizLogger(c).Warnf("删除「%s」旧头像失败: %v", acc.Email, err)
+		}
+	}
+	acc.Avatar = key
+
+	utils.BizLogger(c).Infof("「%s」已更新头像", acc.Email)
+	return profileVO(acc), nil
+}
+
+// profileVO 将账户转换为个人资料视图对象
+// 参数：
+//   - acc: 账户信息
+//
+// 返回值：
+//   - *account.ProfileVO: 个人资料
+func profileVO(acc *model.Account) *account.ProfileVO {
+	return &account.ProfileVO{
+		ID:                  acc.ID,
+		Email:               acc.Email,
+		Nickname:            acc.Nickname,
+		Phone:               acc.Phone,
+		PhoneVerified:       acc.PhoneVerified,
+		Avatar:              avatarURL(acc.Avatar),
+		Status:              acc.Status,
+		DeletionScheduledAt: acc.DeletionScheduledAt,
+		OrganizationID:      acc.OrganizationID,
+		GmtCreate:           acc.GmtCreate,
+	}
+}
+
+// avatarURL 将头像字段解析为访问地址，已是完整地址的历史数据原样返回
+// 参数：
+//   - avatar: 头像对象键或地址
+//
+// 返回值：
+//   - string: 访问地址，未上传时为空
+func avatarURL(avatar string) string {
+	if !isStoredAvatar(avatar) {
+		return avatar
+	}
+	if store := storage.Default(); store != nil {
+		return store.URL(avatar)
+	}
+	return avatar
+}
+
+// isStoredAvatar 判断头像字段是否为存储中的对象键
+// 参数：
+//   - avatar: 头像对象键或地址
+//
+// 返回值：
+//   - bool: 是对象键返回 true
+func isStoredAvatar(avatar string) bool {
+	return strings.HasPrefix(avatar, AVATAR_STORAGE_DIR+"/")
+}
+
+// avatarTooLargeError 构造头像文件过大错误
+// 返回值：
+//   - error: AVATAR_TOO_LARGE 业务错误
+func avatarTooLargeError() error {
+	return bizErr.New(bizErr.AVATAR_TOO_LARGE, fmt.Sprintf("头像文件不能超过 %d MB", AVATAR_MAX_SIZE>>20))
+}
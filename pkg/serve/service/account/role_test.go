@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"testing"
+
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	rbacModel "lease/internal/model/rbac"
+)
+
+// TestRegrantRevokedRole 撤销角色后可再次授予，同一角色不会重复授予
+func TestRegrantRevokedRole(t *testing.T) {
+	email := "regrant@example.com"
+	register(t, email)
+
+	var acc model.Account
+	if err := global.DB.Where("email = ?", email).First(&acc).Error; err != nil {
+		t.Fatalf("查询账户失败: %v", err)
+	}
+	req := map[string]interface{}{"account_id": acc.ID, "role_code": rbacModel.ROLE_TENANT}
+
+	// 授予与撤销角色会清除该账户的全部会话，每次操作前重新登录
+	if resp := call(t, "POST", "/api/v1/rbac/grantRole", req, login(t, email)); resp.Code == 0 {
+		t.Fatalf("重复授予已拥有的角色应失败")
+	}
+	mustSucceed(t, "POST", "/api/v1/rbac/revokeRole", req, login(t, email), nil)
+	mustSucceed(t, "POST", "/api/v1/rbac/grantRole", req, login(t, email), nil)
+
+	var count int64
+	if err := global.DB.Model(&rbacModel.AccountRole{}).
+		Joins("JOIN roles ON roles.id = account_roles.role_id").
+		Where("account_roles.account_id = ? AND roles.code = ?", acc.ID, rbacModel.ROLE_TENANT).
+		Count(&count).Error; err != nil {
+		t.Fatalf("查询账户角色失败: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("账户角色记录数 = %d，期望 1", count)
+	}
+
+	// 绕过授予接口直接写入重复记录，由唯一索引拒绝
+	var role rbacModel.Role
+	if err := global.DB.Where("code = ?", rbacModel.ROLE_TENANT).First(&role).Error; err != nil {
+		t.Fatalf("查询角色失败: %v", err)
+	}
+	duplicate := &rbacModel.AccountRole{OrganizationID: acc.OrganizationID, AccountID: acc.ID, RoleID: role.ID}
+	if err := global.DB.Create(duplicate).Error; err == nil {
+		t.Fatalf("唯一索引未拒绝重复的账户角色")
+	}
+}
@@ -0,0 +1,74 @@
+// Package service 提供业务逻辑处理，处理账户相关业务
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/account/dto"
+	"lease/pkg/vo/account"
+)
+
+// ListSessions 列出当前账户在各设备上的有效登录会话
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - []*account.SessionVO: 会话列表，按最近活跃时间倒序
+//   - error: 操作过程中的错误
+func ListSessions(c *gin.Context) ([]*account.SessionVO, error) {
+	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %w", err)
+	}
+
+	sessions, err := utils.ListSessions(c.Request.Context(), accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账户「%d」会话失败: %v", accountID, err)
+		return nil, fmt.Errorf("查询会话失败: %w", err)
+	}
+
+	currentSessionID, _ := auth_middleware.GetSessionID(c)
+	list := make([]*account.SessionVO, 0, len(sessions))
+	for _, session := range sessions {
+		sessionVO, err := utils.MapModelToVO(session, &account.SessionVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("会话映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("会话映射 VO 失败: %w", err)
+		}
+		result := sessionVO.(*account.SessionVO)
+		result.Current = session.SessionID == currentSessionID
+		list = append(list, result)
+	}
+
+	return list, nil
+}
+
+// RevokeSession 注销当前账户的指定会话，该设备上的令牌随即失效
+// 参数：
+//   - c: Gin 上下文
+//   - req: 注销会话请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeSession(c *gin.Context, req *dto.RevokeSessionRequest) error {
+	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return fmt.Errorf("解析 access token 失败: %w", err)
+	}
+
+	if err := utils.RevokeSession(c.Request.Context(), accountID, req.SessionID); err != nil {
+		if errors.Is(err, utils.ErrSessionRevoked) {
+			return fmt.Errorf("「%s」会话不存在或已失效", req.SessionID)
+		}
+		utils.BizLogger(c).Errorf("注销账户「%d」会话「%s」失败: %v", accountID, req.SessionID, err)
+		return fmt.Errorf("注销会话失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	bizErr "lease/internal/error"
+	"lease/internal/global"
+	model "lease/internal/model/account"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+)
+
+// TestAccessTokenCarriesPermissionClaims Access Token 写入组织与权限声明，撤销的权限在令牌过期前即时失效
+func TestAccessTokenCarriesPermissionClaims(t *testing.T) {
+	email := "claims@example.com"
+	register(t, email)
+	token := login(t, email)
+
+	var acc model.Account
+	if err := global.DB.Where("email = ?", email).First(&acc).Error; err != nil {
+		t.Fatalf("查询账户失败: %v", err)
+	}
+	organizationID, err := utils.ParseOrganizationIDFromJWT(token)
+	if err != nil || organizationID != acc.OrganizationID {
+		t.Fatalf("令牌组织声明 = %d (%v)，期望 %d", organizationID, err, acc.OrganizationID)
+	}
+	permissions, err := utils.ParsePermissionsFromJWT(token)
+	if err != nil || !slices.Contains(permissions, rbacModel.PERMISSION_RBAC_MANAGE) {
+		t.Fatalf("令牌权限声明 = %v (%v)，期望包含「%s」", permissions, err, rbacModel.PERMISSION_RBAC_MANAGE)
+	}
+	mustSucceed(t, "POST", "/api/v1/rbac/listRoles", nil, token, nil)
+
+	// 绕过授予接口直接撤销管理员角色，并清除会话中缓存的权限以模拟缓存过期
+	var admin rbacModel.Role
+	if err := global.DB.Where("code = ?", rbacModel.ROLE_ADMIN).First(&admin).Error; err != nil {
+		t.Fatalf("查询角色失败: %v", err)
+	}
+	if err := global.DB.Where("account_id = ? AND role_id = ?", acc.ID, admin.ID).Delete(&rbacModel.AccountRole{}).Error; err != nil {
+		t.Fatalf("撤销角色失败: %v", err)
+	}
+	sessionID, _ := utils.ParseSessionIDFromJWT(token)
+	mr.HDel(fmt.Sprintf("%s:%d:%s", utils.SESSION_CACHE_PREFIX, acc.ID, sessionID), utils.SESSION_FIELD_AUTHZ_AT)
+
+	expectCode(t, "POST", "/api/v1/rbac/listRoles", nil, token, bizErr.FORBIDDEN)
+}
@@ -0,0 +1,104 @@
+// Package service 提供业务逻辑处理，处理押金收取与结算相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	leaseModel "lease/internal/model/lease"
+	ledgerModel "lease/internal/model/ledger"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/deposit/dto"
+	ledgerDto "lease/pkg/serve/controller/ledger/dto"
+	"lease/pkg/serve/mapper"
+	leaseService "lease/pkg/serve/service/lease"
+	ledgerService "lease/pkg/serve/service/ledger"
+	"lease/pkg/vo/deposit"
+)
+
+// HoldDeposit 业主或持有账务管理权限的组织成员登记收到的押金并计入代管押金，累计不超过合同约定押金
+// 参数：
+//   - c: Gin 上下文
+//   - req: 收取押金请求
+//
+// 返回值：
+//   - *deposit.DepositVO: 收取后的合同押金信息
+//   - error: 操作过程中的错误
+func HoldDeposit(c *gin.Context, req *dto.HoldDepositRequest) (*deposit.DepositVO, error) {
+	contract, _, err := leaseService.GetManagedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+	if contract.Status != leaseModel.CONTRACT_STATUS_PENDING_SIGNATURE && contract.Status != leaseModel.CONTRACT_STATUS_ACTIVE {
+		utils.BizLogger(c).Errorf("合同「%d」状态为「%s」，不可收取押金", contract.ID, contract.Status)
+		return nil, fmt.Errorf("仅待签署或生效中的合同可收取押金")
+	}
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 先锁定合同再读取已收押金，防止并发收取使累计押金超过约定
+		if err := ledgerService.LockContract(c, contract.ID); err != nil {
+			return err
+		}
+
+		held, err := ledgerService.DepositBalance(c, contract.ID)
+		if err != nil {
+			return err
+		}
+		if held+req.Amount > contract.Deposit {
+			utils.BizLogger(c).Errorf("合同「%d」已收押金 %d，本次 %d 超过约定押金 %d", contract.ID, held, req.Amount, contract.Deposit)
+			return fmt.Errorf("累计收取押金超过合同约定押金")
+		}
+
+		_, err = ledgerService.RecordPayment(c, &ledgerDto.RecordPaymentRequest{
+			ContractID: contract.ID,
+			Purpose:    ledgerModel.PAYMENT_PURPOSE_DEPOSIT,
+			Method:     req.Method,
+			Amount:     req.Amount,
+			PaidDate:   req.PaidDate,
+			Reference:  req.Reference,
+			Remark:     req.Remark,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetDeposit(c, &dto.ContractDepositRequest{ContractID: contract.ID})
+}
+
+// GetDeposit 获取合同押金信息及押金结算单，仅合同双方及持有账务管理权限的组织成员可查看
+// 参数：
+//   - c: Gin 上下文
+//   - req: 合同押金请求
+//
+// 返回值：
+//   - *deposit.DepositVO: 合同押金信息
+//   - error: 操作过程中的错误
+func GetDeposit(c *gin.Context, req *dto.ContractDepositRequest) (*deposit.DepositVO, error) {
+	contract, _, err := leaseService.GetParticipatedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+
+	held, err := ledgerService.DepositBalance(c, contract.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	depositVO := &deposit.DepositVO{
+		ContractID:   contract.ID,
+		AgreedAmount: contract.Deposit,
+		HeldAmount:   held,
+	}
+
+	if settlement, _ := mapper.GetDepositSettlementByContractID(c, contract.ID); settlement != nil {
+		if depositVO.Settlement, err = toSettlementVO(c, settlement); err != nil {
+			return nil, err
+		}
+	}
+
+	return depositVO, nil
+}
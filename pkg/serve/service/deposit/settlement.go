@@ -0,0 +1,471 @@
+// Package service 提供业务逻辑处理，处理押金收取与结算相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/deposit"
+	leaseModel "lease/internal/model/lease"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/deposit/dto"
+	"lease/pkg/serve/mapper"
+	leaseService "lease/pkg/serve/service/lease"
+	ledgerService "lease/pkg/serve/service/ledger"
+	"lease/pkg/vo/deposit"
+)
+
+// settlementEditableStatuses 允许业主调整扣款明细的结算状态
+var settlementEditableStatuses = []string{model.SETTLEMENT_STATUS_DRAFT, model.SETTLEMENT_STATUS_DISPUTED}
+
+// settlementContractStatuses 允许发起押金结算的合同状态
+var settlementContractStatuses = []string{leaseModel.CONTRACT_STATUS_TERMINATED, leaseModel.CONTRACT_STATUS_EXPIRED}
+
+// CreateSettlement 业主或持有账务管理权限的组织成员在合同结束后发起押金结算单
+// 参数：
+//   - c: Gin 上下文
+//   - req: 合同押金请求
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 押金结算单
+//   - error: 操作过程中的错误
+func CreateSettlement(c *gin.Context, req *dto.ContractDepositRequest) (*deposit.DepositSettlementVO, error) {
+	contract, _, err := leaseService.GetManagedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(settlementContractStatuses, contract.Status) {
+		utils.BizLogger(c).Errorf("合同「%d」状态为「%s」，不可发起押金结算", contract.ID, contract.Status)
+		return nil, fmt.Errorf("仅已解约或已到期的合同可发起押金结算")
+	}
+
+	var settlement *model.DepositSettlement
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 先锁定合同，防止重复发起结算或在读取代管押金后押金发生变动
+		if err := ledgerService.LockContract(c, contract.ID); err != nil {
+			return err
+		}
+
+		if existing, _ := mapper.GetDepositSettlementByContractID(c, contract.ID); existing != nil {
+			utils.BizLogger(c).Errorf("合同「%d」已存在押金结算单「%d」", contract.ID, existing.ID)
+			return fmt.Errorf("该合同已存在押金结算单")
+		}
+
+		held, err := ledgerService.DepositBalance(c, contract.ID)
+		if err != nil {
+			return err
+		}
+
+		settlement = &model.DepositSettlement{
+			ContractID:   contract.ID,
+			LandlordID:   contract.LandlordID,
+			TenantID:     contract.TenantID,
+			Status:       model.SETTLEMENT_STATUS_DRAFT,
+			HeldAmount:   held,
+			RefundAmount: held,
+		}
+		if err := mapper.CreateDepositSettlement(c, settlement); err != nil {
+			utils.BizLogger(c).Errorf("创建押金结算单失败: %v", err)
+			return fmt.Errorf("创建押金结算单失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementVO(c, settlement)
+}
+
+// AddDeduction 业主或持有账务管理权限的组织成员在结算单中添加扣款明细，扣款合计不得超过代管押金
+// 参数：
+//   - c: Gin 上下文
+//   - req: 添加扣款请求
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 更新后的押金结算单
+//   - error: 操作过程中的错误
+func AddDeduction(c *gin.Context, req *dto.AddDeductionRequest) (*deposit.DepositSettlementVO, error) {
+	var settlement *model.DepositSettlement
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		settlement, err = getEditableSettlement(c, req.SettlementID)
+		if err != nil {
+			return err
+		}
+
+		deduction := &model.DepositDeduction{
+			SettlementID: settlement.ID,
+			Category:     req.Category,
+			Reason:       req.Reason,
+			Amount:       req.Amount,
+			EvidenceIDs:  req.EvidenceIDs,
+		}
+		if err := mapper.CreateDepositDeduction(c, deduction); err != nil {
+			utils.BizLogger(c).Errorf("创建押金扣款明细失败: %v", err)
+			return fmt.Errorf("创建押金扣款明细失败: %w", err)
+		}
+
+		_, err = recalculateSettlement(c, settlement)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementVO(c, settlement)
+}
+
+// RemoveDeduction 业主或持有账务管理权限的组织成员删除结算单中的扣款明细
+// 参数：
+//   - c: Gin 上下文
+//   - req: 扣款明细 ID 请求
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 更新后的押金结算单
+//   - error: 操作过程中的错误
+func RemoveDeduction(c *gin.Context, req *dto.DeductionIDRequest) (*deposit.DepositSettlementVO, error) {
+	var settlement *model.DepositSettlement
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		deduction, err := mapper.GetDepositDeductionByID(c, req.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」押金扣款明细不存在: %v", req.ID, err)
+			return fmt.Errorf("「%d」押金扣款明细不存在: %w", req.ID, err)
+		}
+
+		settlement, err = getEditableSettlement(c, deduction.SettlementID)
+		if err != nil {
+			return err
+		}
+
+		if err := mapper.DeleteDepositDeductionByID(c, deduction.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除押金扣款明细失败: %v", err)
+			return fmt.Errorf("删除押金扣款明细失败: %w", err)
+		}
+
+		_, err = recalculateSettlement(c, settlement)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementVO(c, settlement)
+}
+
+// SubmitSettlement 业主或持有账务管理权限的组织成员提交结算单等待租客确认
+// 参数：
+//   - c: Gin 上下文
+//   - req: 结算单 ID 请求
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 更新后的押金结算单
+//   - error: 操作过程中的错误
+func SubmitSettlement(c *gin.Context, req *dto.SettlementIDRequest) (*deposit.DepositSettlementVO, error) {
+	var settlement *model.DepositSettlement
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		settlement, err = getEditableSettlement(c, req.ID)
+		if err != nil {
+			return err
+		}
+
+		deductions, err := recalculateSettlement(c, settlement)
+		if err != nil {
+			return err
+		}
+
+		// 欠租扣款只能抵扣尚未结清的账单
+		unpaidRent, _ := splitDeductions(deductions)
+		if unpaidRent > 0 {
+			outstanding, err := mapper.SumInvoiceOutstanding(c, mapper.InvoiceFilter{ContractID: settlement.ContractID})
+			if err != nil {
+				utils.BizLogger(c).Errorf("汇总合同「%d」未结清账单失败: %v", settlement.ContractID, err)
+				return fmt.Errorf("汇总未结清账单失败: %w", err)
+			}
+			if unpaidRent > outstanding {
+				utils.BizLogger(c).Errorf("结算单「%d」欠租扣款 %d 超过未结清账单金额 %d", settlement.ID, unpaidRent, outstanding)
+				return fmt.Errorf("欠租扣款超过未结清账单金额")
+			}
+		}
+
+		settlement.Status = model.SETTLEMENT_STATUS_PENDING_ACK
+		settlement.TenantComment = ""
+		if err := mapper.UpdateDepositSettlement(c, settlement); err != nil {
+			utils.BizLogger(c).Errorf("提交押金结算单失败: %v", err)
+			return fmt.Errorf("提交押金结算单失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementVO(c, settlement)
+}
+
+// AcknowledgeSettlement 租客确认扣款明细，或提出异议退回业主修改
+// 参数：
+//   - c: Gin 上下文
+//   - req: 租客确认请求
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 更新后的押金结算单
+//   - error: 操作过程中的错误
+func AcknowledgeSettlement(c *gin.Context, req *dto.AcknowledgeSettlementRequest) (*deposit.DepositSettlementVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	var settlement *model.DepositSettlement
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		settlement, err = mapper.LockDepositSettlementByID(c, req.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」押金结算单不存在: %v", req.ID, err)
+			return fmt.Errorf("「%d」押金结算单不存在: %w", req.ID, err)
+		}
+		if settlement.TenantID != accountID {
+			utils.BizLogger(c).Errorf("账户「%d」不是押金结算单「%d」的租客", accountID, req.ID)
+			return fmt.Errorf("仅租客可确认押金结算")
+		}
+		if settlement.Status != model.SETTLEMENT_STATUS_PENDING_ACK {
+			utils.BizLogger(c).Errorf("押金结算单「%d」状态为「%s」，不可确认", settlement.ID, settlement.Status)
+			return fmt.Errorf("押金结算单当前不在待确认状态")
+		}
+
+		settlement.TenantComment = req.Comment
+		if *req.Accept {
+			settlement.Status = model.SETTLEMENT_STATUS_ACKNOWLEDGED
+			settlement.AcknowledgedAt = time.Now().Unix()
+		} else {
+			if req.Comment == "" {
+				return fmt.Errorf("提出异议时须填写意见")
+			}
+			settlement.Status = model.SETTLEMENT_STATUS_DISPUTED
+		}
+
+		if err := mapper.UpdateDepositSettlement(c, settlement); err != nil {
+			utils.BizLogger(c).Errorf("更新押金结算单失败: %v", err)
+			return fmt.Errorf("更新押金结算单失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementVO(c, settlement)
+}
+
+// FinalizeSettlement 业主或持有账务管理权限的组织成员在租客确认后完成押金结算，扣款与退款一并记入账本
+// 参数：
+//   - c: Gin 上下文
+//   - req: 结算单 ID 请求
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 已结算的押金结算单
+//   - error: 操作过程中的错误
+func FinalizeSettlement(c *gin.Context, req *dto.SettlementIDRequest) (*deposit.DepositSettlementVO, error) {
+	var settlement *model.DepositSettlement
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var contract *leaseModel.LeaseContract
+		var err error
+		settlement, contract, err = getManagedSettlement(c, req.ID)
+		if err != nil {
+			return err
+		}
+		if settlement.Status != model.SETTLEMENT_STATUS_ACKNOWLEDGED {
+			utils.BizLogger(c).Errorf("押金结算单「%d」状态为「%s」，不可结算", settlement.ID, settlement.Status)
+			return fmt.Errorf("押金结算单须经租客确认后才能结算")
+		}
+
+		// 租客确认后代管押金发生变动时，须重新提交确认
+		held, err := ledgerService.DepositBalance(c, contract.ID)
+		if err != nil {
+			return err
+		}
+		if held != settlement.HeldAmount {
+			utils.BizLogger(c).Errorf("押金结算单「%d」确认时押金 %d，当前 %d", settlement.ID, settlement.HeldAmount, held)
+			return fmt.Errorf("代管押金已变动，请重新提交租客确认")
+		}
+
+		deductions, err := mapper.GetDepositDeductionsBySettlementID(c, settlement.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询押金扣款明细失败: %v", err)
+			return fmt.Errorf("查询押金扣款明细失败: %w", err)
+		}
+		unpaidRent, other := splitDeductions(deductions)
+
+		txn, err := ledgerService.PostDepositSettlement(c, contract, unpaidRent, other, settlement.RefundAmount, contract.LandlordID)
+		if err != nil {
+			return err
+		}
+		if txn != nil {
+			settlement.TransactionID = txn.ID
+		}
+
+		settlement.Status = model.SETTLEMENT_STATUS_SETTLED
+		settlement.SettledAt = time.Now().Unix()
+		if err := mapper.UpdateDepositSettlement(c, settlement); err != nil {
+			utils.BizLogger(c).Errorf("更新押金结算单失败: %v", err)
+			return fmt.Errorf("更新押金结算单失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementVO(c, settlement)
+}
+
+// getManagedSettlement 获取当前账户可管理的押金结算单及其合同；须在事务中调用，
+// 依次锁定结算单与合同后再读取，结算单的修改与代管押金的变动均串行执行
+// 参数：
+//   - c: Gin 上下文
+//   - settlementID: 结算单 ID
+//
+// 返回值：
+//   - *model.DepositSettlement: 结算单信息
+//   - *leaseModel.LeaseContract: 合同信息
+//   - error: 结算单不存在或当前账户无权管理时返回错误
+func getManagedSettlement(c *gin.Context, settlementID int64) (*model.DepositSettlement, *leaseModel.LeaseContract, error) {
+	settlement, err := mapper.LockDepositSettlementByID(c, settlementID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」押金结算单不存在: %v", settlementID, err)
+		return nil, nil, fmt.Errorf("「%d」押金结算单不存在: %w", settlementID, err)
+	}
+	if err := ledgerService.LockContract(c, settlement.ContractID); err != nil {
+		return nil, nil, err
+	}
+
+	contract, _, err := leaseService.GetManagedContract(c, settlement.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, nil, err
+	}
+	return settlement, contract, nil
+}
+
+// getEditableSettlement 获取当前账户可管理且允许调整扣款明细的押金结算单
+// 参数：
+//   - c: Gin 上下文
+//   - settlementID: 结算单 ID
+//
+// 返回值：
+//   - *model.DepositSettlement: 结算单信息
+//   - error: 结算单不存在、无权管理或不可编辑时返回错误
+func getEditableSettlement(c *gin.Context, settlementID int64) (*model.DepositSettlement, error) {
+	settlement, _, err := getManagedSettlement(c, settlementID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(settlementEditableStatuses, settlement.Status) {
+		utils.BizLogger(c).Errorf("押金结算单「%d」状态为「%s」，不可修改", settlement.ID, settlement.Status)
+		return nil, fmt.Errorf("押金结算单已提交或已结算，不可修改")
+	}
+	return settlement, nil
+}
+
+// recalculateSettlement 按当前代管押金与扣款明细重新计算结算单金额并保存
+// 参数：
+//   - c: Gin 上下文
+//   - settlement: 结算单
+//
+// 返回值：
+//   - []*model.DepositDeduction: 扣款明细
+//   - error: 扣款合计超过代管押金或保存失败时返回错误
+func recalculateSettlement(c *gin.Context, settlement *model.DepositSettlement) ([]*model.DepositDeduction, error) {
+	held, err := ledgerService.DepositBalance(c, settlement.ContractID)
+	if err != nil {
+		return nil, err
+	}
+
+	deductions, err := mapper.GetDepositDeductionsBySettlementID(c, settlement.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询押金扣款明细失败: %v", err)
+		return nil, fmt.Errorf("查询押金扣款明细失败: %w", err)
+	}
+
+	var total int64
+	for _, deduction := range deductions {
+		total += deduction.Amount
+	}
+	if total > held {
+		utils.BizLogger(c).Errorf("押金结算单「%d」扣款合计 %d 超过代管押金 %d", settlement.ID, total, held)
+		return nil, fmt.Errorf("扣款合计超过代管押金")
+	}
+
+	settlement.HeldAmount = held
+	settlement.DeductionAmount = total
+	settlement.RefundAmount = held - total
+	if err := mapper.UpdateDepositSettlement(c, settlement); err != nil {
+		utils.BizLogger(c).Errorf("更新押金结算单失败: %v", err)
+		return nil, fmt.Errorf("更新押金结算单失败: %w", err)
+	}
+	return deductions, nil
+}
+
+// splitDeductions 将扣款明细按欠租与其他扣款分别汇总
+// 参数：
+//   - deductions: 扣款明细
+//
+// 返回值：
+//   - int64: 欠缴租金及杂费扣款合计（分）
+//   - int64: 其他扣款合计（分）
+func splitDeductions(deductions []*model.DepositDeduction) (int64, int64) {
+	var unpaidRent, other int64
+	for _, deduction := range deductions {
+		if deduction.Category == model.DEDUCTION_CATEGORY_UNPAID_RENT {
+			unpaidRent += deduction.Amount
+		} else {
+			other += deduction.Amount
+		}
+	}
+	return unpaidRent, other
+}
+
+// toSettlementVO 将押金结算单及扣款明细映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - settlement: 结算单模型
+//
+// 返回值：
+//   - *deposit.DepositSettlementVO: 结算单视图对象
+//   - error: 映射过程中的错误
+func toSettlementVO(c *gin.Context, settlement *model.DepositSettlement) (*deposit.DepositSettlementVO, error) {
+	deductions, err := mapper.GetDepositDeductionsBySettlementID(c, settlement.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询押金扣款明细失败: %v", err)
+		return nil, fmt.Errorf("查询押金扣款明细失败: %w", err)
+	}
+
+	settlementVO, err := utils.MapModelToVO(settlement, &deposit.DepositSettlementVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("押金结算单映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("押金结算单映射 VO 失败: %w", err)
+	}
+
+	result := settlementVO.(*deposit.DepositSettlementVO)
+	result.Deductions = make([]*deposit.DepositDeductionVO, 0, len(deductions))
+	for _, deduction := range deductions {
+		deductionVO, err := utils.MapModelToVO(deduction, &deposit.DepositDeductionVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("押金扣款明细映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("押金扣款明细映射 VO 失败: %w", err)
+		}
+		result.Deductions = append(result.Deductions, deductionVO.(*deposit.DepositDeductionVO))
+	}
+	return result, nil
+}
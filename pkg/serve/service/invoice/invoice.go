@@ -0,0 +1,407 @@
+// Package service 提供业务逻辑处理，处理租金账单相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/invoice"
+	leaseModel "lease/internal/model/lease"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/invoice/dto"
+	"lease/pkg/serve/mapper"
+	leaseService "lease/pkg/serve/service/lease"
+	ledgerService "lease/pkg/serve/service/ledger"
+	"lease/pkg/vo"
+	"lease/pkg/vo/invoice"
+)
+
+// RENT_ITEM_NAME 租金明细名称
+const RENT_ITEM_NAME = "租金"
+
+// billableStatuses 可出账的合同状态；续约合同签署后原合同即流转为已续约，其到期日前剩余的账期仍须出账
+var billableStatuses = []string{
+	leaseModel.CONTRACT_STATUS_ACTIVE,
+	leaseModel.CONTRACT_STATUS_RENEWED,
+}
+
+// GenerateInvoices 为业主名下生效中与已续约的合同生成截至出账日期已开始、且不晚于合同到期日的各期账单，已出账的账期自动跳过
+// 参数：
+//   - c: Gin 上下文
+//   - req: 生成账单请求
+//
+// 返回值：
+//   - *invoice.GenerateInvoiceVO: 生成结果
+//   - error: 操作过程中的错误
+func GenerateInvoices(c *gin.Context, req *dto.GenerateInvoiceRequest) (*invoice.GenerateInvoiceVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	asOf := req.AsOf
+	if asOf == "" {
+		asOf = time.Now().Format(leaseModel.DATE_LAYOUT)
+	}
+
+	var contracts []*leaseModel.LeaseContract
+	if req.ContractID != 0 {
+		contract, _, err := leaseService.GetManagedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(billableStatuses, contract.Status) {
+			utils.BizLogger(c).Errorf("合同「%d」状态为「%s」，不可出账", contract.ID, contract.Status)
+			return nil, fmt.Errorf("仅生效中或已续约的合同可生成账单")
+		}
+		contracts = append(contracts, contract)
+	} else {
+		var err error
+		contracts, err = mapper.GetLeaseContractsByLandlordAndStatuses(c, accountID, billableStatuses)
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询可出账的合同失败: %v", err)
+			return nil, fmt.Errorf("查询可出账的合同失败: %w", err)
+		}
+	}
+
+	result := &invoice.GenerateInvoiceVO{Invoices: make([]*invoice.InvoiceVO, 0)}
+	for _, contract := range contracts {
+		invoices, skipped, err := generateContractInvoices(c, contract, asOf)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, inv := range invoices {
+			invoiceVO, err := toInvoiceVO(c, inv.invoice, inv.items)
+			if err != nil {
+				return nil, err
+			}
+			result.Invoices = append(result.Invoices, invoiceVO)
+		}
+		result.Created += len(invoices)
+		result.Skipped += skipped
+	}
+
+	return result, nil
+}
+
+// GetBillingSchedule 获取合同完整账期表，按当前租金与杂费测算每期金额并标注已出账的账单
+// 参数：
+//   - c: Gin 上下文
+//   - req: 合同账期请求
+//
+// 返回值：
+//   - []*invoice.BillingPeriodVO: 账期列表
+//   - error: 操作过程中的错误
+func GetBillingSchedule(c *gin.Context, req *dto.ContractScheduleRequest) ([]*invoice.BillingPeriodVO, error) {
+	contract, _, err := leaseService.GetParticipatedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := buildSchedule(contract.StartDate, contract.EndDate, contract.BillingCycle)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成合同「%d」账期表失败: %v", contract.ID, err)
+		return nil, fmt.Errorf("生成合同账期表失败: %w", err)
+	}
+
+	fees, err := mapper.GetRecurringFeesByContractID(c, contract.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询周期性杂费失败: %v", err)
+		return nil, fmt.Errorf("查询周期性杂费失败: %w", err)
+	}
+
+	invoiced, err := mapper.GetInvoicedPeriodStarts(c, contract.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询合同已出账账期失败: %v", err)
+		return nil, fmt.Errorf("查询合同已出账账期失败: %w", err)
+	}
+
+	schedule := make([]*invoice.BillingPeriodVO, 0, len(periods))
+	for _, period := range periods {
+		inv, items := buildInvoice(contract, period, fees)
+
+		itemVOs := make([]*invoice.InvoiceItemVO, 0, len(items))
+		for _, item := range items {
+			itemVO, err := toInvoiceItemVO(c, item)
+			if err != nil {
+				return nil, err
+			}
+			itemVOs = append(itemVOs, itemVO)
+		}
+
+		schedule = append(schedule, &invoice.BillingPeriodVO{
+			PeriodStart: inv.PeriodStart,
+			PeriodEnd:   inv.PeriodEnd,
+			DueDate:     inv.DueDate,
+			Amount:      inv.Amount,
+			Prorated:    inv.Prorated,
+			InvoiceID:   invoiced[inv.PeriodStart],
+			Items:       itemVOs,
+		})
+	}
+
+	return schedule, nil
+}
+
+// GetInvoice 获取账单详情及明细，仅合同双方及持有账务管理权限的组织成员可查看
+// 参数：
+//   - c: Gin 上下文
+//   - req: 账单 ID 请求
+//
+// 返回值：
+//   - *invoice.InvoiceVO: 账单视图对象
+//   - error: 操作过程中的错误
+func GetInvoice(c *gin.Context, req *dto.InvoiceIDRequest) (*invoice.InvoiceVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	inv, err := mapper.GetInvoiceByID(c, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账单不存在: %v", req.ID, err)
+		return nil, fmt.Errorf("「%d」账单不存在: %w", req.ID, err)
+	}
+	// 账单查询已限定在当前组织内，持有账务管理权限的组织成员可查看
+	if inv.LandlordID != accountID && inv.TenantID != accountID && !slices.Contains(auth_middleware.GetPermissions(c), rbacModel.PERMISSION_BILLING_WRITE) {
+		utils.BizLogger(c).Errorf("账户「%d」无权访问账单「%d」", accountID, req.ID)
+		return nil, fmt.Errorf("无权访问该账单")
+	}
+
+	items, err := mapper.GetInvoiceItemsByInvoiceID(c, inv.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账单明细失败: %v", err)
+		return nil, fmt.Errorf("查询账单明细失败: %w", err)
+	}
+
+	return toInvoiceVO(c, inv, items)
+}
+
+// ListInvoices 按业主或租客身份分页查询当前账户相关的账单
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页查询请求
+//
+// 返回值：
+//   - *vo.PageVO: 账单分页结果
+//   - error: 操作过程中的错误
+func ListInvoices(c *gin.Context, req *dto.ListInvoiceRequest) (*vo.PageVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	filter := mapper.InvoiceFilter{ContractID: req.ContractID, Status: req.Status}
+	switch req.Role {
+	case "landlord":
+		filter.LandlordID = accountID
+	default:
+		filter.TenantID = accountID
+	}
+
+	invoices, total, err := mapper.ListInvoices(c, filter, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账单列表失败: %v", err)
+		return nil, fmt.Errorf("查询账单列表失败: %w", err)
+	}
+
+	list := make([]*invoice.InvoiceVO, 0, len(invoices))
+	for _, inv := range invoices {
+		invoiceVO, err := toInvoiceVO(c, inv, nil)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, invoiceVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// generatedInvoice 新生成的账单及其明细
+type generatedInvoice struct {
+	invoice *model.Invoice
+	items   []*model.InvoiceItem
+}
+
+// generateContractInvoices 在单个事务中为合同生成截至出账日期已开始且尚未出账的各期账单，出账记账后以预收余额抵扣
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 生效中的合同
+//   - asOf: 出账截止日期
+//
+// 返回值：
+//   - []generatedInvoice: 新生成的账单
+//   - int: 已出账而跳过的账期数
+//   - error: 操作过程中的错误
+func generateContractInvoices(c *gin.Context, contract *leaseModel.LeaseContract, asOf string) ([]generatedInvoice, int, error) {
+	var generated []generatedInvoice
+	var skipped int
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		// 先锁定合同再读取账单与余额，与同一合同的收款、退款依次执行
+		if err := ledgerService.LockContract(c, contract.ID); err != nil {
+			return err
+		}
+
+		periods, err := buildSchedule(contract.StartDate, contract.EndDate, contract.BillingCycle)
+		if err != nil {
+			utils.BizLogger(c).Errorf("生成合同「%d」账期表失败: %v", contract.ID, err)
+			return fmt.Errorf("生成合同账期表失败: %w", err)
+		}
+
+		fees, err := mapper.GetRecurringFeesByContractID(c, contract.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询周期性杂费失败: %v", err)
+			return fmt.Errorf("查询周期性杂费失败: %w", err)
+		}
+
+		invoiced, err := mapper.GetInvoicedPeriodStarts(c, contract.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询合同已出账账期失败: %v", err)
+			return fmt.Errorf("查询合同已出账账期失败: %w", err)
+		}
+
+		for _, period := range periods {
+			if period.Start.Format(leaseModel.DATE_LAYOUT) > asOf {
+				break
+			}
+
+			inv, items := buildInvoice(contract, period, fees)
+			// 同一合同同一账期只出一次账，重复执行时直接跳过
+			if _, ok := invoiced[inv.PeriodStart]; ok {
+				skipped++
+				continue
+			}
+
+			if err := mapper.CreateInvoice(c, inv, items); err != nil {
+				utils.BizLogger(c).Errorf("合同「%d」账期「%s」出账失败: %v", contract.ID, inv.PeriodStart, err)
+				return fmt.Errorf("合同「%d」账期「%s」出账失败: %w", contract.ID, inv.PeriodStart, err)
+			}
+			if err := ledgerService.PostInvoice(c, inv, items, contract.LandlordID); err != nil {
+				return err
+			}
+			generated = append(generated, generatedInvoice{invoice: inv, items: items})
+		}
+
+		// 预收余额自动结转抵扣新出账的账单
+		applied, err := ledgerService.ApplyContractCredit(c, contract, contract.LandlordID)
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			for i := range generated {
+				inv, err := mapper.GetInvoiceByID(c, generated[i].invoice.ID)
+				if err != nil {
+					utils.BizLogger(c).Errorf("「%d」账单不存在: %v", generated[i].invoice.ID, err)
+					return fmt.Errorf("「%d」账单不存在: %w", generated[i].invoice.ID, err)
+				}
+				generated[i].invoice = inv
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return generated, skipped, nil
+}
+
+// buildInvoice 根据合同租金与周期性杂费计算单个账期的账单及明细
+// 参数：
+//   - contract: 合同
+//   - period: 账期
+//   - fees: 周期性杂费
+//
+// 返回值：
+//   - *model.Invoice: 账单（未持久化）
+//   - []*model.InvoiceItem: 账单明细（未持久化）
+func buildInvoice(contract *leaseModel.LeaseContract, period billingPeriod, fees []*model.RecurringFee) (*model.Invoice, []*model.InvoiceItem) {
+	rent, remark := prorate(contract.MonthlyRent, period.Start, period.End)
+	items := []*model.InvoiceItem{{
+		ItemType: model.INVOICE_ITEM_TYPE_RENT,
+		Name:     RENT_ITEM_NAME,
+		Amount:   rent,
+		Remark:   remark,
+	}}
+
+	total := rent
+	for _, fee := range fees {
+		amount, remark := prorate(fee.MonthlyAmount, period.Start, period.End)
+		items = append(items, &model.InvoiceItem{
+			ItemType: model.INVOICE_ITEM_TYPE_FEE,
+			FeeID:    fee.ID,
+			Name:     fee.Name,
+			Amount:   amount,
+			Remark:   remark,
+		})
+		total += amount
+	}
+
+	periodStart := period.Start.Format(leaseModel.DATE_LAYOUT)
+	return &model.Invoice{
+		ContractID:  contract.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   period.End.Format(leaseModel.DATE_LAYOUT),
+		UnitID:      contract.UnitID,
+		LandlordID:  contract.LandlordID,
+		TenantID:    contract.TenantID,
+		DueDate:     periodStart,
+		Amount:      total,
+		Prorated:    period.Prorated,
+		Status:      model.INVOICE_STATUS_UNPAID,
+	}, items
+}
+
+// toInvoiceVO 将账单模型及明细映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - inv: 账单模型
+//   - items: 账单明细，为 nil 时不返回明细
+//
+// 返回值：
+//   - *invoice.InvoiceVO: 账单视图对象
+//   - error: 映射过程中的错误
+func toInvoiceVO(c *gin.Context, inv *model.Invoice, items []*model.InvoiceItem) (*invoice.InvoiceVO, error) {
+	invoiceVO, err := utils.MapModelToVO(inv, &invoice.InvoiceVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("账单映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("账单映射 VO 失败: %w", err)
+	}
+
+	result := invoiceVO.(*invoice.InvoiceVO)
+	for _, item := range items {
+		itemVO, err := toInvoiceItemVO(c, item)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, itemVO)
+	}
+	return result, nil
+}
+
+// toInvoiceItemVO 将账单明细模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - item: 账单明细模型
+//
+// 返回值：
+//   - *invoice.InvoiceItemVO: 账单明细视图对象
+//   - error: 映射过程中的错误
+func toInvoiceItemVO(c *gin.Context, item *model.InvoiceItem) (*invoice.InvoiceItemVO, error) {
+	itemVO, err := utils.MapModelToVO(item, &invoice.InvoiceItemVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("账单明细映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("账单明细映射 VO 失败: %w", err)
+	}
+	return itemVO.(*invoice.InvoiceItemVO), nil
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"lease/internal/db/dbtest"
+)
+
+// TestMain 以临时 SQLite 数据库执行账单业务测试
+func TestMain(m *testing.M) {
+	os.Exit(dbtest.Run(m))
+}
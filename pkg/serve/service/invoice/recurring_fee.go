@@ -0,0 +1,205 @@
+// Package service 提供业务逻辑处理，处理租金账单相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/invoice"
+	leaseModel "lease/internal/model/lease"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/invoice/dto"
+	"lease/pkg/serve/mapper"
+	leaseService "lease/pkg/serve/service/lease"
+	"lease/pkg/vo/invoice"
+)
+
+// feeEditableStatuses 允许调整周期性杂费的合同状态
+var feeEditableStatuses = []string{
+	leaseModel.CONTRACT_STATUS_DRAFT,
+	leaseModel.CONTRACT_STATUS_PENDING_SIGNATURE,
+	leaseModel.CONTRACT_STATUS_ACTIVE,
+}
+
+// CreateRecurringFee 业主或持有账务管理权限的组织成员为合同添加周期性杂费，自下一次出账起生效
+// 参数：
+//   - c: Gin 上下文
+//   - req: 创建周期性杂费请求
+//
+// 返回值：
+//   - *invoice.RecurringFeeVO: 杂费视图对象
+//   - error: 操作过程中的错误
+func CreateRecurringFee(c *gin.Context, req *dto.CreateRecurringFeeRequest) (*invoice.RecurringFeeVO, error) {
+	contract, err := getFeeEditableContract(c, req.ContractID)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := &model.RecurringFee{
+		ContractID:    contract.ID,
+		Category:      req.Category,
+		Name:          req.Name,
+		MonthlyAmount: req.MonthlyAmount,
+	}
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		if err := mapper.CreateRecurringFee(c, fee); err != nil {
+			utils.BizLogger(c).Errorf("创建周期性杂费失败: %v", err)
+			return fmt.Errorf("创建周期性杂费失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRecurringFeeVO(c, fee)
+}
+
+// UpdateRecurringFee 业主或持有账务管理权限的组织成员修改周期性杂费，已生成的账单不受影响
+// 参数：
+//   - c: Gin 上下文
+//   - req: 更新周期性杂费请求
+//
+// 返回值：
+//   - *invoice.RecurringFeeVO: 更新后的杂费视图对象
+//   - error: 操作过程中的错误
+func UpdateRecurringFee(c *gin.Context, req *dto.UpdateRecurringFeeRequest) (*invoice.RecurringFeeVO, error) {
+	fee, err := getEditableRecurringFee(c, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		fee.Name = *req.Name
+	}
+	if req.MonthlyAmount != nil {
+		fee.MonthlyAmount = *req.MonthlyAmount
+	}
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		if err := mapper.UpdateRecurringFee(c, fee); err != nil {
+			utils.BizLogger(c).Errorf("更新周期性杂费失败: %v", err)
+			return fmt.Errorf("更新周期性杂费失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRecurringFeeVO(c, fee)
+}
+
+// DeleteRecurringFee 业主或持有账务管理权限的组织成员删除周期性杂费，已生成的账单不受影响
+// 参数：
+//   - c: Gin 上下文
+//   - req: 周期性杂费 ID 请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteRecurringFee(c *gin.Context, req *dto.RecurringFeeIDRequest) error {
+	fee, err := getEditableRecurringFee(c, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return utils.RunDBTransaction(c, func(tx error) error {
+		if err := mapper.DeleteRecurringFeeByID(c, fee.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除周期性杂费失败: %v", err)
+			return fmt.Errorf("删除周期性杂费失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListRecurringFees 获取合同的周期性杂费，仅合同双方及持有账务管理权限的组织成员可查看
+// 参数：
+//   - c: Gin 上下文
+//   - req: 合同账期请求
+//
+// 返回值：
+//   - []*invoice.RecurringFeeVO: 杂费列表
+//   - error: 操作过程中的错误
+func ListRecurringFees(c *gin.Context, req *dto.ContractScheduleRequest) ([]*invoice.RecurringFeeVO, error) {
+	if _, _, err := leaseService.GetParticipatedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE); err != nil {
+		return nil, err
+	}
+
+	fees, err := mapper.GetRecurringFeesByContractID(c, req.ContractID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询周期性杂费失败: %v", err)
+		return nil, fmt.Errorf("查询周期性杂费失败: %w", err)
+	}
+
+	list := make([]*invoice.RecurringFeeVO, 0, len(fees))
+	for _, fee := range fees {
+		feeVO, err := toRecurringFeeVO(c, fee)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, feeVO)
+	}
+	return list, nil
+}
+
+// getFeeEditableContract 获取当前账户可管理且允许调整杂费的合同
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - *leaseModel.LeaseContract: 合同信息
+//   - error: 合同不存在、无权管理或合同已结束时返回错误
+func getFeeEditableContract(c *gin.Context, contractID int64) (*leaseModel.LeaseContract, error) {
+	contract, _, err := leaseService.GetManagedContract(c, contractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(feeEditableStatuses, contract.Status) {
+		utils.BizLogger(c).Errorf("合同「%d」状态为「%s」，不可调整杂费", contract.ID, contract.Status)
+		return nil, fmt.Errorf("合同已结束，不可调整杂费")
+	}
+	return contract, nil
+}
+
+// getEditableRecurringFee 获取当前账户可调整的周期性杂费
+// 参数：
+//   - c: Gin 上下文
+//   - feeID: 杂费 ID
+//
+// 返回值：
+//   - *model.RecurringFee: 杂费信息
+//   - error: 杂费不存在或无权调整时返回错误
+func getEditableRecurringFee(c *gin.Context, feeID int64) (*model.RecurringFee, error) {
+	fee, err := mapper.GetRecurringFeeByID(c, feeID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」周期性杂费不存在: %v", feeID, err)
+		return nil, fmt.Errorf("「%d」周期性杂费不存在: %w", feeID, err)
+	}
+
+	if _, err := getFeeEditableContract(c, fee.ContractID); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+// toRecurringFeeVO 将周期性杂费模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - fee: 杂费模型
+//
+// 返回值：
+//   - *invoice.RecurringFeeVO: 杂费视图对象
+//   - error: 映射过程中的错误
+func toRecurringFeeVO(c *gin.Context, fee *model.RecurringFee) (*invoice.RecurringFeeVO, error) {
+	feeVO, err := utils.MapModelToVO(fee, &invoice.RecurringFeeVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("周期性杂费映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("周期性杂费映射 VO 失败: %w", err)
+	}
+	return feeVO.(*invoice.RecurringFeeVO), nil
+}
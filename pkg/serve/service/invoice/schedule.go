@@ -0,0 +1,106 @@
+// Package service 提供业务逻辑处理，处理租金账单相关业务
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	leaseModel "lease/internal/model/lease"
+)
+
+// cycleMonths 各计费周期包含的月数
+var cycleMonths = map[string]int{
+	leaseModel.BILLING_CYCLE_MONTHLY:   1,
+	leaseModel.BILLING_CYCLE_QUARTERLY: 3,
+	leaseModel.BILLING_CYCLE_YEARLY:    12,
+}
+
+// billingPeriod 计费周期，按自然月、自然季度或自然年对齐
+type billingPeriod struct {
+	Start    time.Time // 周期开始日期
+	End      time.Time // 周期结束日期（含当日）
+	Prorated bool      // 是否为按天折算的非完整周期
+}
+
+// buildSchedule 根据合同起止日期与计费周期生成账期表，首尾不完整的周期单独成期
+// 参数：
+//   - startDate: 起租日期
+//   - endDate: 到期日期（含当日）
+//   - cycle: 计费周期
+//
+// 返回值：
+//   - []billingPeriod: 账期列表
+//   - error: 日期或计费周期不合法时返回错误
+func buildSchedule(startDate, endDate, cycle string) ([]billingPeriod, error) {
+	months, ok := cycleMonths[cycle]
+	if !ok {
+		return nil, fmt.Errorf("不支持的计费周期「%s」", cycle)
+	}
+
+	start, err := time.Parse(leaseModel.DATE_LAYOUT, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("起租日期格式错误: %w", err)
+	}
+	end, err := time.Parse(leaseModel.DATE_LAYOUT, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("到期日期格式错误: %w", err)
+	}
+
+	var periods []billingPeriod
+	for cur := start; !cur.After(end); {
+		// 周期起点对齐到 1 月、4 月、7 月、10 月等自然边界
+		alignedStart := time.Date(cur.Year(), cur.Month()-time.Month((int(cur.Month())-1)%months), 1, 0, 0, 0, 0, time.UTC)
+		alignedEnd := alignedStart.AddDate(0, months, -1)
+
+		periodEnd := alignedEnd
+		if end.Before(periodEnd) {
+			periodEnd = end
+		}
+
+		periods = append(periods, billingPeriod{
+			Start:    cur,
+			End:      periodEnd,
+			Prorated: !cur.Equal(alignedStart) || !periodEnd.Equal(alignedEnd),
+		})
+		cur = periodEnd.AddDate(0, 0, 1)
+	}
+
+	return periods, nil
+}
+
+// prorate 计算按月计价的费用在指定日期区间内的金额，整月按月价计算，不足整月按当月实际天数折算并四舍五入到分
+// 参数：
+//   - monthlyAmount: 月费用（分）
+//   - start: 区间开始日期
+//   - end: 区间结束日期（含当日）
+//
+// 返回值：
+//   - int64: 区间金额（分）
+//   - string: 折算说明，区间均为整月时为空
+func prorate(monthlyAmount int64, start, end time.Time) (int64, string) {
+	var amount int64
+	var remarks []string
+
+	for cur := start; !cur.After(end); {
+		monthStart := time.Date(cur.Year(), cur.Month(), 1, 0, 0, 0, 0, time.UTC)
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		segmentEnd := monthEnd
+		if end.Before(segmentEnd) {
+			segmentEnd = end
+		}
+
+		daysInMonth := int64(monthEnd.Day())
+		days := int64(segmentEnd.Sub(cur).Hours()/24) + 1
+		if days == daysInMonth {
+			amount += monthlyAmount
+		} else {
+			amount += (monthlyAmount*days + daysInMonth/2) / daysInMonth
+			remarks = append(remarks, fmt.Sprintf("%s 按 %d/%d 天折算", cur.Format("2006-01"), days, daysInMonth))
+		}
+
+		cur = segmentEnd.AddDate(0, 0, 1)
+	}
+
+	return amount, strings.Join(remarks, "；")
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"lease/internal/db/dbtest"
+	model "lease/internal/model/invoice"
+	leaseModel "lease/internal/model/lease"
+	ledgerModel "lease/internal/model/ledger"
+	"lease/pkg/serve/mapper"
+)
+
+// 测试使用的账户与组织
+const (
+	testOrganizationID = 1
+	testLandlordID     = 1001
+	testTenantID       = 1002
+	testMonthlyRent    = 300000
+)
+
+// date 解析测试日期
+func date(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	d, err := time.Parse(leaseModel.DATE_LAYOUT, value)
+	if err != nil {
+		t.Fatalf("解析日期「%s」失败: %v", value, err)
+	}
+	return d
+}
+
+func TestBuildSchedule(t *testing.T) {
+	type period struct {
+		start, end string
+		prorated   bool
+	}
+	tests := []struct {
+		name, start, end, cycle string
+		want                    []period
+	}{
+		{"月中起租按月", "2025-01-15", "2025-03-31", leaseModel.BILLING_CYCLE_MONTHLY, []period{
+			{"2025-01-15", "2025-01-31", true},
+			{"2025-02-01", "2025-02-28", false},
+			{"2025-03-01", "2025-03-31", false},
+		}},
+		{"月末起租且月中到期", "2025-01-31", "2025-03-14", leaseModel.BILLING_CYCLE_MONTHLY, []period{
+			{"2025-01-31", "2025-01-31", true},
+			{"2025-02-01", "2025-02-28", false},
+			{"2025-03-01", "2025-03-14", true},
+		}},
+		{"闰年 2 月 29 日起租", "2024-02-29", "2024-04-30", leaseModel.BILLING_CYCLE_MONTHLY, []period{
+			{"2024-02-29", "2024-02-29", true},
+			{"2024-03-01", "2024-03-31", false},
+			{"2024-04-01", "2024-04-30", false},
+		}},
+		{"闰年 2 月整月", "2024-02-01", "2024-02-29", leaseModel.BILLING_CYCLE_MONTHLY, []period{
+			{"2024-02-01", "2024-02-29", false},
+		}},
+		{"起止同日", "2025-06-30", "2025-06-30", leaseModel.BILLING_CYCLE_MONTHLY, []period{
+			{"2025-06-30", "2025-06-30", true},
+		}},
+		{"按季对齐自然季度", "2025-02-10", "2025-12-31", leaseModel.BILLING_CYCLE_QUARTERLY, []period{
+			{"2025-02-10", "2025-03-31", true},
+			{"2025-04-01", "2025-06-30", false},
+			{"2025-07-01", "2025-09-30", false},
+			{"2025-10-01", "2025-12-31", false},
+		}},
+		{"按季于闰年 2 月 29 日到期", "2023-11-15", "2024-02-29", leaseModel.BILLING_CYCLE_QUARTERLY, []period{
+			{"2023-11-15", "2023-12-31", true},
+			{"2024-01-01", "2024-02-29", true},
+		}},
+		{"按年对齐自然年", "2024-03-01", "2026-02-28", leaseModel.BILLING_CYCLE_YEARLY, []period{
+			{"2024-03-01", "2024-12-31", true},
+			{"2025-01-01", "2025-12-31", false},
+			{"2026-01-01", "2026-02-28", true},
+		}},
+		{"到期日早于起租日", "2025-03-01", "2025-02-28", leaseModel.BILLING_CYCLE_MONTHLY, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildSchedule(tt.start, tt.end, tt.cycle)
+			if err != nil {
+				t.Fatalf("buildSchedule(%q, %q, %q) 失败: %v", tt.start, tt.end, tt.cycle, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("buildSchedule(%q, %q, %q) 返回 %d 期，期望 %d 期: %v", tt.start, tt.end, tt.cycle, len(got), len(tt.want), got)
+			}
+			for i, p := range got {
+				want := tt.want[i]
+				if p.Start.Format(leaseModel.DATE_LAYOUT) != want.start || p.End.Format(leaseModel.DATE_LAYOUT) != want.end || p.Prorated != want.prorated {
+					t.Fatalf("第 %d 期 = %s ~ %s (折算 %v)，期望 %s ~ %s (折算 %v)", i+1,
+						p.Start.Format(leaseModel.DATE_LAYOUT), p.End.Format(leaseModel.DATE_LAYOUT), p.Prorated, want.start, want.end, want.prorated)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildScheduleInvalid(t *testing.T) {
+	tests := []struct {
+		name, start, end, cycle string
+	}{
+		{"不支持的计费周期", "2025-01-01", "2025-12-31", "weekly"},
+		{"起租日期不合法", "2025-02-30", "2025-12-31", leaseModel.BILLING_CYCLE_MONTHLY},
+		{"到期日期格式错误", "2025-01-01", "2025/12/31", leaseModel.BILLING_CYCLE_MONTHLY},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildSchedule(tt.start, tt.end, tt.cycle); err == nil {
+				t.Fatalf("buildSchedule(%q, %q, %q) 应返回错误", tt.start, tt.end, tt.cycle)
+			}
+		})
+	}
+}
+
+func TestProrate(t *testing.T) {
+	tests := []struct {
+		name       string
+		monthly    int64
+		start, end string
+		want       int64
+		wantRemark string
+	}{
+		{"整月", testMonthlyRent, "2025-01-01", "2025-01-31", testMonthlyRent, ""},
+		{"平年 2 月整月", testMonthlyRent, "2025-02-01", "2025-02-28", testMonthlyRent, ""},
+		{"闰年 2 月整月", testMonthlyRent, "2024-02-01", "2024-02-29", testMonthlyRent, ""},
+		{"整季", testMonthlyRent, "2025-04-01", "2025-06-30", 3 * testMonthlyRent, ""},
+		{"月中起租", testMonthlyRent, "2025-01-15", "2025-01-31", 164516, "2025-01 按 17/31 天折算"},
+		{"闰年 2 月 29 日单日", testMonthlyRent, "2024-02-29", "2024-02-29", 10345, "2024-02 按 1/29 天折算"},
+		{"跨月首尾折算", testMonthlyRent, "2025-01-31", "2025-03-14", 9677 + testMonthlyRent + 135484, "2025-01 按 1/31 天折算；2025-03 按 14/31 天折算"},
+		{"半分四舍五入", 1, "2025-04-01", "2025-04-15", 1, "2025-04 按 15/30 天折算"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, remark := prorate(tt.monthly, date(t, tt.start), date(t, tt.end))
+			if got != tt.want || remark != tt.wantRemark {
+				t.Fatalf("prorate(%d, %s, %s) = %d, %q，期望 %d, %q", tt.monthly, tt.start, tt.end, got, remark, tt.want, tt.wantRemark)
+			}
+		})
+	}
+}
+
+// TestGenerateInvoicesIdempotent 重复出账跳过已出账的账期，同一账期不会重复计费
+func TestGenerateInvoicesIdempotent(t *testing.T) {
+	c := dbtest.NewContext(testLandlordID, testOrganizationID)
+	contract := &leaseModel.LeaseContract{
+		UnitID:       1,
+		PropertyID:   1,
+		LandlordID:   testLandlordID,
+		TenantID:     testTenantID,
+		StartDate:    "2025-01-15",
+		EndDate:      "2025-12-31",
+		MonthlyRent:  testMonthlyRent,
+		BillingCycle: leaseModel.BILLING_CYCLE_MONTHLY,
+		Status:       leaseModel.CONTRACT_STATUS_ACTIVE,
+	}
+	if err := mapper.CreateLeaseContract(c, contract); err != nil {
+		t.Fatalf("创建合同失败: %v", err)
+	}
+
+	runs := []struct {
+		asOf             string
+		created, skipped int
+	}{
+		{"2025-03-01", 3, 0},
+		{"2025-03-01", 0, 3},
+		{"2025-04-10", 1, 3},
+	}
+	for _, run := range runs {
+		generated, skipped, err := generateContractInvoices(c, contract, run.asOf)
+		if err != nil {
+			t.Fatalf("截至 %s 出账失败: %v", run.asOf, err)
+		}
+		if len(generated) != run.created || skipped != run.skipped {
+			t.Fatalf("截至 %s 出账生成 %d 张、跳过 %d 期，期望生成 %d 张、跳过 %d 期", run.asOf, len(generated), skipped, run.created, run.skipped)
+		}
+	}
+
+	invoiced, err := mapper.GetInvoicedPeriodStarts(c, contract.ID)
+	if err != nil {
+		t.Fatalf("查询已出账账期失败: %v", err)
+	}
+	if len(invoiced) != 4 {
+		t.Fatalf("已出账 %d 期，期望 4 期: %v", len(invoiced), invoiced)
+	}
+
+	billed, err := mapper.SumInvoiceOutstanding(c, mapper.InvoiceFilter{ContractID: contract.ID})
+	if err != nil {
+		t.Fatalf("汇总未结清金额失败: %v", err)
+	}
+	balances, err := mapper.GetLedgerBalances(c, mapper.LedgerFilter{ContractID: contract.ID, Accounts: []string{ledgerModel.LEDGER_ACCOUNT_RECEIVABLE}})
+	if err != nil {
+		t.Fatalf("查询应收余额失败: %v", err)
+	}
+	receivable := balances[ledgerModel.LEDGER_ACCOUNT_RECEIVABLE]
+	if receivable == nil || receivable.Debit != billed {
+		t.Fatalf("应收借方合计 = %v，期望与账单合计 %d 一致", receivable, billed)
+	}
+
+	// 并发出账绕过已出账检查时，由唯一索引拒绝同一账期的第二张账单
+	duplicate := &model.Invoice{
+		ContractID:  contract.ID,
+		PeriodStart: "2025-01-15",
+		PeriodEnd:   "2025-01-31",
+		UnitID:      contract.UnitID,
+		LandlordID:  contract.LandlordID,
+		TenantID:    contract.TenantID,
+		DueDate:     "2025-01-15",
+		Amount:      1,
+		Status:      model.INVOICE_STATUS_UNPAID,
+	}
+	if err := mapper.CreateInvoice(c, duplicate, nil); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("重复账期出账错误 = %v，期望 %v", err, gorm.ErrDuplicatedKey)
+	}
+}
@@ -0,0 +1,126 @@
+// Package service 提供业务逻辑处理，处理租约合同相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/lease"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+)
+
+// GetParticipatedContract 获取当前账户可访问的合同：合同业主、租客，或在合同所属组织内持有指定权限的账户
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//   - permission: 非合同当事人访问所需的权限编码，如 lease:write
+//
+// 返回值：
+//   - *model.LeaseContract: 合同信息
+//   - int64: 当前账户 ID
+//   - error: 合同不存在或当前账户无权访问时返回错误
+func GetParticipatedContract(c *gin.Context, contractID int64, permission string) (*model.LeaseContract, int64, error) {
+	contract, accountID, err := loadContract(c, contractID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if contract.LandlordID != accountID && contract.TenantID != accountID && !hasContractPermission(c, contract, permission) {
+		utils.BizLogger(c).Errorf("账户「%d」无权访问合同「%d」", accountID, contractID)
+		return nil, 0, fmt.Errorf("无权访问该合同")
+	}
+
+	return contract, accountID, nil
+}
+
+// GetManagedContract 获取当前账户可管理的合同：合同业主，或在合同所属组织内持有指定权限的账户，如物业管理员与组织管理员
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//   - permission: 管理该合同所需的权限编码，如 billing:write
+//
+// 返回值：
+//   - *model.LeaseContract: 合同信息
+//   - int64: 当前账户 ID
+//   - error: 合同不存在或当前账户无权管理时返回错误
+func GetManagedContract(c *gin.Context, contractID int64, permission string) (*model.LeaseContract, int64, error) {
+	contract, accountID, err := loadContract(c, contractID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if contract.LandlordID != accountID && !hasContractPermission(c, contract, permission) {
+		utils.BizLogger(c).Errorf("账户「%d」无权管理合同「%d」", accountID, contractID)
+		return nil, 0, fmt.Errorf("仅业主或持有「%s」权限的组织成员可执行该操作", permission)
+	}
+
+	return contract, accountID, nil
+}
+
+// GetTenantContract 获取当前账户作为租客的合同
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - *model.LeaseContract: 合同信息
+//   - int64: 当前账户 ID
+//   - error: 合同不存在或当前账户非租客时返回错误
+func GetTenantContract(c *gin.Context, contractID int64) (*model.LeaseContract, int64, error) {
+	contract, accountID, err := loadContract(c, contractID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if contract.TenantID != accountID {
+		utils.BizLogger(c).Errorf("账户「%d」不是合同「%d」的租客", accountID, contractID)
+		return nil, 0, fmt.Errorf("仅租客可执行该操作")
+	}
+
+	return contract, accountID, nil
+}
+
+// loadContract 获取经认证的当前账户 ID 及合同，合同查询限定在当前组织内
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - *model.LeaseContract: 合同信息
+//   - int64: 当前账户 ID
+//   - error: 未认证或合同不存在时返回错误
+func loadContract(c *gin.Context, contractID int64) (*model.LeaseContract, int64, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, 0, fmt.Errorf("未获取到当前账户")
+	}
+
+	contract, err := mapper.GetLeaseContractByID(c, contractID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」合同不存在: %v", contractID, err)
+		return nil, 0, fmt.Errorf("「%d」合同不存在: %w", contractID, err)
+	}
+
+	return contract, accountID, nil
+}
+
+// hasContractPermission 判断当前账户是否在合同所属组织内持有指定权限
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同
+//   - permission: 权限编码
+//
+// 返回值：
+//   - bool: 是否持有
+func hasContractPermission(c *gin.Context, contract *model.LeaseContract, permission string) bool {
+	organizationID, ok := auth_middleware.GetOrganizationID(c)
+	if !ok || organizationID != contract.OrganizationID {
+		return false
+	}
+	return slices.Contains(auth_middleware.GetPermissions(c), permission)
+}
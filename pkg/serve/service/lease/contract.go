@@ -0,0 +1,629 @@
+// Package service 提供业务逻辑处理，处理租约合同相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/lease"
+	propertyModel "lease/internal/model/property"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/lease/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
+	"lease/pkg/vo/lease"
+)
+
+// occupyingStatuses 占用出租单元档期的合同状态
+var occupyingStatuses = []string{model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_STATUS_ACTIVE}
+
+// CreateContract 创建合同草稿逻辑，当前账户须为出租单元所属房源的业主或持有合同管理权限的组织成员，合同业主始终为房源业主
+// 参数：
+//   - c: Gin 上下文
+//   - req: 创建合同请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 合同视图对象
+//   - error: 操作过程中的错误
+func CreateContract(c *gin.Context, req *dto.CreateContractRequest) (*lease.LeaseContractVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	var contract *model.LeaseContract
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		unit, err := mapper.GetUnitByID(c, req.UnitID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」出租单元不存在: %v", req.UnitID, err)
+			return fmt.Errorf("「%d」出租单元不存在: %w", req.UnitID, err)
+		}
+
+		property, err := mapper.GetPropertyByID(c, unit.PropertyID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」房源不存在: %v", unit.PropertyID, err)
+			return fmt.Errorf("「%d」房源不存在: %w", unit.PropertyID, err)
+		}
+		// 房源查询已限定在当前组织内，持有合同管理权限的组织成员可代业主起草
+		if property.OwnerID != accountID && !slices.Contains(auth_middleware.GetPermissions(c), rbacModel.PERMISSION_LEASE_WRITE) {
+			utils.BizLogger(c).Errorf("账户「%d」无权为出租单元「%d」创建合同", accountID, req.UnitID)
+			return fmt.Errorf("无权为该出租单元创建合同")
+		}
+
+		if err := validateTenant(c, req.TenantID, property.OwnerID); err != nil {
+			return err
+		}
+		if err := validateContractDates(c, req.StartDate, req.EndDate); err != nil {
+			return err
+		}
+
+		contract = &model.LeaseContract{
+			UnitID:       unit.ID,
+			PropertyID:   property.ID,
+			LandlordID:   property.OwnerID,
+			TenantID:     req.TenantID,
+			StartDate:    req.StartDate,
+			EndDate:      req.EndDate,
+			MonthlyRent:  req.MonthlyRent,
+			Deposit:      req.Deposit,
+			BillingCycle: req.BillingCycle,
+			Status:       model.CONTRACT_STATUS_DRAFT,
+		}
+		if err := mapper.CreateLeaseContract(c, contract); err != nil {
+			utils.BizLogger(c).Errorf("创建合同失败: %v", err)
+			return fmt.Errorf("创建合同失败: %w", err)
+		}
+
+		return recordContractAudit(c, contract.ID, model.CONTRACT_ACTION_CREATE, "", model.CONTRACT_STATUS_DRAFT, accountID, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// GetContract 获取合同详情逻辑，仅合同双方及持有合同管理权限的组织成员可查看
+// 参数：
+//   - c: Gin 上下文
+//   - req: 合同 ID 请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 合同视图对象
+//   - error: 操作过程中的错误
+func GetContract(c *gin.Context, req *dto.ContractIDRequest) (*lease.LeaseContractVO, error) {
+	contract, _, err := GetParticipatedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// UpdateContract 修改合同草稿逻辑，仅业主或持有合同管理权限的组织成员可修改草稿状态的合同
+// 参数：
+//   - c: Gin 上下文
+//   - req: 更新合同请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 更新后的合同视图对象
+//   - error: 操作过程中的错误
+func UpdateContract(c *gin.Context, req *dto.UpdateContractRequest) (*lease.LeaseContractVO, error) {
+	var contract *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		contract, _, err = GetManagedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+		if err != nil {
+			return err
+		}
+
+		if contract.Status != model.CONTRACT_STATUS_DRAFT {
+			utils.BizLogger(c).Errorf("合同「%d」状态为「%s」，不可修改", contract.ID, contract.Status)
+			return fmt.Errorf("仅草稿状态的合同可修改")
+		}
+
+		if req.StartDate != nil {
+			contract.StartDate = *req.StartDate
+		}
+		if req.EndDate != nil {
+			contract.EndDate = *req.EndDate
+		}
+		if req.MonthlyRent != nil {
+			contract.MonthlyRent = *req.MonthlyRent
+		}
+		if req.Deposit != nil {
+			contract.Deposit = *req.Deposit
+		}
+		if req.BillingCycle != nil {
+			contract.BillingCycle = *req.BillingCycle
+		}
+
+		if err := validateContractDates(c, contract.StartDate, contract.EndDate); err != nil {
+			return err
+		}
+
+		if err := mapper.UpdateLeaseContract(c, contract); err != nil {
+			utils.BizLogger(c).Errorf("更新合同失败: %v", err)
+			return fmt.Errorf("更新合同失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// SubmitContract 业主或持有合同管理权限的组织成员提交合同等待租客签署
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 流转后的合同视图对象
+//   - error: 操作过程中的错误
+func SubmitContract(c *gin.Context, req *dto.TransitionContractRequest) (*lease.LeaseContractVO, error) {
+	var contract *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var accountID int64
+		var err error
+		contract, accountID, err = GetManagedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureUnitAvailable(c, contract); err != nil {
+			return err
+		}
+
+		return transitionContract(c, contract, model.CONTRACT_ACTION_SUBMIT, accountID, req.Remark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// WithdrawContract 业主或持有合同管理权限的组织成员撤回待签署合同至草稿
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 流转后的合同视图对象
+//   - error: 操作过程中的错误
+func WithdrawContract(c *gin.Context, req *dto.TransitionContractRequest) (*lease.LeaseContractVO, error) {
+	var contract *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var accountID int64
+		var err error
+		contract, accountID, err = GetManagedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+		if err != nil {
+			return err
+		}
+
+		return transitionContract(c, contract, model.CONTRACT_ACTION_WITHDRAW, accountID, req.Remark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// SignContract 租客签署合同使其生效，出租单元标记为已出租；若为续约合同，原合同同时流转为已续约，其到期日前剩余的账期仍照常出账
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 流转后的合同视图对象
+//   - error: 操作过程中的错误
+func SignContract(c *gin.Context, req *dto.TransitionContractRequest) (*lease.LeaseContractVO, error) {
+	var contract *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		contract, _, err = GetTenantContract(c, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if contract.EndDate < today() {
+			utils.BizLogger(c).Errorf("合同「%d」已过到期日「%s」，无法签署", contract.ID, contract.EndDate)
+			return fmt.Errorf("合同已过到期日，无法签署")
+		}
+		if err := ensureUnitAvailable(c, contract); err != nil {
+			return err
+		}
+
+		contract.SignedAt = time.Now().Unix()
+		if err := transitionContract(c, contract, model.CONTRACT_ACTION_SIGN, contract.TenantID, req.Remark); err != nil {
+			return err
+		}
+
+		if contract.RenewedFromID != 0 {
+			previous, err := mapper.GetLeaseContractByID(c, contract.RenewedFromID)
+			if err != nil {
+				utils.BizLogger(c).Errorf("「%d」原合同不存在: %v", contract.RenewedFromID, err)
+				return fmt.Errorf("「%d」原合同不存在: %w", contract.RenewedFromID, err)
+			}
+			if previous.Status == model.CONTRACT_STATUS_ACTIVE {
+				remark := fmt.Sprintf("续约合同「%d」已生效", contract.ID)
+				if err := transitionContract(c, previous, model.CONTRACT_ACTION_RENEW, contract.TenantID, remark); err != nil {
+					return err
+				}
+			}
+		}
+
+		return setUnitStatus(c, contract.UnitID, propertyModel.UNIT_STATUS_OCCUPIED)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// TerminateContract 合同任一方或持有合同管理权限的组织成员提前解约，出租单元恢复空置
+// 参数：
+//   - c: Gin 上下文
+//   - req: 解约请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 流转后的合同视图对象
+//   - error: 操作过程中的错误
+func TerminateContract(c *gin.Context, req *dto.TerminateContractRequest) (*lease.LeaseContractVO, error) {
+	var contract *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var accountID int64
+		var err error
+		contract, accountID, err = GetParticipatedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+		if err != nil {
+			return err
+		}
+
+		contract.TerminatedAt = time.Now().Unix()
+		contract.TerminationReason = req.Reason
+		if err := transitionContract(c, contract, model.CONTRACT_ACTION_TERMINATE, accountID, req.Reason); err != nil {
+			return err
+		}
+
+		return releaseUnit(c, contract)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// ExpireContract 业主或持有合同管理权限的组织成员将已过到期日的合同标记为到期，出租单元恢复空置
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 流转后的合同视图对象
+//   - error: 操作过程中的错误
+func ExpireContract(c *gin.Context, req *dto.TransitionContractRequest) (*lease.LeaseContractVO, error) {
+	var contract *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var accountID int64
+		var err error
+		contract, accountID, err = GetManagedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+		if err != nil {
+			return err
+		}
+
+		if contract.EndDate >= today() {
+			utils.BizLogger(c).Errorf("合同「%d」到期日「%s」未到，无法标记到期", contract.ID, contract.EndDate)
+			return fmt.Errorf("合同尚未到期")
+		}
+
+		if err := transitionContract(c, contract, model.CONTRACT_ACTION_EXPIRE, accountID, req.Remark); err != nil {
+			return err
+		}
+
+		return releaseUnit(c, contract)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, contract)
+}
+
+// RenewContract 业主或持有合同管理权限的组织成员基于生效中的合同创建续约草稿，续约合同签署后原合同流转为已续约
+// 参数：
+//   - c: Gin 上下文
+//   - req: 续约请求
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 续约合同草稿视图对象
+//   - error: 操作过程中的错误
+func RenewContract(c *gin.Context, req *dto.RenewContractRequest) (*lease.LeaseContractVO, error) {
+	var renewal *model.LeaseContract
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		previous, accountID, err := GetManagedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE)
+		if err != nil {
+			return err
+		}
+
+		if previous.Status != model.CONTRACT_STATUS_ACTIVE {
+			utils.BizLogger(c).Errorf("合同「%d」状态为「%s」，不可续约", previous.ID, previous.Status)
+			return fmt.Errorf("仅生效中的合同可续约")
+		}
+		if existing, _ := mapper.GetRenewalLeaseContract(c, previous.ID); existing != nil {
+			utils.BizLogger(c).Errorf("合同「%d」已存在未完成的续约合同「%d」", previous.ID, existing.ID)
+			return fmt.Errorf("该合同已存在未完成的续约合同")
+		}
+
+		previousEnd, _ := time.Parse(model.DATE_LAYOUT, previous.EndDate)
+		startDate := previousEnd.AddDate(0, 0, 1).Format(model.DATE_LAYOUT)
+		if err := validateContractDates(c, startDate, req.EndDate); err != nil {
+			return err
+		}
+
+		renewal = &model.LeaseContract{
+			UnitID:        previous.UnitID,
+			PropertyID:    previous.PropertyID,
+			LandlordID:    previous.LandlordID,
+			TenantID:      previous.TenantID,
+			StartDate:     startDate,
+			EndDate:       req.EndDate,
+			MonthlyRent:   previous.MonthlyRent,
+			Deposit:       previous.Deposit,
+			BillingCycle:  previous.BillingCycle,
+			Status:        model.CONTRACT_STATUS_DRAFT,
+			RenewedFromID: previous.ID,
+		}
+		if req.MonthlyRent != nil {
+			renewal.MonthlyRent = *req.MonthlyRent
+		}
+		if req.Deposit != nil {
+			renewal.Deposit = *req.Deposit
+		}
+		if req.BillingCycle != nil {
+			renewal.BillingCycle = *req.BillingCycle
+		}
+
+		if err := mapper.CreateLeaseContract(c, renewal); err != nil {
+			utils.BizLogger(c).Errorf("创建续约合同失败: %v", err)
+			return fmt.Errorf("创建续约合同失败: %w", err)
+		}
+
+		remark := fmt.Sprintf("续约自合同「%d」", previous.ID)
+		return recordContractAudit(c, renewal.ID, model.CONTRACT_ACTION_CREATE, "", model.CONTRACT_STATUS_DRAFT, accountID, remark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toContractVO(c, renewal)
+}
+
+// ListContracts 按业主或租客身份分页查询当前账户相关的合同
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页查询请求
+//
+// 返回值：
+//   - *vo.PageVO: 合同分页结果
+//   - error: 操作过程中的错误
+func ListContracts(c *gin.Context, req *dto.ListContractRequest) (*vo.PageVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	filter := mapper.LeaseContractFilter{UnitID: req.UnitID, Status: req.Status}
+	switch req.Role {
+	case "landlord":
+		filter.LandlordID = accountID
+	default:
+		filter.TenantID = accountID
+	}
+
+	contracts, total, err := mapper.ListLeaseContracts(c, filter, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询合同列表失败: %v", err)
+		return nil, fmt.Errorf("查询合同列表失败: %w", err)
+	}
+
+	list := make([]*lease.LeaseContractVO, 0, len(contracts))
+	for _, contract := range contracts {
+		contractVO, err := toContractVO(c, contract)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, contractVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// ListContractAudits 获取合同状态流转审计记录，仅合同双方及持有合同管理权限的组织成员可查看
+// 参数：
+//   - c: Gin 上下文
+//   - req: 合同 ID 请求
+//
+// 返回值：
+//   - []*lease.LeaseContractAuditVO: 审计记录列表
+//   - error: 操作过程中的错误
+func ListContractAudits(c *gin.Context, req *dto.ContractIDRequest) ([]*lease.LeaseContractAuditVO, error) {
+	if _, _, err := GetParticipatedContract(c, req.ID, rbacModel.PERMISSION_LEASE_WRITE); err != nil {
+		return nil, err
+	}
+
+	audits, err := mapper.GetLeaseContractAuditsByContractID(c, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询合同审计记录失败: %v", err)
+		return nil, fmt.Errorf("查询合同审计记录失败: %w", err)
+	}
+
+	list := make([]*lease.LeaseContractAuditVO, 0, len(audits))
+	for _, audit := range audits {
+		auditVO, err := utils.MapModelToVO(audit, &lease.LeaseContractAuditVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("合同审计记录映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("合同审计记录映射 VO 失败: %w", err)
+		}
+		list = append(list, auditVO.(*lease.LeaseContractAuditVO))
+	}
+
+	return list, nil
+}
+
+// validateTenant 校验租客账户存在且不是业主本人
+// 参数：
+//   - c: Gin 上下文
+//   - tenantID: 租客账户 ID
+//   - landlordID: 业主账户 ID
+//
+// 返回值：
+//   - error: 校验失败时返回错误
+func validateTenant(c *gin.Context, tenantID, landlordID int64) error {
+	if tenantID == landlordID {
+		utils.BizLogger(c).Errorf("租客与业主不能为同一账户「%d」", tenantID)
+		return fmt.Errorf("租客与业主不能为同一账户")
+	}
+	if _, err := mapper.GetAccountByAccountID(c, tenantID); err != nil {
+		utils.BizLogger(c).Errorf("「%d」租客账户不存在: %v", tenantID, err)
+		return fmt.Errorf("「%d」租客账户不存在: %w", tenantID, err)
+	}
+	return nil
+}
+
+// validateContractDates 校验合同起止日期
+// 参数：
+//   - c: Gin 上下文
+//   - startDate: 起租日期
+//   - endDate: 到期日期
+//
+// 返回值：
+//   - error: 校验失败时返回错误
+func validateContractDates(c *gin.Context, startDate, endDate string) error {
+	start, err := time.Parse(model.DATE_LAYOUT, startDate)
+	if err != nil {
+		utils.BizLogger(c).Errorf("起租日期「%s」格式错误: %v", startDate, err)
+		return fmt.Errorf("起租日期格式错误: %w", err)
+	}
+	end, err := time.Parse(model.DATE_LAYOUT, endDate)
+	if err != nil {
+		utils.BizLogger(c).Errorf("到期日期「%s」格式错误: %v", endDate, err)
+		return fmt.Errorf("到期日期格式错误: %w", err)
+	}
+	if !end.After(start) {
+		utils.BizLogger(c).Errorf("到期日期「%s」须晚于起租日期「%s」", endDate, startDate)
+		return fmt.Errorf("到期日期须晚于起租日期")
+	}
+	return nil
+}
+
+// ensureUnitAvailable 校验合同档期内出租单元未被其他待签署或生效中的合同占用
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同
+//
+// 返回值：
+//   - error: 档期冲突时返回错误
+func ensureUnitAvailable(c *gin.Context, contract *model.LeaseContract) error {
+	excludeIDs := []int64{contract.ID}
+	if contract.RenewedFromID != 0 {
+		excludeIDs = append(excludeIDs, contract.RenewedFromID)
+	}
+
+	count, err := mapper.CountOverlappingLeaseContracts(c, contract.UnitID, contract.StartDate, contract.EndDate, occupyingStatuses, excludeIDs...)
+	if err != nil {
+		utils.BizLogger(c).Errorf("检查出租单元档期失败: %v", err)
+		return fmt.Errorf("检查出租单元档期失败: %w", err)
+	}
+	if count > 0 {
+		utils.BizLogger(c).Errorf("出租单元「%d」在「%s ~ %s」已有 %d 份合同", contract.UnitID, contract.StartDate, contract.EndDate, count)
+		return fmt.Errorf("出租单元在该时间段已被其他合同占用")
+	}
+	return nil
+}
+
+// releaseUnit 合同结束后将出租单元恢复为空置，单元仍有其他生效合同时保持不变
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 已结束的合同
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func releaseUnit(c *gin.Context, contract *model.LeaseContract) error {
+	count, err := mapper.CountOverlappingLeaseContracts(c, contract.UnitID, today(), today(),
+		[]string{model.CONTRACT_STATUS_ACTIVE}, contract.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("检查出租单元生效合同失败: %v", err)
+		return fmt.Errorf("检查出租单元生效合同失败: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	return setUnitStatus(c, contract.UnitID, propertyModel.UNIT_STATUS_VACANT)
+}
+
+// setUnitStatus 更新出租单元状态
+// 参数：
+//   - c: Gin 上下文
+//   - unitID: 出租单元 ID
+//   - status: 目标状态
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func setUnitStatus(c *gin.Context, unitID int64, status string) error {
+	unit, err := mapper.GetUnitByID(c, unitID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」出租单元不存在: %v", unitID, err)
+		return fmt.Errorf("「%d」出租单元不存在: %w", unitID, err)
+	}
+
+	unit.Status = status
+	if err := mapper.UpdateUnit(c, unit); err != nil {
+		utils.BizLogger(c).Errorf("更新出租单元状态失败: %v", err)
+		return fmt.Errorf("更新出租单元状态失败: %w", err)
+	}
+	return nil
+}
+
+// today 获取当前日期字符串
+// 返回值：
+//   - string: 格式为 2006-01-02 的日期
+func today() string {
+	return time.Now().Format(model.DATE_LAYOUT)
+}
+
+// toContractVO 将合同模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同模型
+//
+// 返回值：
+//   - *lease.LeaseContractVO: 合同视图对象
+//   - error: 映射过程中的错误
+func toContractVO(c *gin.Context, contract *model.LeaseContract) (*lease.LeaseContractVO, error) {
+	contractVO, err := utils.MapModelToVO(contract, &lease.LeaseContractVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("合同映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("合同映射 VO 失败: %w", err)
+	}
+	return contractVO.(*lease.LeaseContractVO), nil
+}
@@ -0,0 +1,89 @@
+// Package service 提供业务逻辑处理，处理租约合同相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/lease"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+)
+
+// contractTransition 合同状态流转规则
+type contractTransition struct {
+	From []string // 允许的起始状态
+	To   string   // 目标状态
+}
+
+// contractTransitions 合同状态机：draft → pending_signature → active → terminated/expired/renewed
+var contractTransitions = map[string]contractTransition{
+	model.CONTRACT_ACTION_SUBMIT:    {From: []string{model.CONTRACT_STATUS_DRAFT}, To: model.CONTRACT_STATUS_PENDING_SIGNATURE},
+	model.CONTRACT_ACTION_WITHDRAW:  {From: []string{model.CONTRACT_STATUS_PENDING_SIGNATURE}, To: model.CONTRACT_STATUS_DRAFT},
+	model.CONTRACT_ACTION_SIGN:      {From: []string{model.CONTRACT_STATUS_PENDING_SIGNATURE}, To: model.CONTRACT_STATUS_ACTIVE},
+	model.CONTRACT_ACTION_TERMINATE: {From: []string{model.CONTRACT_STATUS_ACTIVE}, To: model.CONTRACT_STATUS_TERMINATED},
+	model.CONTRACT_ACTION_EXPIRE:    {From: []string{model.CONTRACT_STATUS_ACTIVE}, To: model.CONTRACT_STATUS_EXPIRED},
+	model.CONTRACT_ACTION_RENEW:     {From: []string{model.CONTRACT_STATUS_ACTIVE}, To: model.CONTRACT_STATUS_RENEWED},
+}
+
+// transitionContract 按状态机执行合同状态流转并写入审计记录，须在 utils.RunDBTransaction 中调用
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同，调用前可先修改除状态外的其他字段
+//   - action: 流转动作
+//   - operatorID: 操作人账户 ID
+//   - remark: 备注
+//
+// 返回值：
+//   - error: 流转不合法或持久化失败时返回错误
+func transitionContract(c *gin.Context, contract *model.LeaseContract, action string, operatorID int64, remark string) error {
+	rule, ok := contractTransitions[action]
+	if !ok {
+		utils.BizLogger(c).Errorf("未知的合同流转动作: %s", action)
+		return fmt.Errorf("未知的合同流转动作: %s", action)
+	}
+
+	fromStatus := contract.Status
+	if !slices.Contains(rule.From, fromStatus) {
+		utils.BizLogger(c).Errorf("合同「%d」当前状态「%s」不允许执行「%s」", contract.ID, fromStatus, action)
+		return fmt.Errorf("合同当前状态「%s」不允许执行「%s」", fromStatus, action)
+	}
+
+	contract.Status = rule.To
+	if err := mapper.UpdateLeaseContractStatus(c, contract, fromStatus); err != nil {
+		utils.BizLogger(c).Errorf("合同「%d」状态流转失败: %v", contract.ID, err)
+		return fmt.Errorf("合同状态流转失败: %w", err)
+	}
+
+	return recordContractAudit(c, contract.ID, action, fromStatus, rule.To, operatorID, remark)
+}
+
+// recordContractAudit 写入合同审计记录
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//   - action: 流转动作
+//   - fromStatus: 流转前状态
+//   - toStatus: 流转后状态
+//   - operatorID: 操作人账户 ID
+//   - remark: 备注
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func recordContractAudit(c *gin.Context, contractID int64, action, fromStatus, toStatus string, operatorID int64, remark string) error {
+	audit := &model.LeaseContractAudit{
+		ContractID: contractID,
+		Action:     action,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		OperatorID: operatorID,
+		Remark:     remark,
+	}
+	if err := mapper.CreateLeaseContractAudit(c, audit); err != nil {
+		utils.BizLogger(c).Errorf("写入合同审计记录失败: %v", err)
+		return fmt.Errorf("写入合同审计记录失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/internal/db/dbtest"
+	model "lease/internal/model/lease"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+)
+
+// 测试使用的账户与组织
+const (
+	testOrganizationID = 1
+	testLandlordID     = 3001
+	testTenantID       = 3002
+)
+
+// newContract 创建指定状态的合同
+func newContract(t *testing.T, c *gin.Context, status string) *model.LeaseContract {
+	t.Helper()
+
+	contract := &model.LeaseContract{
+		UnitID:       1,
+		PropertyID:   1,
+		LandlordID:   testLandlordID,
+		TenantID:     testTenantID,
+		StartDate:    "2025-01-01",
+		EndDate:      "2025-12-31",
+		MonthlyRent:  100000,
+		BillingCycle: model.BILLING_CYCLE_MONTHLY,
+		Status:       status,
+	}
+	if err := mapper.CreateLeaseContract(c, contract); err != nil {
+		t.Fatalf("创建合同失败: %v", err)
+	}
+	return contract
+}
+
+// transition 在事务中执行合同状态流转
+func transition(c *gin.Context, contract *model.LeaseContract, action string) error {
+	return utils.RunDBTransaction(c, func(tx error) error {
+		return transitionContract(c, contract, action, testLandlordID, action)
+	})
+}
+
+// assertContract 校验合同持久化的状态及审计记录数量
+func assertContract(t *testing.T, c *gin.Context, contractID int64, wantStatus string, wantAudits int) []*model.LeaseContractAudit {
+	t.Helper()
+
+	contract, err := mapper.GetLeaseContractByID(c, contractID)
+	if err != nil {
+		t.Fatalf("查询合同失败: %v", err)
+	}
+	if contract.Status != wantStatus {
+		t.Fatalf("合同状态 = %s，期望 %s", contract.Status, wantStatus)
+	}
+
+	audits, err := mapper.GetLeaseContractAuditsByContractID(c, contractID)
+	if err != nil {
+		t.Fatalf("查询审计记录失败: %v", err)
+	}
+	if len(audits) != wantAudits {
+		t.Fatalf("审计记录 %d 条，期望 %d 条", len(audits), wantAudits)
+	}
+	return audits
+}
+
+// TestTransitionContract 每次合法流转都持久化目标状态并写入一条审计记录
+func TestTransitionContract(t *testing.T) {
+	c := dbtest.NewContext(testLandlordID, testOrganizationID)
+
+	type step struct{ action, from, to string }
+	paths := []struct {
+		name  string
+		steps []step
+	}{
+		{"签署后解约", []step{
+			{model.CONTRACT_ACTION_SUBMIT, model.CONTRACT_STATUS_DRAFT, model.CONTRACT_STATUS_PENDING_SIGNATURE},
+			{model.CONTRACT_ACTION_WITHDRAW, model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_STATUS_DRAFT},
+			{model.CONTRACT_ACTION_SUBMIT, model.CONTRACT_STATUS_DRAFT, model.CONTRACT_STATUS_PENDING_SIGNATURE},
+			{model.CONTRACT_ACTION_SIGN, model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_STATUS_ACTIVE},
+			{model.CONTRACT_ACTION_TERMINATE, model.CONTRACT_STATUS_ACTIVE, model.CONTRACT_STATUS_TERMINATED},
+		}},
+		{"到期", []step{
+			{model.CONTRACT_ACTION_SUBMIT, model.CONTRACT_STATUS_DRAFT, model.CONTRACT_STATUS_PENDING_SIGNATURE},
+			{model.CONTRACT_ACTION_SIGN, model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_STATUS_ACTIVE},
+			{model.CONTRACT_ACTION_EXPIRE, model.CONTRACT_STATUS_ACTIVE, model.CONTRACT_STATUS_EXPIRED},
+		}},
+		{"续约", []step{
+			{model.CONTRACT_ACTION_SUBMIT, model.CONTRACT_STATUS_DRAFT, model.CONTRACT_STATUS_PENDING_SIGNATURE},
+			{model.CONTRACT_ACTION_SIGN, model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_STATUS_ACTIVE},
+			{model.CONTRACT_ACTION_RENEW, model.CONTRACT_STATUS_ACTIVE, model.CONTRACT_STATUS_RENEWED},
+		}},
+	}
+	for _, path := range paths {
+		t.Run(path.name, func(t *testing.T) {
+			contract := newContract(t, c, model.CONTRACT_STATUS_DRAFT)
+			for i, step := range path.steps {
+				if err := transition(c, contract, step.action); err != nil {
+					t.Fatalf("「%s」失败: %v", step.action, err)
+				}
+
+				audits := assertContract(t, c, contract.ID, step.to, i+1)
+				audit := audits[i]
+				if audit.Action != step.action || audit.FromStatus != step.from || audit.ToStatus != step.to || audit.OperatorID != testLandlordID {
+					t.Fatalf("审计记录 = %s %s → %s (操作人 %d)，期望 %s %s → %s (操作人 %d)",
+						audit.Action, audit.FromStatus, audit.ToStatus, audit.OperatorID, step.action, step.from, step.to, testLandlordID)
+				}
+			}
+		})
+	}
+}
+
+// TestTransitionContractRejectsIllegal 不合法的流转不修改状态也不写入审计记录
+func TestTransitionContractRejectsIllegal(t *testing.T) {
+	c := dbtest.NewContext(testLandlordID, testOrganizationID)
+
+	tests := []struct {
+		status, action string
+	}{
+		{model.CONTRACT_STATUS_DRAFT, model.CONTRACT_ACTION_SIGN},
+		{model.CONTRACT_STATUS_DRAFT, model.CONTRACT_ACTION_TERMINATE},
+		{model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_ACTION_SUBMIT},
+		{model.CONTRACT_STATUS_PENDING_SIGNATURE, model.CONTRACT_ACTION_EXPIRE},
+		{model.CONTRACT_STATUS_ACTIVE, model.CONTRACT_ACTION_WITHDRAW},
+		{model.CONTRACT_STATUS_ACTIVE, model.CONTRACT_ACTION_SIGN},
+		{model.CONTRACT_STATUS_TERMINATED, model.CONTRACT_ACTION_SIGN},
+		{model.CONTRACT_STATUS_EXPIRED, model.CONTRACT_ACTION_RENEW},
+		{model.CONTRACT_STATUS_RENEWED, model.CONTRACT_ACTION_TERMINATE},
+		{model.CONTRACT_STATUS_ACTIVE, model.CONTRACT_ACTION_CREATE},
+		{model.CONTRACT_STATUS_DRAFT, "archive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status+"/"+tt.action, func(t *testing.T) {
+			contract := newContract(t, c, tt.status)
+			if err := transition(c, contract, tt.action); err == nil {
+				t.Fatalf("「%s」状态执行「%s」应被拒绝", tt.status, tt.action)
+			}
+			assertContract(t, c, contract.ID, tt.status, 0)
+		})
+	}
+}
+
+// TestTransitionContractStaleStatus 基于过期状态的流转因条件更新未命中而失败，不覆盖并发请求已写入的状态
+func TestTransitionContractStaleStatus(t *testing.T) {
+	c := dbtest.NewContext(testLandlordID, testOrganizationID)
+	contract := newContract(t, c, model.CONTRACT_STATUS_PENDING_SIGNATURE)
+
+	stale, err := mapper.GetLeaseContractByID(c, contract.ID)
+	if err != nil {
+		t.Fatalf("查询合同失败: %v", err)
+	}
+
+	if err := transition(c, contract, model.CONTRACT_ACTION_SIGN); err != nil {
+		t.Fatalf("签署失败: %v", err)
+	}
+
+	// 另一请求仍持有待签署状态，撤回须被拒绝
+	if err := transition(c, stale, model.CONTRACT_ACTION_WITHDRAW); err == nil {
+		t.Fatalf("基于过期状态的撤回应被拒绝")
+	}
+
+	audits := assertContract(t, c, contract.ID, model.CONTRACT_STATUS_ACTIVE, 1)
+	if audits[0].Action != model.CONTRACT_ACTION_SIGN {
+		t.Fatalf("审计记录动作 = %s，期望 %s", audits[0].Action, model.CONTRACT_ACTION_SIGN)
+	}
+}
@@ -0,0 +1,298 @@
+// Package service 提供业务逻辑处理，处理账本记账与收款相关业务
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "lease/internal/error"
+	auth_middleware "lease/internal/middleware/auth"
+	invoiceModel "lease/internal/model/invoice"
+	leaseModel "lease/internal/model/lease"
+	model "lease/internal/model/ledger"
+	rbacModel "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/ledger/dto"
+	"lease/pkg/serve/mapper"
+	leaseService "lease/pkg/serve/service/lease"
+	"lease/pkg/vo"
+	"lease/pkg/vo/ledger"
+)
+
+// RecordPayment 业主或持有账务管理权限的组织成员登记租客付款：押金计入代管押金；租金款项按账期先后核销未结清账单，多付部分结转为预收余额
+// 参数：
+//   - c: Gin 上下文
+//   - req: 登记收款请求
+//
+// 返回值：
+//   - *ledger.PaymentVO: 收款视图对象
+//   - error: 操作过程中的错误
+func RecordPayment(c *gin.Context, req *dto.RecordPaymentRequest) (*ledger.PaymentVO, error) {
+	contract, accountID, err := leaseService.GetManagedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+	if contract.Status == leaseModel.CONTRACT_STATUS_DRAFT {
+		utils.BizLogger(c).Errorf("合同「%d」尚为草稿，不可登记收款", contract.ID)
+		return nil, fmt.Errorf("草稿合同不可登记收款")
+	}
+
+	payment := &model.Payment{
+		ContractID: contract.ID,
+		LandlordID: contract.LandlordID,
+		TenantID:   contract.TenantID,
+		Purpose:    req.Purpose,
+		Method:     req.Method,
+		Reference:  req.Reference,
+		Amount:     req.Amount,
+		PaidDate:   req.PaidDate,
+		Remark:     req.Remark,
+		OperatorID: accountID,
+	}
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 同一合同的收款、抵扣与退款依次记账，核销账单与结转预收余额均在锁内完成
+		if err := LockContract(c, contract.ID); err != nil {
+			return err
+		}
+
+		entries := []*model.LedgerEntry{debit(model.LEDGER_ACCOUNT_CASH, req.Amount, 0)}
+
+		switch req.Purpose {
+		case model.PAYMENT_PURPOSE_DEPOSIT:
+			entries = append(entries, credit(model.LEDGER_ACCOUNT_DEPOSIT_HELD, req.Amount, 0))
+		default:
+			if req.InvoiceID != 0 {
+				if err := ensureOpenInvoice(c, contract.ID, req.InvoiceID); err != nil {
+					return err
+				}
+			}
+
+			applied, settlements, err := settleInvoices(c, contract.ID, req.Amount, req.InvoiceID)
+			if err != nil {
+				return err
+			}
+			payment.AppliedAmount = applied
+			payment.CreditAmount = req.Amount - applied
+
+			entries = append(entries, settlements...)
+			if payment.CreditAmount > 0 {
+				entries = append(entries, credit(model.LEDGER_ACCOUNT_TENANT_CREDIT, payment.CreditAmount, 0))
+			}
+		}
+
+		if err := mapper.CreatePayment(c, payment); err != nil {
+			utils.BizLogger(c).Errorf("创建收款记录失败: %v", err)
+			return fmt.Errorf("创建收款记录失败: %w", err)
+		}
+
+		return postTransaction(c, &model.LedgerTransaction{
+			TxnType:    model.LEDGER_TXN_PAYMENT,
+			ContractID: contract.ID,
+			LandlordID: contract.LandlordID,
+			TenantID:   contract.TenantID,
+			InvoiceID:  req.InvoiceID,
+			PaymentID:  payment.ID,
+			Memo:       joinMemo(fmt.Sprintf("%s收款（%s）", purposeLabel(req.Purpose), req.Method), req.Reference, req.Remark),
+			OperatorID: accountID,
+		}, entries)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPaymentVO(c, payment)
+}
+
+// RefundCredit 业主或持有账务管理权限的组织成员退还合同下租客的预收余额：借租客预收余额，贷银行存款
+// 参数：
+//   - c: Gin 上下文
+//   - req: 退还预收余额请求
+//
+// 返回值：
+//   - *ledger.LedgerTransactionVO: 退款凭证视图对象
+//   - error: 操作过程中的错误
+func RefundCredit(c *gin.Context, req *dto.RefundCreditRequest) (*ledger.LedgerTransactionVO, error) {
+	contract, accountID, err := leaseService.GetManagedContract(c, req.ContractID, rbacModel.PERMISSION_BILLING_WRITE)
+	if err != nil {
+		return nil, err
+	}
+
+	txn := &model.LedgerTransaction{
+		TxnType:    model.LEDGER_TXN_REFUND,
+		ContractID: contract.ID,
+		LandlordID: contract.LandlordID,
+		TenantID:   contract.TenantID,
+		Memo:       joinMemo(fmt.Sprintf("退还预收余额（%s）", req.Method), req.Reference, req.Remark),
+		OperatorID: accountID,
+	}
+	entries := []*model.LedgerEntry{
+		debit(model.LEDGER_ACCOUNT_TENANT_CREDIT, req.Amount, 0),
+		credit(model.LEDGER_ACCOUNT_CASH, req.Amount, 0),
+	}
+
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 先锁定合同再读取余额，防止并发退款或抵扣使预收余额为负
+		if err := LockContract(c, contract.ID); err != nil {
+			return err
+		}
+
+		available, err := creditBalance(c, contract.ID)
+		if err != nil {
+			return err
+		}
+		if req.Amount > available {
+			utils.BizLogger(c).Errorf("合同「%d」预收余额 %d 不足以退款 %d", contract.ID, available, req.Amount)
+			return bizErr.New(bizErr.CREDIT_BALANCE_INSUFFICIENT, "退款金额超过预收余额")
+		}
+
+		return postTransaction(c, txn, entries)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTransactionVO(c, txn, entries)
+}
+
+// ListPayments 按业主或租客身份分页查询当前账户相关的收款记录
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页查询请求
+//
+// 返回值：
+//   - *vo.PageVO: 收款分页结果
+//   - error: 操作过程中的错误
+func ListPayments(c *gin.Context, req *dto.ListPaymentRequest) (*vo.PageVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	filter := mapper.PaymentFilter{ContractID: req.ContractID}
+	switch req.Role {
+	case "landlord":
+		filter.LandlordID = accountID
+	default:
+		filter.TenantID = accountID
+	}
+
+	payments, total, err := mapper.ListPayments(c, filter, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询收款列表失败: %v", err)
+		return nil, fmt.Errorf("查询收款列表失败: %w", err)
+	}
+
+	list := make([]*ledger.PaymentVO, 0, len(payments))
+	for _, payment := range payments {
+		paymentVO, err := toPaymentVO(c, payment)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, paymentVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// ensureOpenInvoice 校验指定账单属于合同且尚未结清
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//   - invoiceID: 账单 ID
+//
+// 返回值：
+//   - error: 校验失败时返回错误
+func ensureOpenInvoice(c *gin.Context, contractID, invoiceID int64) error {
+	inv, err := mapper.GetInvoiceByID(c, invoiceID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」账单不存在: %v", invoiceID, err)
+		return fmt.Errorf("「%d」账单不存在: %w", invoiceID, err)
+	}
+	if inv.ContractID != contractID {
+		utils.BizLogger(c).Errorf("账单「%d」不属于合同「%d」", invoiceID, contractID)
+		return fmt.Errorf("账单不属于该合同")
+	}
+	if inv.Status != invoiceModel.INVOICE_STATUS_UNPAID && inv.Status != invoiceModel.INVOICE_STATUS_PARTIALLY_PAID {
+		utils.BizLogger(c).Errorf("账单「%d」状态为「%s」，无需核销", invoiceID, inv.Status)
+		return fmt.Errorf("账单已结清或已作废")
+	}
+	return nil
+}
+
+// purposeLabel 获取收款用途的中文名称
+// 参数：
+//   - purpose: 收款用途
+//
+// 返回值：
+//   - string: 中文名称
+func purposeLabel(purpose string) string {
+	if purpose == model.PAYMENT_PURPOSE_DEPOSIT {
+		return "押金"
+	}
+	return "租金"
+}
+
+// joinMemo 拼接凭证摘要，忽略空白片段
+// 参数：
+//   - parts: 摘要片段
+//
+// 返回值：
+//   - string: 摘要
+func joinMemo(parts ...string) string {
+	var memo []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			memo = append(memo, part)
+		}
+	}
+	return strings.Join(memo, "；")
+}
+
+// toPaymentVO 将收款模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - payment: 收款模型
+//
+// 返回值：
+//   - *ledger.PaymentVO: 收款视图对象
+//   - error: 映射过程中的错误
+func toPaymentVO(c *gin.Context, payment *model.Payment) (*ledger.PaymentVO, error) {
+	paymentVO, err := utils.MapModelToVO(payment, &ledger.PaymentVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("收款记录映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("收款记录映射 VO 失败: %w", err)
+	}
+	return paymentVO.(*ledger.PaymentVO), nil
+}
+
+// toTransactionVO 将凭证及分录映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - txn: 凭证模型
+//   - entries: 分录模型
+//
+// 返回值：
+//   - *ledger.LedgerTransactionVO: 凭证视图对象
+//   - error: 映射过程中的错误
+func toTransactionVO(c *gin.Context, txn *model.LedgerTransaction, entries []*model.LedgerEntry) (*ledger.LedgerTransactionVO, error) {
+	txnVO, err := utils.MapModelToVO(txn, &ledger.LedgerTransactionVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("账本凭证映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("账本凭证映射 VO 失败: %w", err)
+	}
+
+	result := txnVO.(*ledger.LedgerTransactionVO)
+	for _, entry := range entries {
+		entryVO, err := utils.MapModelToVO(entry, &ledger.LedgerEntryVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("账本分录映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("账本分录映射 VO 失败: %w", err)
+		}
+		result.Entries = append(result.Entries, entryVO.(*ledger.LedgerEntryVO))
+	}
+	return result, nil
+}
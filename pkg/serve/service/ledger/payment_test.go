@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/internal/db/dbtest"
+	bizErr "lease/internal/error"
+	invoiceModel "lease/internal/model/invoice"
+	leaseModel "lease/internal/model/lease"
+	model "lease/internal/model/ledger"
+	"lease/pkg/serve/controller/ledger/dto"
+	"lease/pkg/serve/mapper"
+)
+
+// 测试使用的账户与组织
+const (
+	testOrganizationID = 1
+	testLandlordID     = 2001
+	testTenantID       = 2002
+)
+
+// newContract 创建生效中的合同
+func newContract(t *testing.T, c *gin.Context) *leaseModel.LeaseContract {
+	t.Helper()
+
+	contract := &leaseModel.LeaseContract{
+		UnitID:       1,
+		PropertyID:   1,
+		LandlordID:   testLandlordID,
+		TenantID:     testTenantID,
+		StartDate:    "2025-01-01",
+		EndDate:      "2025-12-31",
+		MonthlyRent:  100000,
+		BillingCycle: leaseModel.BILLING_CYCLE_MONTHLY,
+		Status:       leaseModel.CONTRACT_STATUS_ACTIVE,
+	}
+	if err := mapper.CreateLeaseContract(c, contract); err != nil {
+		t.Fatalf("创建合同失败: %v", err)
+	}
+	return contract
+}
+
+// issueInvoice 为合同出具一张租金账单并记账
+func issueInvoice(t *testing.T, c *gin.Context, contract *leaseModel.LeaseContract, periodStart string, amount int64) *invoiceModel.Invoice {
+	t.Helper()
+
+	inv := &invoiceModel.Invoice{
+		ContractID:  contract.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart,
+		UnitID:      contract.UnitID,
+		LandlordID:  contract.LandlordID,
+		TenantID:    contract.TenantID,
+		DueDate:     periodStart,
+		Amount:      amount,
+		Status:      invoiceModel.INVOICE_STATUS_UNPAID,
+	}
+	items := []*invoiceModel.InvoiceItem{{ItemType: invoiceModel.INVOICE_ITEM_TYPE_RENT, Name: "租金", Amount: amount}}
+	if err := mapper.CreateInvoice(c, inv, items); err != nil {
+		t.Fatalf("创建账单失败: %v", err)
+	}
+	if err := PostInvoice(c, inv, items, testLandlordID); err != nil {
+		t.Fatalf("账单记账失败: %v", err)
+	}
+	return inv
+}
+
+// payRent 登记租金收款
+func payRent(t *testing.T, c *gin.Context, contract *leaseModel.LeaseContract, amount int64) {
+	t.Helper()
+
+	_, err := RecordPayment(c, &dto.RecordPaymentRequest{
+		ContractID: contract.ID,
+		Purpose:    model.PAYMENT_PURPOSE_RENT,
+		Method:     model.PAYMENT_METHOD_BANK_TRANSFER,
+		Amount:     amount,
+		PaidDate:   "2025-01-05",
+	})
+	if err != nil {
+		t.Fatalf("登记收款 %d 失败: %v", amount, err)
+	}
+}
+
+// assertLedger 校验每张凭证借贷平衡、应收余额与未结清账单一致，且预收余额符合预期
+func assertLedger(t *testing.T, c *gin.Context, contractID, wantCredit int64) {
+	t.Helper()
+
+	entries, err := mapper.GetLedgerEntries(c, mapper.LedgerFilter{ContractID: contractID})
+	if err != nil {
+		t.Fatalf("查询账本分录失败: %v", err)
+	}
+	net := make(map[int64]int64)
+	balances := make(map[string]int64)
+	for _, entry := range entries {
+		if entry.Direction == model.ENTRY_DIRECTION_DEBIT {
+			net[entry.TransactionID] += entry.Amount
+			balances[entry.Account] += entry.Amount
+		} else {
+			net[entry.TransactionID] -= entry.Amount
+			balances[entry.Account] -= entry.Amount
+		}
+	}
+	for txnID, diff := range net {
+		if diff != 0 {
+			t.Fatalf("凭证「%d」借贷相差 %d", txnID, diff)
+		}
+	}
+
+	outstanding, err := mapper.SumInvoiceOutstanding(c, mapper.InvoiceFilter{ContractID: contractID})
+	if err != nil {
+		t.Fatalf("汇总未结清账单失败: %v", err)
+	}
+	if receivable := balances[model.LEDGER_ACCOUNT_RECEIVABLE]; receivable != outstanding {
+		t.Fatalf("应收余额 = %d，期望与未结清账单 %d 一致", receivable, outstanding)
+	}
+
+	available, err := creditBalance(c, contractID)
+	if err != nil {
+		t.Fatalf("查询预收余额失败: %v", err)
+	}
+	if available != wantCredit {
+		t.Fatalf("预收余额 = %d，期望 %d", available, wantCredit)
+	}
+}
+
+// assertInvoice 校验账单已付金额与状态
+func assertInvoice(t *testing.T, c *gin.Context, invoiceID, wantPaid int64, wantStatus string) {
+	t.Helper()
+
+	inv, err := mapper.GetInvoiceByID(c, invoiceID)
+	if err != nil {
+		t.Fatalf("查询账单失败: %v", err)
+	}
+	if inv.PaidAmount != wantPaid || inv.Status != wantStatus {
+		t.Fatalf("账单「%d」已付 %d (%s)，期望已付 %d (%s)", invoiceID, inv.PaidAmount, inv.Status, wantPaid, wantStatus)
+	}
+}
+
+// TestPaymentLifecycle 部分付款、多付结转、预收抵扣与退款后账本均借贷平衡
+func TestPaymentLifecycle(t *testing.T) {
+	c := dbtest.NewContext(testLandlordID, testOrganizationID)
+	contract := newContract(t, c)
+
+	jan := issueInvoice(t, c, contract, "2025-01-01", 100000)
+	feb := issueInvoice(t, c, contract, "2025-02-01", 100000)
+	assertLedger(t, c, contract.ID, 0)
+
+	// 部分付款只核销最早的账单
+	payRent(t, c, contract, 60000)
+	assertInvoice(t, c, jan.ID, 60000, invoiceModel.INVOICE_STATUS_PARTIALLY_PAID)
+	assertInvoice(t, c, feb.ID, 0, invoiceModel.INVOICE_STATUS_UNPAID)
+	assertLedger(t, c, contract.ID, 0)
+
+	// 多付部分结转为预收余额
+	payRent(t, c, contract, 200000)
+	assertInvoice(t, c, jan.ID, 100000, invoiceModel.INVOICE_STATUS_PAID)
+	assertInvoice(t, c, feb.ID, 100000, invoiceModel.INVOICE_STATUS_PAID)
+	assertLedger(t, c, contract.ID, 60000)
+
+	// 新出账的账单以预收余额抵扣
+	mar := issueInvoice(t, c, contract, "2025-03-01", 50000)
+	applied, err := ApplyContractCredit(c, contract, testLandlordID)
+	if err != nil || applied != 50000 {
+		t.Fatalf("预收抵扣 = %d (%v)，期望 50000", applied, err)
+	}
+	assertInvoice(t, c, mar.ID, 50000, invoiceModel.INVOICE_STATUS_PAID)
+	assertLedger(t, c, contract.ID, 10000)
+
+	// 退款超过预收余额时拒绝且不记账
+	refund := &dto.RefundCreditRequest{ContractID: contract.ID, Amount: 20000, Method: model.PAYMENT_METHOD_BANK_TRANSFER}
+	_, err = RefundCredit(c, refund)
+	var xErr *bizErr.Err
+	if !errors.As(err, &xErr) || xErr.Code != bizErr.CREDIT_BALANCE_INSUFFICIENT {
+		t.Fatalf("超额退款错误 = %v，期望错误码 %d", err, bizErr.CREDIT_BALANCE_INSUFFICIENT)
+	}
+	assertLedger(t, c, contract.ID, 10000)
+
+	refund.Amount = 10000
+	if _, err := RefundCredit(c, refund); err != nil {
+		t.Fatalf("退还预收余额失败: %v", err)
+	}
+	assertLedger(t, c, contract.ID, 0)
+}
+
+// TestPostTransactionRejectsUnbalanced 借贷不平衡或金额非正的凭证不写入账本
+func TestPostTransactionRejectsUnbalanced(t *testing.T) {
+	c := dbtest.NewContext(testLandlordID, testOrganizationID)
+	contract := newContract(t, c)
+
+	tests := []struct {
+		name    string
+		entries []*model.LedgerEntry
+	}{
+		{"借贷不平衡", []*model.LedgerEntry{debit(model.LEDGER_ACCOUNT_CASH, 100, 0), credit(model.LEDGER_ACCOUNT_TENANT_CREDIT, 99, 0)}},
+		{"金额为零", []*model.LedgerEntry{debit(model.LEDGER_ACCOUNT_CASH, 0, 0), credit(model.LEDGER_ACCOUNT_TENANT_CREDIT, 0, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &model.LedgerTransaction{TxnType: model.LEDGER_TXN_PAYMENT, ContractID: contract.ID, LandlordID: testLandlordID, TenantID: testTenantID}
+			if err := postTransaction(c, txn, tt.entries); err == nil {
+				t.Fatalf("postTransaction 应拒绝%s的凭证", tt.name)
+			}
+		})
+	}
+	assertLedger(t, c, contract.ID, 0)
+}
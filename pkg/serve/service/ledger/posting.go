@@ -0,0 +1,346 @@
+// Package service 提供业务逻辑处理，处理账本记账与收款相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	invoiceModel "lease/internal/model/invoice"
+	leaseModel "lease/internal/model/lease"
+	model "lease/internal/model/ledger"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+)
+
+// PostInvoice 账单出账记账：借应收租客款，按明细类型贷租金收入与杂费收入
+// 参数：
+//   - c: Gin 上下文
+//   - inv: 已持久化的账单
+//   - items: 账单明细
+//   - operatorID: 操作人账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func PostInvoice(c *gin.Context, inv *invoiceModel.Invoice, items []*invoiceModel.InvoiceItem, operatorID int64) error {
+	if inv.Amount == 0 {
+		return nil
+	}
+
+	var rent, fee int64
+	for _, item := range items {
+		if item.ItemType == invoiceModel.INVOICE_ITEM_TYPE_RENT {
+			rent += item.Amount
+		} else {
+			fee += item.Amount
+		}
+	}
+
+	entries := []*model.LedgerEntry{debit(model.LEDGER_ACCOUNT_RECEIVABLE, inv.Amount, inv.ID)}
+	if rent > 0 {
+		entries = append(entries, credit(model.LEDGER_ACCOUNT_RENT_INCOME, rent, inv.ID))
+	}
+	if fee > 0 {
+		entries = append(entries, credit(model.LEDGER_ACCOUNT_FEE_INCOME, fee, inv.ID))
+	}
+
+	return postTransaction(c, &model.LedgerTransaction{
+		TxnType:    model.LEDGER_TXN_INVOICE,
+		ContractID: inv.ContractID,
+		LandlordID: inv.LandlordID,
+		TenantID:   inv.TenantID,
+		InvoiceID:  inv.ID,
+		Memo:       fmt.Sprintf("账单 %s ~ %s 出账", inv.PeriodStart, inv.PeriodEnd),
+		OperatorID: operatorID,
+	}, entries)
+}
+
+// ApplyContractCredit 以合同下的预收余额按账期先后抵扣未结清账单：借租客预收余额，贷应收租客款；须在事务中调用
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同
+//   - operatorID: 操作人账户 ID
+//
+// 返回值：
+//   - int64: 本次抵扣金额（分）
+//   - error: 操作过程中的错误
+func ApplyContractCredit(c *gin.Context, contract *leaseModel.LeaseContract, operatorID int64) (int64, error) {
+	// 先锁定合同再读取预收余额，防止与并发的收款、退款重复使用同一笔余额
+	if err := LockContract(c, contract.ID); err != nil {
+		return 0, err
+	}
+
+	available, err := creditBalance(c, contract.ID)
+	if err != nil {
+		return 0, err
+	}
+	if available <= 0 {
+		return 0, nil
+	}
+
+	applied, settlements, err := settleInvoices(c, contract.ID, available, 0)
+	if err != nil {
+		return 0, err
+	}
+	if applied == 0 {
+		return 0, nil
+	}
+
+	entries := append([]*model.LedgerEntry{debit(model.LEDGER_ACCOUNT_TENANT_CREDIT, applied, 0)}, settlements...)
+	err = postTransaction(c, &model.LedgerTransaction{
+		TxnType:    model.LEDGER_TXN_CREDIT_APPLY,
+		ContractID: contract.ID,
+		LandlordID: contract.LandlordID,
+		TenantID:   contract.TenantID,
+		Memo:       "预收余额抵扣账单",
+		OperatorID: operatorID,
+	}, entries)
+	if err != nil {
+		return 0, err
+	}
+
+	return applied, nil
+}
+
+// PostDepositSettlement 押金结算记账：借代管押金，欠租扣款贷应收租客款并核销账单，其他扣款贷押金扣款收入，余额贷银行存款退还租客；须在事务中调用
+// 参数：
+//   - c: Gin 上下文
+//   - contract: 合同
+//   - unpaidRent: 抵扣欠缴租金及杂费的金额（分）
+//   - deduction: 其他扣款金额（分）
+//   - refund: 退还租客金额（分）
+//   - operatorID: 操作人账户 ID
+//
+// 返回值：
+//   - *model.LedgerTransaction: 结算凭证，代管押金为 0 时为 nil
+//   - error: 金额与代管押金余额不一致或记账失败时返回错误
+func PostDepositSettlement(c *gin.Context, contract *leaseModel.LeaseContract, unpaidRent, deduction, refund, operatorID int64) (*model.LedgerTransaction, error) {
+	// 先锁定合同再读取代管押金，防止结算期间并发收取押金或核销账单
+	if err := LockContract(c, contract.ID); err != nil {
+		return nil, err
+	}
+
+	held, err := DepositBalance(c, contract.ID)
+	if err != nil {
+		return nil, err
+	}
+	if unpaidRent+deduction+refund != held {
+		utils.BizLogger(c).Errorf("合同「%d」押金结算金额 %d 与代管押金余额 %d 不一致", contract.ID, unpaidRent+deduction+refund, held)
+		return nil, fmt.Errorf("押金结算金额与代管押金余额不一致")
+	}
+	if held == 0 {
+		return nil, nil
+	}
+
+	entries := []*model.LedgerEntry{debit(model.LEDGER_ACCOUNT_DEPOSIT_HELD, held, 0)}
+	if unpaidRent > 0 {
+		applied, settlements, err := settleInvoices(c, contract.ID, unpaidRent, 0)
+		if err != nil {
+			return nil, err
+		}
+		if applied != unpaidRent {
+			utils.BizLogger(c).Errorf("合同「%d」欠租扣款 %d 超过未结清账单金额 %d", contract.ID, unpaidRent, applied)
+			return nil, fmt.Errorf("欠租扣款超过未结清账单金额")
+		}
+		entries = append(entries, settlements...)
+	}
+	if deduction > 0 {
+		entries = append(entries, credit(model.LEDGER_ACCOUNT_DEDUCTION, deduction, 0))
+	}
+	if refund > 0 {
+		entries = append(entries, credit(model.LEDGER_ACCOUNT_CASH, refund, 0))
+	}
+
+	txn := &model.LedgerTransaction{
+		TxnType:    model.LEDGER_TXN_DEPOSIT,
+		ContractID: contract.ID,
+		LandlordID: contract.LandlordID,
+		TenantID:   contract.TenantID,
+		Memo:       fmt.Sprintf("押金结算：抵扣欠租 %d，扣款 %d，退还 %d", unpaidRent, deduction, refund),
+		OperatorID: operatorID,
+	}
+	if err := postTransaction(c, txn, entries); err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+// LockContract 锁定合同以串行化同一合同下的记账，须在事务中先于余额查询调用，锁在事务结束时释放
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - error: 锁定失败时返回错误
+func LockContract(c *gin.Context, contractID int64) error {
+	if _, err := mapper.LockLeaseContractByID(c, contractID); err != nil {
+		utils.BizLogger(c).Errorf("锁定合同「%d」失败: %v", contractID, err)
+		return fmt.Errorf("锁定合同失败: %w", err)
+	}
+	return nil
+}
+
+// DepositBalance 计算合同下的代管押金余额
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - int64: 代管押金余额（分）
+//   - error: 操作过程中的错误
+func DepositBalance(c *gin.Context, contractID int64) (int64, error) {
+	return accountBalance(c, contractID, model.LEDGER_ACCOUNT_DEPOSIT_HELD)
+}
+
+// settleInvoices 按账期先后核销合同未结清账单，并以比较并交换的方式回写账单已付金额与状态
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//   - amount: 可用于核销的金额（分）
+//   - preferredInvoiceID: 优先核销的账单 ID，为 0 时按账期先后
+//
+// 返回值：
+//   - int64: 实际核销金额（分）
+//   - []*model.LedgerEntry: 贷记应收租客款的分录，每张账单一条
+//   - error: 操作过程中的错误
+func settleInvoices(c *gin.Context, contractID, amount, preferredInvoiceID int64) (int64, []*model.LedgerEntry, error) {
+	invoices, err := mapper.GetOpenInvoicesByContractID(c, contractID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询合同「%d」未结清账单失败: %v", contractID, err)
+		return 0, nil, fmt.Errorf("查询未结清账单失败: %w", err)
+	}
+
+	if preferredInvoiceID != 0 {
+		for i, inv := range invoices {
+			if inv.ID == preferredInvoiceID {
+				invoices = append([]*invoiceModel.Invoice{inv}, append(invoices[:i:i], invoices[i+1:]...)...)
+				break
+			}
+		}
+	}
+
+	var applied int64
+	var entries []*model.LedgerEntry
+	for _, inv := range invoices {
+		remaining := amount - applied
+		if remaining <= 0 {
+			break
+		}
+
+		pay := min(inv.Amount-inv.PaidAmount, remaining)
+		if pay <= 0 {
+			continue
+		}
+
+		if err := mapper.ApplyInvoicePayment(c, inv, pay); err != nil {
+			utils.BizLogger(c).Errorf("更新账单「%d」已付金额失败: %v", inv.ID, err)
+			return 0, nil, fmt.Errorf("更新账单已付金额失败: %w", err)
+		}
+
+		applied += pay
+		entries = append(entries, credit(model.LEDGER_ACCOUNT_RECEIVABLE, pay, inv.ID))
+	}
+
+	return applied, entries, nil
+}
+
+// creditBalance 计算合同下租客的预收余额
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//
+// 返回值：
+//   - int64: 预收余额（分）
+//   - error: 操作过程中的错误
+func creditBalance(c *gin.Context, contractID int64) (int64, error) {
+	return accountBalance(c, contractID, model.LEDGER_ACCOUNT_TENANT_CREDIT)
+}
+
+// accountBalance 计算合同下负债类科目的贷方余额
+// 参数：
+//   - c: Gin 上下文
+//   - contractID: 合同 ID
+//   - account: 会计科目
+//
+// 返回值：
+//   - int64: 科目余额（分）
+//   - error: 操作过程中的错误
+func accountBalance(c *gin.Context, contractID int64, account string) (int64, error) {
+	balances, err := mapper.GetLedgerBalances(c, mapper.LedgerFilter{
+		ContractID: contractID,
+		Accounts:   []string{account},
+	})
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询合同「%d」科目「%s」余额失败: %v", contractID, account, err)
+		return 0, fmt.Errorf("查询科目余额失败: %w", err)
+	}
+
+	balance, ok := balances[account]
+	if !ok {
+		return 0, nil
+	}
+	return balance.Credit - balance.Debit, nil
+}
+
+// postTransaction 校验借贷平衡后写入凭证及分录，分录的合同与当事人信息取自凭证
+// 参数：
+//   - c: Gin 上下文
+//   - txn: 凭证
+//   - entries: 分录列表
+//
+// 返回值：
+//   - error: 借贷不平衡或写入失败时返回错误
+func postTransaction(c *gin.Context, txn *model.LedgerTransaction, entries []*model.LedgerEntry) error {
+	var debitTotal, creditTotal int64
+	for _, entry := range entries {
+		if entry.Amount <= 0 {
+			utils.BizLogger(c).Errorf("「%s」凭证分录金额必须为正数: %d", txn.TxnType, entry.Amount)
+			return fmt.Errorf("凭证分录金额必须为正数")
+		}
+		if entry.Direction == model.ENTRY_DIRECTION_DEBIT {
+			debitTotal += entry.Amount
+		} else {
+			creditTotal += entry.Amount
+		}
+
+		entry.ContractID = txn.ContractID
+		entry.LandlordID = txn.LandlordID
+		entry.TenantID = txn.TenantID
+	}
+
+	if debitTotal != creditTotal {
+		utils.BizLogger(c).Errorf("「%s」凭证借贷不平衡: 借 %d，贷 %d", txn.TxnType, debitTotal, creditTotal)
+		return fmt.Errorf("凭证借贷不平衡")
+	}
+
+	txn.Amount = debitTotal
+	if err := mapper.CreateLedgerTransaction(c, txn, entries); err != nil {
+		utils.BizLogger(c).Errorf("写入账本凭证失败: %v", err)
+		return fmt.Errorf("写入账本凭证失败: %w", err)
+	}
+	return nil
+}
+
+// debit 构造借方分录
+// 参数：
+//   - account: 会计科目
+//   - amount: 金额（分）
+//   - invoiceID: 关联账单 ID
+//
+// 返回值：
+//   - *model.LedgerEntry: 分录
+func debit(account string, amount, invoiceID int64) *model.LedgerEntry {
+	return &model.LedgerEntry{Account: account, Direction: model.ENTRY_DIRECTION_DEBIT, Amount: amount, InvoiceID: invoiceID}
+}
+
+// credit 构造贷方分录
+// 参数：
+//   - account: 会计科目
+//   - amount: 金额（分）
+//   - invoiceID: 关联账单 ID
+//
+// 返回值：
+//   - *model.LedgerEntry: 分录
+func credit(account string, amount, invoiceID int64) *model.LedgerEntry {
+	return &model.LedgerEntry{Account: account, Direction: model.ENTRY_DIRECTION_CREDIT, Amount: amount, InvoiceID: invoiceID}
+}
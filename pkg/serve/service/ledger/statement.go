@@ -0,0 +1,180 @@
+// Package service 提供业务逻辑处理，处理账本记账与收款相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/ledger"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/ledger/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/ledger"
+)
+
+// statementAccounts 对账单关注的往来科目
+var statementAccounts = []string{
+	model.LEDGER_ACCOUNT_RECEIVABLE,
+	model.LEDGER_ACCOUNT_TENANT_CREDIT,
+	model.LEDGER_ACCOUNT_DEPOSIT_HELD,
+}
+
+// GetStatement 生成租客对账单，逐笔列示应收、预收与押金往来并核对账本与账单是否一致；配置只读副本时从副本查询
+// 参数：
+//   - c: Gin 上下文
+//   - req: 对账单请求
+//
+// 返回值：
+//   - *ledger.StatementVO: 对账单
+//   - error: 操作过程中的错误
+func GetStatement(c *gin.Context, req *dto.StatementRequest) (*ledger.StatementVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+	utils.UseReportDB(c)
+
+	filter := mapper.LedgerFilter{ContractID: req.ContractID}
+	switch req.Role {
+	case "landlord":
+		filter.LandlordID = accountID
+		filter.TenantID = req.TenantID
+	default:
+		filter.TenantID = accountID
+	}
+
+	entries, err := mapper.GetLedgerEntries(c, mapper.LedgerFilter{
+		LandlordID: filter.LandlordID,
+		TenantID:   filter.TenantID,
+		ContractID: filter.ContractID,
+		Accounts:   statementAccounts,
+	})
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询租客往来分录失败: %v", err)
+		return nil, fmt.Errorf("查询租客往来分录失败: %w", err)
+	}
+
+	var txnIDs []int64
+	entriesByTxn := make(map[int64][]*model.LedgerEntry)
+	for _, entry := range entries {
+		if _, ok := entriesByTxn[entry.TransactionID]; !ok {
+			txnIDs = append(txnIDs, entry.TransactionID)
+		}
+		entriesByTxn[entry.TransactionID] = append(entriesByTxn[entry.TransactionID], entry)
+	}
+
+	txns, err := mapper.GetLedgerTransactionsByIDs(c, txnIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账本凭证失败: %v", err)
+		return nil, fmt.Errorf("查询账本凭证失败: %w", err)
+	}
+
+	statement := &ledger.StatementVO{
+		TenantID:   filter.TenantID,
+		ContractID: filter.ContractID,
+		Lines:      make([]*ledger.StatementLineVO, 0, len(txns)),
+	}
+
+	var balance int64
+	for _, txn := range txns {
+		// 应付净额 = 应收租客款余额 - 租客预收余额
+		var due, deposit int64
+		for _, entry := range entriesByTxn[txn.ID] {
+			amount := entry.Amount
+			if entry.Direction == model.ENTRY_DIRECTION_CREDIT {
+				amount = -amount
+			}
+			switch entry.Account {
+			case model.LEDGER_ACCOUNT_RECEIVABLE, model.LEDGER_ACCOUNT_TENANT_CREDIT:
+				due += amount
+			case model.LEDGER_ACCOUNT_DEPOSIT_HELD:
+				deposit -= amount
+			}
+		}
+
+		balance += due
+		line := &ledger.StatementLineVO{
+			TransactionID: txn.ID,
+			TxnType:       txn.TxnType,
+			ContractID:    txn.ContractID,
+			InvoiceID:     txn.InvoiceID,
+			PaymentID:     txn.PaymentID,
+			Memo:          txn.Memo,
+			Deposit:       deposit,
+			Balance:       balance,
+			GmtCreate:     txn.GmtCreate,
+		}
+		if due > 0 {
+			line.Debit = due
+		} else {
+			line.Credit = -due
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	if err := summarizeStatement(c, filter, statement); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// summarizeStatement 汇总对账单各科目余额，并核对账本借贷平衡及应收余额与未结清账单一致
+// 参数：
+//   - c: Gin 上下文
+//   - filter: 账本查询条件
+//   - statement: 待填充的对账单
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func summarizeStatement(c *gin.Context, filter mapper.LedgerFilter, statement *ledger.StatementVO) error {
+	balances, err := mapper.GetLedgerBalances(c, filter)
+	if err != nil {
+		utils.BizLogger(c).Errorf("汇总账本余额失败: %v", err)
+		return fmt.Errorf("汇总账本余额失败: %w", err)
+	}
+
+	var debitTotal, creditTotal int64
+	for _, balance := range balances {
+		debitTotal += balance.Debit
+		creditTotal += balance.Credit
+	}
+
+	get := func(account string) *mapper.LedgerBalance {
+		if balance, ok := balances[account]; ok {
+			return balance
+		}
+		return &mapper.LedgerBalance{Account: account}
+	}
+	receivable := get(model.LEDGER_ACCOUNT_RECEIVABLE)
+	cash := get(model.LEDGER_ACCOUNT_CASH)
+	tenantCredit := get(model.LEDGER_ACCOUNT_TENANT_CREDIT)
+	deposit := get(model.LEDGER_ACCOUNT_DEPOSIT_HELD)
+
+	statement.TotalInvoiced = receivable.Debit
+	statement.TotalReceived = cash.Debit
+	statement.TotalRefunded = cash.Credit
+	statement.Receivable = receivable.Debit - receivable.Credit
+	statement.CreditBalance = tenantCredit.Credit - tenantCredit.Debit
+	statement.DepositHeld = deposit.Credit - deposit.Debit
+	statement.BalanceDue = statement.Receivable - statement.CreditBalance
+
+	outstanding, err := mapper.SumInvoiceOutstanding(c, mapper.InvoiceFilter{
+		LandlordID: filter.LandlordID,
+		TenantID:   filter.TenantID,
+		ContractID: filter.ContractID,
+	})
+	if err != nil {
+		utils.BizLogger(c).Errorf("汇总账单待付金额失败: %v", err)
+		return fmt.Errorf("汇总账单待付金额失败: %w", err)
+	}
+
+	statement.Reconciled = debitTotal == creditTotal && statement.Receivable == outstanding
+	if !statement.Reconciled {
+		utils.BizLogger(c).Errorf("租客「%d」账本未对平: 借 %d，贷 %d，应收 %d，账单待付 %d",
+			filter.TenantID, debitTotal, creditTotal, statement.Receivable, outstanding)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Package service 提供业务逻辑处理，处理报修工单相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/maintenance"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/maintenance/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/maintenance"
+)
+
+// AddComment 工单相关人员在工单下发表评论，已关闭或已取消的工单不再接受评论
+// 参数：
+//   - c: Gin 上下文
+//   - req: 工单评论请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceCommentVO: 评论视图对象
+//   - error: 操作过程中的错误
+func AddComment(c *gin.Context, req *dto.AddCommentRequest) (*maintenance.MaintenanceCommentVO, error) {
+	ticket, accountID, err := getVisibleTicket(c, req.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == model.TICKET_STATUS_CLOSED || ticket.Status == model.TICKET_STATUS_CANCELLED {
+		utils.BizLogger(c).Errorf("报修工单「%d」状态为「%s」，不可评论", ticket.ID, ticket.Status)
+		return nil, fmt.Errorf("工单已结束，不可评论")
+	}
+
+	comment := &model.MaintenanceComment{
+		TicketID: ticket.ID,
+		AuthorID: accountID,
+		Content:  req.Content,
+	}
+	if err := mapper.CreateMaintenanceComment(c, comment); err != nil {
+		utils.BizLogger(c).Errorf("创建工单评论失败: %v", err)
+		return nil, fmt.Errorf("创建工单评论失败: %w", err)
+	}
+
+	return toCommentVO(c, comment)
+}
+
+// listComments 获取工单的全部评论，调用方须先校验访问权限
+// 参数：
+//   - c: Gin 上下文
+//   - ticketID: 工单 ID
+//
+// 返回值：
+//   - []*maintenance.MaintenanceCommentVO: 评论列表
+//   - error: 操作过程中的错误
+func listComments(c *gin.Context, ticketID int64) ([]*maintenance.MaintenanceCommentVO, error) {
+	comments, err := mapper.GetMaintenanceCommentsByTicketID(c, ticketID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询工单评论失败: %v", err)
+		return nil, fmt.Errorf("查询工单评论失败: %w", err)
+	}
+
+	list := make([]*maintenance.MaintenanceCommentVO, 0, len(comments))
+	for _, comment := range comments {
+		commentVO, err := toCommentVO(c, comment)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, commentVO)
+	}
+	return list, nil
+}
+
+// toCommentVO 将评论模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - comment: 评论模型
+//
+// 返回值：
+//   - *maintenance.MaintenanceCommentVO: 评论视图对象
+//   - error: 映射过程中的错误
+func toCommentVO(c *gin.Context, comment *model.MaintenanceComment) (*maintenance.MaintenanceCommentVO, error) {
+	commentVO, err := utils.MapModelToVO(comment, &maintenance.MaintenanceCommentVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("工单评论映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("工单评论映射 VO 失败: %w", err)
+	}
+	return commentVO.(*maintenance.MaintenanceCommentVO), nil
+}
@@ -0,0 +1,170 @@
+// Package service 提供业务逻辑处理，处理报修工单相关业务
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/maintenance"
+	"lease/internal/utils"
+	"lease/pkg/serve/mapper"
+)
+
+// 工单参与角色
+const (
+	roleLandlord = "landlord" // 单元业主
+	roleReporter = "reporter" // 报修人或承租租客
+	roleAssignee = "assignee" // 维修人员
+)
+
+// ticketTransition 工单状态流转规则
+type ticketTransition struct {
+	From   []string // 允许的起始状态
+	To     string   // 目标状态
+	Actors []string // 允许执行的角色
+}
+
+// ticketTransitions 工单状态机：open → assigned → scheduled → in_progress → resolved → closed，未开工前可取消
+var ticketTransitions = map[string]ticketTransition{
+	model.TICKET_ACTION_ASSIGN: {
+		From:   []string{model.TICKET_STATUS_OPEN, model.TICKET_STATUS_ASSIGNED, model.TICKET_STATUS_SCHEDULED},
+		To:     model.TICKET_STATUS_ASSIGNED,
+		Actors: []string{roleLandlord},
+	},
+	model.TICKET_ACTION_SCHEDULE: {
+		From:   []string{model.TICKET_STATUS_ASSIGNED, model.TICKET_STATUS_SCHEDULED},
+		To:     model.TICKET_STATUS_SCHEDULED,
+		Actors: []string{roleLandlord, roleAssignee},
+	},
+	model.TICKET_ACTION_START: {
+		From:   []string{model.TICKET_STATUS_ASSIGNED, model.TICKET_STATUS_SCHEDULED},
+		To:     model.TICKET_STATUS_IN_PROGRESS,
+		Actors: []string{roleLandlord, roleAssignee},
+	},
+	model.TICKET_ACTION_RESOLVE: {
+		From:   []string{model.TICKET_STATUS_IN_PROGRESS},
+		To:     model.TICKET_STATUS_RESOLVED,
+		Actors: []string{roleLandlord, roleAssignee},
+	},
+	model.TICKET_ACTION_REOPEN: {
+		From:   []string{model.TICKET_STATUS_RESOLVED},
+		To:     model.TICKET_STATUS_IN_PROGRESS,
+		Actors: []string{roleLandlord, roleReporter},
+	},
+	model.TICKET_ACTION_CLOSE: {
+		From:   []string{model.TICKET_STATUS_RESOLVED},
+		To:     model.TICKET_STATUS_CLOSED,
+		Actors: []string{roleLandlord},
+	},
+	model.TICKET_ACTION_CANCEL: {
+		From:   []string{model.TICKET_STATUS_OPEN, model.TICKET_STATUS_ASSIGNED, model.TICKET_STATUS_SCHEDULED},
+		To:     model.TICKET_STATUS_CANCELLED,
+		Actors: []string{roleLandlord, roleReporter},
+	},
+}
+
+// ticketRoles 计算账户在工单中的参与角色
+// 参数：
+//   - ticket: 工单
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - []string: 参与角色，无权访问时为空
+func ticketRoles(ticket *model.MaintenanceTicket, accountID int64) []string {
+	var roles []string
+	if ticket.LandlordID == accountID {
+		roles = append(roles, roleLandlord)
+	}
+	if ticket.ReporterID == accountID || ticket.TenantID == accountID {
+		roles = append(roles, roleReporter)
+	}
+	if ticket.AssigneeID != 0 && ticket.AssigneeID == accountID {
+		roles = append(roles, roleAssignee)
+	}
+	return roles
+}
+
+// transitionTicket 按状态机校验操作人角色并执行工单状态流转，写入流转记录，须在 utils.RunDBTransaction 中调用
+// 参数：
+//   - c: Gin 上下文
+//   - ticket: 工单，调用前可先修改除状态外的其他字段
+//   - action: 流转动作
+//   - operatorID: 操作人账户 ID
+//   - remark: 备注
+//
+// 返回值：
+//   - error: 流转不合法、无权操作或持久化失败时返回错误
+func transitionTicket(c *gin.Context, ticket *model.MaintenanceTicket, action string, operatorID int64, remark string) error {
+	rule, ok := ticketTransitions[action]
+	if !ok {
+		utils.BizLogger(c).Errorf("未知的工单流转动作: %s", action)
+		return fmt.Errorf("未知的工单流转动作: %s", action)
+	}
+
+	fromStatus := ticket.Status
+	if !slices.Contains(rule.From, fromStatus) {
+		utils.BizLogger(c).Errorf("工单「%d」当前状态「%s」不允许执行「%s」", ticket.ID, fromStatus, action)
+		return fmt.Errorf("工单当前状态「%s」不允许执行「%s」", fromStatus, action)
+	}
+
+	ticket.Status = rule.To
+	if err := mapper.UpdateMaintenanceTicketStatus(c, ticket, fromStatus); err != nil {
+		utils.BizLogger(c).Errorf("工单「%d」状态流转失败: %v", ticket.ID, err)
+		return fmt.Errorf("工单状态流转失败: %w", err)
+	}
+
+	return recordTicketEvent(c, ticket.ID, action, fromStatus, rule.To, operatorID, remark)
+}
+
+// authorizeTicketAction 校验账户是否具备执行工单流转动作的角色
+// 参数：
+//   - c: Gin 上下文
+//   - ticket: 工单
+//   - action: 流转动作
+//   - accountID: 操作人账户 ID
+//
+// 返回值：
+//   - error: 无权执行时返回错误
+func authorizeTicketAction(c *gin.Context, ticket *model.MaintenanceTicket, action string, accountID int64) error {
+	rule, ok := ticketTransitions[action]
+	if !ok {
+		return fmt.Errorf("未知的工单流转动作: %s", action)
+	}
+	for _, role := range ticketRoles(ticket, accountID) {
+		if slices.Contains(rule.Actors, role) {
+			return nil
+		}
+	}
+	utils.BizLogger(c).Errorf("账户「%d」无权对工单「%d」执行「%s」", accountID, ticket.ID, action)
+	return fmt.Errorf("无权对该工单执行「%s」", action)
+}
+
+// recordTicketEvent 写入工单状态流转记录
+// 参数：
+//   - c: Gin 上下文
+//   - ticketID: 工单 ID
+//   - action: 流转动作
+//   - fromStatus: 流转前状态
+//   - toStatus: 流转后状态
+//   - operatorID: 操作人账户 ID
+//   - remark: 备注
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func recordTicketEvent(c *gin.Context, ticketID int64, action, fromStatus, toStatus string, operatorID int64, remark string) error {
+	event := &model.MaintenanceTicketEvent{
+		TicketID:   ticketID,
+		Action:     action,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		OperatorID: operatorID,
+		Remark:     remark,
+	}
+	if err := mapper.CreateMaintenanceTicketEvent(c, event); err != nil {
+		utils.BizLogger(c).Errorf("写入工单流转记录失败: %v", err)
+		return fmt.Errorf("写入工单流转记录失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+// Package service 提供业务逻辑处理，处理报修工单相关业务
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	leaseModel "lease/internal/model/lease"
+	model "lease/internal/model/maintenance"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/maintenance/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
+	"lease/pkg/vo/maintenance"
+)
+
+// CreateTicket 提交报修逻辑，租客须承租该出租单元，业主须为单元所属房源的业主
+// 参数：
+//   - c: Gin 上下文
+//   - req: 提交报修请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func CreateTicket(c *gin.Context, req *dto.CreateTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	var ticket *model.MaintenanceTicket
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		unit, err := mapper.GetUnitByID(c, req.UnitID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」出租单元不存在: %v", req.UnitID, err)
+			return fmt.Errorf("「%d」出租单元不存在: %w", req.UnitID, err)
+		}
+
+		property, err := mapper.GetPropertyByID(c, unit.PropertyID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」房源不存在: %v", unit.PropertyID, err)
+			return fmt.Errorf("「%d」房源不存在: %w", unit.PropertyID, err)
+		}
+
+		ticket = &model.MaintenanceTicket{
+			UnitID:      unit.ID,
+			PropertyID:  property.ID,
+			LandlordID:  property.OwnerID,
+			ReporterID:  accountID,
+			Title:       req.Title,
+			Description: req.Description,
+			Category:    req.Category,
+			Priority:    req.Priority,
+			Status:      model.TICKET_STATUS_OPEN,
+		}
+
+		// 租客报修须持有该单元生效中的合同
+		if property.OwnerID != accountID {
+			contracts, _, err := mapper.ListLeaseContracts(c, mapper.LeaseContractFilter{
+				TenantID: accountID,
+				UnitID:   unit.ID,
+				Status:   leaseModel.CONTRACT_STATUS_ACTIVE,
+			}, 1, 1)
+			if err != nil {
+				utils.BizLogger(c).Errorf("查询租客「%d」的生效合同失败: %v", accountID, err)
+				return fmt.Errorf("查询生效合同失败: %w", err)
+			}
+			if len(contracts) == 0 {
+				utils.BizLogger(c).Errorf("账户「%d」未承租出租单元「%d」", accountID, unit.ID)
+				return fmt.Errorf("仅承租该单元的租客或业主可提交报修")
+			}
+			ticket.TenantID = accountID
+			ticket.ContractID = contracts[0].ID
+		}
+
+		if err := mapper.CreateMaintenanceTicket(c, ticket); err != nil {
+			utils.BizLogger(c).Errorf("创建报修工单失败: %v", err)
+			return fmt.Errorf("创建报修工单失败: %w", err)
+		}
+
+		return recordTicketEvent(c, ticket.ID, model.TICKET_ACTION_CREATE, "", model.TICKET_STATUS_OPEN, accountID, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketVO(c, ticket)
+}
+
+// GetTicket 获取工单详情，附带评论与状态流转记录，仅租客、业主和维修人员可查看
+// 参数：
+//   - c: Gin 上下文
+//   - req: 工单 ID 请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func GetTicket(c *gin.Context, req *dto.TicketIDRequest) (*maintenance.MaintenanceTicketVO, error) {
+	ticket, _, err := getVisibleTicket(c, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketVO, err := toTicketVO(c, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := listComments(c, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	ticketVO.Comments = comments
+
+	events, err := mapper.GetMaintenanceTicketEventsByTicketID(c, ticket.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询工单流转记录失败: %v", err)
+		return nil, fmt.Errorf("查询工单流转记录失败: %w", err)
+	}
+	ticketVO.Events = make([]*maintenance.MaintenanceTicketEventVO, 0, len(events))
+	for _, event := range events {
+		eventVO, err := utils.MapModelToVO(event, &maintenance.MaintenanceTicketEventVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("工单流转记录映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("工单流转记录映射 VO 失败: %w", err)
+		}
+		ticketVO.Events = append(ticketVO.Events, eventVO.(*maintenance.MaintenanceTicketEventVO))
+	}
+
+	return ticketVO, nil
+}
+
+// ListTickets 按业主、租客或维修人员身份分页查询当前账户相关的工单，紧急程度高的排在前面
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页查询请求
+//
+// 返回值：
+//   - *vo.PageVO: 工单分页结果
+//   - error: 操作过程中的错误
+func ListTickets(c *gin.Context, req *dto.ListTicketRequest) (*vo.PageVO, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	filter := mapper.MaintenanceTicketFilter{UnitID: req.UnitID, Status: req.Status, Priority: req.Priority}
+	switch req.Role {
+	case "landlord":
+		filter.LandlordID = accountID
+	case "assignee":
+		filter.AssigneeID = accountID
+	default:
+		filter.TenantID = accountID
+	}
+
+	tickets, total, err := mapper.ListMaintenanceTickets(c, filter, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询报修工单列表失败: %v", err)
+		return nil, fmt.Errorf("查询报修工单列表失败: %w", err)
+	}
+
+	list := make([]*maintenance.MaintenanceTicketVO, 0, len(tickets))
+	for _, ticket := range tickets {
+		ticketVO, err := toTicketVO(c, ticket)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ticketVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// AssignTicket 业主将工单指派或改派给维修人员，改派会清除原预约时间
+// 参数：
+//   - c: Gin 上下文
+//   - req: 指派请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func AssignTicket(c *gin.Context, req *dto.AssignTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_ASSIGN, req.Remark, func(ticket *model.MaintenanceTicket) error {
+		if req.AssigneeID == ticket.TenantID {
+			return fmt.Errorf("不能将工单指派给租客本人")
+		}
+		if _, err := mapper.GetAccountByAccountID(c, req.AssigneeID); err != nil {
+			utils.BizLogger(c).Errorf("「%d」维修人员账户不存在: %v", req.AssigneeID, err)
+			return fmt.Errorf("「%d」维修人员账户不存在: %w", req.AssigneeID, err)
+		}
+		ticket.AssigneeID = req.AssigneeID
+		ticket.ScheduledAt = ""
+		return nil
+	})
+}
+
+// ScheduleTicket 业主或维修人员预约上门时间，预约时间不得早于当前时间
+// 参数：
+//   - c: Gin 上下文
+//   - req: 预约上门请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func ScheduleTicket(c *gin.Context, req *dto.ScheduleTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_SCHEDULE, req.Remark, func(ticket *model.MaintenanceTicket) error {
+		scheduledAt, err := time.ParseInLocation(model.SCHEDULE_LAYOUT, req.ScheduledAt, time.Local)
+		if err != nil {
+			utils.BizLogger(c).Errorf("上门时间「%s」格式错误: %v", req.ScheduledAt, err)
+			return fmt.Errorf("上门时间格式错误: %w", err)
+		}
+		if scheduledAt.Before(time.Now()) {
+			return fmt.Errorf("上门时间不能早于当前时间")
+		}
+		ticket.ScheduledAt = req.ScheduledAt
+		return nil
+	})
+}
+
+// StartTicket 业主或维修人员开始维修
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func StartTicket(c *gin.Context, req *dto.TransitionTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_START, req.Remark, nil)
+}
+
+// ResolveTicket 业主或维修人员完成维修，备注作为处理结果说明
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func ResolveTicket(c *gin.Context, req *dto.TransitionTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_RESOLVE, req.Remark, func(ticket *model.MaintenanceTicket) error {
+		ticket.ResolvedAt = time.Now().Unix()
+		ticket.Resolution = req.Remark
+		return nil
+	})
+}
+
+// ReopenTicket 报修人或业主认为未修好时重新打开工单
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func ReopenTicket(c *gin.Context, req *dto.TransitionTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_REOPEN, req.Remark, func(ticket *model.MaintenanceTicket) error {
+		ticket.ResolvedAt = 0
+		return nil
+	})
+}
+
+// CloseTicket 业主核定维修费用并关闭工单
+// 参数：
+//   - c: Gin 上下文
+//   - req: 关闭工单请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func CloseTicket(c *gin.Context, req *dto.CloseTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_CLOSE, req.Remark, func(ticket *model.MaintenanceTicket) error {
+		ticket.Cost = req.Cost
+		ticket.ClosedAt = time.Now().Unix()
+		return nil
+	})
+}
+
+// CancelTicket 报修人或业主在开始维修前取消工单
+// 参数：
+//   - c: Gin 上下文
+//   - req: 状态流转请求
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func CancelTicket(c *gin.Context, req *dto.TransitionTicketRequest) (*maintenance.MaintenanceTicketVO, error) {
+	return runTicketAction(c, req.ID, model.TICKET_ACTION_CANCEL, req.Remark, nil)
+}
+
+// runTicketAction 在事务中加载工单、校验操作人角色、修改字段并执行状态流转
+// 参数：
+//   - c: Gin 上下文
+//   - ticketID: 工单 ID
+//   - action: 流转动作
+//   - remark: 备注
+//   - mutate: 流转前修改工单字段，可为 nil
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 操作过程中的错误
+func runTicketAction(c *gin.Context, ticketID int64, action, remark string, mutate func(ticket *model.MaintenanceTicket) error) (*maintenance.MaintenanceTicketVO, error) {
+	var ticket *model.MaintenanceTicket
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var accountID int64
+		var err error
+		ticket, accountID, err = getVisibleTicket(c, ticketID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTicketAction(c, ticket, action, accountID); err != nil {
+			return err
+		}
+		if mutate != nil {
+			if err := mutate(ticket); err != nil {
+				return err
+			}
+		}
+		return transitionTicket(c, ticket, action, accountID, remark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketVO(c, ticket)
+}
+
+// getVisibleTicket 获取当前账户可查看的工单，仅租客、报修人、业主和维修人员可见
+// 参数：
+//   - c: Gin 上下文
+//   - ticketID: 工单 ID
+//
+// 返回值：
+//   - *model.MaintenanceTicket: 工单信息
+//   - int64: 当前账户 ID
+//   - error: 工单不存在或当前账户无权查看时返回错误
+func getVisibleTicket(c *gin.Context, ticketID int64) (*model.MaintenanceTicket, int64, error) {
+	accountID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, 0, fmt.Errorf("未获取到当前账户")
+	}
+
+	ticket, err := mapper.GetMaintenanceTicketByID(c, ticketID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」报修工单不存在: %v", ticketID, err)
+		return nil, 0, fmt.Errorf("「%d」报修工单不存在: %w", ticketID, err)
+	}
+
+	if len(ticketRoles(ticket, accountID)) == 0 {
+		utils.BizLogger(c).Errorf("账户「%d」无权访问报修工单「%d」", accountID, ticketID)
+		return nil, 0, fmt.Errorf("无权访问该报修工单")
+	}
+
+	return ticket, accountID, nil
+}
+
+// toTicketVO 将工单模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - ticket: 工单模型
+//
+// 返回值：
+//   - *maintenance.MaintenanceTicketVO: 工单视图对象
+//   - error: 映射过程中的错误
+func toTicketVO(c *gin.Context, ticket *model.MaintenanceTicket) (*maintenance.MaintenanceTicketVO, error) {
+	ticketVO, err := utils.MapModelToVO(ticket, &maintenance.MaintenanceTicketVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("报修工单映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("报修工单映射 VO 失败: %w", err)
+	}
+	return ticketVO.(*maintenance.MaintenanceTicketVO), nil
+}
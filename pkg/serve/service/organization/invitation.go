@@ -0,0 +1,261 @@
+// Package service 提供业务逻辑处理，处理组织相关业务
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lease/configs"
+	model "lease/internal/model/organization"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/organization/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
+	"lease/pkg/vo/organization"
+)
+
+const (
+	INVITATION_EXPIRE_TIME = time.Hour * 24 * 7 // 邀请有效期
+	INVITATION_CODE_BYTES  = 16                 // 邀请码随机字节数
+)
+
+// CreateInvitation 组织管理员邀请邮箱加入组织，邀请码通过邮件发送，邮件发送失败不影响邀请创建
+// 参数：
+//   - c: Gin 上下文
+//   - req: 邀请成员请求
+//
+// 返回值：
+//   - *organization.InvitationVO: 邀请视图对象
+//   - error: 操作过程中的错误
+func CreateInvitation(c *gin.Context, req *dto.CreateInvitationRequest) (*organization.InvitationVO, error) {
+	operatorID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %w", err)
+	}
+
+	var org *model.Organization
+	var invitation *model.OrganizationInvitation
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		org, err = getCurrentOrganization(c)
+		if err != nil {
+			return err
+		}
+
+		if _, err := mapper.GetRoleByCode(c, req.RoleCode); err != nil {
+			utils.BizLogger(c).Errorf("「%s」角色不存在: %v", req.RoleCode, err)
+			return fmt.Errorf("「%s」角色不存在: %w", req.RoleCode, err)
+		}
+
+		if existing, _ := mapper.GetAccountByEmail(c, req.Email); existing != nil {
+			utils.BizLogger(c).Errorf("「%s」邮箱已被注册", req.Email)
+			return fmt.Errorf("「%s」邮箱已被注册", req.Email)
+		}
+
+		if err := checkSeatAvailable(c, org, true); err != nil {
+			return err
+		}
+
+		code, err := newInvitationCode()
+		if err != nil {
+			utils.BizLogger(c).Errorf("生成邀请码失败: %v", err)
+			return fmt.Errorf("生成邀请码失败: %w", err)
+		}
+
+		invitation = &model.OrganizationInvitation{
+			Email:     req.Email,
+			RoleCode:  req.RoleCode,
+			Code:      code,
+			Status:    model.INVITATION_STATUS_PENDING,
+			InvitedBy: operatorID,
+			ExpiresAt: time.Now().Add(INVITATION_EXPIRE_TIME).Unix(),
+		}
+		if err := mapper.CreateOrganizationInvitation(c, invitation); err != nil {
+			utils.BizLogger(c).Errorf("创建「%s」邀请失败: %v", req.Email, err)
+			return fmt.Errorf("创建邀请失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sendInvitationEmail(c, org, invitation)
+	return toInvitationVO(c, invitation)
+}
+
+// ListInvitations 分页查询当前组织发出的邀请
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页查询邀请请求
+//
+// 返回值：
+//   - *vo.PageVO: 邀请分页结果
+//   - error: 操作过程中的错误
+func ListInvitations(c *gin.Context, req *dto.ListInvitationRequest) (*vo.PageVO, error) {
+	organizationID, err := currentOrganizationID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, total, err := mapper.ListOrganizationInvitations(c, organizationID, req.Status, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询组织「%d」邀请失败: %v", organizationID, err)
+		return nil, fmt.Errorf("查询组织邀请失败: %w", err)
+	}
+
+	list := make([]*organization.InvitationVO, 0, len(invitations))
+	for _, invitation := range invitations {
+		invitationVO, err := toInvitationVO(c, invitation)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, invitationVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// RevokeInvitation 组织管理员撤销尚未接受的邀请，释放其占用的席位
+// 参数：
+//   - c: Gin 上下文
+//   - req: 邀请 ID 请求
+//
+// 返回值：
+//   - *organization.InvitationVO: 撤销后的邀请视图对象
+//   - error: 操作过程中的错误
+func RevokeInvitation(c *gin.Context, req *dto.InvitationIDRequest) (*organization.InvitationVO, error) {
+	var invitation *model.OrganizationInvitation
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		invitation, err = mapper.GetOrganizationInvitationByID(c, req.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」邀请不存在: %v", req.ID, err)
+			return fmt.Errorf("「%d」邀请不存在: %w", req.ID, err)
+		}
+		if invitation.Status != model.INVITATION_STATUS_PENDING {
+			return fmt.Errorf("仅待接受的邀请可撤销，当前状态为「%s」", invitation.Status)
+		}
+
+		invitation.Status = model.INVITATION_STATUS_REVOKED
+		if err := mapper.UpdateOrganizationInvitationStatus(c, invitation, model.INVITATION_STATUS_PENDING); err != nil {
+			utils.BizLogger(c).Errorf("撤销「%d」邀请失败: %v", req.ID, err)
+			return fmt.Errorf("撤销邀请失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitationVO(c, invitation)
+}
+
+// CheckInvitation 注册时校验邀请码：须为待接受、未过期、邮箱一致且组织仍有空余席位
+// 参数：
+//   - c: Gin 上下文
+//   - code: 邀请码
+//   - email: 注册邮箱
+//
+// 返回值：
+//   - *model.OrganizationInvitation: 邀请信息
+//   - error: 邀请码无效或组织席位已满时返回错误
+func CheckInvitation(c *gin.Context, code, email string) (*model.OrganizationInvitation, error) {
+	invitation, err := mapper.GetOrganizationInvitationByCode(c, code)
+	if err != nil {
+		utils.BizLogger(c).Errorf("邀请码不存在: %v", err)
+		return nil, fmt.Errorf("邀请码无效")
+	}
+	if invitation.Status != model.INVITATION_STATUS_PENDING {
+		return nil, fmt.Errorf("邀请码已失效")
+	}
+	if invitation.ExpiresAt <= time.Now().Unix() {
+		return nil, fmt.Errorf("邀请码已过期")
+	}
+	if !strings.EqualFold(invitation.Email, email) {
+		utils.BizLogger(c).Errorf("邀请「%d」邮箱「%s」与注册邮箱「%s」不一致", invitation.ID, invitation.Email, email)
+		return nil, fmt.Errorf("邀请码与注册邮箱不匹配")
+	}
+
+	org, err := mapper.GetOrganizationByID(c, invitation.OrganizationID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」组织不存在: %v", invitation.OrganizationID, err)
+		return nil, fmt.Errorf("「%d」组织不存在: %w", invitation.OrganizationID, err)
+	}
+	if err := checkSeatAvailable(c, org, false); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation 将邀请标记为已接受，同一邀请码只能使用一次，须在 utils.RunDBTransaction 中调用
+// 参数：
+//   - c: Gin 上下文
+//   - invitation: 通过 CheckInvitation 校验的邀请
+//   - accountID: 接受邀请的账户 ID
+//
+// 返回值：
+//   - error: 邀请已被使用或更新失败时返回错误
+func AcceptInvitation(c *gin.Context, invitation *model.OrganizationInvitation, accountID int64) error {
+	invitation.Status = model.INVITATION_STATUS_ACCEPTED
+	invitation.AcceptedBy = accountID
+	invitation.AcceptedAt = time.Now().Unix()
+	if err := mapper.UpdateOrganizationInvitationStatus(c, invitation, model.INVITATION_STATUS_PENDING); err != nil {
+		utils.BizLogger(c).Errorf("接受「%d」邀请失败: %v", invitation.ID, err)
+		return fmt.Errorf("接受邀请失败: %w", err)
+	}
+	return nil
+}
+
+// sendInvitationEmail 向受邀邮箱发送邀请码，发送失败仅记录日志，管理员仍可通过邀请列表转交邀请码
+// 参数：
+//   - c: Gin 上下文
+//   - org: 组织信息
+//   - invitation: 邀请信息
+func sendInvitationEmail(c *gin.Context, org *model.Organization, invitation *model.OrganizationInvitation) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Warnf("加载配置失败，未发送「%s」邀请邮件: %v", invitation.Email, err)
+		return
+	}
+
+	content := fmt.Sprintf("您被邀请加入「%s」的%s，注册时请填写邀请码：%s，邀请码 %s 前有效。",
+		org.Name, cfg.AppConfig.AppName, invitation.Code, time.Unix(invitation.ExpiresAt, 0).Format(time.DateTime))
+	if _, err := utils.SendEmail(content, []string{invitation.Email}); err != nil {
+		utils.BizLogger(c).Warnf("「%s」邀请邮件发送失败: %v", invitation.Email, err)
+	}
+}
+
+// newInvitationCode 生成随机邀请码
+// 返回值：
+//   - string: 十六进制邀请码
+//   - error: 生成过程中的错误
+func newInvitationCode() (string, error) {
+	buf := make([]byte, INVITATION_CODE_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// toInvitationVO 邀请映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - invitation: 邀请信息
+//
+// 返回值：
+//   - *organization.InvitationVO: 邀请视图对象
+//   - error: 操作过程中的错误
+func toInvitationVO(c *gin.Context, invitation *model.OrganizationInvitation) (*organization.InvitationVO, error) {
+	invitationVO, err := utils.MapModelToVO(invitation, &organization.InvitationVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("组织邀请映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("组织邀请映射 VO 失败: %w", err)
+	}
+	return invitationVO.(*organization.InvitationVO), nil
+}
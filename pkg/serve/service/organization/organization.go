@@ -0,0 +1,226 @@
+// Package service 提供业务逻辑处理，处理组织相关业务
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/organization"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/organization/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
+	"lease/pkg/vo/organization"
+)
+
+// GetOrganization 获取当前账户所属组织及席位占用情况
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *organization.OrganizationVO: 组织视图对象
+//   - error: 操作过程中的错误
+func GetOrganization(c *gin.Context) (*organization.OrganizationVO, error) {
+	org, err := getCurrentOrganization(c)
+	if err != nil {
+		return nil, err
+	}
+	return toOrganizationVO(c, org)
+}
+
+// UpdateOrganization 组织管理员修改组织名称或席位上限，席位上限不得低于当前成员数
+// 参数：
+//   - c: Gin 上下文
+//   - req: 更新组织请求
+//
+// 返回值：
+//   - *organization.OrganizationVO: 更新后的组织视图对象
+//   - error: 操作过程中的错误
+func UpdateOrganization(c *gin.Context, req *dto.UpdateOrganizationRequest) (*organization.OrganizationVO, error) {
+	var org *model.Organization
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		org, err = getCurrentOrganization(c)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			org.Name = *req.Name
+		}
+		if req.SeatLimit != nil && *req.SeatLimit > 0 {
+			members, err := mapper.CountAccountsByOrganizationID(c, org.ID)
+			if err != nil {
+				utils.BizLogger(c).Errorf("统计组织「%d」成员数量失败: %v", org.ID, err)
+				return fmt.Errorf("统计组织成员数量失败: %w", err)
+			}
+			if int64(*req.SeatLimit) < members {
+				return fmt.Errorf("席位上限 %d 低于当前成员数 %d", *req.SeatLimit, members)
+			}
+		}
+		if req.SeatLimit != nil {
+			org.SeatLimit = *req.SeatLimit
+		}
+
+		if err := mapper.UpdateOrganization(c, org); err != nil {
+			utils.BizLogger(c).Errorf("更新组织「%d」失败: %v", org.ID, err)
+			return fmt.Errorf("更新组织失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganizationVO(c, org)
+}
+
+// ListMembers 分页查询当前组织的成员
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页查询成员请求
+//
+// 返回值：
+//   - *vo.PageVO: 成员分页结果
+//   - error: 操作过程中的错误
+func ListMembers(c *gin.Context, req *dto.ListMemberRequest) (*vo.PageVO, error) {
+	organizationID, err := currentOrganizationID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, total, err := mapper.ListAccountsByOrganizationID(c, organizationID, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询组织「%d」成员失败: %v", organizationID, err)
+		return nil, fmt.Errorf("查询组织成员失败: %w", err)
+	}
+
+	list := make([]*organization.MemberVO, 0, len(accounts))
+	for _, acc := range accounts {
+		memberVO, err := utils.MapModelToVO(acc, &organization.MemberVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("组织成员映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("组织成员映射 VO 失败: %w", err)
+		}
+		list = append(list, memberVO.(*organization.MemberVO))
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// CreateOwnedOrganization 为自行注册的账户创建组织并设为创建人，须在 utils.RunDBTransaction 中调用
+// 参数：
+//   - c: Gin 上下文
+//   - name: 组织名称
+//   - ownerID: 创建人账户 ID
+//
+// 返回值：
+//   - *model.Organization: 新建的组织
+//   - error: 操作过程中的错误
+func CreateOwnedOrganization(c *gin.Context, name string, ownerID int64) (*model.Organization, error) {
+	org := &model.Organization{Name: name, OwnerID: ownerID}
+	if err := mapper.CreateOrganization(c, org); err != nil {
+		utils.BizLogger(c).Errorf("创建组织「%s」失败: %v", name, err)
+		return nil, fmt.Errorf("创建组织失败: %w", err)
+	}
+	return org, nil
+}
+
+// getCurrentOrganization 获取当前账户所属组织
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - *model.Organization: 组织信息
+//   - error: 操作过程中的错误
+func getCurrentOrganization(c *gin.Context) (*model.Organization, error) {
+	organizationID, err := currentOrganizationID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := mapper.GetOrganizationByID(c, organizationID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」组织不存在: %v", organizationID, err)
+		return nil, fmt.Errorf("「%d」组织不存在: %w", organizationID, err)
+	}
+	return org, nil
+}
+
+// checkSeatAvailable 校验组织是否仍有空余席位，待接受的邀请同样占用席位
+// 参数：
+//   - c: Gin 上下文
+//   - org: 组织信息
+//   - pendingInvitations: 是否将未过期的待接受邀请计入已占用席位
+//
+// 返回值：
+//   - error: 席位已满或统计失败时返回错误
+func checkSeatAvailable(c *gin.Context, org *model.Organization, pendingInvitations bool) error {
+	if org.SeatLimit == 0 {
+		return nil
+	}
+
+	used, err := mapper.CountAccountsByOrganizationID(c, org.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("统计组织「%d」成员数量失败: %v", org.ID, err)
+		return fmt.Errorf("统计组织成员数量失败: %w", err)
+	}
+	if pendingInvitations {
+		pending, err := mapper.CountPendingOrganizationInvitations(c, org.ID, time.Now().Unix())
+		if err != nil {
+			utils.BizLogger(c).Errorf("统计组织「%d」待接受邀请失败: %v", org.ID, err)
+			return fmt.Errorf("统计组织邀请数量失败: %w", err)
+		}
+		used += pending
+	}
+
+	if used >= int64(org.SeatLimit) {
+		utils.BizLogger(c).Errorf("组织「%d」席位已满 (%d/%d)", org.ID, used, org.SeatLimit)
+		return fmt.Errorf("组织席位已满 (%d/%d)", used, org.SeatLimit)
+	}
+	return nil
+}
+
+// toOrganizationVO 组织映射为视图对象并统计已占用席位
+// 参数：
+//   - c: Gin 上下文
+//   - org: 组织信息
+//
+// 返回值：
+//   - *organization.OrganizationVO: 组织视图对象
+//   - error: 操作过程中的错误
+func toOrganizationVO(c *gin.Context, org *model.Organization) (*organization.OrganizationVO, error) {
+	members, err := mapper.CountAccountsByOrganizationID(c, org.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("统计组织「%d」成员数量失败: %v", org.ID, err)
+		return nil, fmt.Errorf("统计组织成员数量失败: %w", err)
+	}
+
+	orgVO, err := utils.MapModelToVO(org, &organization.OrganizationVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("组织映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("组织映射 VO 失败: %w", err)
+	}
+	result := orgVO.(*organization.OrganizationVO)
+	result.SeatsUsed = members
+	return result, nil
+}
+
+// currentOrganizationID 从认证上下文中获取当前账户所属组织 ID
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - int64: 组织 ID
+//   - error: 当前账户未归属任何组织时返回错误
+func currentOrganizationID(c *gin.Context) (int64, error) {
+	organizationID, ok := auth_middleware.GetOrganizationID(c)
+	if !ok || organizationID == 0 {
+		utils.BizLogger(c).Error("当前账户未归属任何组织")
+		return 0, fmt.Errorf("当前账户未归属任何组织，请重新登录")
+	}
+	return organizationID, nil
+}
@@ -0,0 +1,237 @@
+// Package service 提供业务逻辑处理，处理房源与出租单元相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/property"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/property/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
+	"lease/pkg/vo/property"
+)
+
+// CreateProperty 创建房源逻辑，当前登录账户即为业主
+// 参数：
+//   - c: Gin 上下文
+//   - req: 创建房源请求
+//
+// 返回值：
+//   - *property.PropertyVO: 房源视图对象
+//   - error: 操作过程中的错误
+func CreateProperty(c *gin.Context, req *dto.CreatePropertyRequest) (*property.PropertyVO, error) {
+	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %w", err)
+	}
+
+	p := &model.Property{
+		OwnerID:     accountID,
+		Name:        req.Name,
+		Province:    req.Province,
+		City:        req.City,
+		District:    req.District,
+		Address:     req.Address,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Description: req.Description,
+	}
+	if err := mapper.CreateProperty(c, p); err != nil {
+		utils.BizLogger(c).Errorf("创建房源失败: %v", err)
+		return nil, fmt.Errorf("创建房源失败: %w", err)
+	}
+
+	return toPropertyVO(c, p)
+}
+
+// GetProperty 获取房源详情逻辑
+// 参数：
+//   - c: Gin 上下文
+//   - req: 房源 ID 请求
+//
+// 返回值：
+//   - *property.PropertyVO: 房源视图对象
+//   - error: 操作过程中的错误
+func GetProperty(c *gin.Context, req *dto.PropertyIDRequest) (*property.PropertyVO, error) {
+	p, err := mapper.GetPropertyByID(c, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」房源不存在: %v", req.ID, err)
+		return nil, fmt.Errorf("「%d」房源不存在: %w", req.ID, err)
+	}
+
+	return toPropertyVO(c, p)
+}
+
+// UpdateProperty 更新房源逻辑，仅业主可操作
+// 参数：
+//   - c: Gin 上下文
+//   - req: 更新房源请求
+//
+// 返回值：
+//   - *property.PropertyVO: 更新后的房源视图对象
+//   - error: 操作过程中的错误
+func UpdateProperty(c *gin.Context, req *dto.UpdatePropertyRequest) (*property.PropertyVO, error) {
+	var p *model.Property
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		p, err = getOwnedProperty(c, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			p.Name = *req.Name
+		}
+		if req.Province != nil {
+			p.Province = *req.Province
+		}
+		if req.City != nil {
+			p.City = *req.City
+		}
+		if req.District != nil {
+			p.District = *req.District
+		}
+		if req.Address != nil {
+			p.Address = *req.Address
+		}
+		if req.Latitude != nil {
+			p.Latitude = *req.Latitude
+		}
+		if req.Longitude != nil {
+			p.Longitude = *req.Longitude
+		}
+		if req.Description != nil {
+			p.Description = *req.Description
+		}
+
+		if err := mapper.UpdateProperty(c, p); err != nil {
+			utils.BizLogger(c).Errorf("更新房源失败: %v", err)
+			return fmt.Errorf("更新房源失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPropertyVO(c, p)
+}
+
+// DeleteProperty 删除房源逻辑，仅业主可操作，存在已出租单元时拒绝删除
+// 参数：
+//   - c: Gin 上下文
+//   - req: 房源 ID 请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteProperty(c *gin.Context, req *dto.PropertyIDRequest) error {
+	return utils.RunDBTransaction(c, func(tx error) error {
+		if _, err := getOwnedProperty(c, req.ID); err != nil {
+			return err
+		}
+
+		occupied, err := mapper.CountUnitsByStatus(c, req.ID, model.UNIT_STATUS_OCCUPIED)
+		if err != nil {
+			utils.BizLogger(c).Errorf("统计已出租单元失败: %v", err)
+			return fmt.Errorf("统计已出租单元失败: %w", err)
+		}
+		if occupied > 0 {
+			utils.BizLogger(c).Errorf("「%d」房源下仍有 %d 个已出租单元", req.ID, occupied)
+			return fmt.Errorf("房源下仍有 %d 个已出租单元，无法删除", occupied)
+		}
+
+		if err := mapper.DeleteUnitsByPropertyID(c, req.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除房源下的出租单元失败: %v", err)
+			return fmt.Errorf("删除房源下的出租单元失败: %w", err)
+		}
+		if err := mapper.DeletePropertyByID(c, req.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除房源失败: %v", err)
+			return fmt.Errorf("删除房源失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListProperties 分页搜索房源逻辑
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页搜索请求
+//
+// 返回值：
+//   - *vo.PageVO: 房源分页结果
+//   - error: 操作过程中的错误
+func ListProperties(c *gin.Context, req *dto.ListPropertyRequest) (*vo.PageVO, error) {
+	filter := mapper.PropertyFilter{
+		Keyword:  req.Keyword,
+		City:     req.City,
+		District: req.District,
+		OwnerID:  req.OwnerID,
+	}
+	properties, total, err := mapper.ListProperties(c, filter, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("搜索房源失败: %v", err)
+		return nil, fmt.Errorf("搜索房源失败: %w", err)
+	}
+
+	list := make([]*property.PropertyVO, 0, len(properties))
+	for _, p := range properties {
+		pVO, err := toPropertyVO(c, p)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, pVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// getOwnedProperty 获取当前账户名下的房源
+// 参数：
+//   - c: Gin 上下文
+//   - propertyID: 房源 ID
+//
+// 返回值：
+//   - *model.Property: 房源信息
+//   - error: 房源不存在或不属于当前账户时返回错误
+func getOwnedProperty(c *gin.Context, propertyID int64) (*model.Property, error) {
+	accountID, err := utils.ParseAccountAndRoleIDFromJWT(c.GetHeader("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %w", err)
+	}
+
+	p, err := mapper.GetPropertyByID(c, propertyID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」房源不存在: %v", propertyID, err)
+		return nil, fmt.Errorf("「%d」房源不存在: %w", propertyID, err)
+	}
+
+	if p.OwnerID != accountID {
+		utils.BizLogger(c).Errorf("账户「%d」无权操作房源「%d」", accountID, propertyID)
+		return nil, fmt.Errorf("无权操作该房源")
+	}
+
+	return p, nil
+}
+
+// toPropertyVO 将房源模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - p: 房源模型
+//
+// 返回值：
+//   - *property.PropertyVO: 房源视图对象
+//   - error: 映射过程中的错误
+func toPropertyVO(c *gin.Context, p *model.Property) (*property.PropertyVO, error) {
+	pVO, err := utils.MapModelToVO(p, &property.PropertyVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("房源映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("房源映射 VO 失败: %w", err)
+	}
+	return pVO.(*property.PropertyVO), nil
+}
@@ -0,0 +1,237 @@
+// Package service 提供业务逻辑处理，处理房源与出租单元相关业务
+package service
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	model "lease/internal/model/property"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/property/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo"
+	"lease/pkg/vo/property"
+)
+
+// CreateUnit 创建出租单元逻辑，仅房源业主可操作
+// 参数：
+//   - c: Gin 上下文
+//   - req: 创建出租单元请求
+//
+// 返回值：
+//   - *property.UnitVO: 出租单元视图对象
+//   - error: 操作过程中的错误
+func CreateUnit(c *gin.Context, req *dto.CreateUnitRequest) (*property.UnitVO, error) {
+	var unit *model.Unit
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		if _, err := getOwnedProperty(c, req.PropertyID); err != nil {
+			return err
+		}
+
+		if existing, _ := mapper.GetUnitByPropertyAndNo(c, req.PropertyID, req.UnitNo); existing != nil {
+			utils.BizLogger(c).Errorf("房源「%d」下已存在房间号「%s」", req.PropertyID, req.UnitNo)
+			return fmt.Errorf("房间号「%s」已存在", req.UnitNo)
+		}
+
+		status := req.Status
+		if status == "" {
+			status = model.UNIT_STATUS_VACANT
+		}
+
+		unit = &model.Unit{
+			PropertyID:    req.PropertyID,
+			UnitNo:        req.UnitNo,
+			Floor:         req.Floor,
+			Area:          req.Area,
+			Rooms:         req.Rooms,
+			RentListPrice: req.RentListPrice,
+			Status:        status,
+		}
+		if err := mapper.CreateUnit(c, unit); err != nil {
+			utils.BizLogger(c).Errorf("创建出租单元失败: %v", err)
+			return fmt.Errorf("创建出租单元失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUnitVO(c, unit)
+}
+
+// GetUnit 获取出租单元详情逻辑
+// 参数：
+//   - c: Gin 上下文
+//   - req: 出租单元 ID 请求
+//
+// 返回值：
+//   - *property.UnitVO: 出租单元视图对象
+//   - error: 操作过程中的错误
+func GetUnit(c *gin.Context, req *dto.UnitIDRequest) (*property.UnitVO, error) {
+	unit, err := mapper.GetUnitByID(c, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」出租单元不存在: %v", req.ID, err)
+		return nil, fmt.Errorf("「%d」出租单元不存在: %w", req.ID, err)
+	}
+
+	return toUnitVO(c, unit)
+}
+
+// UpdateUnit 更新出租单元逻辑，仅房源业主可操作
+// 参数：
+//   - c: Gin 上下文
+//   - req: 更新出租单元请求
+//
+// 返回值：
+//   - *property.UnitVO: 更新后的出租单元视图对象
+//   - error: 操作过程中的错误
+func UpdateUnit(c *gin.Context, req *dto.UpdateUnitRequest) (*property.UnitVO, error) {
+	var unit *model.Unit
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		var err error
+		unit, err = getOwnedUnit(c, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if req.UnitNo != nil && *req.UnitNo != unit.UnitNo {
+			if existing, _ := mapper.GetUnitByPropertyAndNo(c, unit.PropertyID, *req.UnitNo); existing != nil {
+				utils.BizLogger(c).Errorf("房源「%d」下已存在房间号「%s」", unit.PropertyID, *req.UnitNo)
+				return fmt.Errorf("房间号「%s」已存在", *req.UnitNo)
+			}
+			unit.UnitNo = *req.UnitNo
+		}
+		if req.Floor != nil {
+			unit.Floor = *req.Floor
+		}
+		if req.Area != nil {
+			unit.Area = *req.Area
+		}
+		if req.Rooms != nil {
+			unit.Rooms = *req.Rooms
+		}
+		if req.RentListPrice != nil {
+			unit.RentListPrice = *req.RentListPrice
+		}
+		if req.Status != nil {
+			unit.Status = *req.Status
+		}
+
+		if err := mapper.UpdateUnit(c, unit); err != nil {
+			utils.BizLogger(c).Errorf("更新出租单元失败: %v", err)
+			return fmt.Errorf("更新出租单元失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUnitVO(c, unit)
+}
+
+// DeleteUnit 删除出租单元逻辑，仅房源业主可操作，已出租单元不可删除
+// 参数：
+//   - c: Gin 上下文
+//   - req: 出租单元 ID 请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteUnit(c *gin.Context, req *dto.UnitIDRequest) error {
+	return utils.RunDBTransaction(c, func(tx error) error {
+		unit, err := getOwnedUnit(c, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if unit.Status == model.UNIT_STATUS_OCCUPIED {
+			utils.BizLogger(c).Errorf("「%d」出租单元已出租，无法删除", req.ID)
+			return fmt.Errorf("出租单元已出租，无法删除")
+		}
+
+		if err := mapper.DeleteUnitByID(c, req.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除出租单元失败: %v", err)
+			return fmt.Errorf("删除出租单元失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListUnits 分页搜索出租单元逻辑
+// 参数：
+//   - c: Gin 上下文
+//   - req: 分页搜索请求
+//
+// 返回值：
+//   - *vo.PageVO: 出租单元分页结果
+//   - error: 操作过程中的错误
+func ListUnits(c *gin.Context, req *dto.ListUnitRequest) (*vo.PageVO, error) {
+	filter := mapper.UnitFilter{
+		PropertyID: req.PropertyID,
+		City:       req.City,
+		Status:     req.Status,
+		MinRent:    req.MinRent,
+		MaxRent:    req.MaxRent,
+		MinRooms:   req.MinRooms,
+		MinArea:    req.MinArea,
+	}
+	units, total, err := mapper.ListUnits(c, filter, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("搜索出租单元失败: %v", err)
+		return nil, fmt.Errorf("搜索出租单元失败: %w", err)
+	}
+
+	list := make([]*property.UnitVO, 0, len(units))
+	for _, unit := range units {
+		unitVO, err := toUnitVO(c, unit)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, unitVO)
+	}
+
+	return &vo.PageVO{List: list, Total: total, PageNo: req.PageNo, PageSize: req.PageSize}, nil
+}
+
+// getOwnedUnit 获取当前账户名下房源中的出租单元
+// 参数：
+//   - c: Gin 上下文
+//   - unitID: 出租单元 ID
+//
+// 返回值：
+//   - *model.Unit: 出租单元信息
+//   - error: 出租单元不存在或不属于当前账户时返回错误
+func getOwnedUnit(c *gin.Context, unitID int64) (*model.Unit, error) {
+	unit, err := mapper.GetUnitByID(c, unitID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」出租单元不存在: %v", unitID, err)
+		return nil, fmt.Errorf("「%d」出租单元不存在: %w", unitID, err)
+	}
+
+	if _, err := getOwnedProperty(c, unit.PropertyID); err != nil {
+		return nil, err
+	}
+
+	return unit, nil
+}
+
+// toUnitVO 将出租单元模型映射为视图对象
+// 参数：
+//   - c: Gin 上下文
+//   - unit: 出租单元模型
+//
+// 返回值：
+//   - *property.UnitVO: 出租单元视图对象
+//   - error: 映射过程中的错误
+func toUnitVO(c *gin.Context, unit *model.Unit) (*property.UnitVO, error) {
+	unitVO, err := utils.MapModelToVO(unit, &property.UnitVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("出租单元映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("出租单元映射 VO 失败: %w", err)
+	}
+	return unitVO.(*property.UnitVO), nil
+}
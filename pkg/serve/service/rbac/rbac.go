@@ -0,0 +1,254 @@
+// Package service 提供业务逻辑处理，处理角色权限相关业务
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	auth_middleware "lease/internal/middleware/auth"
+	model "lease/internal/model/rbac"
+	"lease/internal/utils"
+	"lease/pkg/serve/controller/rbac/dto"
+	"lease/pkg/serve/mapper"
+	"lease/pkg/vo/rbac"
+)
+
+// ListRoles 获取全部角色及其权限
+// 参数：
+//   - c: Gin 上下文
+//
+// 返回值：
+//   - []*rbac.RoleVO: 角色列表
+//   - error: 操作过程中的错误
+func ListRoles(c *gin.Context) ([]*rbac.RoleVO, error) {
+	roles, err := mapper.ListRoles(c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询角色列表失败: %v", err)
+		return nil, fmt.Errorf("查询角色列表失败: %w", err)
+	}
+
+	list := make([]*rbac.RoleVO, 0, len(roles))
+	for _, role := range roles {
+		permissions, err := mapper.GetPermissionCodesByRoleID(c, role.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询角色「%s」权限失败: %v", role.Code, err)
+			return nil, fmt.Errorf("查询角色权限失败: %w", err)
+		}
+
+		roleVO, err := utils.MapModelToVO(role, &rbac.RoleVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("角色映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("角色映射 VO 失败: %w", err)
+		}
+		result := roleVO.(*rbac.RoleVO)
+		result.Permissions = permissions
+		list = append(list, result)
+	}
+
+	return list, nil
+}
+
+// GetAccountRoles 获取账户拥有的角色及权限
+// 参数：
+//   - c: Gin 上下文
+//   - req: 账户 ID 请求
+//
+// 返回值：
+//   - *rbac.AccountRoleVO: 账户角色视图对象
+//   - error: 操作过程中的错误
+func GetAccountRoles(c *gin.Context, req *dto.AccountIDRequest) (*rbac.AccountRoleVO, error) {
+	if _, err := mapper.GetAccountByAccountID(c, req.AccountID); err != nil {
+		utils.BizLogger(c).Errorf("「%d」账户不存在: %v", req.AccountID, err)
+		return nil, fmt.Errorf("「%d」账户不存在: %w", req.AccountID, err)
+	}
+	return toAccountRoleVO(c, req.AccountID)
+}
+
+// GrantRole 管理员为账户授予角色，授予后注销账户的全部会话，重新登录后按新角色鉴权
+// 参数：
+//   - c: Gin 上下文
+//   - req: 授予角色请求
+//
+// 返回值：
+//   - *rbac.AccountRoleVO: 账户角色视图对象
+//   - error: 操作过程中的错误
+func GrantRole(c *gin.Context, req *dto.AccountRoleRequest) (*rbac.AccountRoleVO, error) {
+	operatorID, ok := auth_middleware.GetAccountID(c)
+	if !ok {
+		utils.BizLogger(c).Errorf("上下文中缺少当前账户 ID")
+		return nil, fmt.Errorf("未获取到当前账户")
+	}
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := mapper.GetAccountByAccountID(c, req.AccountID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」账户不存在: %v", req.AccountID, err)
+			return fmt.Errorf("「%d」账户不存在: %w", req.AccountID, err)
+		}
+
+		role, err := mapper.GetRoleByCode(c, req.RoleCode)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」角色不存在: %v", req.RoleCode, err)
+			return fmt.Errorf("「%s」角色不存在: %w", req.RoleCode, err)
+		}
+
+		if existing, _ := mapper.GetAccountRole(c, req.AccountID, role.ID); existing != nil {
+			utils.BizLogger(c).Errorf("账户「%d」已拥有角色「%s」", req.AccountID, role.Code)
+			return fmt.Errorf("账户已拥有「%s」角色", role.Code)
+		}
+
+		// 并发授予同一角色时由唯一索引兜底，视为已拥有该角色
+		err = grantRole(c, acc.ID, acc.OrganizationID, role, operatorID)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			utils.BizLogger(c).Errorf("账户「%d」已拥有角色「%s」", req.AccountID, role.Code)
+			return fmt.Errorf("账户已拥有「%s」角色", role.Code)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invalidateSession(c, req.AccountID); err != nil {
+		return nil, err
+	}
+	return toAccountRoleVO(c, req.AccountID)
+}
+
+// RevokeRole 管理员撤销账户角色，组织须至少保留一名管理员
+// 参数：
+//   - c: Gin 上下文
+//   - req: 撤销角色请求
+//
+// 返回值：
+//   - *rbac.AccountRoleVO: 账户角色视图对象
+//   - error: 操作过程中的错误
+func RevokeRole(c *gin.Context, req *dto.AccountRoleRequest) (*rbac.AccountRoleVO, error) {
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		role, err := mapper.GetRoleByCode(c, req.RoleCode)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」角色不存在: %v", req.RoleCode, err)
+			return fmt.Errorf("「%s」角色不存在: %w", req.RoleCode, err)
+		}
+
+		accountRole, err := mapper.GetAccountRole(c, req.AccountID, role.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("账户「%d」未拥有角色「%s」: %v", req.AccountID, role.Code, err)
+			return fmt.Errorf("账户未拥有「%s」角色", role.Code)
+		}
+
+		if role.Code == model.ROLE_ADMIN {
+			admins, err := mapper.CountAccountsByRoleID(c, role.ID)
+			if err != nil {
+				utils.BizLogger(c).Errorf("统计管理员数量失败: %v", err)
+				return fmt.Errorf("统计管理员数量失败: %w", err)
+			}
+			if admins <= 1 {
+				return fmt.Errorf("组织须至少保留一名管理员")
+			}
+		}
+
+		if err := mapper.DeleteAccountRoleByID(c, accountRole.ID); err != nil {
+			utils.BizLogger(c).Errorf("撤销账户「%d」角色「%s」失败: %v", req.AccountID, role.Code, err)
+			return fmt.Errorf("撤销账户角色失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invalidateSession(c, req.AccountID); err != nil {
+		return nil, err
+	}
+	return toAccountRoleVO(c, req.AccountID)
+}
+
+// GrantInitialRoles 为新注册账户授予初始角色，注册请求未经认证，须显式指定所属组织，须在 utils.RunDBTransaction 中调用
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - organizationID: 账户所属组织 ID
+//   - codes: 角色编码列表
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func GrantInitialRoles(c *gin.Context, accountID, organizationID int64, codes []string) error {
+	for _, code := range codes {
+		role, err := mapper.GetRoleByCode(c, code)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」角色不存在: %v", code, err)
+			return fmt.Errorf("「%s」角色不存在: %w", code, err)
+		}
+		if err := grantRole(c, accountID, organizationID, role, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantRole 写入账户角色关联
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//   - organizationID: 账户所属组织 ID
+//   - role: 角色
+//   - operatorID: 授权人账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func grantRole(c *gin.Context, accountID, organizationID int64, role *model.Role, operatorID int64) error {
+	accountRole := &model.AccountRole{AccountID: accountID, RoleID: role.ID, GrantedBy: operatorID}
+	accountRole.OrganizationID = organizationID
+	if err := mapper.CreateAccountRole(c, accountRole); err != nil {
+		utils.BizLogger(c).Errorf("授予账户「%d」角色「%s」失败: %v", accountID, role.Code, err)
+		return fmt.Errorf("授予账户角色失败: %w", err)
+	}
+	return nil
+}
+
+// invalidateSession 注销账户在所有设备上的会话及会话中缓存的权限，使角色变更立即生效
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func invalidateSession(c *gin.Context, accountID int64) error {
+	if err := utils.RevokeAllSessions(c.Request.Context(), accountID); err != nil {
+		utils.BizLogger(c).Errorf("清除账户「%d」会话失败: %v", accountID, err)
+		return fmt.Errorf("清除账户会话失败: %w", err)
+	}
+	return nil
+}
+
+// toAccountRoleVO 汇总账户的角色与权限
+// 参数：
+//   - c: Gin 上下文
+//   - accountID: 账户 ID
+//
+// 返回值：
+//   - *rbac.AccountRoleVO: 账户角色视图对象
+//   - error: 操作过程中的错误
+func toAccountRoleVO(c *gin.Context, accountID int64) (*rbac.AccountRoleVO, error) {
+	roles, err := mapper.GetRolesByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账户「%d」角色失败: %v", accountID, err)
+		return nil, fmt.Errorf("查询账户角色失败: %w", err)
+	}
+
+	permissions, err := mapper.GetPermissionCodesByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询账户「%d」权限失败: %v", accountID, err)
+		return nil, fmt.Errorf("查询账户权限失败: %w", err)
+	}
+
+	codes := make([]string, 0, len(roles))
+	for _, role := range roles {
+		codes = append(codes, role.Code)
+	}
+	return &rbac.AccountRoleVO{AccountID: accountID, Roles: codes, Permissions: permissions}, nil
+}
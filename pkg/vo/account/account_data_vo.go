@@ -0,0 +1,43 @@
+// Package account 提供账户相关的视图对象定义
+package account
+
+import (
+	"lease/pkg/vo/lease"
+	"lease/pkg/vo/ledger"
+	"lease/pkg/vo/maintenance"
+)
+
+// AccountExportVO       个人数据导出档案
+// @Description	当前账户的个人数据档案，包含个人资料及以租客、业主或报修人身份参与的合同、收款与报修记录
+// @Property			exported_at			body	int64	true	"导出时间，秒级时间戳"
+// @Property			account				body	object	true	"个人资料"
+// @Property			leases				body	array	true	"租约合同"
+// @Property			payments			body	array	true	"收款记录"
+// @Property			tickets				body	array	true	"报修工单"
+// @Property			ticket_comments		body	array	true	"本人发表的工单评论"
+// @Property			identities			body	array	true	"已关联的第三方身份"
+type AccountExportVO struct {
+	ExportedAt     int64                               `json:"exported_at"`
+	Account        *ProfileVO                          `json:"account"`
+	Leases         []*lease.LeaseContractVO            `json:"leases"`
+	Payments       []*ledger.PaymentVO                 `json:"payments"`
+	Tickets        []*maintenance.MaintenanceTicketVO  `json:"tickets"`
+	TicketComments []*maintenance.MaintenanceCommentVO `json:"ticket_comments"`
+	Identities     []*AccountIdentityVO                `json:"identities"`
+}
+
+// AccountDeletionVO     账户注销申请
+// @Description	账户注销申请状态，宽限期结束前可撤销
+// @Property			deletion_requested_at	body	int64	true	"申请注销时间，秒级时间戳"
+// @Property			deletion_scheduled_at	body	int64	true	"计划执行注销的时间，秒级时间戳"
+type AccountDeletionVO struct {
+	DeletionRequestedAt int64 `json:"deletion_requested_at"`
+	DeletionScheduledAt int64 `json:"deletion_scheduled_at"`
+}
+
+// PurgeDeletedAccountsVO 注销执行结果
+// @Description	本次执行匿名化的账户数量
+// @Property			purged	body	int	true	"已匿名化的账户数量"
+type PurgeDeletedAccountsVO struct {
+	Purged int `json:"purged"`
+}
@@ -4,10 +4,20 @@
 package account
 
 // LoginVO           返回给前端的登录信息
-// @Description	登录成功后返回的访问令牌和刷新令牌
+// @Description	登录成功后返回的访问令牌和刷新令牌，启用两步验证的账户仅返回两步验证凭证，角色要求启用两步验证但尚未启用的账户仅返回强制绑定凭证
 // @Property			access_token	body	string	true	"访问令牌"
 // @Property			refresh_token	body	string	true	"刷新令牌"
+// @Property			session_id		body	string	true	"会话 ID"
+// @Property			mfa_required	body	bool	true	"是否需要完成两步验证"
+// @Property			mfa_enrollment_required	body	bool	true	"是否需要先完成两步验证绑定"
+// @Property			mfa_token		body	string	false	"两步验证凭证或强制绑定凭证，提交动态码时携带"
+// @Property			recovery_codes	body	[]string	false	"恢复码，仅在完成强制绑定时返回一次"
 type LoginVO struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken           string   `json:"access_token"`
+	RefreshToken          string   `json:"refresh_token"`
+	SessionID             string   `json:"session_id"`
+	MFARequired           bool     `json:"mfa_required"`
+	MFAEnrollmentRequired bool     `json:"mfa_enrollment_required"`
+	MFAToken              string   `json:"mfa_token,omitempty"`
+	RecoveryCodes         []string `json:"recovery_codes,omitempty"`
 }
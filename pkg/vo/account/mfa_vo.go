@@ -0,0 +1,18 @@
+// Package account 提供账户相关的视图对象定义
+package account
+
+// MFAEnrollVO           两步验证绑定信息
+// @Description	身份验证器应用绑定所需的密钥与 otpauth URI，URI 可直接生成二维码供扫码
+// @Property			secret				body	string	true	"Base32 编码的 TOTP 密钥"
+// @Property			provisioning_uri	body	string	true	"otpauth 格式的绑定 URI"
+type MFAEnrollVO struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFARecoveryCodesVO    两步验证恢复码
+// @Description	新生成的一次性恢复码，仅在生成时返回一次
+// @Property			recovery_codes	body	[]string	true	"恢复码列表"
+type MFARecoveryCodesVO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
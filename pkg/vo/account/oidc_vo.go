@@ -0,0 +1,33 @@
+// Package account 提供账户相关的视图对象定义
+package account
+
+// OIDCProviderVO        身份提供方
+// @Description	可用于登录的第三方身份提供方
+// @Property			name			body	string	true	"身份提供方标识"
+// @Property			display_name	body	string	true	"展示名称"
+type OIDCProviderVO struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// OIDCAuthorizeVO       第三方授权地址
+// @Description	前端跳转到授权地址，身份提供方认证后携带 code 与 state 重定向回配置的回调地址
+// @Property			authorization_url	body	string	true	"身份提供方授权地址"
+// @Property			state				body	string	true	"本次授权的 state，回调时原样提交"
+type OIDCAuthorizeVO struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// AccountIdentityVO     已关联的第三方身份
+// @Description	账户已关联的第三方身份
+// @Property			provider		body	string	true	"身份提供方标识"
+// @Property			email			body	string	true	"关联时身份提供方返回的邮箱"
+// @Property			linked_at		body	int64	true	"关联时间，秒级时间戳"
+// @Property			last_login_at	body	int64	true	"最近一次通过该身份登录的时间，秒级时间戳"
+type AccountIdentityVO struct {
+	Provider    string `json:"provider"`
+	Email       string `json:"email"`
+	LinkedAt    int64  `json:"linked_at"`
+	LastLoginAt int64  `json:"last_login_at"`
+}
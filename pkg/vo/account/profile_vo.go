@@ -0,0 +1,27 @@
+// Package account 提供账户相关的视图对象定义
+package account
+
+// ProfileVO             当前账户个人资料
+// @Description	当前登录账户的个人资料
+// @Property			id					body	int64	true	"账户 ID"
+// @Property			email				body	string	true	"用户邮箱"
+// @Property			nickname			body	string	true	"用户昵称"
+// @Property			phone				body	string	false	"用户手机号，未绑定时为空"
+// @Property			phone_verified		body	bool	true	"手机号是否已验证，已验证的手机号可用于短信登录"
+// @Property			avatar				body	string	false	"头像访问地址，未上传时为空"
+// @Property			status				body	string	true	"账户状态"
+// @Property			deletion_scheduled_at	body	int64	true	"计划执行注销的时间，未申请注销时为 0"
+// @Property			organization_id		body	int64	true	"所属组织 ID"
+// @Property			gmt_create			body	string	true	"注册时间，秒级时间戳"
+type ProfileVO struct {
+	ID                  int64  `json:"id"`
+	Email               string `json:"email"`
+	Nickname            string `json:"nickname"`
+	Phone               string `json:"phone"`
+	PhoneVerified       bool   `json:"phone_verified"`
+	Avatar              string `json:"avatar"`
+	Status              string `json:"status"`
+	DeletionScheduledAt int64  `json:"deletion_scheduled_at"`
+	OrganizationID      int64  `json:"organization_id"`
+	GmtCreate           int64  `json:"gmt_create"`
+}
@@ -8,7 +8,9 @@ package account
 // @Property			email	    body	string	true	"用户邮箱"
 // @Property			nickname	body	string	true	"用户昵称"
 // @Property			role_code	body	string	true	"用户角色编码"
+// @Property			status		body	string	true	"账户状态，pending_verification 表示需通过激活邮件激活后才能登录"
 type RegisterAccountVO struct {
 	Nickname string `json:"nickname"`
 	Email    string `json:"email"`
+	Status   string `json:"status"`
 }
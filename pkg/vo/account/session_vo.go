@@ -0,0 +1,19 @@
+// Package account 提供账户相关的视图对象定义
+package account
+
+// SessionVO           登录会话信息
+// @Description	账户在某台设备上的登录会话
+// @Property			session_id		body	string	true	"会话 ID"
+// @Property			user_agent		body	string	true	"登录设备 User-Agent"
+// @Property			ip				body	string	true	"登录 IP"
+// @Property			created_at		body	int		true	"登录时间"
+// @Property			last_active_at	body	int		true	"最近活跃时间"
+// @Property			current			body	bool	true	"是否为当前请求所用会话"
+type SessionVO struct {
+	SessionID    string `json:"session_id"`
+	UserAgent    string `json:"user_agent"`
+	IP           string `json:"ip"`
+	CreatedAt    int64  `json:"created_at"`
+	LastActiveAt int64  `json:"last_active_at"`
+	Current      bool   `json:"current"`
+}
@@ -0,0 +1,59 @@
+// Package deposit 提供押金相关的视图对象定义
+package deposit
+
+// DepositVO      合同押金信息
+// @Description	合同约定押金、代管押金余额及押金结算单，金额单位为分
+// @Property			contract_id		body	int		true	"合同 ID"
+// @Property			agreed_amount	body	int		true	"合同约定押金"
+// @Property			held_amount		body	int		true	"代管押金余额"
+// @Property			settlement		body	object	false	"押金结算单"
+type DepositVO struct {
+	ContractID   int64                `json:"contract_id"`
+	AgreedAmount int64                `json:"agreed_amount"`
+	HeldAmount   int64                `json:"held_amount"`
+	Settlement   *DepositSettlementVO `json:"settlement,omitempty"`
+}
+
+// DepositSettlementVO  押金结算单
+// @Description	押金结算单及扣款明细，金额单位为分
+// @Property			id					body	int		true	"结算单 ID"
+// @Property			contract_id			body	int		true	"合同 ID"
+// @Property			status				body	string	true	"结算状态"
+// @Property			held_amount			body	int		true	"结算时代管押金"
+// @Property			deduction_amount	body	int		true	"扣款合计"
+// @Property			refund_amount		body	int		true	"退还金额"
+// @Property			tenant_comment		body	string	true	"租客意见"
+// @Property			acknowledged_at		body	int		true	"租客确认时间"
+// @Property			settled_at			body	int		true	"结算入账时间"
+// @Property			transaction_id		body	int		true	"结算账本凭证 ID"
+// @Property			deductions			body	array	true	"扣款明细"
+// @Property			gmt_create			body	int		true	"创建时间"
+type DepositSettlementVO struct {
+	ID              int64                 `json:"id"`
+	ContractID      int64                 `json:"contract_id"`
+	Status          string                `json:"status"`
+	HeldAmount      int64                 `json:"held_amount"`
+	DeductionAmount int64                 `json:"deduction_amount"`
+	RefundAmount    int64                 `json:"refund_amount"`
+	TenantComment   string                `json:"tenant_comment"`
+	AcknowledgedAt  int64                 `json:"acknowledged_at"`
+	SettledAt       int64                 `json:"settled_at"`
+	TransactionID   int64                 `json:"transaction_id"`
+	Deductions      []*DepositDeductionVO `json:"deductions"`
+	GmtCreate       int64                 `json:"gmt_create"`
+}
+
+// DepositDeductionVO  押金扣款明细
+// @Description	单项押金扣款，金额单位为分
+// @Property			id				body	int		true	"扣款明细 ID"
+// @Property			category		body	string	true	"扣款类别"
+// @Property			reason			body	string	true	"扣款原因"
+// @Property			amount			body	int		true	"扣款金额"
+// @Property			evidence_ids	body	array	true	"证据附件 ID 列表"
+type DepositDeductionVO struct {
+	ID          int64    `json:"id"`
+	Category    string   `json:"category"`
+	Reason      string   `json:"reason"`
+	Amount      int64    `json:"amount"`
+	EvidenceIDs []string `json:"evidence_ids"`
+}
@@ -0,0 +1,21 @@
+// Package invoice 提供租金账单相关的视图对象定义
+package invoice
+
+// BillingPeriodVO  合同账期
+// @Description	合同账期表中的一期，金额为按当前租金与杂费测算的应付金额，单位为分
+// @Property			period_start	body	string	true	"计费周期开始日期"
+// @Property			period_end		body	string	true	"计费周期结束日期"
+// @Property			due_date		body	string	true	"应付日期"
+// @Property			amount			body	int		true	"测算应付总额"
+// @Property			prorated		body	bool	true	"是否按天折算"
+// @Property			invoice_id		body	int		true	"已出账的账单 ID，未出账为 0"
+// @Property			items			body	array	true	"测算明细"
+type BillingPeriodVO struct {
+	PeriodStart string           `json:"period_start"`
+	PeriodEnd   string           `json:"period_end"`
+	DueDate     string           `json:"due_date"`
+	Amount      int64            `json:"amount"`
+	Prorated    bool             `json:"prorated"`
+	InvoiceID   int64            `json:"invoice_id"`
+	Items       []*InvoiceItemVO `json:"items"`
+}
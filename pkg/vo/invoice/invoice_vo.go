@@ -0,0 +1,63 @@
+// Package invoice 提供租金账单相关的视图对象定义
+package invoice
+
+// InvoiceVO      租金账单信息
+// @Description	返回给前端的租金账单信息，金额单位为分
+// @Property			id				body	int		true	"账单 ID"
+// @Property			contract_id		body	int		true	"合同 ID"
+// @Property			unit_id			body	int		true	"出租单元 ID"
+// @Property			landlord_id		body	int		true	"业主账户 ID"
+// @Property			tenant_id		body	int		true	"租客账户 ID"
+// @Property			period_start	body	string	true	"计费周期开始日期"
+// @Property			period_end		body	string	true	"计费周期结束日期"
+// @Property			due_date		body	string	true	"应付日期"
+// @Property			amount			body	int		true	"应付总额"
+// @Property			paid_amount		body	int		true	"已付金额"
+// @Property			prorated		body	bool	true	"是否按天折算"
+// @Property			status			body	string	true	"账单状态"
+// @Property			items			body	array	false	"账单明细"
+// @Property			gmt_create		body	int		true	"出账时间"
+type InvoiceVO struct {
+	ID          int64            `json:"id"`
+	ContractID  int64            `json:"contract_id"`
+	UnitID      int64            `json:"unit_id"`
+	LandlordID  int64            `json:"landlord_id"`
+	TenantID    int64            `json:"tenant_id"`
+	PeriodStart string           `json:"period_start"`
+	PeriodEnd   string           `json:"period_end"`
+	DueDate     string           `json:"due_date"`
+	Amount      int64            `json:"amount"`
+	PaidAmount  int64            `json:"paid_amount"`
+	Prorated    bool             `json:"prorated"`
+	Status      string           `json:"status"`
+	Items       []*InvoiceItemVO `json:"items,omitempty"`
+	GmtCreate   int64            `json:"gmt_create"`
+}
+
+// InvoiceItemVO  账单明细
+// @Description	账单中的租金或杂费明细，金额单位为分
+// @Property			id			body	int		true	"明细 ID"
+// @Property			item_type	body	string	true	"明细类型: rent, fee"
+// @Property			fee_id		body	int		true	"周期性杂费 ID"
+// @Property			name		body	string	true	"明细名称"
+// @Property			amount		body	int		true	"金额"
+// @Property			remark		body	string	true	"计费说明"
+type InvoiceItemVO struct {
+	ID       int64  `json:"id"`
+	ItemType string `json:"item_type"`
+	FeeID    int64  `json:"fee_id"`
+	Name     string `json:"name"`
+	Amount   int64  `json:"amount"`
+	Remark   string `json:"remark"`
+}
+
+// GenerateInvoiceVO  账单生成结果
+// @Description	本次生成的账单与因已出账而跳过的账期数量
+// @Property			created		body	int		true	"新生成的账单数"
+// @Property			skipped		body	int		true	"已出账跳过的账期数"
+// @Property			invoices	body	array	true	"新生成的账单"
+type GenerateInvoiceVO struct {
+	Created  int          `json:"created"`
+	Skipped  int          `json:"skipped"`
+	Invoices []*InvoiceVO `json:"invoices"`
+}
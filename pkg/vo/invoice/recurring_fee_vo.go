@@ -0,0 +1,19 @@
+// Package invoice 提供租金账单相关的视图对象定义
+package invoice
+
+// RecurringFeeVO  周期性杂费信息
+// @Description	合同按月计价的周期性杂费，金额单位为分
+// @Property			id				body	int		true	"杂费 ID"
+// @Property			contract_id		body	int		true	"合同 ID"
+// @Property			category		body	string	true	"杂费类别"
+// @Property			name			body	string	true	"杂费名称"
+// @Property			monthly_amount	body	int		true	"月费用"
+// @Property			gmt_create		body	int		true	"创建时间"
+type RecurringFeeVO struct {
+	ID            int64  `json:"id"`
+	ContractID    int64  `json:"contract_id"`
+	Category      string `json:"category"`
+	Name          string `json:"name"`
+	MonthlyAmount int64  `json:"monthly_amount"`
+	GmtCreate     int64  `json:"gmt_create"`
+}
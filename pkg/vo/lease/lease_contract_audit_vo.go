@@ -0,0 +1,23 @@
+// Package lease 提供租约合同相关的视图对象定义
+package lease
+
+// LeaseContractAuditVO  合同状态流转审计记录
+// @Description	合同每次状态流转的审计信息
+// @Property			id			body	int		true	"记录 ID"
+// @Property			contract_id	body	int		true	"合同 ID"
+// @Property			action		body	string	true	"流转动作"
+// @Property			from_status	body	string	true	"流转前状态"
+// @Property			to_status	body	string	true	"流转后状态"
+// @Property			operator_id	body	int		true	"操作人账户 ID"
+// @Property			remark		body	string	true	"备注"
+// @Property			gmt_create	body	int		true	"流转时间"
+type LeaseContractAuditVO struct {
+	ID         int64  `json:"id"`
+	ContractID int64  `json:"contract_id"`
+	Action     string `json:"action"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	OperatorID int64  `json:"operator_id"`
+	Remark     string `json:"remark"`
+	GmtCreate  int64  `json:"gmt_create"`
+}
@@ -0,0 +1,39 @@
+// Package lease 提供租约合同相关的视图对象定义
+package lease
+
+// LeaseContractVO   租约合同信息
+// @Description	返回给前端的租约合同信息，金额单位为分
+// @Property			id					body	int		true	"合同 ID"
+// @Property			unit_id				body	int		true	"出租单元 ID"
+// @Property			property_id			body	int		true	"房源 ID"
+// @Property			landlord_id			body	int		true	"业主账户 ID"
+// @Property			tenant_id			body	int		true	"租客账户 ID"
+// @Property			start_date			body	string	true	"起租日期"
+// @Property			end_date			body	string	true	"到期日期"
+// @Property			monthly_rent		body	int		true	"月租金"
+// @Property			deposit				body	int		true	"押金"
+// @Property			billing_cycle		body	string	true	"计费周期"
+// @Property			status				body	string	true	"合同状态"
+// @Property			renewed_from_id		body	int		true	"续约来源合同 ID"
+// @Property			signed_at			body	int		true	"签署时间"
+// @Property			terminated_at		body	int		true	"解约时间"
+// @Property			termination_reason	body	string	true	"解约原因"
+// @Property			gmt_create			body	int		true	"创建时间"
+type LeaseContractVO struct {
+	ID                int64  `json:"id"`
+	UnitID            int64  `json:"unit_id"`
+	PropertyID        int64  `json:"property_id"`
+	LandlordID        int64  `json:"landlord_id"`
+	TenantID          int64  `json:"tenant_id"`
+	StartDate         string `json:"start_date"`
+	EndDate           string `json:"end_date"`
+	MonthlyRent       int64  `json:"monthly_rent"`
+	Deposit           int64  `json:"deposit"`
+	BillingCycle      string `json:"billing_cycle"`
+	Status            string `json:"status"`
+	RenewedFromID     int64  `json:"renewed_from_id"`
+	SignedAt          int64  `json:"signed_at"`
+	TerminatedAt      int64  `json:"terminated_at"`
+	TerminationReason string `json:"termination_reason"`
+	GmtCreate         int64  `json:"gmt_create"`
+}
@@ -0,0 +1,40 @@
+// Package ledger 提供账本与收款相关的视图对象定义
+package ledger
+
+// LedgerTransactionVO  账本凭证
+// @Description	账本凭证及其借贷分录，金额单位为分
+// @Property			id			body	int		true	"凭证 ID"
+// @Property			txn_type	body	string	true	"凭证类型"
+// @Property			contract_id	body	int		true	"合同 ID"
+// @Property			tenant_id	body	int		true	"租客账户 ID"
+// @Property			invoice_id	body	int		true	"关联账单 ID"
+// @Property			payment_id	body	int		true	"关联收款 ID"
+// @Property			amount		body	int		true	"凭证金额"
+// @Property			memo		body	string	true	"摘要"
+// @Property			entries		body	array	true	"分录"
+// @Property			gmt_create	body	int		true	"入账时间"
+type LedgerTransactionVO struct {
+	ID         int64            `json:"id"`
+	TxnType    string           `json:"txn_type"`
+	ContractID int64            `json:"contract_id"`
+	TenantID   int64            `json:"tenant_id"`
+	InvoiceID  int64            `json:"invoice_id"`
+	PaymentID  int64            `json:"payment_id"`
+	Amount     int64            `json:"amount"`
+	Memo       string           `json:"memo"`
+	Entries    []*LedgerEntryVO `json:"entries"`
+	GmtCreate  int64            `json:"gmt_create"`
+}
+
+// LedgerEntryVO  账本分录
+// @Description	凭证下的单条借贷分录，金额单位为分
+// @Property			account		body	string	true	"会计科目"
+// @Property			direction	body	string	true	"记账方向: debit, credit"
+// @Property			amount		body	int		true	"金额"
+// @Property			invoice_id	body	int		true	"关联账单 ID"
+type LedgerEntryVO struct {
+	Account   string `json:"account"`
+	Direction string `json:"direction"`
+	Amount    int64  `json:"amount"`
+	InvoiceID int64  `json:"invoice_id"`
+}
@@ -0,0 +1,33 @@
+// Package ledger 提供账本与收款相关的视图对象定义
+package ledger
+
+// PaymentVO      收款记录
+// @Description	收款记录及其核销与预收结转金额，金额单位为分
+// @Property			id				body	int		true	"收款 ID"
+// @Property			contract_id		body	int		true	"合同 ID"
+// @Property			landlord_id		body	int		true	"业主账户 ID"
+// @Property			tenant_id		body	int		true	"租客账户 ID"
+// @Property			purpose			body	string	true	"收款用途"
+// @Property			method			body	string	true	"收款方式"
+// @Property			reference		body	string	true	"外部流水号"
+// @Property			amount			body	int		true	"收款金额"
+// @Property			applied_amount	body	int		true	"核销账单金额"
+// @Property			credit_amount	body	int		true	"结转预收金额"
+// @Property			paid_date		body	string	true	"收款日期"
+// @Property			remark			body	string	true	"备注"
+// @Property			gmt_create		body	int		true	"登记时间"
+type PaymentVO struct {
+	ID            int64  `json:"id"`
+	ContractID    int64  `json:"contract_id"`
+	LandlordID    int64  `json:"landlord_id"`
+	TenantID      int64  `json:"tenant_id"`
+	Purpose       string `json:"purpose"`
+	Method        string `json:"method"`
+	Reference     string `json:"reference"`
+	Amount        int64  `json:"amount"`
+	AppliedAmount int64  `json:"applied_amount"`
+	CreditAmount  int64  `json:"credit_amount"`
+	PaidDate      string `json:"paid_date"`
+	Remark        string `json:"remark"`
+	GmtCreate     int64  `json:"gmt_create"`
+}
@@ -0,0 +1,56 @@
+// Package ledger 提供账本与收款相关的视图对象定义
+package ledger
+
+// StatementVO    租客对账单
+// @Description	租客往来明细与余额汇总，金额单位为分；balance_due 为正表示租客欠款，为负表示预收余额
+// @Property			tenant_id		body	int		true	"租客账户 ID"
+// @Property			contract_id		body	int		true	"合同 ID，为 0 表示全部合同"
+// @Property			lines			body	array	true	"往来明细"
+// @Property			total_invoiced	body	int		true	"累计出账金额"
+// @Property			total_received	body	int		true	"累计收款金额（含押金）"
+// @Property			total_refunded	body	int		true	"累计退款金额"
+// @Property			receivable		body	int		true	"应收余额"
+// @Property			credit_balance	body	int		true	"预收余额"
+// @Property			deposit_held	body	int		true	"代管押金余额"
+// @Property			balance_due		body	int		true	"应付净额"
+// @Property			reconciled		body	bool	true	"账本借贷平衡且应收余额与未结清账单一致"
+type StatementVO struct {
+	TenantID      int64              `json:"tenant_id"`
+	ContractID    int64              `json:"contract_id"`
+	Lines         []*StatementLineVO `json:"lines"`
+	TotalInvoiced int64              `json:"total_invoiced"`
+	TotalReceived int64              `json:"total_received"`
+	TotalRefunded int64              `json:"total_refunded"`
+	Receivable    int64              `json:"receivable"`
+	CreditBalance int64              `json:"credit_balance"`
+	DepositHeld   int64              `json:"deposit_held"`
+	BalanceDue    int64              `json:"balance_due"`
+	Reconciled    bool               `json:"reconciled"`
+}
+
+// StatementLineVO  对账单明细
+// @Description	对账单中的一笔往来，debit 增加租客应付，credit 减少租客应付
+// @Property			transaction_id	body	int		true	"凭证 ID"
+// @Property			txn_type		body	string	true	"凭证类型"
+// @Property			contract_id		body	int		true	"合同 ID"
+// @Property			invoice_id		body	int		true	"关联账单 ID"
+// @Property			payment_id		body	int		true	"关联收款 ID"
+// @Property			memo			body	string	true	"摘要"
+// @Property			debit			body	int		true	"增加应付"
+// @Property			credit			body	int		true	"减少应付"
+// @Property			deposit			body	int		true	"押金变动"
+// @Property			balance			body	int		true	"应付净额"
+// @Property			gmt_create		body	int		true	"入账时间"
+type StatementLineVO struct {
+	TransactionID int64  `json:"transaction_id"`
+	TxnType       string `json:"txn_type"`
+	ContractID    int64  `json:"contract_id"`
+	InvoiceID     int64  `json:"invoice_id"`
+	PaymentID     int64  `json:"payment_id"`
+	Memo          string `json:"memo"`
+	Debit         int64  `json:"debit"`
+	Credit        int64  `json:"credit"`
+	Deposit       int64  `json:"deposit"`
+	Balance       int64  `json:"balance"`
+	GmtCreate     int64  `json:"gmt_create"`
+}
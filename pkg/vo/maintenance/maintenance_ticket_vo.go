@@ -0,0 +1,83 @@
+// Package maintenance 提供报修工单相关的视图对象定义
+package maintenance
+
+// MaintenanceTicketVO   报修工单信息
+// @Description	返回给前端的报修工单信息，金额单位为分，查询详情时附带评论与流转记录
+// @Property			id				body	int		true	"工单 ID"
+// @Property			unit_id			body	int		true	"出租单元 ID"
+// @Property			property_id		body	int		true	"房源 ID"
+// @Property			contract_id		body	int		true	"报修时生效的合同 ID"
+// @Property			landlord_id		body	int		true	"业主账户 ID"
+// @Property			tenant_id		body	int		true	"租客账户 ID"
+// @Property			reporter_id		body	int		true	"报修人账户 ID"
+// @Property			assignee_id		body	int		true	"维修人员账户 ID"
+// @Property			title			body	string	true	"标题"
+// @Property			description		body	string	true	"问题描述"
+// @Property			category		body	string	true	"类别"
+// @Property			priority		body	string	true	"优先级"
+// @Property			status			body	string	true	"工单状态"
+// @Property			scheduled_at	body	string	true	"预约上门时间"
+// @Property			resolved_at		body	int		true	"修复时间"
+// @Property			closed_at		body	int		true	"关闭时间"
+// @Property			cost			body	int		true	"维修费用"
+// @Property			resolution		body	string	true	"处理结果说明"
+// @Property			comments		body	array	false	"评论"
+// @Property			events			body	array	false	"状态流转记录"
+// @Property			gmt_create		body	int		true	"报修时间"
+type MaintenanceTicketVO struct {
+	ID          int64                       `json:"id"`
+	UnitID      int64                       `json:"unit_id"`
+	PropertyID  int64                       `json:"property_id"`
+	ContractID  int64                       `json:"contract_id"`
+	LandlordID  int64                       `json:"landlord_id"`
+	TenantID    int64                       `json:"tenant_id"`
+	ReporterID  int64                       `json:"reporter_id"`
+	AssigneeID  int64                       `json:"assignee_id"`
+	Title       string                      `json:"title"`
+	Description string                      `json:"description"`
+	Category    string                      `json:"category"`
+	Priority    string                      `json:"priority"`
+	Status      string                      `json:"status"`
+	ScheduledAt string                      `json:"scheduled_at"`
+	ResolvedAt  int64                       `json:"resolved_at"`
+	ClosedAt    int64                       `json:"closed_at"`
+	Cost        int64                       `json:"cost"`
+	Resolution  string                      `json:"resolution"`
+	Comments    []*MaintenanceCommentVO     `json:"comments,omitempty"`
+	Events      []*MaintenanceTicketEventVO `json:"events,omitempty"`
+	GmtCreate   int64                       `json:"gmt_create"`
+}
+
+// MaintenanceCommentVO  工单评论
+// @Description	工单下的一条评论
+// @Property			id			body	int		true	"评论 ID"
+// @Property			ticket_id	body	int		true	"工单 ID"
+// @Property			author_id	body	int		true	"评论人账户 ID"
+// @Property			content		body	string	true	"评论内容"
+// @Property			gmt_create	body	int		true	"评论时间"
+type MaintenanceCommentVO struct {
+	ID        int64  `json:"id"`
+	TicketID  int64  `json:"ticket_id"`
+	AuthorID  int64  `json:"author_id"`
+	Content   string `json:"content"`
+	GmtCreate int64  `json:"gmt_create"`
+}
+
+// MaintenanceTicketEventVO  工单状态流转记录
+// @Description	工单每次状态流转的记录
+// @Property			id			body	int		true	"记录 ID"
+// @Property			action		body	string	true	"流转动作"
+// @Property			from_status	body	string	true	"流转前状态"
+// @Property			to_status	body	string	true	"流转后状态"
+// @Property			operator_id	body	int		true	"操作人账户 ID"
+// @Property			remark		body	string	true	"备注"
+// @Property			gmt_create	body	int		true	"流转时间"
+type MaintenanceTicketEventVO struct {
+	ID         int64  `json:"id"`
+	Action     string `json:"action"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	OperatorID int64  `json:"operator_id"`
+	Remark     string `json:"remark"`
+	GmtCreate  int64  `json:"gmt_create"`
+}
@@ -0,0 +1,25 @@
+// Package organization 提供组织相关的视图对象定义
+package organization
+
+// InvitationVO   组织邀请
+// @Description	组织邀请信息，邀请码用于受邀人注册
+// @Property			id			body	int		true	"邀请 ID"
+// @Property			email		body	string	true	"受邀邮箱"
+// @Property			role_code	body	string	true	"加入后授予的角色编码"
+// @Property			code		body	string	true	"邀请码"
+// @Property			status		body	string	true	"邀请状态"
+// @Property			invited_by	body	int		true	"邀请人账户 ID"
+// @Property			expires_at	body	int		true	"过期时间"
+// @Property			accepted_by	body	int		true	"接受邀请的账户 ID"
+// @Property			accepted_at	body	int		true	"接受时间"
+type InvitationVO struct {
+	ID         int64  `json:"id"`
+	Email      string `json:"email"`
+	RoleCode   string `json:"role_code"`
+	Code       string `json:"code"`
+	Status     string `json:"status"`
+	InvitedBy  int64  `json:"invited_by"`
+	ExpiresAt  int64  `json:"expires_at"`
+	AcceptedBy int64  `json:"accepted_by"`
+	AcceptedAt int64  `json:"accepted_at"`
+}
@@ -0,0 +1,34 @@
+// Package organization 提供组织相关的视图对象定义
+package organization
+
+// OrganizationVO   组织信息
+// @Description	组织基本信息及席位占用情况
+// @Property			id			body	int		true	"组织 ID"
+// @Property			name		body	string	true	"组织名称"
+// @Property			owner_id	body	int		true	"创建人账户 ID"
+// @Property			seat_limit	body	int		true	"账户席位上限，0 表示不限"
+// @Property			seats_used	body	int		true	"已占用席位数"
+type OrganizationVO struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	OwnerID   int64  `json:"owner_id"`
+	SeatLimit int    `json:"seat_limit"`
+	SeatsUsed int64  `json:"seats_used"`
+}
+
+// MemberVO   组织成员
+// @Description	组织内的账户信息
+// @Property			id			body	int		true	"账户 ID"
+// @Property			email		body	string	true	"邮箱"
+// @Property			nickname	body	string	true	"昵称"
+// @Property			phone		body	string	true	"手机号"
+// @Property			status		body	string	true	"账户状态"
+// @Property			gmt_create	body	int		true	"加入时间"
+type MemberVO struct {
+	ID        int64  `json:"id"`
+	Email     string `json:"email"`
+	Nickname  string `json:"nickname"`
+	Phone     string `json:"phone"`
+	Status    string `json:"status"`
+	GmtCreate int64  `json:"gmt_create"`
+}
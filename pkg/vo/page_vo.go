@@ -0,0 +1,15 @@
+// Package vo 提供视图对象定义和响应结果包装
+package vo
+
+// PageVO         分页查询结果
+// @Description	分页查询返回的数据列表与分页信息
+// @Property			list		body	array	true	"数据列表"
+// @Property			total		body	int		true	"总条数"
+// @Property			page_no		body	int		true	"当前页码"
+// @Property			page_size	body	int		true	"每页条数"
+type PageVO struct {
+	List     interface{} `json:"list"`
+	Total    int64       `json:"total"`
+	PageNo   int         `json:"page_no"`
+	PageSize int         `json:"page_size"`
+}
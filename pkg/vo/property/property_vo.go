@@ -0,0 +1,29 @@
+// Package property 提供房源相关的视图对象定义
+package property
+
+// PropertyVO        房源信息
+// @Description	返回给前端的房源信息
+// @Property			id			body	int		true	"房源 ID"
+// @Property			owner_id	body	int		true	"业主账户 ID"
+// @Property			name		body	string	true	"房源名称"
+// @Property			province	body	string	true	"省份"
+// @Property			city		body	string	true	"城市"
+// @Property			district	body	string	true	"区县"
+// @Property			address		body	string	true	"详细地址"
+// @Property			latitude	body	number	true	"纬度"
+// @Property			longitude	body	number	true	"经度"
+// @Property			description	body	string	true	"房源描述"
+// @Property			gmt_create	body	int		true	"创建时间"
+type PropertyVO struct {
+	ID          int64   `json:"id"`
+	OwnerID     int64   `json:"owner_id"`
+	Name        string  `json:"name"`
+	Province    string  `json:"province"`
+	City        string  `json:"city"`
+	District    string  `json:"district"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Description string  `json:"description"`
+	GmtCreate   int64   `json:"gmt_create"`
+}
@@ -0,0 +1,25 @@
+// Package property 提供房源相关的视图对象定义
+package property
+
+// UnitVO            出租单元信息
+// @Description	返回给前端的出租单元信息，租金单位为分
+// @Property			id				body	int		true	"出租单元 ID"
+// @Property			property_id		body	int		true	"所属房源 ID"
+// @Property			unit_no			body	string	true	"房间号"
+// @Property			floor			body	int		true	"楼层"
+// @Property			area			body	number	true	"面积（平方米）"
+// @Property			rooms			body	int		true	"房间数"
+// @Property			rent_list_price	body	int		true	"挂牌月租金（分）"
+// @Property			status			body	string	true	"房间状态"
+// @Property			gmt_create		body	int		true	"创建时间"
+type UnitVO struct {
+	ID            int64   `json:"id"`
+	PropertyID    int64   `json:"property_id"`
+	UnitNo        string  `json:"unit_no"`
+	Floor         int     `json:"floor"`
+	Area          float64 `json:"area"`
+	Rooms         int     `json:"rooms"`
+	RentListPrice int64   `json:"rent_list_price"`
+	Status        string  `json:"status"`
+	GmtCreate     int64   `json:"gmt_create"`
+}
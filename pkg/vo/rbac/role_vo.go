@@ -0,0 +1,28 @@
+// Package rbac 提供角色权限相关的视图对象定义
+package rbac
+
+// RoleVO   角色信息
+// @Description	角色及其拥有的权限编码
+// @Property			id			body	int		true	"角色 ID"
+// @Property			code		body	string	true	"角色编码"
+// @Property			name		body	string	true	"角色名称"
+// @Property			description	body	string	true	"角色说明"
+// @Property			permissions	body	array	true	"权限编码"
+type RoleVO struct {
+	ID          int64    `json:"id"`
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// AccountRoleVO   账户角色信息
+// @Description	账户拥有的角色及汇总后的权限编码
+// @Property			account_id	body	int		true	"账户 ID"
+// @Property			roles		body	array	true	"角色编码"
+// @Property			permissions	body	array	true	"权限编码"
+type AccountRoleVO struct {
+	AccountID   int64    `json:"account_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
@@ -7,9 +7,10 @@ import (
 	"errors"
 	"time"
 
-	"github.com/labstack/echo/v4"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
 
-	bizErr "jank.com/jank_blog/internal/error"
+	bizErr "lease/internal/error"
 )
 
 // Result 通用 API 响应结果结构体
@@ -22,35 +23,35 @@ type Result struct {
 
 // Success 成功返回
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - data: 响应数据
 //
 // 返回值：
 //   - Result: 成功响应结果
-func Success(c echo.Context, data interface{}) Result {
+func Success(c *gin.Context, data interface{}) Result {
 	return Result{
 		Err:       nil,
 		Data:      data,
-		RequestId: c.Response().Header().Get(echo.HeaderXRequestID),
+		RequestId: requestid.Get(c),
 		TimeStamp: time.Now().Unix(),
 	}
 }
 
 // Fail 失败返回
 // 参数：
-//   - c: Echo 上下文
+//   - c: Gin 上下文
 //   - data: 错误相关数据
 //   - err: 错误对象
 //
 // 返回值：
 //   - Result: 失败响应结果
-func Fail(c echo.Context, data interface{}, err error) Result {
+func Fail(c *gin.Context, data interface{}, err error) Result {
 	var newBizErr *bizErr.Err
 	if ok := errors.As(err, &newBizErr); ok {
 		return Result{
 			Err:       newBizErr,
 			Data:      data,
-			RequestId: c.Response().Header().Get(echo.HeaderXRequestID),
+			RequestId: requestid.Get(c),
 			TimeStamp: time.Now().Unix(),
 		}
 	}
@@ -58,7 +59,7 @@ func Fail(c echo.Context, data interface{}, err error) Result {
 	return Result{
 		Err:       bizErr.New(bizErr.SERVER_ERR),
 		Data:      data,
-		RequestId: c.Response().Header().Get(echo.HeaderXRequestID),
+		RequestId: requestid.Get(c),
 		TimeStamp: time.Now().Unix(),
 	}
 }
@@ -0,0 +1,9 @@
+// Package verification 提供验证码相关的视图对象定义
+package verification
+
+// ImgVerificationVO    图形验证码
+// @Description	生成的图形验证码
+// @Property			img_base64	body	string	true	"Base64 编码的验证码图片"
+type ImgVerificationVO struct {
+	ImgBase64 string `json:"img_base64"`
+}